@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/bus"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/table"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/ui"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/format"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/wagoodman/go-partybus"
+)
+
+const convertExample = `  {{.appName}} {{.command}} img.syft.json -o spdx-json   convert a syft SBOM to a SPDX 2.2 JSON formatted SBOM
+  {{.appName}} {{.command}} img.syft.json -o table       show a summary of the packages within a syft SBOM
+  {{.appName}} {{.command}} -             -o cyclonedx   convert a syft SBOM read from stdin to a CycloneDX formatted SBOM
+
+  Only syft JSON formatted SBOMs (created with "-o json") are currently supported as input.
+`
+
+var (
+	convertOutputs []reportOutput
+	convertCmd     = &cobra.Command{
+		Use:   "convert [SOURCE-SBOM] -o [FORMAT]",
+		Short: "Convert between SBOM formats",
+		Long:  "Convert an existing SBOM into another SBOM format without cataloging the original source again",
+		Example: internal.Tprintf(convertExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "convert",
+		}),
+		Args:          validateConvertArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			outputs, err := parseReportOutputs(appConfig.Output, appConfig.File)
+			if err != nil {
+				return err
+			}
+			convertOutputs = outputs
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return convertExec(cmd, args)
+		},
+	}
+)
+
+func init() {
+	setConvertFlags(convertCmd.Flags())
+
+	rootCmd.AddCommand(convertCmd)
+}
+
+func setConvertFlags(flags *pflag.FlagSet) {
+	setPackageFilterFlags(flags)
+
+	flags.StringArrayP(
+		"output", "o", []string{string(format.TableOption)},
+		fmt.Sprintf("report output formatter, formats=%v (may be given multiple times, as <format>=<file> to write a report to its own file)", format.AllOptions),
+	)
+
+	flags.StringP(
+		"file", "", "",
+		"file to write the report output to (default is STDOUT)",
+	)
+
+	flags.StringP(
+		"spdx-namespace", "", "",
+		fmt.Sprintf("the URI prefix of the SPDX document namespace, which is followed by a unique ID (default %q)", spdxhelpers.DefaultDocumentNamespacePrefix),
+	)
+
+	flags.BoolP(
+		"spdx-minimal", "", false,
+		"omit optional SPDX fields that only describe how packages were found (e.g. annotations and source info), keeping the fields required by the spec",
+	)
+
+	flags.BoolP(
+		"spdx-metadata-comment", "", false,
+		"include the raw metadata of each package as JSON within the SPDX package comment (considerably grows the document)",
+	)
+
+	flags.StringP(
+		"spdx-created", "", "",
+		fmt.Sprintf("the fixed creation time of SPDX documents for reproducible output, as an RFC 3339 timestamp or seconds since the Unix epoch (default is the $%s env var, otherwise the current time)", spdxhelpers.SourceDateEpochEnvVar),
+	)
+
+	flags.BoolP(
+		"spdx-unpackaged-files", "", false,
+		"describe the top-level files (or those selected by the spdx.unpackaged-files.globs config) that no package contains as unpackaged files in SPDX documents",
+	)
+
+	flags.StringArrayP(
+		"column", "", nil,
+		fmt.Sprintf("add a column to the table output (may be given multiple times), options=%v", table.AllColumns),
+	)
+
+	flags.IntP(
+		"column-width", "", 0,
+		"the maximum width of the columns added with --column, where longer values are truncated (0 for no limit)",
+	)
+
+	flags.BoolP(
+		"wrap-columns", "", false,
+		"split values wider than --column-width over several lines instead of truncating them",
+	)
+}
+
+func bindConvertConfigOptions(flags *pflag.FlagSet) error {
+	if err := bindPackageFilterConfigOptions(flags); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("output", flags.Lookup("output")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("file", flags.Lookup("file")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.namespace", flags.Lookup("spdx-namespace")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.minimal", flags.Lookup("spdx-minimal")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.metadata-comment", flags.Lookup("spdx-metadata-comment")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.created", flags.Lookup("spdx-created")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.unpackaged-files.enabled", flags.Lookup("spdx-unpackaged-files")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("table.columns", flags.Lookup("column")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("table.column-width", flags.Lookup("column-width")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("table.wrap", flags.Lookup("wrap-columns")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateConvertArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		// in the case that no arguments are given we want to show the help text and return with a non-0 return code.
+		if err := cmd.Help(); err != nil {
+			return fmt.Errorf("unable to display help: %w", err)
+		}
+		return fmt.Errorf("an SBOM argument is required")
+	}
+
+	return cobra.MaximumNArgs(1)(cmd, args)
+}
+
+func convertExec(_ *cobra.Command, args []string) error {
+	// a path to an SBOM file, or "-" for stdin
+	userInput := args[0]
+
+	reporter, closer, err := reportWriter()
+	defer func() {
+		if err := closer(); err != nil {
+			log.Warnf("unable to write to report destination: %+v", err)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	writers, writersCloser, err := reportOutputWriters(convertOutputs)
+	defer func() {
+		if err := writersCloser(); err != nil {
+			log.Warnf("unable to write to report destination: %+v", err)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	return eventLoop(
+		convertExecWorker(userInput, writers),
+		setupSignals(),
+		eventSubscription,
+		stereoscope.Cleanup,
+		ui.Select(isVerbose(), appConfig.Quiet, reporter)...,
+	)
+}
+
+func convertExecWorker(userInput string, writers []io.Writer) <-chan error {
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+
+		reader, closer, err := openSBOM(userInput)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer closer()
+
+		s, inputOption, err := syft.Decode(reader)
+		if err != nil {
+			errs <- fmt.Errorf("failed to decode SBOM: %w", err)
+			return
+		}
+
+		if inputOption != format.JSONOption {
+			errs <- fmt.Errorf("unsupported SBOM format %q: only syft JSON formatted SBOMs may be converted", inputOption)
+			return
+		}
+
+		pres, err := newReportPresenter(filterPackages(appConfig, *s), convertOutputs, writers, formatOptions(appConfig), nil)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		bus.Publish(partybus.Event{
+			Type:  event.PresenterReady,
+			Value: pres,
+		})
+	}()
+	return errs
+}
+
+// openSBOM opens the SBOM at the given path, where "-" indicates that the SBOM should be read from stdin.
+func openSBOM(path string) (io.Reader, func(), error) {
+	if path == "-" {
+		return os.Stdin, func() {}, nil
+	}
+
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("unable to open SBOM=%q: %w", path, err)
+	}
+
+	return fh, func() {
+		if err := fh.Close(); err != nil {
+			log.Warnf("unable to close SBOM=%q: %+v", path, err)
+		}
+	}, nil
+}
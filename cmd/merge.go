@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/bus"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/ui"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/spf13/cobra"
+	"github.com/wagoodman/go-partybus"
+)
+
+const mergeExample = `  {{.appName}} {{.command}} build.syft.json runtime.syft.json -o json        merge two syft SBOMs into one syft SBOM
+  {{.appName}} {{.command}} stages/*.syft.json -o spdx-json                 merge the syft SBOMs of every build stage into a SPDX 2.2 JSON formatted SBOM
+
+  The same package found within several SBOMs (by type, name, version, and PURL) is reported once, with the locations,
+  licenses, and CPEs from every SBOM. Only syft JSON formatted SBOMs (created with "-o json") are currently supported as input.
+`
+
+var (
+	mergeOutputs []reportOutput
+	mergeCmd     = &cobra.Command{
+		Use:   "merge [SOURCE-SBOM]... -o [FORMAT]",
+		Short: "Merge several SBOMs into one SBOM",
+		Long:  "Merge the packages and sources of existing SBOMs (e.g. from each build stage) into one SBOM without cataloging the original sources again",
+		Example: internal.Tprintf(mergeExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "merge",
+		}),
+		Args:          validateMergeArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			outputs, err := parseReportOutputs(appConfig.Output, appConfig.File)
+			if err != nil {
+				return err
+			}
+			mergeOutputs = outputs
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mergeExec(cmd, args)
+		},
+	}
+)
+
+func init() {
+	// the merge command has the same output options as the convert command
+	setConvertFlags(mergeCmd.Flags())
+
+	rootCmd.AddCommand(mergeCmd)
+}
+
+func validateMergeArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		// in the case that no arguments are given we want to show the help text and return with a non-0 return code.
+		if err := cmd.Help(); err != nil {
+			return fmt.Errorf("unable to display help: %w", err)
+		}
+		return fmt.Errorf("at least one SBOM argument is required")
+	}
+
+	stdinInputs := 0
+	for _, arg := range args {
+		if arg == "-" {
+			stdinInputs++
+		}
+	}
+	if stdinInputs > 1 {
+		return fmt.Errorf("only one SBOM may be read from stdin")
+	}
+	return nil
+}
+
+func mergeExec(_ *cobra.Command, args []string) error {
+	reporter, closer, err := reportWriter()
+	defer func() {
+		if err := closer(); err != nil {
+			log.Warnf("unable to write to report destination: %+v", err)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	writers, writersCloser, err := reportOutputWriters(mergeOutputs)
+	defer func() {
+		if err := writersCloser(); err != nil {
+			log.Warnf("unable to write to report destination: %+v", err)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	return eventLoop(
+		mergeExecWorker(args, writers),
+		setupSignals(),
+		eventSubscription,
+		stereoscope.Cleanup,
+		ui.Select(isVerbose(), appConfig.Quiet, reporter)...,
+	)
+}
+
+func mergeExecWorker(userInputs []string, writers []io.Writer) <-chan error {
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+
+		var sboms []sbom.SBOM
+		for _, userInput := range userInputs {
+			s, err := decodeSyftSBOM(userInput)
+			if err != nil {
+				errs <- err
+				return
+			}
+			sboms = append(sboms, *s)
+		}
+
+		pres, err := newReportPresenter(filterPackages(appConfig, syft.MergeSBOMs(sboms...)), mergeOutputs, writers, formatOptions(appConfig), nil)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		bus.Publish(partybus.Event{
+			Type:  event.PresenterReady,
+			Value: pres,
+		})
+	}()
+	return errs
+}
+
+// decodeSyftSBOM reads the syft JSON formatted SBOM at the given path (or from stdin, given "-").
+func decodeSyftSBOM(userInput string) (*sbom.SBOM, error) {
+	reader, closer, err := openSBOM(userInput)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	s, inputOption, err := syft.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode SBOM=%q: %w", userInput, err)
+	}
+
+	if inputOption != format.JSONOption {
+		return nil, fmt.Errorf("unsupported SBOM format %q for SBOM=%q: only syft JSON formatted SBOMs are supported", inputOption, userInput)
+	}
+	return s, nil
+}
@@ -3,20 +3,26 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/anchore/stereoscope"
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/anchore"
 	"github.com/anchore/syft/internal/bus"
-	"github.com/anchore/syft/internal/formats"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/table"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/internal/ui"
 	"github.com/anchore/syft/internal/version"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 	"github.com/pkg/profile"
@@ -33,10 +39,12 @@ const (
   {{.appName}} {{.command}} alpine:latest -o spdx        show a SPDX 2.2 tag-value formatted SBOM
   {{.appName}} {{.command}} alpine:latest -o spdx-json   show a SPDX 2.2 JSON formatted SBOM
   {{.appName}} {{.command}} alpine:latest -vv            show verbose debug information
+  {{.appName}} {{.command}} alpine:latest debian:latest  show a single SBOM for multiple sources
 
   Supports the following image sources:
     {{.appName}} {{.command}} yourrepo/yourimage:tag     defaults to using images from a Docker daemon. If Docker is not present, the image is pulled directly from the registry.
     {{.appName}} {{.command}} path/to/a/file/or/dir      a Docker tar, OCI tar, OCI directory, or generic filesystem directory
+    {{.appName}} {{.command}} -                          a Docker tar or OCI tar read from stdin
 
   You can also explicitly specify the scheme to use:
     {{.appName}} {{.command}} docker:yourrepo/yourimage:tag          explicitly use the Docker daemon
@@ -45,30 +53,54 @@ const (
     {{.appName}} {{.command}} oci-dir:path/to/yourimage              read directly from a path on disk for OCI layout directories (from Skopeo or otherwise)
     {{.appName}} {{.command}} dir:path/to/yourproject                read directly from a path on disk (any directory)
     {{.appName}} {{.command}} file:path/to/yourproject/file          read directly from a path on disk (any single file)
+    {{.appName}} {{.command}} git:https://host/yourrepo.git#ref      clone a git repository (optionally at a branch, tag, or commit) and read it as a directory
+    {{.appName}} {{.command}} archive:path/to/yourproject.tar.gz     extract an archive (e.g. zip or tar.gz) and read it as a directory
     {{.appName}} {{.command}} registry:yourrepo/yourimage:tag        pull image directly from a registry (no container runtime required)
 `
 )
 
 var (
-	packagesPresenterOpt format.Option
-	packagesCmd          = &cobra.Command{
-		Use:   "packages [SOURCE]",
+	packagesOutputs []reportOutput
+	packagesCmd     = &cobra.Command{
+		Use:   "packages [SOURCE]...",
 		Short: "Generate a package SBOM",
 		Long:  "Generate a packaged-based Software Bill Of Materials (SBOM) from container images and filesystems",
 		Example: internal.Tprintf(packagesExample, map[string]interface{}{
 			"appName": internal.ApplicationName,
 			"command": "packages",
 		}),
-		Args:          validateInputArgs,
+		Args:          validateMultipleInputArgs,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			// set the presenter
-			presenterOption := format.ParseOption(appConfig.Output)
-			if presenterOption == format.UnknownFormatOption {
-				return fmt.Errorf("bad --output value '%s'", appConfig.Output)
+			// set the presenters
+			outputs, err := parseReportOutputs(appConfig.Output, appConfig.File)
+			if err != nil {
+				return err
+			}
+			packagesOutputs = outputs
+
+			if cmd.Flags().Changed("file-digests") {
+				if appConfig.PackageOnly {
+					return fmt.Errorf("cannot compute file digests when only cataloging packages (--package-only)")
+				}
+				// file cataloging is disabled by default, so explicitly asking for file digests enables it
+				appConfig.FileMetadata.Cataloger.Enabled = true
+			}
+
+			if appConfig.SPDX.UnpackagedFiles.Enabled && (hasReportOutput(outputs, format.SPDXTagValueOption) || hasReportOutput(outputs, format.SPDXJSONOption)) {
+				if appConfig.PackageOnly {
+					return fmt.Errorf("cannot describe unpackaged files when only cataloging packages (--package-only)")
+				}
+				// unpackaged files are described by their digests, so file cataloging must be enabled
+				appConfig.FileMetadata.Cataloger.Enabled = true
+			}
+
+			if len(args) > 1 && (hasReportOutput(outputs, format.SPDXTagValueOption) || hasReportOutput(outputs, format.SPDXJSONOption)) {
+				// SPDX requires a SHA1 checksum for every file entry, which is computed while presenting when there
+				// is a single source (see sourceFileResolver), otherwise always compute it when cataloging file digests
+				appConfig.FileMetadata.Digests = appendDigestIfMissing(appConfig.FileMetadata.Digests, "sha1")
 			}
-			packagesPresenterOpt = presenterOption
 
 			if appConfig.Dev.ProfileCPU && appConfig.Dev.ProfileMem {
 				return fmt.Errorf("cannot profile CPU and memory simultaneously")
@@ -95,15 +127,17 @@ func init() {
 }
 
 func setPackageFlags(flags *pflag.FlagSet) {
+	setPackageFilterFlags(flags)
+
 	// Formatting & Input options //////////////////////////////////////////////
 
 	flags.StringP(
 		"scope", "s", source.SquashedScope.String(),
 		fmt.Sprintf("selection of layers to catalog, options=%v", source.AllScopes))
 
-	flags.StringP(
-		"output", "o", string(format.TableOption),
-		fmt.Sprintf("report output formatter, options=%v", format.AllOptions),
+	flags.StringArrayP(
+		"output", "o", []string{string(format.TableOption)},
+		fmt.Sprintf("report output formatter, formats=%v (may be given multiple times, as <format>=<file> to write a report to its own file)", format.AllOptions),
 	)
 
 	flags.StringP(
@@ -111,6 +145,111 @@ func setPackageFlags(flags *pflag.FlagSet) {
 		"file to write the report output to (default is STDOUT)",
 	)
 
+	flags.StringArrayP(
+		"exclude", "", nil,
+		"exclude paths from being scanned using a glob expression relative to the scanned directory (e.g. '**/node_modules')",
+	)
+
+	flags.DurationP(
+		"pull-timeout", "", 30*time.Minute,
+		"the maximum duration of each attempt to pull an image from a registry (0 for no timeout)",
+	)
+
+	flags.IntP(
+		"pull-retries", "", 2,
+		"the number of times a failed pull from a registry is retried (with an exponential backoff)",
+	)
+
+	flags.StringP(
+		"platform", "", "",
+		"the platform (os/arch[/variant], e.g. linux/arm64) of the image to select from a multi-arch image",
+	)
+
+	flags.BoolP(
+		"package-only", "", false,
+		"only catalog packages, skipping all file analysis (package-owned files, file digests, etc.)",
+	)
+
+	flags.IntP(
+		"parallelism", "", 1,
+		"the number of package catalogers to run concurrently",
+	)
+
+	flags.BoolP(
+		"skip-deduplication", "", false,
+		"report the same package found multiple times as separate packages instead of merging them (useful for debugging)",
+	)
+
+	flags.StringSliceP(
+		"catalogers", "", nil,
+		"only run the given catalogers by name (may be given multiple times or comma-separated), or add to (+name) and remove from (-name) the default catalogers",
+	)
+
+	flags.BoolP(
+		"fail-on-error", "", false,
+		"fail (without writing any report) when any file selected by a cataloger fails to parse (e.g. a corrupt lockfile), instead of skipping it with a warning",
+	)
+
+	flags.BoolP(
+		"skip-layer-cache", "", false,
+		"do not reuse the packages found within image layers by previous runs (nor record them for later runs)",
+	)
+
+	flags.BoolP(
+		"detect-licenses", "", false,
+		"conclude licenses from the license files (e.g. LICENSE or COPYING) owned by packages without licenses in their metadata",
+	)
+
+	flags.BoolP(
+		"normalize-versions", "", false,
+		"also record package versions in a form comparable across ecosystems (e.g. without a deb epoch and release, or a \"v\" prefix)",
+	)
+
+	flags.StringP(
+		"spdx-namespace", "", "",
+		fmt.Sprintf("the URI prefix of the SPDX document namespace, which is followed by a unique ID (default %q)", spdxhelpers.DefaultDocumentNamespacePrefix),
+	)
+
+	flags.BoolP(
+		"spdx-minimal", "", false,
+		"omit optional SPDX fields that only describe how packages were found (e.g. annotations and source info), keeping the fields required by the spec",
+	)
+
+	flags.BoolP(
+		"spdx-metadata-comment", "", false,
+		"include the raw metadata of each package as JSON within the SPDX package comment (considerably grows the document)",
+	)
+
+	flags.StringP(
+		"spdx-created", "", "",
+		fmt.Sprintf("the fixed creation time of SPDX documents for reproducible output, as an RFC 3339 timestamp or seconds since the Unix epoch (default is the $%s env var, otherwise the current time)", spdxhelpers.SourceDateEpochEnvVar),
+	)
+
+	flags.BoolP(
+		"spdx-unpackaged-files", "", false,
+		"describe the top-level files (or those selected by the spdx.unpackaged-files.globs config) that no package contains as unpackaged files in SPDX documents",
+	)
+
+	flags.StringArrayP(
+		"column", "", nil,
+		fmt.Sprintf("add a column to the table output (may be given multiple times), options=%v", table.AllColumns),
+	)
+
+	flags.IntP(
+		"column-width", "", 0,
+		"the maximum width of the columns added with --column, where longer values are truncated (0 for no limit)",
+	)
+
+	flags.BoolP(
+		"wrap-columns", "", false,
+		"split values wider than --column-width over several lines instead of truncating them",
+	)
+
+	flags.StringArrayP(
+		"file-digests", "", nil,
+		"compute digests for all files with the given algorithm (may be given multiple times), options=[md5 sha1 sha256]",
+	)
+
 	// Upload options //////////////////////////////////////////////////////////
 	flags.StringP(
 		"host", "H", "",
@@ -143,7 +282,69 @@ func setPackageFlags(flags *pflag.FlagSet) {
 	)
 }
 
+// setPackageFilterFlags adds the flags that select which packages are reported, which are shared by every command that
+// reports packages (see filterPackages).
+func setPackageFilterFlags(flags *pflag.FlagSet) {
+	flags.StringArrayP(
+		"select-type", "", nil,
+		fmt.Sprintf("only report packages of the given type (may be given multiple times), options=%v", pkg.AllPkgs),
+	)
+
+	flags.StringArrayP(
+		"exclude-type", "", nil,
+		fmt.Sprintf("do not report packages of the given type (may be given multiple times), options=%v", pkg.AllPkgs),
+	)
+
+	flags.StringArrayP(
+		"name", "", nil,
+		"only report packages with names matching the given glob (may be given multiple times, e.g. 'log4j*')",
+	)
+
+	flags.BoolP(
+		"name-regex", "", false,
+		"interpret --name values as regular expressions instead of globs",
+	)
+
+	flags.BoolP(
+		"name-case-sensitive", "", false,
+		"match --name values case-sensitively",
+	)
+
+	flags.BoolP(
+		"exclude-dev", "", false,
+		"do not report packages that lockfiles record as development dependencies (e.g. npm, Poetry, and Composer)",
+	)
+}
+
+func bindPackageFilterConfigOptions(flags *pflag.FlagSet) error {
+	if err := viper.BindPFlag("package.select-type", flags.Lookup("select-type")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.exclude-type", flags.Lookup("exclude-type")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.name", flags.Lookup("name")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.name-regex", flags.Lookup("name-regex")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.name-case-sensitive", flags.Lookup("name-case-sensitive")); err != nil {
+		return err
+	}
+
+	return viper.BindPFlag("package.exclude-dev", flags.Lookup("exclude-dev"))
+}
+
 func bindPackagesConfigOptions(flags *pflag.FlagSet) error {
+	if err := bindPackageFilterConfigOptions(flags); err != nil {
+		return err
+	}
+
 	// Formatting & Input options //////////////////////////////////////////////
 
 	if err := viper.BindPFlag("package.cataloger.scope", flags.Lookup("scope")); err != nil {
@@ -158,6 +359,90 @@ func bindPackagesConfigOptions(flags *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := viper.BindPFlag("exclude", flags.Lookup("exclude")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("registry.pull-timeout", flags.Lookup("pull-timeout")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("registry.pull-retries", flags.Lookup("pull-retries")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("registry.platform", flags.Lookup("platform")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package-only", flags.Lookup("package-only")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.parallelism", flags.Lookup("parallelism")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.skip-deduplication", flags.Lookup("skip-deduplication")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.detect-licenses", flags.Lookup("detect-licenses")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.normalize-versions", flags.Lookup("normalize-versions")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.catalogers", flags.Lookup("catalogers")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.fail-on-error", flags.Lookup("fail-on-error")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("package.skip-layer-cache", flags.Lookup("skip-layer-cache")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("file-metadata.digests", flags.Lookup("file-digests")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.namespace", flags.Lookup("spdx-namespace")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.minimal", flags.Lookup("spdx-minimal")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.metadata-comment", flags.Lookup("spdx-metadata-comment")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.created", flags.Lookup("spdx-created")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("spdx.unpackaged-files.enabled", flags.Lookup("spdx-unpackaged-files")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("table.columns", flags.Lookup("column")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("table.column-width", flags.Lookup("column-width")); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag("table.wrap", flags.Lookup("wrap-columns")); err != nil {
+		return err
+	}
+
 	// Upload options //////////////////////////////////////////////////////////
 
 	if err := viper.BindPFlag("anchore.host", flags.Lookup("host")); err != nil {
@@ -187,7 +472,24 @@ func bindPackagesConfigOptions(flags *pflag.FlagSet) error {
 	return nil
 }
 
+func appendDigestIfMissing(digests []string, digest string) []string {
+	for _, d := range digests {
+		if file.CleanDigestAlgorithmName(d) == digest {
+			return digests
+		}
+	}
+	return append(digests, digest)
+}
+
 func validateInputArgs(cmd *cobra.Command, args []string) error {
+	if err := validateMultipleInputArgs(cmd, args); err != nil {
+		return err
+	}
+
+	return cobra.MaximumNArgs(1)(cmd, args)
+}
+
+func validateMultipleInputArgs(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		// in the case that no arguments are given we want to show the help text and return with a non-0 return code.
 		if err := cmd.Help(); err != nil {
@@ -196,12 +498,12 @@ func validateInputArgs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("an image/directory argument is required")
 	}
 
-	return cobra.MaximumNArgs(1)(cmd, args)
+	return nil
 }
 
 func packagesExec(_ *cobra.Command, args []string) error {
-	// could be an image or a directory, with or without a scheme
-	userInput := args[0]
+	// each could be an image or a directory, with or without a scheme
+	userInputs := args
 
 	reporter, closer, err := reportWriter()
 	defer func() {
@@ -214,15 +516,52 @@ func packagesExec(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	writers, writersCloser, err := reportOutputWriters(packagesOutputs)
+	defer func() {
+		if err := writersCloser(); err != nil {
+			log.Warnf("unable to write to report destination: %+v", err)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	// note: the sources are only cleaned up once the report has been presented, since presenters may read source files
+	cleanups := &sourceCleanups{}
 	return eventLoop(
-		packagesExecWorker(userInput),
+		packagesExecWorker(writers, cleanups, userInputs...),
 		setupSignals(),
 		eventSubscription,
-		stereoscope.Cleanup,
+		func() {
+			cleanups.run()
+			stereoscope.Cleanup()
+		},
 		ui.Select(isVerbose(), appConfig.Quiet, reporter)...,
 	)
 }
 
+// sourceCleanups are the cleanup functions of all sources, which may be added while the sources are cataloged.
+type sourceCleanups struct {
+	lock     sync.Mutex
+	cleanups []func()
+}
+
+func (c *sourceCleanups) add(cleanup func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cleanups = append(c.cleanups, cleanup)
+}
+
+func (c *sourceCleanups) run() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, cleanup := range c.cleanups {
+		cleanup()
+	}
+	c.cleanups = nil
+}
+
 func isVerbose() (result bool) {
 	isPipedInput, err := internal.IsPipedInput()
 	if err != nil {
@@ -234,7 +573,7 @@ func isVerbose() (result bool) {
 	return appConfig.CliOptions.Verbosity > 0 || isPipedInput
 }
 
-func packagesExecWorker(userInput string) <-chan error {
+func packagesExecWorker(writers []io.Writer, cleanups *sourceCleanups, userInputs ...string) <-chan error {
 	errs := make(chan error)
 	go func() {
 		defer close(errs)
@@ -245,56 +584,100 @@ func packagesExecWorker(userInput string) <-chan error {
 			return
 		}
 
-		f := formats.ByOption(packagesPresenterOpt)
-		if f == nil {
-			errs <- fmt.Errorf("unknown format: %s", packagesPresenterOpt)
+		if appConfig.Anchore.Host != "" && len(userInputs) > 1 {
+			errs <- fmt.Errorf("uploading to Anchore Enterprise is only supported for a single source")
 			return
 		}
 
 		checkForApplicationUpdate()
 
-		src, cleanup, err := source.New(userInput, appConfig.Registry.ToOptions())
-		if err != nil {
-			errs <- fmt.Errorf("failed to determine image source: %w", err)
-			return
-		}
-		if cleanup != nil {
-			defer cleanup()
-		}
+		var sources []*source.Source
+		var sboms []sbom.SBOM
+		for _, userInput := range userInputs {
+			src, cleanup, err := source.NewWithOptions(userInput, source.Options{
+				RegistryOptions: appConfig.Registry.ToOptions(),
+				PullOptions:     appConfig.Registry.ToPullOptions(),
+				Exclusions:      appConfig.Exclusions,
+			})
+			if cleanup != nil {
+				cleanups.add(cleanup)
+			}
+			if err != nil {
+				errs <- fmt.Errorf("failed to determine image source: %w", err)
+				return
+			}
 
-		s := sbom.SBOM{
-			Source: src.Metadata,
-			Descriptor: sbom.Descriptor{
-				Name:          internal.ApplicationName,
-				Version:       version.FromBuild().Version,
-				Configuration: appConfig,
-			},
+			sources = append(sources, src)
+			sboms = append(sboms, catalogSource(src, tasks, errs))
 		}
 
-		var relationships []<-chan artifact.Relationship
-		for _, task := range tasks {
-			c := make(chan artifact.Relationship)
-			relationships = append(relationships, c)
-
-			go runTask(task, &s.Artifacts, src, c, errs)
-		}
-		s.Relationships = append(s.Relationships, mergeRelationships(relationships...)...)
+		s := filterPackages(appConfig, sbom.Merge(sboms...))
 
 		if appConfig.Anchore.Host != "" {
-			if err := runPackageSbomUpload(src, s); err != nil {
+			if err := runPackageSbomUpload(sources[0], s); err != nil {
 				errs <- err
 				return
 			}
 		}
 
+		resolver, err := sourceFileResolver(sources)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		pres, err := newReportPresenter(s, packagesOutputs, writers, formatOptions(appConfig), resolver)
+		if err != nil {
+			errs <- err
+			return
+		}
+
 		bus.Publish(partybus.Event{
 			Type:  event.PresenterReady,
-			Value: f.Presenter(s),
+			Value: pres,
 		})
 	}()
 	return errs
 }
 
+// sourceFileResolver returns the resolver that presenters may read the files of the cataloged source with (e.g. to
+// compute the file checksums required by SPDX). Files are only read when file cataloging is enabled, and only for a
+// single source, since the files of merged sources cannot be told apart.
+func sourceFileResolver(sources []*source.Source) (*file.CoordinatesResolver, error) {
+	if !appConfig.FileMetadata.Cataloger.Enabled || len(sources) != 1 {
+		return nil, nil
+	}
+
+	resolver, err := sources[0].FileResolver(appConfig.FileMetadata.Cataloger.ScopeOpt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine resolver while presenting: %w", err)
+	}
+	return file.NewCoordinatesResolver(resolver), nil
+}
+
+// catalogSource runs all tasks against the given source, returning the results as a SBOM.
+func catalogSource(src *source.Source, tasks []task, errs chan<- error) sbom.SBOM {
+	s := sbom.SBOM{
+		Source: src.Metadata,
+		Descriptor: sbom.Descriptor{
+			Name:          internal.ApplicationName,
+			Version:       version.FromBuild().Version,
+			Configuration: appConfig,
+		},
+	}
+
+	var relationships []<-chan artifact.Relationship
+	for _, task := range tasks {
+		c := make(chan artifact.Relationship)
+		relationships = append(relationships, c)
+
+		go runTask(task, &s.Artifacts, src, c, errs)
+	}
+	s.Relationships = append(s.Relationships, mergeRelationships(relationships...)...)
+
+	return s
+}
+
 func mergeRelationships(cs ...<-chan artifact.Relationship) (relationships []artifact.Relationship) {
 	for _, c := range cs {
 		for n := range c {
@@ -113,7 +113,11 @@ func powerUserExecWorker(userInput string) <-chan error {
 
 		checkForApplicationUpdate()
 
-		src, cleanup, err := source.New(userInput, appConfig.Registry.ToOptions())
+		src, cleanup, err := source.NewWithOptions(userInput, source.Options{
+			RegistryOptions: appConfig.Registry.ToOptions(),
+			PullOptions:     appConfig.Registry.ToPullOptions(),
+			Exclusions:      appConfig.Exclusions,
+		})
 		if err != nil {
 			errs <- err
 			return
@@ -143,7 +147,7 @@ func powerUserExecWorker(userInput string) <-chan error {
 
 		bus.Publish(partybus.Event{
 			Type:  event.PresenterReady,
-			Value: syftjson.Format().Presenter(s),
+			Value: syftjson.Format().Presenter(filterPackages(appConfig, s)),
 		})
 	}()
 
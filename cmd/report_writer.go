@@ -4,11 +4,44 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/anchore/go-presenter"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/formats"
 	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/hashicorp/go-multierror"
 )
 
+// reportOutput is a format to write the report as, and the file to write it to (where an empty path indicates the
+// report destination, which is either stdout or the --file value).
+type reportOutput struct {
+	option format.Option
+	path   string
+}
+
+// filterPackages returns the given SBOM with only the packages selected by the package filters (e.g. --select-type and
+// --name), which apply to every command that reports packages. The given SBOM is not modified.
+func filterPackages(cfg *config.Application, s sbom.SBOM) sbom.SBOM {
+	if !cfg.Package.IsFiltered() {
+		return s
+	}
+	return sbom.FilterPackages(s, cfg.Package.KeepPackage)
+}
+
+// formatOptions returns the configured options of all formats (such as the SPDX document namespace and the table
+// columns), which are shared by every command that writes reports.
+func formatOptions(cfg *config.Application) formats.Options {
+	return formats.Options{
+		SPDX:  cfg.SPDX.ToOptions(),
+		Table: cfg.Table.ToOptions(),
+	}
+}
+
 func reportWriter() (io.Writer, func() error, error) {
 	nop := func() error { return nil }
 	path := strings.TrimSpace(appConfig.File)
@@ -31,3 +64,148 @@ func reportWriter() (io.Writer, func() error, error) {
 		}, nil
 	}
 }
+
+// parseReportOutputs parses all --output values, each of the form "<format>" or "<format>=<file>". Values without a
+// file are written to the report destination (stdout or the given --file value). No two reports may be written to the
+// same destination.
+func parseReportOutputs(values []string, reportPath string) ([]reportOutput, error) {
+	if len(values) == 0 {
+		values = []string{string(format.TableOption)}
+	}
+
+	var outputs []reportOutput
+	destinations := make(map[string]string)
+	for _, value := range values {
+		name, path := value, ""
+		if idx := strings.Index(value, "="); idx >= 0 {
+			name, path = strings.TrimSpace(value[:idx]), strings.TrimSpace(value[idx+1:])
+			if path == "" {
+				return nil, fmt.Errorf("bad --output value '%s': no file given", value)
+			}
+		}
+
+		option := format.ParseOption(name)
+		if option == format.UnknownFormatOption {
+			return nil, fmt.Errorf("bad --output value '%s'", value)
+		}
+
+		destination, err := reportDestination(path, reportPath)
+		if err != nil {
+			return nil, err
+		}
+		if other, exists := destinations[destination]; exists {
+			return nil, fmt.Errorf("bad --output value '%s': the %q report is already written to the same destination", value, other)
+		}
+		destinations[destination] = string(option)
+
+		outputs = append(outputs, reportOutput{
+			option: option,
+			path:   path,
+		})
+	}
+	return outputs, nil
+}
+
+// reportDestination describes where a report with the given output path is written to, such that the destinations of
+// two reports are equal if they would be written to the same place.
+func reportDestination(path, reportPath string) (string, error) {
+	if path == "" {
+		path = strings.TrimSpace(reportPath)
+	}
+	if path == "" {
+		return "", nil
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine absolute path for report file=%q: %w", path, err)
+	}
+	return abs, nil
+}
+
+// hasReportOutput indicates if any of the given outputs is of the given format.
+func hasReportOutput(outputs []reportOutput, option format.Option) bool {
+	for _, o := range outputs {
+		if o.option == option {
+			return true
+		}
+	}
+	return false
+}
+
+// reportOutputWriters opens a file for every report that is written to its own file, returning a writer for each of
+// the given outputs (nil for all reports written to the report destination) and a function to close all files.
+func reportOutputWriters(outputs []reportOutput) ([]io.Writer, func() error, error) {
+	var files []*os.File
+	closer := func() error {
+		var errs error
+		for _, f := range files {
+			if err := f.Close(); err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			log.Infof("report written to file=%q", f.Name())
+		}
+		return errs
+	}
+
+	writers := make([]io.Writer, len(outputs))
+	for i, o := range outputs {
+		if o.path == "" {
+			continue
+		}
+
+		reportFile, err := os.OpenFile(o.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			_ = closer()
+			return nil, func() error { return nil }, fmt.Errorf("unable to create report file=%q: %w", o.path, err)
+		}
+		files = append(files, reportFile)
+		writers[i] = reportFile
+	}
+
+	return writers, closer, nil
+}
+
+// reportPresenter presents a report for each of several outputs, where each report is written to its own writer
+// (or the report destination given to Present when there is no writer).
+type reportPresenter struct {
+	presenters []presenter.Presenter
+	writers    []io.Writer
+}
+
+var _ presenter.Presenter = (*reportPresenter)(nil)
+
+// newReportPresenter creates a presenter for the given SBOM in each of the given output formats (configured with the
+// given options), where the writers correspond to the outputs (see reportOutputWriters). Formats may look up source
+// files with the given resolver, which is nil when the source is not available.
+func newReportPresenter(s sbom.SBOM, outputs []reportOutput, writers []io.Writer, opts formats.Options, resolver *file.CoordinatesResolver) (*reportPresenter, error) {
+	if len(outputs) != len(writers) {
+		return nil, fmt.Errorf("mismatched report outputs (%d) and writers (%d)", len(outputs), len(writers))
+	}
+
+	pres := &reportPresenter{
+		writers: writers,
+	}
+	for _, o := range outputs {
+		f := formats.ByOptionWithOptions(o.option, opts)
+		if f == nil {
+			return nil, fmt.Errorf("unknown format: %s", o.option)
+		}
+		pres.presenters = append(pres.presenters, f.PresenterWithResolver(s, resolver))
+	}
+	return pres, nil
+}
+
+func (p *reportPresenter) Present(output io.Writer) error {
+	for i, pres := range p.presenters {
+		writer := p.writers[i]
+		if writer == nil {
+			writer = output
+		}
+		if err := pres.Present(writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/formats"
+	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseReportOutputs(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		reportPath string
+		expected   []reportOutput
+		wantErr    require.ErrorAssertionFunc
+	}{
+		{
+			name:     "default to table",
+			expected: []reportOutput{{option: format.TableOption}},
+		},
+		{
+			name:     "single format",
+			values:   []string{"json"},
+			expected: []reportOutput{{option: format.JSONOption}},
+		},
+		{
+			name:   "formats to their own files",
+			values: []string{"spdx=out.spdx", "json=out.json", "table"},
+			expected: []reportOutput{
+				{option: format.SPDXTagValueOption, path: "out.spdx"},
+				{option: format.JSONOption, path: "out.json"},
+				{option: format.TableOption},
+			},
+		},
+		{
+			name:       "format to its own file and the report file",
+			values:     []string{"spdx=out.spdx", "json"},
+			reportPath: "out.json",
+			expected: []reportOutput{
+				{option: format.SPDXTagValueOption, path: "out.spdx"},
+				{option: format.JSONOption},
+			},
+		},
+		{
+			name:    "unknown format",
+			values:  []string{"bogus=out.txt"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "missing file",
+			values:  []string{"json="},
+			wantErr: require.Error,
+		},
+		{
+			name:    "same file twice",
+			values:  []string{"spdx=out.txt", "json=./out.txt"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "stdout twice",
+			values:  []string{"spdx", "json"},
+			wantErr: require.Error,
+		},
+		{
+			name:       "same file as the report file",
+			values:     []string{"spdx=out.txt", "json"},
+			reportPath: "out.txt",
+			wantErr:    require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+
+			actual, err := parseReportOutputs(test.values, test.reportPath)
+			test.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_reportPresenter(t *testing.T) {
+	dir := t.TempDir()
+	spdxPath := filepath.Join(dir, "out.spdx")
+	jsonPath := filepath.Join(dir, "out.json")
+
+	outputs, err := parseReportOutputs([]string{"spdx=" + spdxPath, "json=" + jsonPath, "table"}, "")
+	require.NoError(t, err)
+
+	writers, closer, err := reportOutputWriters(outputs)
+	require.NoError(t, err)
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(pkg.Package{
+				Name:    "package-1",
+				Version: "1.0.1",
+				Type:    pkg.PythonPkg,
+			}),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	pres, err := newReportPresenter(s, outputs, writers, formats.Options{}, nil)
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	require.NoError(t, pres.Present(&stdout))
+	require.NoError(t, closer())
+
+	// the report without a file is written to the report destination
+	assert.Contains(t, stdout.String(), "package-1")
+	assert.NotContains(t, stdout.String(), "SPDXVersion")
+
+	spdxContents, err := ioutil.ReadFile(spdxPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(spdxContents), "SPDXVersion: SPDX-2.2"))
+	assert.Contains(t, string(spdxContents), "PackageName: package-1")
+
+	jsonContents, err := ioutil.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonContents, &doc))
+	assert.Contains(t, doc, "artifacts")
+	assert.Contains(t, string(jsonContents), `"name": "package-1"`)
+}
+
+func Test_newReportPresenter_mismatchedWriters(t *testing.T) {
+	outputs := []reportOutput{{option: format.JSONOption}, {option: format.TableOption}}
+	_, err := newReportPresenter(sbom.SBOM{}, outputs, []io.Writer{nil}, formats.Options{}, nil)
+	assert.Error(t, err)
+}
+
+func Test_formatOptions(t *testing.T) {
+	cfg := config.Application{}
+	cfg.SPDX.Minimal = true
+	cfg.SPDX.Created = "1651406400"
+	cfg.Table.Columns = []string{"purl"}
+
+	opts := formatOptions(&cfg)
+	assert.True(t, opts.SPDX.Minimal)
+	assert.Equal(t, time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC), opts.SPDX.Created)
+	assert.Equal(t, []string{"purl"}, opts.Table.Columns)
+
+	// options are passed to each format instead of being shared by all of them
+	f := formats.ByOptionWithOptions(format.TableOption, opts)
+	require.NotNil(t, f)
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(pkg.Package{Name: "package-1", Version: "1.0.1", PURL: "pkg:pypi/package-1@1.0.1"}),
+		},
+	}
+	var configured, defaults bytes.Buffer
+	require.NoError(t, f.Encode(&configured, s))
+	require.NoError(t, formats.ByOption(format.TableOption).Encode(&defaults, s))
+	assert.Contains(t, configured.String(), "pkg:pypi/package-1@1.0.1")
+	assert.NotContains(t, defaults.String(), "pkg:pypi/package-1@1.0.1")
+}
+
+func Test_filterPackages(t *testing.T) {
+	apk := pkg.Package{Name: "musl", Version: "1.2.2-r3", Type: pkg.ApkPkg}
+	npm := pkg.Package{Name: "left-pad", Version: "1.3.0", Type: pkg.NpmPkg}
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(apk, npm),
+		},
+	}
+
+	// without any package filters the SBOM is reported as-is
+	cfg := config.Application{}
+	assert.Equal(t, 2, filterPackages(&cfg, s).Artifacts.PackageCatalog.PackageCount())
+
+	cfg.Package.SelectTypes = []string{string(pkg.NpmPkg)}
+	filtered := filterPackages(&cfg, s)
+	require.Equal(t, 1, filtered.Artifacts.PackageCatalog.PackageCount())
+	assert.NotNil(t, filtered.Artifacts.PackageCatalog.Package(npm.ID()))
+	assert.Equal(t, 2, s.Artifacts.PackageCatalog.PackageCount())
+}
+
+func Test_packageFilterFlags(t *testing.T) {
+	// every command that reports packages accepts the package filters
+	for _, cmd := range []*cobra.Command{packagesCmd, convertCmd, mergeCmd} {
+		for _, name := range []string{"select-type", "exclude-type", "name", "name-regex", "name-case-sensitive", "exclude-dev"} {
+			assert.NotNil(t, cmd.Flags().Lookup(name), "command=%s flag=%s", cmd.Name(), name)
+		}
+	}
+}
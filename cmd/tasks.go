@@ -7,6 +7,7 @@ import (
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg/cataloger"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
@@ -45,7 +46,18 @@ func generateCatalogPackagesTask() (task, error) {
 	}
 
 	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
-		packageCatalog, relationships, theDistro, err := syft.CatalogPackages(src, appConfig.Package.Cataloger.ScopeOpt)
+		packageCatalog, relationships, theDistro, err := syft.CatalogPackagesWithConfig(src, cataloger.Config{
+			Scope:             appConfig.Package.Cataloger.ScopeOpt,
+			Parallelism:       appConfig.Package.Parallelism,
+			SkipFileOwnership: appConfig.PackageOnly,
+			SkipDeduplication: appConfig.Package.SkipDeduplication,
+			DetectLicenses:    appConfig.Package.DetectLicenses,
+			NormalizeVersions: appConfig.Package.NormalizeVersions,
+			Catalogers:        appConfig.Package.Catalogers,
+			LayerCacheDir:     appConfig.Package.SelectedLayerCacheDir(),
+			FailOnError:       appConfig.Package.FailOnError,
+			CPERules:          appConfig.Package.CPE.Rules,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -30,7 +30,7 @@ type parser interface {
 // Application is the main syft application configuration.
 type Application struct {
 	ConfigPath         string             `yaml:",omitempty" json:"configPath"`                                                         // the location where the application config was read from (either from -c or discovered while loading)
-	Output             string             `yaml:"output" json:"output" mapstructure:"output"`                                           // -o, the Presenter hint string to use for report formatting
+	Output             []string           `yaml:"output" json:"output" mapstructure:"output"`                                           // -o, the Presenter hint strings to use for report formatting (optionally as "<format>=<file>")
 	File               string             `yaml:"file" json:"file" mapstructure:"file"`                                                 // --file, the file to write report output to
 	Quiet              bool               `yaml:"quiet" json:"quiet" mapstructure:"quiet"`                                              // -q, indicates to not show any status output to stderr (ETUI or logging UI)
 	CheckForAppUpdate  bool               `yaml:"check-for-app-update" json:"check-for-app-update" mapstructure:"check-for-app-update"` // whether to check for an application update on start up or not
@@ -44,6 +44,10 @@ type Application struct {
 	FileContents       fileContents       `yaml:"file-contents" json:"file-contents" mapstructure:"file-contents"`
 	Secrets            secrets            `yaml:"secrets" json:"secrets" mapstructure:"secrets"`
 	Registry           registry           `yaml:"registry" json:"registry" mapstructure:"registry"`
+	SPDX               spdx               `yaml:"spdx" json:"spdx" mapstructure:"spdx"`
+	Table              tableOptions       `yaml:"table" json:"table" mapstructure:"table"`
+	Exclusions         []string           `yaml:"exclude" json:"exclude" mapstructure:"exclude"`                // --exclude, glob patterns of paths to skip while scanning a directory
+	PackageOnly        bool               `yaml:"package-only" json:"package-only" mapstructure:"package-only"` // --package-only, only catalog packages (no file analysis of any kind)
 }
 
 // PowerUserCatalogerEnabledDefault switches all catalogers to be enabled when running power-user command
@@ -84,6 +88,8 @@ func LoadApplicationConfig(v *viper.Viper, cliOpts CliOnlyOptions) (*Application
 func (cfg Application) loadDefaultValues(v *viper.Viper) {
 	// set the default values for primitive fields in this struct
 	v.SetDefault("check-for-app-update", true)
+	v.SetDefault("exclude", []string{})
+	v.SetDefault("package-only", false)
 
 	// for each field in the configuration struct, see if the field implements the defaultValueLoader interface and invoke it if it does
 	value := reflect.ValueOf(cfg)
@@ -101,6 +107,7 @@ func (cfg *Application) parseConfigValues() error {
 	for _, optionFn := range []func() error{
 		cfg.parseUploadOptions,
 		cfg.parseLogLevelOption,
+		cfg.parsePackageOnlyOption,
 	} {
 		if err := optionFn(); err != nil {
 			return err
@@ -130,6 +137,19 @@ func (cfg *Application) parseUploadOptions() error {
 	return nil
 }
 
+func (cfg *Application) parsePackageOnlyOption() error {
+	if !cfg.PackageOnly {
+		return nil
+	}
+
+	// only packages are cataloged, so all file catalogers are disabled (regardless of how they are configured)
+	cfg.FileMetadata.Cataloger.Enabled = false
+	cfg.FileClassification.Cataloger.Enabled = false
+	cfg.FileContents.Cataloger.Enabled = false
+	cfg.Secrets.Cataloger.Enabled = false
+	return nil
+}
+
 func (cfg *Application) parseLogLevelOption() error {
 	switch {
 	case cfg.Quiet:
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+	"github.com/spf13/viper"
+)
+
+type cpeOptions struct {
+	Allow []cpeRule `yaml:"allow" json:"allow" mapstructure:"allow"` // generated CPEs matching these rules are never suppressed
+	Deny  []cpeRule `yaml:"deny" json:"deny" mapstructure:"deny"`    // generated CPEs matching these rules are suppressed
+	Rules cpe.Rules `yaml:"-" json:"-"`
+}
+
+type cpeRule struct {
+	PackageType string `yaml:"package-type" json:"package-type" mapstructure:"package-type"`
+	Vendor      string `yaml:"vendor" json:"vendor" mapstructure:"vendor"`
+	Product     string `yaml:"product" json:"product" mapstructure:"product"`
+}
+
+func (cfg cpeOptions) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("package.cpe.allow", []cpeRule{})
+	v.SetDefault("package.cpe.deny", []cpeRule{})
+}
+
+func (cfg *cpeOptions) parseConfigValues() error {
+	cfg.Rules = cpe.Rules{
+		Allow: toCPERules(cfg.Allow),
+		Deny:  toCPERules(cfg.Deny),
+	}
+	return cfg.Rules.Validate()
+}
+
+func toCPERules(rules []cpeRule) (results []cpe.Rule) {
+	for _, rule := range rules {
+		results = append(results, cpe.Rule{
+			PackageType: pkg.Type(rule.PackageType),
+			Vendor:      rule.Vendor,
+			Product:     rule.Product,
+		})
+	}
+	return results
+}
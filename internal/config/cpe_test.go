@@ -0,0 +1,47 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPEOptions_loadFromConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "syft.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+package:
+  cataloger:
+    scope: squashed
+  cpe:
+    allow:
+      - vendor: "*"
+        product: lodash
+    deny:
+      - package-type: npm
+        vendor: "*"
+      - vendor: nodejs
+`), 0644))
+
+	cfg, err := LoadApplicationConfig(viper.New(), CliOnlyOptions{ConfigPath: configPath})
+	require.NoError(t, err)
+
+	assert.Equal(t, cpe.Rules{
+		Allow: []cpe.Rule{{Vendor: "*", Product: "lodash"}},
+		Deny:  []cpe.Rule{{PackageType: pkg.NpmPkg, Vendor: "*"}, {Vendor: "nodejs"}},
+	}, cfg.Package.CPE.Rules)
+}
+
+func TestCPEOptions_parseConfigValues(t *testing.T) {
+	cfg := cpeOptions{Deny: []cpeRule{{PackageType: "not-a-type", Vendor: "*"}}}
+	assert.Error(t, cfg.parseConfigValues())
+
+	// rules must match something narrower than every CPE
+	cfg = cpeOptions{Allow: []cpeRule{{}}}
+	assert.Error(t, cfg.parseConfigValues())
+}
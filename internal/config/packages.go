@@ -1,15 +1,173 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/spf13/viper"
+)
 
 type packages struct {
-	Cataloger catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+	Cataloger         catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+	SelectTypes       []string         `yaml:"select-type" json:"select-type" mapstructure:"select-type"`                         // --select-type, only report packages of these types
+	ExcludeTypes      []string         `yaml:"exclude-type" json:"exclude-type" mapstructure:"exclude-type"`                      // --exclude-type, do not report packages of these types
+	Parallelism       int              `yaml:"parallelism" json:"parallelism" mapstructure:"parallelism"`                         // --parallelism, the number of package catalogers (and files within each cataloger) to process concurrently
+	Names             []string         `yaml:"name" json:"name" mapstructure:"name"`                                              // --name, only report packages with names matching these globs (or regular expressions)
+	NameRegex         bool             `yaml:"name-regex" json:"name-regex" mapstructure:"name-regex"`                            // --name-regex, interpret the name patterns as regular expressions instead of globs
+	NameCaseSensitive bool             `yaml:"name-case-sensitive" json:"name-case-sensitive" mapstructure:"name-case-sensitive"` // --name-case-sensitive, match the name patterns case-sensitively
+	SkipDeduplication bool             `yaml:"skip-deduplication" json:"skip-deduplication" mapstructure:"skip-deduplication"`    // --skip-deduplication, report the same package found multiple times as separate packages
+	ExcludeDev        bool             `yaml:"exclude-dev" json:"exclude-dev" mapstructure:"exclude-dev"`                         // --exclude-dev, do not report packages that lockfiles record as development-only dependencies
+	DetectLicenses    bool             `yaml:"detect-licenses" json:"detect-licenses" mapstructure:"detect-licenses"`             // --detect-licenses, conclude licenses from the license files owned by packages without licenses in their metadata
+	NormalizeVersions bool             `yaml:"normalize-versions" json:"normalize-versions" mapstructure:"normalize-versions"`    // --normalize-versions, record package versions in a form comparable across ecosystems
+	Catalogers        []string         `yaml:"catalogers" json:"catalogers" mapstructure:"catalogers"`                            // --catalogers, the names of the catalogers to run (or with a +/- prefix, to add to or remove from the defaults)
+	FailOnError       bool             `yaml:"fail-on-error" json:"fail-on-error" mapstructure:"fail-on-error"`                   // --fail-on-error, fail the scan when any file selected by a cataloger fails to parse
+	SkipLayerCache    bool             `yaml:"skip-layer-cache" json:"skip-layer-cache" mapstructure:"skip-layer-cache"`          // --skip-layer-cache, do not reuse (or record) the packages found within image layers by previous runs
+	LayerCacheDir     string           `yaml:"layer-cache-dir" json:"layer-cache-dir" mapstructure:"layer-cache-dir"`             // the dir of the image layer cache (defaults to <xdg cache home>/syft/layer-cache)
+	CPE               cpeOptions       `yaml:"cpe" json:"cpe" mapstructure:"cpe"`                                                 // rules that suppress generated CPEs (config file only)
+	NameExps          []*regexp.Regexp `yaml:"-" json:"-"`
 }
 
 func (cfg packages) loadDefaultValues(v *viper.Viper) {
 	v.SetDefault("package.cataloger.enabled", true)
+	v.SetDefault("package.select-type", []string{})
+	v.SetDefault("package.exclude-type", []string{})
+	v.SetDefault("package.parallelism", 1)
+	v.SetDefault("package.name", []string{})
+	v.SetDefault("package.name-regex", false)
+	v.SetDefault("package.name-case-sensitive", false)
+	v.SetDefault("package.skip-deduplication", false)
+	v.SetDefault("package.exclude-dev", false)
+	v.SetDefault("package.detect-licenses", false)
+	v.SetDefault("package.normalize-versions", false)
+	v.SetDefault("package.catalogers", []string{})
+	v.SetDefault("package.fail-on-error", false)
+	v.SetDefault("package.skip-layer-cache", false)
+	v.SetDefault("package.layer-cache-dir", "")
+	cfg.CPE.loadDefaultValues(v)
 }
 
 func (cfg *packages) parseConfigValues() error {
+	for _, t := range append(cfg.SelectTypes, cfg.ExcludeTypes...) {
+		if !isKnownPackageType(t) {
+			return fmt.Errorf("bad package type given: %q (options: %v)", t, pkg.AllPkgs)
+		}
+	}
+	if cfg.Parallelism < 1 {
+		return fmt.Errorf("bad parallelism value given: %d (must be at least 1)", cfg.Parallelism)
+	}
+
+	if _, err := cataloger.SelectCatalogers(nil, cfg.Catalogers); err != nil {
+		return err
+	}
+
+	cfg.NameExps = nil
+	for _, name := range cfg.Names {
+		exp, err := nameExpression(name, cfg.NameRegex, cfg.NameCaseSensitive)
+		if err != nil {
+			return fmt.Errorf("bad package name pattern given: %q: %w", name, err)
+		}
+		cfg.NameExps = append(cfg.NameExps, exp)
+	}
+
+	if err := cfg.CPE.parseConfigValues(); err != nil {
+		return err
+	}
+
 	return cfg.Cataloger.parseConfigValues()
 }
+
+// SelectedLayerCacheDir returns the dir of the image layer cache, or an empty string when the cache is skipped.
+func (cfg packages) SelectedLayerCacheDir() string {
+	if cfg.SkipLayerCache {
+		return ""
+	}
+	if cfg.LayerCacheDir != "" {
+		return cfg.LayerCacheDir
+	}
+	return filepath.Join(xdg.CacheHome, internal.ApplicationName, "layer-cache")
+}
+
+// KeepPackage indicates if the given package should be reported, based on the selected and excluded package types,
+// the package name patterns (a package is kept if the name matches any of the patterns), and whether development
+// dependencies are excluded.
+func (cfg packages) KeepPackage(p *pkg.Package) bool {
+	if cfg.ExcludeDev && pkg.IsDevDependency(*p) {
+		return false
+	}
+	if len(cfg.SelectTypes) > 0 && !containsType(cfg.SelectTypes, p.Type) {
+		return false
+	}
+	if containsType(cfg.ExcludeTypes, p.Type) {
+		return false
+	}
+	return len(cfg.NameExps) == 0 || matchesAny(cfg.NameExps, p.Name)
+}
+
+// IsFiltered indicates if any package types have been selected or excluded, if any package name patterns were given, or
+// if development dependencies are excluded.
+func (cfg packages) IsFiltered() bool {
+	return len(cfg.SelectTypes) > 0 || len(cfg.ExcludeTypes) > 0 || len(cfg.NameExps) > 0 || cfg.ExcludeDev
+}
+
+// nameExpression compiles the given package name pattern (either a glob or a regular expression) into a regular
+// expression. Globs must match the entire name, where "*" matches any sequence of characters (including "/") and "?"
+// matches any single character. Regular expressions match anywhere within the name unless anchored.
+func nameExpression(pattern string, isRegex, caseSensitive bool) (*regexp.Regexp, error) {
+	if !isRegex {
+		pattern = globToRegex(pattern)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+func matchesAny(exps []*regexp.Regexp, value string) bool {
+	for _, exp := range exps {
+		if exp.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownPackageType(value string) bool {
+	for _, t := range pkg.AllPkgs {
+		if string(t) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(types []string, t pkg.Type) bool {
+	for _, value := range types {
+		if value == string(t) {
+			return true
+		}
+	}
+	return false
+}
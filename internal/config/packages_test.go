@@ -0,0 +1,209 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackages_KeepPackage_names(t *testing.T) {
+	candidates := []pkg.Package{
+		{Name: "log4j-core", Type: pkg.JavaPkg},
+		{Name: "Log4j-API", Type: pkg.JavaPkg},
+		{Name: "@babel/core", Type: pkg.NpmPkg},
+		{Name: "babel-loader", Type: pkg.NpmPkg},
+		{Name: "spring-core", Type: pkg.JavaPkg},
+		{Name: "log4j", Type: pkg.NpmPkg},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      packages
+		expected []string
+	}{
+		{
+			name:     "no patterns",
+			cfg:      packages{},
+			expected: []string{"log4j-core", "Log4j-API", "@babel/core", "babel-loader", "spring-core", "log4j"},
+		},
+		{
+			name: "glob is case-insensitive by default",
+			cfg: packages{
+				Names: []string{"log4j-*"},
+			},
+			expected: []string{"log4j-core", "Log4j-API"},
+		},
+		{
+			name: "case-sensitive glob",
+			cfg: packages{
+				Names:             []string{"log4j-*"},
+				NameCaseSensitive: true,
+			},
+			expected: []string{"log4j-core"},
+		},
+		{
+			name: "glob must match the entire name",
+			cfg: packages{
+				Names: []string{"core"},
+			},
+		},
+		{
+			name: "glob wildcard matches across slashes",
+			cfg: packages{
+				Names: []string{"*babel*"},
+			},
+			expected: []string{"@babel/core", "babel-loader"},
+		},
+		{
+			name: "glob single character wildcard",
+			cfg: packages{
+				Names: []string{"log?j"},
+			},
+			expected: []string{"log4j"},
+		},
+		{
+			name: "glob special characters are literal",
+			cfg: packages{
+				Names: []string{"@babel/cor."},
+			},
+		},
+		{
+			name: "any of several globs",
+			cfg: packages{
+				Names: []string{"spring-*", "@babel/*"},
+			},
+			expected: []string{"@babel/core", "spring-core"},
+		},
+		{
+			name: "regex matches anywhere within the name",
+			cfg: packages{
+				Names:     []string{"core"},
+				NameRegex: true,
+			},
+			expected: []string{"log4j-core", "@babel/core", "spring-core"},
+		},
+		{
+			name: "anchored regex",
+			cfg: packages{
+				Names:     []string{"^log4j(-api)?$"},
+				NameRegex: true,
+			},
+			expected: []string{"Log4j-API", "log4j"},
+		},
+		{
+			name: "case-sensitive regex",
+			cfg: packages{
+				Names:             []string{"^log4j"},
+				NameRegex:         true,
+				NameCaseSensitive: true,
+			},
+			expected: []string{"log4j-core", "log4j"},
+		},
+		{
+			name: "names combined with types",
+			cfg: packages{
+				Names:        []string{"log4j*"},
+				ExcludeTypes: []string{string(pkg.NpmPkg)},
+			},
+			expected: []string{"log4j-core", "Log4j-API"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.cfg.Cataloger.Scope = "squashed"
+			test.cfg.Parallelism = 1
+			require.NoError(t, test.cfg.parseConfigValues())
+
+			var actual []string
+			for i := range candidates {
+				if test.cfg.KeepPackage(&candidates[i]) {
+					actual = append(actual, candidates[i].Name)
+				}
+			}
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, len(test.cfg.Names) > 0 || len(test.cfg.ExcludeTypes) > 0, test.cfg.IsFiltered())
+		})
+	}
+}
+
+func TestPackages_KeepPackage_excludeDev(t *testing.T) {
+	candidates := []pkg.Package{
+		{Name: "lodash", Type: pkg.NpmPkg, MetadataType: pkg.NpmPackageLockJSONMetadataType, Metadata: pkg.NpmPackageLockJSONMetadata{}},
+		{Name: "jest", Type: pkg.NpmPkg, MetadataType: pkg.NpmPackageLockJSONMetadataType, Metadata: pkg.NpmPackageLockJSONMetadata{Dev: true}},
+		{Name: "pytest", Type: pkg.PythonPkg, MetadataType: pkg.PythonPoetryLockMetadataType, Metadata: pkg.PythonPoetryLockMetadata{Category: "dev", Dev: true}},
+		{Name: "phpunit/phpunit", Type: pkg.PhpComposerPkg, MetadataType: pkg.PhpComposerMetadataType, Metadata: pkg.PhpComposerMetadata{Dev: true}},
+		{Name: "log4j-core", Type: pkg.JavaPkg},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      packages
+		expected []string
+	}{
+		{
+			name:     "development dependencies are kept by default",
+			cfg:      packages{},
+			expected: []string{"lodash", "jest", "pytest", "phpunit/phpunit", "log4j-core"},
+		},
+		{
+			name: "exclude development dependencies",
+			cfg: packages{
+				ExcludeDev: true,
+			},
+			expected: []string{"lodash", "log4j-core"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var actual []string
+			for i := range candidates {
+				if test.cfg.KeepPackage(&candidates[i]) {
+					actual = append(actual, candidates[i].Name)
+				}
+			}
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.cfg.ExcludeDev, test.cfg.IsFiltered())
+		})
+	}
+}
+
+func TestPackages_parseConfigValues_badNamePattern(t *testing.T) {
+	cfg := packages{
+		Cataloger:   catalogerOptions{Scope: "squashed"},
+		Parallelism: 1,
+		Names:       []string{"log4j-(core"},
+		NameRegex:   true,
+	}
+	assert.Error(t, cfg.parseConfigValues())
+
+	// the same pattern is a valid glob
+	cfg.NameRegex = false
+	assert.NoError(t, cfg.parseConfigValues())
+}
+
+func TestPackages_parseConfigValues_catalogers(t *testing.T) {
+	cfg := packages{
+		Cataloger:   catalogerOptions{Scope: "squashed"},
+		Parallelism: 1,
+		Catalogers:  []string{"java-cataloger", "-rpmdb-cataloger"},
+	}
+	assert.NoError(t, cfg.parseConfigValues())
+
+	cfg.Catalogers = append(cfg.Catalogers, "not-a-cataloger")
+	assert.Error(t, cfg.parseConfigValues())
+}
+
+func TestPackages_SelectedLayerCacheDir(t *testing.T) {
+	cfg := packages{}
+	assert.True(t, strings.HasSuffix(cfg.SelectedLayerCacheDir(), filepath.Join("syft", "layer-cache")))
+
+	cfg.LayerCacheDir = "/tmp/layers"
+	assert.Equal(t, "/tmp/layers", cfg.SelectedLayerCacheDir())
+
+	cfg.SkipLayerCache = true
+	assert.Empty(t, cfg.SelectedLayerCacheDir())
+}
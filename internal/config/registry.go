@@ -1,9 +1,18 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/syft/source"
+	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/spf13/viper"
 )
@@ -18,21 +27,33 @@ type RegistryCredentials struct {
 	Token string `yaml:"-" json:"-" mapstructure:"token"`
 }
 
+// indexedRegistryAuthEnvPattern matches env vars that provide credentials for one of several registries (e.g.
+// SYFT_REGISTRY_AUTH_1_AUTHORITY and SYFT_REGISTRY_AUTH_1_TOKEN), where all env vars with the same index make up a
+// single set of credentials.
+var indexedRegistryAuthEnvPattern = regexp.MustCompile(`^SYFT_REGISTRY_AUTH_(\d+)_(AUTHORITY|USERNAME|PASSWORD|TOKEN)=(.*)$`)
+
 type registry struct {
 	InsecureSkipTLSVerify bool                  `yaml:"insecure-skip-tls-verify" json:"insecure-skip-tls-verify" mapstructure:"insecure-skip-tls-verify"`
 	InsecureUseHTTP       bool                  `yaml:"insecure-use-http" json:"insecure-use-http" mapstructure:"insecure-use-http"`
 	Auth                  []RegistryCredentials `yaml:"auth" json:"auth" mapstructure:"auth"`
+	PullTimeout           time.Duration         `yaml:"pull-timeout" json:"pull-timeout" mapstructure:"pull-timeout"` // --pull-timeout, the maximum duration of each attempt to pull an image from a registry (0 for no timeout)
+	PullRetries           int                   `yaml:"pull-retries" json:"pull-retries" mapstructure:"pull-retries"` // --pull-retries, the number of times a failed pull from a registry is retried (with an exponential backoff)
+	Platform              string                `yaml:"platform" json:"platform" mapstructure:"platform"`             // --platform, the platform (os/arch[/variant]) of the image to select from a multi-arch image
 }
 
 func (cfg registry) loadDefaultValues(v *viper.Viper) {
 	v.SetDefault("registry.insecure-skip-tls-verify", false)
 	v.SetDefault("registry.insecure-use-http", false)
 	v.SetDefault("registry.auth", []RegistryCredentials{})
+	v.SetDefault("registry.pull-timeout", 30*time.Minute)
+	v.SetDefault("registry.pull-retries", 2)
+	v.SetDefault("registry.platform", "")
 }
 
-// nolint: unparam
 func (cfg *registry) parseConfigValues() error {
 	// there may be additional credentials provided by env var that should be appended to the set of credentials
+	var fromEnv []RegistryCredentials
+
 	authority, username, password, token :=
 		os.Getenv("SYFT_REGISTRY_AUTH_AUTHORITY"),
 		os.Getenv("SYFT_REGISTRY_AUTH_USERNAME"),
@@ -40,19 +61,83 @@ func (cfg *registry) parseConfigValues() error {
 		os.Getenv("SYFT_REGISTRY_AUTH_TOKEN")
 
 	if hasNonEmptyCredentials(username, password, token) {
+		fromEnv = append(fromEnv, RegistryCredentials{
+			Authority: authority,
+			Username:  username,
+			Password:  password,
+			Token:     token,
+		})
+	}
+
+	fromEnv = append(fromEnv, indexedRegistryCredentials(os.Environ())...)
+
+	if len(fromEnv) > 0 {
 		// note: we prepend the credentials such that the environment variables take precedence over on-disk configuration.
-		cfg.Auth = append([]RegistryCredentials{
-			{
-				Authority: authority,
-				Username:  username,
-				Password:  password,
-				Token:     token,
-			},
-		}, cfg.Auth...)
+		cfg.Auth = append(fromEnv, cfg.Auth...)
+	}
+
+	if cfg.PullTimeout < 0 {
+		return fmt.Errorf("bad registry pull timeout %q: must not be negative", cfg.PullTimeout)
+	}
+	if cfg.PullRetries < 0 {
+		return fmt.Errorf("bad registry pull retries %d: must not be negative", cfg.PullRetries)
+	}
+	if cfg.Platform != "" {
+		if _, err := source.ParsePlatform(cfg.Platform); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// indexedRegistryCredentials returns all credentials provided by indexed env vars (see indexedRegistryAuthEnvPattern)
+// from the given environment, ordered by index.
+func indexedRegistryCredentials(environ []string) (results []RegistryCredentials) {
+	byIndex := make(map[int]*RegistryCredentials)
+	for _, env := range environ {
+		match := indexedRegistryAuthEnvPattern.FindStringSubmatch(env)
+		if match == nil {
+			continue
+		}
+
+		index, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+
+		credentials, exists := byIndex[index]
+		if !exists {
+			credentials = &RegistryCredentials{}
+			byIndex[index] = credentials
+		}
+
+		switch match[2] {
+		case "AUTHORITY":
+			credentials.Authority = match[3]
+		case "USERNAME":
+			credentials.Username = match[3]
+		case "PASSWORD":
+			credentials.Password = match[3]
+		case "TOKEN":
+			credentials.Token = match[3]
+		}
+	}
+
+	indexes := make([]int, 0, len(byIndex))
+	for index := range byIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		c := byIndex[index]
+		if hasNonEmptyCredentials(c.Username, c.Password, c.Token) {
+			results = append(results, *c)
+		}
+	}
+	return results
+}
+
 func hasNonEmptyCredentials(username, password, token string) bool {
 	return password != "" && username != "" || token != ""
 }
@@ -61,7 +146,7 @@ func (cfg *registry) ToOptions() *image.RegistryOptions {
 	var auth = make([]image.RegistryCredentials, len(cfg.Auth))
 	for i, a := range cfg.Auth {
 		auth[i] = image.RegistryCredentials{
-			Authority: a.Authority,
+			Authority: normalizeRegistryAuthority(a.Authority),
 			Username:  a.Username,
 			Password:  a.Password,
 			Token:     a.Token,
@@ -73,3 +158,33 @@ func (cfg *registry) ToOptions() *image.RegistryOptions {
 		Credentials:           auth,
 	}
 }
+
+// normalizeRegistryAuthority returns the registry host for the given authority as it is referred to when pulling
+// images, such that credentials for Docker Hub given as "docker.io" (or as a URL, as found in docker config files)
+// are used for "index.docker.io" images.
+func normalizeRegistryAuthority(authority string) string {
+	authority = strings.TrimSpace(authority)
+	if u, err := url.Parse(authority); err == nil && u.Host != "" {
+		authority = u.Host
+	}
+	authority = strings.TrimSuffix(authority, "/")
+
+	switch authority {
+	case "docker.io", "registry-1.docker.io":
+		return name.DefaultRegistry
+	}
+	return authority
+}
+
+func (cfg *registry) ToPullOptions() *source.RegistryPullOptions {
+	var platform *source.Platform
+	if cfg.Platform != "" {
+		// note: the platform has already been validated when the config was parsed
+		platform, _ = source.ParsePlatform(cfg.Platform)
+	}
+	return &source.RegistryPullOptions{
+		Timeout:  cfg.PullTimeout,
+		Retries:  cfg.PullRetries,
+		Platform: platform,
+	}
+}
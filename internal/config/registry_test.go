@@ -1,11 +1,19 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
-	"github.com/anchore/stereoscope/pkg/image"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/registrytest"
+	"github.com/anchore/syft/syft/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHasNonEmptyCredentials(t *testing.T) {
@@ -109,3 +117,206 @@ func Test_registry_ToOptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_indexedRegistryCredentials(t *testing.T) {
+	environ := []string{
+		"SYFT_REGISTRY_AUTH_2_AUTHORITY=ghcr.io",
+		"SYFT_REGISTRY_AUTH_2_TOKEN=a-token",
+		"SYFT_REGISTRY_AUTH_1_AUTHORITY=localhost:5000",
+		"SYFT_REGISTRY_AUTH_1_USERNAME=user",
+		"SYFT_REGISTRY_AUTH_1_PASSWORD=pass",
+		// incomplete credentials are ignored
+		"SYFT_REGISTRY_AUTH_3_AUTHORITY=quay.io",
+		"SYFT_REGISTRY_AUTH_3_USERNAME=user-without-password",
+		// not indexed credentials
+		"SYFT_REGISTRY_AUTH_TOKEN=another-token",
+		"HOME=/root",
+	}
+
+	expected := []RegistryCredentials{
+		{
+			Authority: "localhost:5000",
+			Username:  "user",
+			Password:  "pass",
+		},
+		{
+			Authority: "ghcr.io",
+			Token:     "a-token",
+		},
+	}
+
+	assert.Equal(t, expected, indexedRegistryCredentials(environ))
+}
+
+func Test_normalizeRegistryAuthority(t *testing.T) {
+	tests := []struct {
+		authority string
+		expected  string
+	}{
+		{authority: "", expected: ""},
+		{authority: "localhost:5000", expected: "localhost:5000"},
+		{authority: "ghcr.io", expected: "ghcr.io"},
+		{authority: "docker.io", expected: "index.docker.io"},
+		{authority: "registry-1.docker.io", expected: "index.docker.io"},
+		{authority: "https://index.docker.io/v1/", expected: "index.docker.io"},
+		{authority: "https://registry.example.com/", expected: "registry.example.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.authority, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizeRegistryAuthority(test.authority))
+		})
+	}
+}
+
+// authenticatedRegistry is a stub registry middleware that only allows requests with the given authorization header,
+// recording the authorization header of every request.
+type authenticatedRegistry struct {
+	next          http.Handler
+	authorization string
+	lock          sync.Mutex
+	received      []string
+}
+
+func (r *authenticatedRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	r.received = append(r.received, req.Header.Get("Authorization"))
+	r.lock.Unlock()
+
+	if req.Header.Get("Authorization") != r.authorization {
+		w.Header().Set("WWW-Authenticate", `Basic realm="stub"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	r.next.ServeHTTP(w, req)
+}
+
+func (r *authenticatedRegistry) receivedAuthorizations() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.received
+}
+
+// newAuthenticatedRegistry starts a stub registry with a single image, returning the registry and the registry host.
+func newAuthenticatedRegistry(t *testing.T, authorization string) (*authenticatedRegistry, string) {
+	stub := &authenticatedRegistry{
+		authorization: authorization,
+	}
+	server := registrytest.New(t, func(next http.Handler) http.Handler {
+		stub.next = next
+		return stub
+	})
+	return stub, server.Host
+}
+
+func basicAuthorization(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func Test_registry_credentialsSentToRegistry(t *testing.T) {
+	basicStub, basicHost := newAuthenticatedRegistry(t, basicAuthorization("basic-user", "basic-pass"))
+	tokenStub, tokenHost := newAuthenticatedRegistry(t, "Bearer a-token")
+	dockerConfigStub, dockerConfigHost := newAuthenticatedRegistry(t, basicAuthorization("docker-user", "docker-pass"))
+
+	// credentials for registries without configured credentials are read from the docker config file
+	dockerConfigDir := t.TempDir()
+	dockerConfig := fmt.Sprintf(`{"auths": {%q: {"auth": %q}}}`, dockerConfigHost, base64.StdEncoding.EncodeToString([]byte("docker-user:docker-pass")))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dockerConfigDir, "config.json"), []byte(dockerConfig), 0600))
+
+	registrytest.Setenv(t, "DOCKER_CONFIG", dockerConfigDir)
+
+	cfg := registry{
+		InsecureUseHTTP: true,
+		Auth: []RegistryCredentials{
+			{
+				Authority: basicHost,
+				Username:  "basic-user",
+				Password:  "basic-pass",
+			},
+			{
+				Authority: tokenHost,
+				Token:     "a-token",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		stub     *authenticatedRegistry
+		host     string
+		expected string
+	}{
+		{
+			name:     "basic auth",
+			stub:     basicStub,
+			host:     basicHost,
+			expected: basicAuthorization("basic-user", "basic-pass"),
+		},
+		{
+			name:     "bearer token",
+			stub:     tokenStub,
+			host:     tokenHost,
+			expected: "Bearer a-token",
+		},
+		{
+			name:     "docker config",
+			stub:     dockerConfigStub,
+			host:     dockerConfigHost,
+			expected: basicAuthorization("docker-user", "docker-pass"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, cleanup, err := source.New("registry:"+test.host+"/some/image:latest", cfg.ToOptions())
+			t.Cleanup(cleanup)
+			require.NoError(t, err)
+			assert.Equal(t, source.ImageScheme, src.Metadata.Scheme)
+
+			// the credentials for one registry must never be sent to another
+			assert.Contains(t, test.stub.receivedAuthorizations(), test.expected)
+			for _, authorization := range test.stub.receivedAuthorizations() {
+				if authorization != "" {
+					assert.Equal(t, test.expected, authorization)
+				}
+			}
+		})
+	}
+}
+
+func Test_registry_ToPullOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    registry
+		expected *source.Platform
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:    "no platform",
+			input:   registry{},
+			wantErr: require.NoError,
+		},
+		{
+			name:     "platform",
+			input:    registry{Platform: "linux/arm/v7"},
+			expected: &source.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			wantErr:  require.NoError,
+		},
+		{
+			name:    "bad platform",
+			input:   registry{Platform: "arm64"},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.parseConfigValues()
+			test.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expected, test.input.ToPullOptions().Platform)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/spf13/viper"
+)
+
+type spdx struct {
+	Namespace       string              `yaml:"namespace" json:"namespace" mapstructure:"namespace"`                      // --spdx-namespace, the URI prefix of the SPDX document namespace (an empty value uses the default prefix)
+	Minimal         bool                `yaml:"minimal" json:"minimal" mapstructure:"minimal"`                            // --spdx-minimal, omit optional SPDX fields that only describe how packages were found
+	MetadataComment bool                `yaml:"metadata-comment" json:"metadata-comment" mapstructure:"metadata-comment"` // --spdx-metadata-comment, include the raw metadata of each package as JSON within the package comment
+	Created         string              `yaml:"created" json:"created" mapstructure:"created"`                            // --spdx-created, the fixed creation time of documents (an RFC 3339 timestamp or seconds since the Unix epoch)
+	UnpackagedFiles spdxUnpackagedFiles `yaml:"unpackaged-files" json:"unpackaged-files" mapstructure:"unpackaged-files"` // describe files that are not contained by any package
+}
+
+type spdxUnpackagedFiles struct {
+	Enabled bool     `yaml:"enabled" json:"enabled" mapstructure:"enabled"` // --spdx-unpackaged-files, describe the selected files that no package contains as unpackaged files
+	Globs   []string `yaml:"globs" json:"globs" mapstructure:"globs"`       // the files (relative to the source root) to consider, the top-level files by default
+}
+
+func (cfg spdx) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("spdx.namespace", "")
+	v.SetDefault("spdx.minimal", false)
+	v.SetDefault("spdx.metadata-comment", false)
+	// note: reproducible build tools set a fixed timestamp with SOURCE_DATE_EPOCH
+	v.SetDefault("spdx.created", os.Getenv(spdxhelpers.SourceDateEpochEnvVar))
+	v.SetDefault("spdx.unpackaged-files.enabled", false)
+	v.SetDefault("spdx.unpackaged-files.globs", spdxhelpers.DefaultUnpackagedFileGlobs)
+}
+
+func (cfg *spdx) parseConfigValues() error {
+	if _, err := spdxhelpers.ParseCreated(cfg.Created); err != nil {
+		return err
+	}
+	return cfg.ToOptions().Validate()
+}
+
+// ToOptions returns the options that SPDX documents are created with.
+func (cfg spdx) ToOptions() spdxhelpers.Options {
+	// note: the creation time has already been validated when the config was parsed
+	created, _ := spdxhelpers.ParseCreated(cfg.Created)
+	return spdxhelpers.Options{
+		NamespacePrefix:     cfg.Namespace,
+		Minimal:             cfg.Minimal,
+		MetadataComment:     cfg.MetadataComment,
+		Created:             created,
+		UnpackagedFileGlobs: cfg.UnpackagedFiles.SelectedGlobs(),
+	}
+}
+
+// SelectedGlobs returns the globs that select unpackaged files, which is empty when unpackaged files are disabled.
+func (cfg spdxUnpackagedFiles) SelectedGlobs() []string {
+	if !cfg.Enabled {
+		return nil
+	}
+	return cfg.Globs
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPDX_parseConfigValues(t *testing.T) {
+	tests := []struct {
+		namespace string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			namespace: "",
+			wantErr:   assert.NoError,
+		},
+		{
+			namespace: "https://sbom.example.com/published",
+			wantErr:   assert.NoError,
+		},
+		{
+			namespace: "sbom.example.com/published",
+			wantErr:   assert.Error,
+		},
+		{
+			namespace: "https://sbom.example.com/published#fragment",
+			wantErr:   assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.namespace, func(t *testing.T) {
+			cfg := spdx{Namespace: test.namespace}
+			test.wantErr(t, cfg.parseConfigValues())
+		})
+	}
+}
+
+func TestSPDX_ToOptions(t *testing.T) {
+	cfg := spdx{
+		Namespace:       "https://sbom.example.com/published",
+		Minimal:         true,
+		MetadataComment: true,
+		Created:         "1651406400",
+		UnpackagedFiles: spdxUnpackagedFiles{
+			Globs: []string{"*"},
+		},
+	}
+
+	assert.Equal(t, spdxhelpers.Options{
+		NamespacePrefix: "https://sbom.example.com/published",
+		Minimal:         true,
+		MetadataComment: true,
+		Created:         time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC),
+	}, cfg.ToOptions())
+
+	// the globs only select unpackaged files when enabled
+	cfg.UnpackagedFiles.Enabled = true
+	assert.Equal(t, []string{"*"}, cfg.ToOptions().UnpackagedFileGlobs)
+
+	cfg.UnpackagedFiles.Globs = []string{"[a-"}
+	assert.Error(t, cfg.parseConfigValues())
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/anchore/syft/internal/formats/table"
+	"github.com/spf13/viper"
+)
+
+type tableOptions struct {
+	Columns     []string `yaml:"columns" json:"columns" mapstructure:"columns"`                // --column, the optional columns to show after the name, version, and type of each package
+	ColumnWidth int      `yaml:"column-width" json:"column-width" mapstructure:"column-width"` // --column-width, the maximum width of the values within optional columns (0 for no limit)
+	Wrap        bool     `yaml:"wrap" json:"wrap" mapstructure:"wrap"`                         // --wrap-columns, split values wider than the column width over several lines instead of truncating them
+}
+
+func (cfg tableOptions) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("table.columns", []string{})
+	v.SetDefault("table.column-width", 0)
+	v.SetDefault("table.wrap", false)
+}
+
+func (cfg *tableOptions) parseConfigValues() error {
+	return cfg.ToOptions().Validate()
+}
+
+// ToOptions returns the options that the table output is written with.
+func (cfg tableOptions) ToOptions() table.Options {
+	return table.Options{
+		Columns:     cfg.Columns,
+		ColumnWidth: cfg.ColumnWidth,
+		Wrap:        cfg.Wrap,
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
@@ -23,12 +27,16 @@ func ToFormatModel(s sbom.SBOM) *cyclonedx.BOM {
 	cdxBOM.Metadata = toBomDescriptor(internal.ApplicationName, versionInfo.Version, s.Source)
 
 	packages := s.Artifacts.PackageCatalog.Sorted()
+	bomRefs := toBomRefs(packages)
 	components := make([]cyclonedx.Component, len(packages))
 	for i, p := range packages {
-		components[i] = toComponent(p)
+		components[i] = toComponent(p, bomRefs[p.ID()])
 	}
 	cdxBOM.Components = &components
 
+	dependencies := toDependencies(packages, bomRefs, s.Relationships)
+	cdxBOM.Dependencies = &dependencies
+
 	return cdxBOM
 }
 
@@ -47,14 +55,93 @@ func toBomDescriptor(name, version string, srcMetadata source.Metadata) *cyclone
 	}
 }
 
-func toComponent(p pkg.Package) cyclonedx.Component {
+func toComponent(p pkg.Package, bomRef string) cyclonedx.Component {
 	return cyclonedx.Component{
+		BOMRef:     bomRef,
 		Type:       cyclonedx.ComponentTypeLibrary,
 		Name:       p.Name,
 		Version:    p.Version,
 		PackageURL: p.PURL,
-		Licenses:   toLicenses(p.Licenses),
+		Licenses:   toLicenses(p.LicenseExpressions()),
+	}
+}
+
+// toBomRefs returns a unique bom-ref for each of the given packages (keyed by package ID). The package URL is used
+// when it identifies a single package, otherwise the package ID is added as a "package-id" qualifier to disambiguate
+// between packages with the same package URL (e.g. the same package installed in different locations). Packages
+// without a (valid) package URL are referenced by package ID.
+func toBomRefs(packages []pkg.Package) map[artifact.ID]string {
+	purlCount := make(map[string]int)
+	for _, p := range packages {
+		purlCount[p.PURL]++
 	}
+
+	refs := make(map[artifact.ID]string, len(packages))
+	for _, p := range packages {
+		refs[p.ID()] = toBomRef(p, purlCount[p.PURL] > 1)
+	}
+	return refs
+}
+
+func toBomRef(p pkg.Package, ambiguous bool) string {
+	if p.PURL == "" {
+		return string(p.ID())
+	}
+	if !ambiguous {
+		return p.PURL
+	}
+
+	purl, err := packageurl.FromString(p.PURL)
+	if err != nil {
+		log.Debugf("unable to parse package URL=%q for bom-ref: %+v", p.PURL, err)
+		return string(p.ID())
+	}
+	purl.Qualifiers = append(purl.Qualifiers, packageurl.Qualifier{
+		Key:   "package-id",
+		Value: string(p.ID()),
+	})
+	return purl.ToString()
+}
+
+// toDependencies describes the dependency graph between all packages, where every package is listed (even if there
+// are no known dependencies) and the dependency edges are taken from the package-to-package "dependency-of"
+// relationships. Dependencies are listed in the same order as the given packages.
+func toDependencies(packages []pkg.Package, bomRefs map[artifact.ID]string, relationships []artifact.Relationship) []cyclonedx.Dependency {
+	dependsOn := make(map[artifact.ID][]artifact.ID)
+	for _, r := range relationships {
+		if r.Type != artifact.DependencyOfRelationship {
+			continue
+		}
+		// the "from" package is a dependency of the "to" package
+		if _, exists := bomRefs[r.From.ID()]; !exists {
+			continue
+		}
+		if _, exists := bomRefs[r.To.ID()]; !exists {
+			continue
+		}
+		dependsOn[r.To.ID()] = append(dependsOn[r.To.ID()], r.From.ID())
+	}
+
+	result := make([]cyclonedx.Dependency, len(packages))
+	for i, p := range packages {
+		refs := internal.NewStringSet()
+		for _, id := range dependsOn[p.ID()] {
+			refs.Add(bomRefs[id])
+		}
+
+		dependencies := make([]cyclonedx.Dependency, 0, len(refs))
+		for _, ref := range refs.ToSlice() {
+			dependencies = append(dependencies, cyclonedx.Dependency{
+				Ref: ref,
+			})
+		}
+
+		result[i] = cyclonedx.Dependency{
+			Ref:          bomRefs[p.ID()],
+			Dependencies: &dependencies,
+		}
+	}
+	return result
 }
 
 func toBomDescriptorComponent(srcMetadata source.Metadata) *cyclonedx.Component {
@@ -75,19 +162,37 @@ func toBomDescriptorComponent(srcMetadata source.Metadata) *cyclonedx.Component
 	return nil
 }
 
-func toLicenses(ls []string) *cyclonedx.Licenses {
+func toLicenses(ls []pkg.License) *cyclonedx.Licenses {
 	if len(ls) == 0 {
 		return nil
 	}
 
 	lc := make(cyclonedx.Licenses, len(ls))
-	for i, licenseName := range ls {
-		lc[i] = cyclonedx.LicenseChoice{
-			License: &cyclonedx.License{
-				Name: licenseName,
-			},
+	for i, l := range ls {
+		switch {
+		case l.Compound:
+			lc[i] = cyclonedx.LicenseChoice{
+				Expression: l.Expression,
+			}
+		case isSPDXLicenseID(l.Expression):
+			lc[i] = cyclonedx.LicenseChoice{
+				License: &cyclonedx.License{
+					ID: l.Expression,
+				},
+			}
+		default:
+			lc[i] = cyclonedx.LicenseChoice{
+				License: &cyclonedx.License{
+					Name: l.Value,
+				},
+			}
 		}
 	}
 
 	return &lc
 }
+
+func isSPDXLicenseID(value string) bool {
+	id, exists := spdxlicense.ID(value)
+	return exists && id == value
+}
@@ -0,0 +1,153 @@
+package cyclonedxhelpers
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toLicenses(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected *cyclonedx.Licenses
+	}{
+		{
+			name:  "no licenses",
+			input: pkg.Package{},
+		},
+		{
+			name: "SPDX license and non-SPDX license",
+			input: pkg.Package{
+				Licenses: []string{"mit", "made-up"},
+			},
+			expected: &cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "MIT"}},
+				{License: &cyclonedx.License{Name: "made-up"}},
+			},
+		},
+		{
+			name: "compound expression",
+			input: pkg.Package{
+				Licenses: []string{"MIT or Apache-2.0"},
+			},
+			expected: &cyclonedx.Licenses{
+				{Expression: "MIT OR Apache-2.0"},
+			},
+		},
+		{
+			name: "free-form names are not expressions",
+			input: pkg.Package{
+				Licenses: []string{"GPL or Artistic", "BSD (3 clause)", "MIT OR my license"},
+			},
+			expected: &cyclonedx.Licenses{
+				{License: &cyclonedx.License{Name: "GPL or Artistic"}},
+				{License: &cyclonedx.License{Name: "BSD (3 clause)"}},
+				{License: &cyclonedx.License{Name: "MIT OR my license"}},
+			},
+		},
+		{
+			name: "compound expression with a license ref",
+			input: pkg.Package{
+				Licenses: []string{"MIT OR LicenseRef-my-license"},
+			},
+			expected: &cyclonedx.Licenses{
+				{Expression: "MIT OR LicenseRef-my-license"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, toLicenses(test.input.LicenseExpressions()))
+		})
+	}
+}
+
+func Test_toBomRefs(t *testing.T) {
+	unique := pkg.Package{Name: "unique", Version: "1.0.0", PURL: "pkg:npm/unique@1.0.0"}
+	duplicate1 := pkg.Package{Name: "dup", Version: "1.0.0", PURL: "pkg:npm/dup@1.0.0?arch=x86", FoundBy: "first"}
+	duplicate2 := pkg.Package{Name: "dup", Version: "1.0.0", PURL: "pkg:npm/dup@1.0.0?arch=x86", FoundBy: "second"}
+	noPURL := pkg.Package{Name: "no-purl", Version: "1.0.0"}
+
+	refs := toBomRefs([]pkg.Package{unique, duplicate1, duplicate2, noPURL})
+
+	assert.Equal(t, map[artifact.ID]string{
+		unique.ID():     "pkg:npm/unique@1.0.0",
+		duplicate1.ID(): "pkg:npm/dup@1.0.0?arch=x86&package-id=" + string(duplicate1.ID()),
+		duplicate2.ID(): "pkg:npm/dup@1.0.0?arch=x86&package-id=" + string(duplicate2.ID()),
+		noPURL.ID():     string(noPURL.ID()),
+	}, refs)
+}
+
+func TestToFormatModel_dependencies(t *testing.T) {
+	// app -> lib -> util, where "standalone" has no dependencies
+	app := pkg.Package{Name: "app", Version: "1.0.0", PURL: "pkg:npm/app@1.0.0"}
+	lib := pkg.Package{Name: "lib", Version: "2.0.0", PURL: "pkg:npm/lib@2.0.0"}
+	util := pkg.Package{Name: "util", Version: "3.0.0", PURL: "pkg:npm/util@3.0.0"}
+	standalone := pkg.Package{Name: "standalone", Version: "4.0.0", PURL: "pkg:npm/standalone@4.0.0"}
+	missing := pkg.Package{Name: "missing", Version: "5.0.0", PURL: "pkg:npm/missing@5.0.0"}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(app, lib, util, standalone),
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From: lib,
+				To:   app,
+				Type: artifact.DependencyOfRelationship,
+			},
+			{
+				From: util,
+				To:   lib,
+				Type: artifact.DependencyOfRelationship,
+			},
+			{
+				// only dependency relationships are described
+				From: app,
+				To:   standalone,
+				Type: artifact.OwnershipByFileOverlapRelationship,
+			},
+			{
+				// relationships to packages that are not in the catalog are dropped
+				From: missing,
+				To:   standalone,
+				Type: artifact.DependencyOfRelationship,
+			},
+		},
+	}
+
+	bom := ToFormatModel(s)
+
+	require.NotNil(t, bom.Components)
+	var componentRefs []string
+	for _, c := range *bom.Components {
+		componentRefs = append(componentRefs, c.BOMRef)
+	}
+	assert.Equal(t, []string{app.PURL, lib.PURL, standalone.PURL, util.PURL}, componentRefs)
+
+	require.NotNil(t, bom.Dependencies)
+	assert.Equal(t, []cyclonedx.Dependency{
+		{
+			Ref:          app.PURL,
+			Dependencies: &[]cyclonedx.Dependency{{Ref: lib.PURL}},
+		},
+		{
+			Ref:          lib.PURL,
+			Dependencies: &[]cyclonedx.Dependency{{Ref: util.PURL}},
+		},
+		{
+			Ref:          standalone.PURL,
+			Dependencies: &[]cyclonedx.Dependency{},
+		},
+		{
+			Ref:          util.PURL,
+			Dependencies: &[]cyclonedx.Dependency{},
+		},
+	}, *bom.Dependencies)
+}
@@ -0,0 +1,38 @@
+package spdxhelpers
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// ToolName returns the name and version of syft, as used to identify the tool that created the SPDX document (and
+// any annotations within it).
+func ToolName() string {
+	return internal.ApplicationName + "-" + version.FromBuild().Version
+}
+
+// FoundByAnnotation returns the comment for an SPDX package annotation that names the cataloger (and the syft version)
+// that discovered the given package. An empty string is returned if the cataloger is not known.
+func FoundByAnnotation(p pkg.Package) string {
+	if p.FoundBy == "" {
+		return ""
+	}
+	return fmt.Sprintf("found-by: %s (cataloged by %s)", p.FoundBy, ToolName())
+}
+
+// LayerAnnotations returns the comments for the SPDX package annotations that describe the image layer that introduced
+// the given package. Since image layers are not SPDX elements, this is captured as a package annotation instead of a
+// relationship.
+func LayerAnnotations(p pkg.Package, relationships []artifact.Relationship) (comments []string) {
+	for _, r := range relationships {
+		if r.Type != artifact.ContainedByLayerRelationship || r.From.ID() != p.ID() {
+			continue
+		}
+		comments = append(comments, fmt.Sprintf("%s: %s", r.Type, r.To.ID()))
+	}
+	return comments
+}
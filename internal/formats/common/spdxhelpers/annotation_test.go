@@ -0,0 +1,19 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FoundByAnnotation(t *testing.T) {
+	actual := FoundByAnnotation(pkg.Package{
+		Name:    "musl",
+		FoundBy: "apkdb-cataloger",
+	})
+	assert.Contains(t, actual, "apkdb-cataloger")
+	assert.Contains(t, actual, ToolName())
+
+	assert.Empty(t, FoundByAnnotation(pkg.Package{Name: "musl"}))
+}
@@ -0,0 +1,30 @@
+package spdxhelpers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SourceDateEpochEnvVar is the environment variable that reproducible build tools use to set a fixed timestamp (see
+// https://reproducible-builds.org/specs/source-date-epoch/), which is the default creation time when set.
+const SourceDateEpochEnvVar = "SOURCE_DATE_EPOCH"
+
+// ParseCreated parses the given SPDX document creation time, which is either an RFC 3339 timestamp (e.g.
+// "2022-05-01T12:00:00Z") or the number of seconds since the Unix epoch (e.g. the value of SOURCE_DATE_EPOCH). An
+// empty value results in the zero time.
+func ParseCreated(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SPDX creation time %q: must be an RFC 3339 timestamp (e.g. 2022-05-01T12:00:00Z) or the number of seconds since the Unix epoch", value)
+	}
+	return t.UTC().Truncate(time.Second), nil
+}
@@ -0,0 +1,55 @@
+package spdxhelpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreated(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Time
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			value:    "",
+			expected: time.Time{},
+		},
+		{
+			value:    "1651406400",
+			expected: time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			value:    "2022-05-01T12:00:00Z",
+			expected: time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			// normalized to UTC without fractional seconds
+			value:    "2022-05-01T14:00:00.5+02:00",
+			expected: time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			value:   "2022-05-01",
+			wantErr: require.Error,
+		},
+		{
+			value:   "yesterday",
+			wantErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+
+			actual, err := ParseCreated(test.value)
+			test.wantErr(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
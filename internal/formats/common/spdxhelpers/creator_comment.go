@@ -0,0 +1,48 @@
+package spdxhelpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/syft/distro"
+	"github.com/anchore/syft/syft/source"
+)
+
+// CreatorComment describes how the scan was performed as space-separated "key=value" pairs (e.g.
+// "scheme=image manifest-digest=sha256:... distro=debian distro-version=11"), which is intended to be parsed by
+// downstream tooling to determine the provenance of the document. The distro is only described if one was identified.
+func CreatorComment(srcMetadata source.Metadata, d *distro.Distro) string {
+	var fields []string
+	switch srcMetadata.Scheme {
+	case source.ImageScheme:
+		fields = append(fields, "scheme=image")
+		if srcMetadata.ImageMetadata.ManifestDigest != "" {
+			fields = append(fields, fmt.Sprintf("manifest-digest=%s", srcMetadata.ImageMetadata.ManifestDigest))
+		}
+	case source.DirectoryScheme:
+		fields = append(fields, "scheme=directory")
+	case source.FileScheme:
+		fields = append(fields, "scheme=file")
+	default:
+		return ""
+	}
+	return strings.Join(append(fields, distroFields(d)...), " ")
+}
+
+// distroFields describes the given Linux distribution as "key=value" pairs, where values never contain whitespace. A
+// distro without a name (e.g. an empty distro decoded from an existing document) is not described.
+func distroFields(d *distro.Distro) (fields []string) {
+	if d == nil || d.Name() == "" {
+		return nil
+	}
+
+	fields = append(fields, fmt.Sprintf("distro=%s", d.Name()))
+	if d.RawVersion != "" {
+		fields = append(fields, fmt.Sprintf("distro-version=%s", d.RawVersion))
+	}
+	// ID_LIKE is a space-separated list of distro IDs (e.g. "rhel fedora")
+	if like := strings.Fields(d.IDLike); len(like) > 0 {
+		fields = append(fields, fmt.Sprintf("distro-id-like=%s", strings.Join(like, ",")))
+	}
+	return fields
+}
@@ -0,0 +1,109 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/distro"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreatorComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		srcMetadata source.Metadata
+		distro      *distro.Distro
+		expected    string
+	}{
+		{
+			name: "image",
+			srcMetadata: source.Metadata{
+				Scheme: source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{
+					UserInput:      "image-repo/name:tag",
+					ManifestDigest: "sha256:2731251dc34951c0e50fcc643b4c5f74922dad1a5d98f302b504cf46cd5d9368",
+				},
+			},
+			expected: "scheme=image manifest-digest=sha256:2731251dc34951c0e50fcc643b4c5f74922dad1a5d98f302b504cf46cd5d9368",
+		},
+		{
+			name: "image without manifest digest",
+			srcMetadata: source.Metadata{
+				Scheme: source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{
+					UserInput: "image-repo/name:tag",
+				},
+			},
+			expected: "scheme=image",
+		},
+		{
+			name: "directory",
+			srcMetadata: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "some/path/to/place",
+			},
+			expected: "scheme=directory",
+		},
+		{
+			name: "file",
+			srcMetadata: source.Metadata{
+				Scheme: source.FileScheme,
+				Path:   "some/path/to/place",
+			},
+			expected: "scheme=file",
+		},
+		{
+			name: "image with distro",
+			srcMetadata: source.Metadata{
+				Scheme: source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{
+					UserInput:      "image-repo/name:tag",
+					ManifestDigest: "sha256:2731251dc34951c0e50fcc643b4c5f74922dad1a5d98f302b504cf46cd5d9368",
+				},
+			},
+			distro:   newDistro(t, distro.CentOS, "8", "rhel fedora"),
+			expected: "scheme=image manifest-digest=sha256:2731251dc34951c0e50fcc643b4c5f74922dad1a5d98f302b504cf46cd5d9368 distro=centos distro-version=8 distro-id-like=rhel,fedora",
+		},
+		{
+			name: "directory with distro without version",
+			srcMetadata: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "some/path/to/place",
+			},
+			distro:   newDistro(t, distro.Alpine, "", ""),
+			expected: "scheme=directory distro=alpine",
+		},
+		{
+			// e.g. the distro of an SBOM that is converted or merged, when the original document has none
+			name: "directory with empty distro",
+			srcMetadata: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "some/path/to/place",
+			},
+			distro:   &distro.Distro{},
+			expected: "scheme=directory",
+		},
+		{
+			name: "unknown",
+			srcMetadata: source.Metadata{
+				Scheme: source.UnknownScheme,
+			},
+			distro:   newDistro(t, distro.Debian, "11", ""),
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CreatorComment(test.srcMetadata, test.distro))
+		})
+	}
+}
+
+func newDistro(t *testing.T, distroType distro.Type, version, like string) *distro.Distro {
+	t.Helper()
+
+	d, err := distro.NewDistro(distroType, version, like)
+	require.NoError(t, err)
+	return &d
+}
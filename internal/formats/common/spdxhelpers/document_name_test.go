@@ -71,3 +71,11 @@ func Test_DocumentName(t *testing.T) {
 	// assert all possible schemes were under test
 	assert.ElementsMatch(t, allSchemes.List(), testedSchemes.List(), "not all source.Schemes are under test")
 }
+
+func Test_DocumentName_unknownScheme(t *testing.T) {
+	_, err := DocumentName(source.Metadata{
+		Scheme: source.UnknownScheme,
+		Path:   "some/path/to/place",
+	})
+	assert.Error(t, err)
+}
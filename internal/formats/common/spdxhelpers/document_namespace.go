@@ -1,24 +1,65 @@
 package spdxhelpers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 	"github.com/google/uuid"
 )
 
-func DocumentNameAndNamespace(srcMetadata source.Metadata) (string, string, error) {
-	name, err := DocumentName(srcMetadata)
+// DefaultDocumentNamespacePrefix is the URI that all SPDX document namespaces are prefixed with, unless overridden.
+const DefaultDocumentNamespacePrefix = "https://anchore.com/" + internal.ApplicationName
+
+// ParseDocumentNamespacePrefix parses the given SPDX document namespace prefix, which must be an absolute URI without
+// a fragment (since the spec forbids a "#" within the namespace).
+func ParseDocumentNamespacePrefix(prefix string) (*url.URL, error) {
+	u, err := url.Parse(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPDX document namespace prefix %q: %w", prefix, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return nil, fmt.Errorf("invalid SPDX document namespace prefix %q: must be an absolute URI (e.g. https://example.com/sboms)", prefix)
+	}
+	if strings.Contains(prefix, "#") {
+		return nil, fmt.Errorf("invalid SPDX document namespace prefix %q: must not contain a fragment", prefix)
+	}
+	return u, nil
+}
+
+func DocumentNameAndNamespace(s sbom.SBOM, opts Options) (string, string, error) {
+	name, err := DocumentName(s.Source)
+	if err != nil {
+		return "", "", err
+	}
+	namespace, err := DocumentNamespace(name, s.Source, s.Artifacts.PackageCatalog, opts)
 	if err != nil {
 		return "", "", err
 	}
-	return name, DocumentNamespace(name, srcMetadata), nil
+	return name, namespace, nil
 }
 
-func DocumentNamespace(name string, srcMetadata source.Metadata) string {
+// DocumentNamespace returns the namespace of the SPDX document with the given name, which is the namespace prefix (see
+// Options.NamespacePrefix) followed by the source type, name, and a unique ID.
+func DocumentNamespace(name string, srcMetadata source.Metadata, catalog *pkg.Catalog, opts Options) (string, error) {
+	prefix := opts.NamespacePrefix
+	if prefix == "" {
+		prefix = DefaultDocumentNamespacePrefix
+	}
+	u, err := ParseDocumentNamespacePrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
 	input := "unknown-source-type"
 	switch srcMetadata.Scheme {
 	case source.ImageScheme:
@@ -29,17 +70,47 @@ func DocumentNamespace(name string, srcMetadata source.Metadata) string {
 		input = "file"
 	}
 
-	uniqueID := uuid.Must(uuid.NewRandom())
+	uniqueID := documentUniqueID(input, name, srcMetadata, catalog, opts.Created)
 	identifier := path.Join(input, uniqueID.String())
 	if name != "." {
 		identifier = path.Join(input, fmt.Sprintf("%s-%s", name, uniqueID.String()))
 	}
 
-	u := url.URL{
-		Scheme: "https",
-		Host:   "anchore.com",
-		Path:   path.Join(internal.ApplicationName, identifier),
+	u.Path = path.Join("/", u.Path, identifier)
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// documentUniqueID returns the unique part of a document namespace, which is random unless the creation time is fixed
+// (see Options.Created). Reproducible documents are instead identified by their source, creation time, and contents, so
+// that the same results for the same source have the same namespace (while other results, such as from a directory
+// whose contents changed, do not).
+func documentUniqueID(input, name string, srcMetadata source.Metadata, catalog *pkg.Catalog, created time.Time) uuid.UUID {
+	if created.IsZero() {
+		return uuid.Must(uuid.NewRandom())
+	}
+	identity := strings.Join([]string{
+		input,
+		name,
+		srcMetadata.Path,
+		srcMetadata.ImageMetadata.ManifestDigest,
+		created.Format(time.RFC3339),
+		contentsDigest(catalog),
+	}, "\n")
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(identity))
+}
+
+// contentsDigest returns a digest of the sorted IDs of all packages within the given catalog.
+func contentsDigest(catalog *pkg.Catalog) string {
+	var ids []string
+	if catalog != nil {
+		for p := range catalog.Enumerate() {
+			ids = append(ids, string(p.ID()))
+		}
 	}
+	sort.Strings(ids)
 
-	return u.String()
+	digest := sha256.Sum256([]byte(strings.Join(ids, "\n")))
+	return hex.EncodeToString(digest[:])
 }
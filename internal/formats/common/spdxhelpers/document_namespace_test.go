@@ -2,12 +2,16 @@ package spdxhelpers
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/source"
 	"github.com/scylladb/go-set/strset"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_documentNamespace(t *testing.T) {
@@ -57,7 +61,7 @@ func Test_documentNamespace(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actual := DocumentNamespace(test.inputName, test.srcMetadata)
+			actual := documentNamespace(t, test.inputName, test.srcMetadata, nil, Options{})
 			// note: since the namespace ends with a UUID we check the prefix
 			assert.True(t, strings.HasPrefix(actual, test.expected), fmt.Sprintf("actual namespace %q", actual))
 
@@ -69,3 +73,132 @@ func Test_documentNamespace(t *testing.T) {
 	// assert all possible schemes were under test
 	assert.ElementsMatch(t, allSchemes.List(), testedSchemes.List(), "not all source.Schemes are under test")
 }
+
+func Test_documentNamespace_uniqueAndValid(t *testing.T) {
+	srcMetadata := source.Metadata{
+		Scheme: source.DirectoryScheme,
+		Path:   "some path/with spaces",
+	}
+
+	name, err := DocumentName(srcMetadata)
+	require.NoError(t, err)
+
+	first := documentNamespace(t, name, srcMetadata, nil, Options{})
+	second := documentNamespace(t, name, srcMetadata, nil, Options{})
+
+	// the spec requires a unique namespace for each document (even for the same input)
+	assert.NotEqual(t, first, second)
+
+	for _, namespace := range []string{first, second} {
+		u, err := url.Parse(namespace)
+		require.NoError(t, err)
+		assert.Equal(t, "https", u.Scheme)
+		assert.Empty(t, u.Fragment)
+		assert.True(t, strings.HasPrefix(u.Path, "/syft/dir/some path/with spaces-"), fmt.Sprintf("actual path %q", u.Path))
+	}
+}
+
+func Test_documentNamespace_prefixOverride(t *testing.T) {
+	srcMetadata := source.Metadata{
+		Scheme: source.ImageScheme,
+	}
+
+	actual := documentNamespace(t, "my-name", srcMetadata, nil, Options{NamespacePrefix: "https://sbom.example.com/published/"})
+	assert.True(t, strings.HasPrefix(actual, "https://sbom.example.com/published/image/my-name-"), fmt.Sprintf("actual namespace %q", actual))
+
+	// an empty value uses the default prefix
+	actual = documentNamespace(t, "my-name", srcMetadata, nil, Options{})
+	assert.True(t, strings.HasPrefix(actual, DefaultDocumentNamespacePrefix+"/image/my-name-"), fmt.Sprintf("actual namespace %q", actual))
+
+	// invalid prefixes are never used
+	_, err := DocumentNamespace("my-name", srcMetadata, nil, Options{NamespacePrefix: "sbom.example.com/published"})
+	assert.Error(t, err)
+}
+
+func Test_ParseDocumentNamespacePrefix(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			prefix:  "https://sbom.example.com",
+			wantErr: require.NoError,
+		},
+		{
+			prefix:  "http://sbom.example.com/published/sboms",
+			wantErr: require.NoError,
+		},
+		{
+			prefix:  "sbom.example.com/published",
+			wantErr: require.Error,
+		},
+		{
+			prefix:  "/published/sboms",
+			wantErr: require.Error,
+		},
+		{
+			prefix:  "https://sbom.example.com/published#fragment",
+			wantErr: require.Error,
+		},
+		{
+			prefix:  "https://sbom.example.com/published#",
+			wantErr: require.Error,
+		},
+		{
+			prefix:  "https://sbom example.com",
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.prefix, func(t *testing.T) {
+			_, err := ParseDocumentNamespacePrefix(test.prefix)
+			test.wantErr(t, err)
+			test.wantErr(t, Options{NamespacePrefix: test.prefix}.Validate())
+		})
+	}
+}
+
+func Test_documentNamespace_reproducible(t *testing.T) {
+	srcMetadata := source.Metadata{
+		Scheme: source.DirectoryScheme,
+		Path:   "some/path/to/place",
+	}
+
+	opts := Options{Created: time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	first := documentNamespace(t, "my-name", srcMetadata, nil, opts)
+	assert.Equal(t, first, documentNamespace(t, "my-name", srcMetadata, nil, opts))
+
+	// other sources and creation times result in other namespaces
+	assert.NotEqual(t, first, documentNamespace(t, "my-name", source.Metadata{Scheme: source.DirectoryScheme, Path: "other"}, nil, opts))
+	opts.Created = opts.Created.Add(time.Second)
+	assert.NotEqual(t, first, documentNamespace(t, "my-name", srcMetadata, nil, opts))
+}
+
+func Test_documentNamespace_reproducibleContents(t *testing.T) {
+	srcMetadata := source.Metadata{
+		Scheme: source.DirectoryScheme,
+		Path:   "some/path/to/place",
+	}
+
+	opts := Options{Created: time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	rake := pkg.Package{Name: "rake", Version: "13.0.6", Type: pkg.GemPkg}
+	rack := pkg.Package{Name: "rack", Version: "2.2.3", Type: pkg.GemPkg}
+
+	// the same directory with the same contents results in the same namespace (regardless of the package order)
+	first := documentNamespace(t, "my-name", srcMetadata, pkg.NewCatalog(rake, rack), opts)
+	assert.Equal(t, first, documentNamespace(t, "my-name", srcMetadata, pkg.NewCatalog(rack, rake), opts))
+
+	// the same directory with other contents (at the same creation time) results in another namespace
+	assert.NotEqual(t, first, documentNamespace(t, "my-name", srcMetadata, pkg.NewCatalog(rake), opts))
+	rack.Version = "2.2.4"
+	assert.NotEqual(t, first, documentNamespace(t, "my-name", srcMetadata, pkg.NewCatalog(rake, rack), opts))
+}
+
+func documentNamespace(t *testing.T, name string, srcMetadata source.Metadata, catalog *pkg.Catalog, opts Options) string {
+	t.Helper()
+	namespace, err := DocumentNamespace(name, srcMetadata, catalog, opts)
+	require.NoError(t, err)
+	return namespace
+}
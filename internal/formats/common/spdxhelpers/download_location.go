@@ -1,6 +1,10 @@
 package spdxhelpers
 
-import "github.com/anchore/syft/syft/pkg"
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
 
 func DownloadLocation(p pkg.Package) string {
 	// 3.7: Package Download Location
@@ -10,6 +14,9 @@ func DownloadLocation(p pkg.Package) string {
 	//   (i) the SPDX file creator has attempted to but cannot reach a reasonable objective determination;
 	//   (ii) the SPDX file creator has made no attempt to determine this field; or
 	//   (iii) the SPDX file creator has intentionally provided no information (no meaning should be implied by doing so).
+	//
+	// only locations recorded by the package metadata are reported; a URL derived from the package name and version
+	// (e.g. for a public registry) may not be where the package was actually obtained from.
 
 	if hasMetadata(p) {
 		switch metadata := p.Metadata.(type) {
@@ -17,7 +24,22 @@ func DownloadLocation(p pkg.Package) string {
 			return NoneIfEmpty(metadata.URL)
 		case pkg.NpmPackageJSONMetadata:
 			return NoneIfEmpty(metadata.URL)
+		case pkg.NpmPackageLockJSONMetadata:
+			return noAssertionIfEmpty(npmResolvedDownloadLocation(metadata.Resolved))
+		case pkg.CondaMetadata:
+			return NoneIfEmpty(metadata.URL)
 		}
 	}
+
 	return "NOASSERTION"
 }
+
+// npmResolvedDownloadLocation returns the "resolved" value from a package-lock.json entry when it refers to a remote
+// location (e.g. a registry tarball or a git repository). Local references (e.g. "file:../pkg") are not download
+// locations and are ignored.
+func npmResolvedDownloadLocation(resolved string) string {
+	if !strings.Contains(resolved, "://") {
+		return ""
+	}
+	return resolved
+}
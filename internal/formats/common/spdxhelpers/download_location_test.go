@@ -36,6 +36,82 @@ func Test_DownloadLocation(t *testing.T) {
 			},
 			expected: "http://a-place.gov",
 		},
+		{
+			name: "from npm lockfile",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageLockJSONMetadata{
+					Resolved:  "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz",
+					Integrity: "sha512-v2kDEe57lecTulaDIuNTPy3Ry4gLGJ6Z1O3vE1krgXZNrsQ+LFTGHVxVjcXPs17LhbZVGedAJv8XZ1tvj5FvSg==",
+				},
+			},
+			expected: "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz",
+		},
+		{
+			name: "from npm lockfile with a local dependency",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageLockJSONMetadata{
+					Resolved: "file:../local-pkg",
+				},
+			},
+			expected: "NOASSERTION",
+		},
+		{
+			name: "from npm lockfile without a resolved location",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageLockJSONMetadata{},
+			},
+			expected: "NOASSERTION",
+		},
+		{
+			name: "from python",
+			input: pkg.Package{
+				Name:    "requests",
+				Version: "2.26.0",
+				Metadata: pkg.PythonPackageMetadata{
+					Name:    "requests",
+					Version: "2.26.0",
+				},
+			},
+			expected: "NOASSERTION",
+		},
+		{
+			name: "from gem",
+			input: pkg.Package{
+				Name:    "bundler",
+				Version: "2.1.4",
+				Metadata: pkg.GemMetadata{
+					Name:    "bundler",
+					Version: "2.1.4",
+				},
+			},
+			expected: "NOASSERTION",
+		},
+		{
+			name: "from conda",
+			input: pkg.Package{
+				Metadata: pkg.CondaMetadata{
+					URL: "https://repo.anaconda.com/pkgs/main/noarch/six-1.16.0-pyhd3eb1b0_0.conda",
+				},
+			},
+			expected: "https://repo.anaconda.com/pkgs/main/noarch/six-1.16.0-pyhd3eb1b0_0.conda",
+		},
+		{
+			name: "from gem without version",
+			input: pkg.Package{
+				Name:     "bundler",
+				Metadata: pkg.GemMetadata{},
+			},
+			expected: "NOASSERTION",
+		},
+		{
+			name: "from go module",
+			input: pkg.Package{
+				Name:    "github.com/BurntSushi/toml",
+				Version: "v0.3.1",
+				Type:    pkg.GoModulePkg,
+			},
+			expected: "NOASSERTION",
+		},
 		{
 			name: "empty",
 			input: pkg.Package{
@@ -0,0 +1,11 @@
+package spdxhelpers
+
+import "regexp"
+
+// source: https://spdx.github.io/spdx-spec/3-package-information/#32-package-spdx-identifier
+var expr = regexp.MustCompile("[^a-zA-Z0-9.-]")
+
+// SanitizeElementID replaces all characters that are not permitted within an SPDX identifier ([a-zA-Z0-9.\-]+) with "-".
+func SanitizeElementID(id string) string {
+	return expr.ReplaceAllString(id, "-")
+}
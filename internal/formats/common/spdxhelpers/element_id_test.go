@@ -0,0 +1,44 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SanitizeElementID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "letters",
+			expected: "letters",
+		},
+		{
+			input:    "ABC-def.123",
+			expected: "ABC-def.123",
+		},
+		{
+			input:    "github.com/anchore/syft",
+			expected: "github.com-anchore-syft",
+		},
+		{
+			input:    "a name with spaces",
+			expected: "a-name-with-spaces",
+		},
+		{
+			input:    "libstdc++",
+			expected: "libstdc--",
+		},
+		{
+			input:    "@scope/name_1:2",
+			expected: "-scope-name-1-2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, SanitizeElementID(test.input))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package spdxhelpers
+
+import (
+	"crypto"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// FileDigests returns the digests for all files described by the SBOM. Files without digests from the file digest
+// cataloger fall back to any digests that the owning package metadata has recorded (e.g. the hashes within a python
+// RECORD file), which allows for emitting file checksums without re-reading the files. The SBOM digests are returned
+// as-is when no package recorded any further digests (so the result must not be modified).
+func FileDigests(s sbom.SBOM) map[source.Coordinates][]file.Digest {
+	// note: the digests of large catalogs take up a lot of memory, so they are only copied when there are additions
+	additions := make(map[source.Coordinates][]file.Digest)
+
+	recordedByPackage := make(map[artifact.ID]map[string][]file.Digest)
+	for _, r := range s.Relationships {
+		if r.Type != artifact.ContainsRelationship {
+			continue
+		}
+
+		p, ok := r.From.(pkg.Package)
+		if !ok {
+			continue
+		}
+
+		coordinates, ok := r.To.(source.Coordinates)
+		if !ok {
+			continue
+		}
+
+		if _, exists := s.Artifacts.FileDigests[coordinates]; exists {
+			continue
+		}
+		if _, exists := additions[coordinates]; exists {
+			continue
+		}
+
+		recorder, ok := p.Metadata.(pkg.FileDigestRecorder)
+		if !ok {
+			continue
+		}
+
+		recorded, exists := recordedByPackage[p.ID()]
+		if !exists {
+			recorded = recorder.RecordedFileDigests()
+			recordedByPackage[p.ID()] = recorded
+		}
+
+		if digests := recorded[coordinates.RealPath]; len(digests) > 0 {
+			additions[coordinates] = digests
+		}
+	}
+
+	if len(additions) == 0 {
+		return s.Artifacts.FileDigests
+	}
+
+	results := make(map[source.Coordinates][]file.Digest, len(s.Artifacts.FileDigests)+len(additions))
+	for coordinates, digests := range s.Artifacts.FileDigests {
+		results[coordinates] = digests
+	}
+	for coordinates, digests := range additions {
+		results[coordinates] = digests
+	}
+	return results
+}
+
+// ResolvedFileDigests returns the digests for all files described by the SBOM (see FileDigests), where the SHA1 digest
+// that SPDX requires for each file is computed with the given resolver for any regular file without one. Files that
+// cannot be read are left as-is. The result is the same as FileDigests when the resolver is nil.
+func ResolvedFileDigests(s sbom.SBOM, resolver *file.CoordinatesResolver) map[source.Coordinates][]file.Digest {
+	digests := FileDigests(s)
+	if resolver == nil {
+		return digests
+	}
+
+	sha1 := file.DigestAlgorithmName(crypto.SHA1)
+	additions := make(map[source.Coordinates][]file.Digest)
+	for _, coordinates := range sbom.AllCoordinates(s) {
+		if hasDigest(digests[coordinates], sha1) {
+			continue
+		}
+		if metadata, exists := s.Artifacts.FileMetadata[coordinates]; exists && metadata.Type != source.RegularFile {
+			continue
+		}
+
+		computed, err := resolver.FileDigests(coordinates, crypto.SHA1)
+		if err != nil {
+			log.Debugf("unable to compute the digest of file=%q: %+v", coordinates, err)
+			continue
+		}
+		if len(computed) > 0 {
+			additions[coordinates] = append(append([]file.Digest{}, digests[coordinates]...), computed...)
+		}
+	}
+
+	if len(additions) == 0 {
+		return digests
+	}
+
+	results := make(map[source.Coordinates][]file.Digest, len(digests)+len(additions))
+	for coordinates, d := range digests {
+		results[coordinates] = d
+	}
+	for coordinates, d := range additions {
+		results[coordinates] = d
+	}
+	return results
+}
+
+func hasDigest(digests []file.Digest, algorithm string) bool {
+	for _, d := range digests {
+		if d.Algorithm == algorithm {
+			return true
+		}
+	}
+	return false
+}
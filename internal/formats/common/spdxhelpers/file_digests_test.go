@@ -0,0 +1,118 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileDigests(t *testing.T) {
+	p := pkg.Package{
+		Name:         "six",
+		Version:      "1.16.0",
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPackageMetadataType,
+		Metadata: pkg.PythonPackageMetadata{
+			Name:                 "six",
+			Version:              "1.16.0",
+			SitePackagesRootPath: "/usr/lib/python3/site-packages",
+			Files: []pkg.PythonFileRecord{
+				{
+					Path: "six.py",
+					Digest: &pkg.PythonFileDigest{
+						Algorithm: "sha256",
+						Value:     "q8QVtzC8XGvQzMgWoTHcOzPfZ0oQNSG5Oeck-oY76U4",
+					},
+				},
+				{
+					Path: "six-1.16.0.dist-info/METADATA",
+					Digest: &pkg.PythonFileDigest{
+						Algorithm: "sha256",
+						Value:     "oWvC7ojwqJ5EFZmo9QOx9Qq7hnI5_QlJzJ0iQkOOoRQ",
+					},
+				},
+			},
+		},
+	}
+
+	recorded := source.NewLocation("/usr/lib/python3/site-packages/six.py").Coordinates
+	cataloged := source.NewLocation("/usr/lib/python3/site-packages/six-1.16.0.dist-info/METADATA").Coordinates
+	catalogedDigests := []file.Digest{
+		{Algorithm: "sha1", Value: "4bd3f6d9c3b2a2c0e8c1a0c7bd4ad0d6c2f1e6a1"},
+	}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			FileDigests: map[source.Coordinates][]file.Digest{
+				cataloged: catalogedDigests,
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From: p,
+				To:   recorded,
+				Type: artifact.ContainsRelationship,
+			},
+			{
+				From: p,
+				To:   cataloged,
+				Type: artifact.ContainsRelationship,
+			},
+		},
+	}
+
+	assert.Equal(t, map[source.Coordinates][]file.Digest{
+		// digests from the file digest cataloger take precedence over recorded digests...
+		cataloged: catalogedDigests,
+		// ...otherwise the recorded digests are used
+		recorded: {
+			{Algorithm: "sha256", Value: "abc415b730bc5c6bd0ccc816a131dc3b33df674a103521b939e724fa863be94e"},
+		},
+	}, FileDigests(s))
+}
+
+func Test_ResolvedFileDigests(t *testing.T) {
+	// note: any readable file works as a fixture
+	digested := source.NewLocation("file_digests.go").Coordinates
+	undigested := source.NewLocation("file_digests_test.go").Coordinates
+	missing := source.NewLocation("missing.go").Coordinates
+	md5 := []file.Digest{{Algorithm: "md5", Value: "d41d8cd98f00b204e9800998ecf8427e"}}
+	sha1 := []file.Digest{{Algorithm: "sha1", Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			FileDigests: map[source.Coordinates][]file.Digest{
+				digested:   sha1,
+				undigested: md5,
+			},
+			FileMetadata: map[source.Coordinates]source.FileMetadata{
+				missing: {Type: source.RegularFile},
+			},
+		},
+	}
+
+	// without a resolver, the digests are as cataloged
+	assert.Equal(t, s.Artifacts.FileDigests, ResolvedFileDigests(s, nil))
+
+	resolver := file.NewCoordinatesResolver(source.NewMockResolverForPaths("file_digests.go", "file_digests_test.go"))
+	actual := ResolvedFileDigests(s, resolver)
+
+	// existing SHA1 digests are kept, and files that cannot be read are skipped
+	assert.Equal(t, sha1, actual[digested])
+	assert.NotContains(t, actual, missing)
+
+	// other digests are kept, followed by the computed SHA1 digest
+	require.Len(t, actual[undigested], 2)
+	assert.Equal(t, md5[0], actual[undigested][0])
+	assert.Equal(t, "sha1", actual[undigested][1].Algorithm)
+	assert.Len(t, actual[undigested][1].Value, 40)
+
+	// the SBOM is not modified
+	assert.Equal(t, md5, s.Artifacts.FileDigests[undigested])
+}
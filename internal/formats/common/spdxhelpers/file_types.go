@@ -0,0 +1,158 @@
+package spdxhelpers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/anchore/syft/syft/source"
+)
+
+// sharedLibraryPattern matches shared objects, including versioned sonames (e.g. "libssl.so.1.1").
+var sharedLibraryPattern = regexp.MustCompile(`\.so(\.\d+)*$`)
+
+// fileTypesByExtension classifies files by extension, which is useful when the file contents were not analyzed (or
+// when the MIME type is too generic to tell, e.g. source code detected as "text/plain").
+var fileTypesByExtension = map[string][]model.FileType{
+	// source
+	".c":     {model.SourceFileType},
+	".cc":    {model.SourceFileType},
+	".cpp":   {model.SourceFileType},
+	".cs":    {model.SourceFileType},
+	".cxx":   {model.SourceFileType},
+	".erl":   {model.SourceFileType},
+	".ex":    {model.SourceFileType},
+	".exs":   {model.SourceFileType},
+	".go":    {model.SourceFileType},
+	".h":     {model.SourceFileType},
+	".hpp":   {model.SourceFileType},
+	".java":  {model.SourceFileType},
+	".js":    {model.SourceFileType},
+	".kt":    {model.SourceFileType},
+	".lua":   {model.SourceFileType},
+	".mjs":   {model.SourceFileType},
+	".php":   {model.SourceFileType},
+	".pl":    {model.SourceFileType},
+	".pm":    {model.SourceFileType},
+	".py":    {model.SourceFileType},
+	".rb":    {model.SourceFileType},
+	".rs":    {model.SourceFileType},
+	".scala": {model.SourceFileType},
+	".sh":    {model.SourceFileType},
+	".swift": {model.SourceFileType},
+	".ts":    {model.SourceFileType},
+	// binary
+	".a":     {model.BinaryFileType},
+	".class": {model.BinaryFileType},
+	".dll":   {model.BinaryFileType},
+	".dylib": {model.BinaryFileType},
+	".exe":   {model.BinaryFileType},
+	".ko":    {model.BinaryFileType},
+	".node":  {model.BinaryFileType},
+	".o":     {model.BinaryFileType},
+	".pyc":   {model.BinaryFileType},
+	".pyo":   {model.BinaryFileType},
+	".wasm":  {model.BinaryFileType},
+	// archive
+	".7z":    {model.ArchiveFileType},
+	".apk":   {model.ArchiveFileType},
+	".bz2":   {model.ArchiveFileType},
+	".crate": {model.ArchiveFileType},
+	".deb":   {model.ArchiveFileType},
+	".ear":   {model.ArchiveFileType},
+	".egg":   {model.ArchiveFileType},
+	".gem":   {model.ArchiveFileType},
+	".gz":    {model.ArchiveFileType},
+	".hpi":   {model.ArchiveFileType},
+	".jar":   {model.ArchiveFileType},
+	".jpi":   {model.ArchiveFileType},
+	".nupkg": {model.ArchiveFileType},
+	".rar":   {model.ArchiveFileType},
+	".rpm":   {model.ArchiveFileType},
+	".tar":   {model.ArchiveFileType},
+	".tgz":   {model.ArchiveFileType},
+	".war":   {model.ArchiveFileType},
+	".whl":   {model.ArchiveFileType},
+	".xz":    {model.ArchiveFileType},
+	".zip":   {model.ArchiveFileType},
+	".zst":   {model.ArchiveFileType},
+	// documentation
+	".adoc": {model.DocumentationFileType},
+	".md":   {model.DocumentationFileType},
+	".rst":  {model.DocumentationFileType},
+	// text
+	".txt": {model.TextFileType},
+	// spdx
+	".spdx": {model.SpdxFileType},
+}
+
+// FileTypes classifies the file at the given path by its MIME type (when the file metadata is known, which is derived
+// from the file contents) and by its extension. When the contents were analyzed but the file could not be classified
+// OTHER is returned, otherwise no file types are returned.
+func FileTypes(filePath string, metadata *source.FileMetadata) (types []string) {
+	add := func(ty model.FileType) {
+		for _, existing := range types {
+			if existing == string(ty) {
+				return
+			}
+		}
+		types = append(types, string(ty))
+	}
+
+	if metadata != nil {
+		for _, ty := range fileTypesByMIMEType(metadata.MIMEType) {
+			add(ty)
+		}
+	}
+
+	for _, ty := range fileTypesByName(path.Base(filePath)) {
+		add(ty)
+	}
+
+	if len(types) == 0 && metadata != nil {
+		add(model.OtherFileType)
+	}
+
+	return types
+}
+
+func fileTypesByMIMEType(mimeType string) (types []model.FileType) {
+	switch strings.Split(mimeType, "/")[0] {
+	case "image":
+		types = append(types, model.ImageFileType)
+	case "video":
+		types = append(types, model.VideoFileType)
+	case "application":
+		types = append(types, model.ApplicationFileType)
+	case "text":
+		types = append(types, model.TextFileType)
+	case "audio":
+		types = append(types, model.AudioFileType)
+	}
+
+	if internal.IsExecutable(mimeType) {
+		types = append(types, model.BinaryFileType)
+	}
+
+	if internal.IsArchive(mimeType) {
+		types = append(types, model.ArchiveFileType)
+	}
+
+	return types
+}
+
+func fileTypesByName(name string) []model.FileType {
+	name = strings.ToLower(name)
+
+	if sharedLibraryPattern.MatchString(name) {
+		return []model.FileType{model.BinaryFileType}
+	}
+
+	if strings.HasSuffix(name, ".spdx.json") {
+		return []model.FileType{model.SpdxFileType}
+	}
+
+	return fileTypesByExtension[path.Ext(name)]
+}
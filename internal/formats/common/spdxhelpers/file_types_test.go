@@ -0,0 +1,156 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FileTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		metadata *source.FileMetadata
+		expected []model.FileType
+	}{
+		{
+			name: "application",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "application/vnd.unknown",
+			},
+			expected: []model.FileType{model.ApplicationFileType},
+		},
+		{
+			name: "archive",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "application/zip",
+			},
+			expected: []model.FileType{model.ApplicationFileType, model.ArchiveFileType},
+		},
+		{
+			name: "audio",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "audio/ogg",
+			},
+			expected: []model.FileType{model.AudioFileType},
+		},
+		{
+			name: "video",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "video/3gpp",
+			},
+			expected: []model.FileType{model.VideoFileType},
+		},
+		{
+			name: "text",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "text/html",
+			},
+			expected: []model.FileType{model.TextFileType},
+		},
+		{
+			name: "image",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "image/png",
+			},
+			expected: []model.FileType{model.ImageFileType},
+		},
+		{
+			name: "binary",
+			path: "/some/file",
+			metadata: &source.FileMetadata{
+				MIMEType: "application/x-sharedlib",
+			},
+			expected: []model.FileType{model.ApplicationFileType, model.BinaryFileType},
+		},
+		{
+			name:     "shared object",
+			path:     "/usr/lib/libcrypto.so",
+			expected: []model.FileType{model.BinaryFileType},
+		},
+		{
+			name:     "versioned shared object",
+			path:     "/usr/lib/libssl.so.1.1",
+			expected: []model.FileType{model.BinaryFileType},
+		},
+		{
+			name:     "python source",
+			path:     "/usr/lib/python3.9/site-packages/requests/api.py",
+			expected: []model.FileType{model.SourceFileType},
+		},
+		{
+			name:     "compiled python",
+			path:     "/usr/lib/python3.9/site-packages/requests/__pycache__/api.cpython-39.pyc",
+			expected: []model.FileType{model.BinaryFileType},
+		},
+		{
+			name:     "java archive",
+			path:     "/opt/app/lib/commons-text-1.8.jar",
+			expected: []model.FileType{model.ArchiveFileType},
+		},
+		{
+			name:     "documentation (case insensitive)",
+			path:     "/usr/share/doc/README.MD",
+			expected: []model.FileType{model.DocumentationFileType},
+		},
+		{
+			name:     "spdx document",
+			path:     "/sboms/app.spdx.json",
+			expected: []model.FileType{model.SpdxFileType},
+		},
+		{
+			name: "source code from text contents",
+			path: "/app/main.py",
+			metadata: &source.FileMetadata{
+				MIMEType: "text/x-script.python",
+			},
+			expected: []model.FileType{model.TextFileType, model.SourceFileType},
+		},
+		{
+			name: "executable from contents",
+			path: "/usr/bin/ls",
+			metadata: &source.FileMetadata{
+				MIMEType: "application/x-executable",
+			},
+			expected: []model.FileType{model.ApplicationFileType, model.BinaryFileType},
+		},
+		{
+			name: "shared object from contents and extension",
+			path: "/usr/lib/libz.so.1",
+			metadata: &source.FileMetadata{
+				MIMEType: "application/x-sharedlib",
+			},
+			expected: []model.FileType{model.ApplicationFileType, model.BinaryFileType},
+		},
+		{
+			name: "unclassified contents",
+			path: "/etc/hostname",
+			metadata: &source.FileMetadata{
+				MIMEType: "",
+			},
+			expected: []model.FileType{model.OtherFileType},
+		},
+		{
+			name:     "unknown without contents",
+			path:     "/etc/hostname",
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var expected []string
+			for _, ty := range test.expected {
+				expected = append(expected, string(ty))
+			}
+			assert.Equal(t, expected, FileTypes(test.path, test.metadata))
+		})
+	}
+}
@@ -9,6 +9,10 @@ func Homepage(p pkg.Package) string {
 			return metadata.Homepage
 		case pkg.NpmPackageJSONMetadata:
 			return metadata.Homepage
+		case pkg.PhpComposerMetadata:
+			return metadata.Homepage
+		case pkg.PythonPackageMetadata:
+			return metadata.Homepage
 		}
 	}
 	return ""
@@ -37,6 +37,24 @@ func Test_Homepage(t *testing.T) {
 			},
 			expected: "http://a-place.gov",
 		},
+		{
+			name: "from php composer",
+			input: pkg.Package{
+				Metadata: pkg.PhpComposerMetadata{
+					Homepage: "http://a-place.gov",
+				},
+			},
+			expected: "http://a-place.gov",
+		},
+		{
+			name: "from python",
+			input: pkg.Package{
+				Metadata: pkg.PythonPackageMetadata{
+					Homepage: "http://a-place.gov",
+				},
+			},
+			expected: "http://a-place.gov",
+		},
 		{
 			// note: since this is an optional field, no value is preferred over NONE or NOASSERTION
 			name: "empty",
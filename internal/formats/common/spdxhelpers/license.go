@@ -1,12 +1,21 @@
 package spdxhelpers
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/syft/pkg"
 )
 
+const licenseRefPrefix = "LicenseRef-"
+
+// OtherLicense represents a license that is not on the SPDX license list, referenced by a "LicenseRef-" identifier.
+type OtherLicense struct {
+	ID   string // the full "LicenseRef-" identifier referenced within license expressions
+	Name string // the license value as originally discovered
+}
+
 func License(p pkg.Package) string {
 	// source: https://spdx.github.io/spdx-spec/3-package-information/#313-concluded-license
 	// The options to populate this field are limited to:
@@ -24,9 +33,14 @@ func License(p pkg.Package) string {
 	// take all licenses and assume an AND expression; for information about license expressions see https://spdx.github.io/spdx-spec/appendix-IV-SPDX-license-expressions/
 	var parsedLicenses []string
 	for _, l := range p.Licenses {
-		if value, exists := spdxlicense.ID(l); exists {
-			parsedLicenses = append(parsedLicenses, value)
+		expression, compound := parseLicenseExpression(l, nil)
+		if expression == "" {
+			continue
 		}
+		if compound && len(p.Licenses) > 1 {
+			expression = "(" + expression + ")"
+		}
+		parsedLicenses = append(parsedLicenses, expression)
 	}
 
 	if len(parsedLicenses) == 0 {
@@ -35,3 +49,90 @@ func License(p pkg.Package) string {
 
 	return strings.Join(parsedLicenses, " AND ")
 }
+
+// DeclaredLicense returns the license expression declared by the authors of the given package (see License), which is
+// NOASSERTION when the package licenses were concluded from the package files instead of declared within the package
+// metadata.
+func DeclaredLicense(p pkg.Package) string {
+	if p.LicensesConcluded {
+		return "NOASSERTION"
+	}
+	return License(p)
+}
+
+// LicenseComments explains why the concluded license differs from the declared license for the given package (if it
+// does at all).
+func LicenseComments(p pkg.Package) string {
+	if p.LicensesConcluded && len(p.Licenses) > 0 {
+		return "the concluded license was determined from the license files of the package (the package metadata declares no license)"
+	}
+	return ""
+}
+
+// OtherLicenses returns all licenses for the given package that are not on the SPDX license list (and are referenced
+// with "LicenseRef-" identifiers within the package license expression), sorted by ID.
+func OtherLicenses(p pkg.Package) (result []OtherLicense) {
+	others := make(map[string]string)
+	for _, l := range p.Licenses {
+		parseLicenseExpression(l, others)
+	}
+
+	for id, name := range others {
+		result = append(result, OtherLicense{
+			ID:   id,
+			Name: name,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// CatalogOtherLicenses returns all licenses for the packages within the given catalog that are not on the SPDX license
+// list, without duplicates and sorted by ID.
+func CatalogOtherLicenses(catalog *pkg.Catalog) (result []OtherLicense) {
+	if catalog == nil {
+		return nil
+	}
+
+	others := make(map[string]OtherLicense)
+	for _, p := range catalog.Sorted() {
+		for _, other := range OtherLicenses(p) {
+			others[other.ID] = other
+		}
+	}
+
+	for _, other := range others {
+		result = append(result, other)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// parseLicenseExpression normalizes the given license value into a valid SPDX license expression, where every license
+// is either converted to an SPDX license list identifier or a "LicenseRef-" identifier. All "LicenseRef-" identifiers
+// are recorded in the given "others" map (if provided). The returned bool indicates if the expression is compound.
+func parseLicenseExpression(value string, others map[string]string) (string, bool) {
+	expression, _, compound := spdxlicense.ParseExpression(value, func(name string) string {
+		return licenseID(name, others)
+	})
+	return expression, compound
+}
+
+func licenseID(name string, others map[string]string) string {
+	if value, exists := spdxlicense.ID(name); exists {
+		return value
+	}
+
+	id := SanitizeElementID(strings.TrimPrefix(name, licenseRefPrefix))
+	id = licenseRefPrefix + id
+	if others != nil {
+		others[id] = name
+	}
+	return id
+}
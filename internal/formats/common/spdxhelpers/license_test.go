@@ -25,8 +25,92 @@ func Test_License(t *testing.T) {
 					"made-up",
 				},
 			},
+			expected: "LicenseRef-made-up",
+		},
+		{
+			name: "empty license value",
+			input: pkg.Package{
+				Licenses: []string{
+					" ",
+				},
+			},
 			expected: "NOASSERTION",
 		},
+		{
+			name: "mixed SPDX and non-SPDX licenses",
+			input: pkg.Package{
+				Licenses: []string{
+					"MIT",
+					"The Apache Software License, Version 2.0",
+				},
+			},
+			expected: "MIT AND LicenseRef-The-Apache-Software-License--Version-2.0",
+		},
+		{
+			name: "dual license expression",
+			input: pkg.Package{
+				Licenses: []string{
+					"MIT OR Apache-2.0",
+				},
+			},
+			expected: "MIT OR Apache-2.0",
+		},
+		{
+			name: "compound expressions are grouped when combined",
+			input: pkg.Package{
+				Licenses: []string{
+					"mit or apache-2.0",
+					"BSD-3-Clause",
+				},
+			},
+			expected: "(MIT OR Apache-2.0) AND BSD-3-Clause",
+		},
+		{
+			name: "nested expression with license ref",
+			input: pkg.Package{
+				Licenses: []string{
+					"(LGPL-2.1 OR LicenseRef-my-license) AND GPL-2.0 WITH Classpath-exception-2.0",
+				},
+			},
+			expected: "(LGPL-2.1 OR LicenseRef-my-license) AND GPL-2.0 WITH Classpath-exception-2.0",
+		},
+		{
+			name: "nested expression with unknown license",
+			input: pkg.Package{
+				Licenses: []string{
+					"(LGPL-2.1 OR my license) AND GPL-2.0 WITH Classpath-exception-2.0",
+				},
+			},
+			expected: "LicenseRef--LGPL-2.1-OR-my-license--AND-GPL-2.0-WITH-Classpath-exception-2.0",
+		},
+		{
+			name: "free-form license with an operator word",
+			input: pkg.Package{
+				Licenses: []string{
+					"GPL or Artistic",
+				},
+			},
+			expected: "LicenseRef-GPL-or-Artistic",
+		},
+		{
+			name: "free-form license with parentheses",
+			input: pkg.Package{
+				Licenses: []string{
+					"BSD (3 clause)",
+					"MIT",
+				},
+			},
+			expected: "LicenseRef-BSD--3-clause- AND MIT",
+		},
+		{
+			name: "malformed expression",
+			input: pkg.Package{
+				Licenses: []string{
+					"(MIT OR Apache-2.0",
+				},
+			},
+			expected: "LicenseRef--MIT-OR-Apache-2.0",
+		},
 		{
 			name: "with SPDX license",
 			input: pkg.Package{
@@ -71,3 +155,117 @@ func Test_License(t *testing.T) {
 		})
 	}
 }
+
+func Test_DeclaredLicense(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            pkg.Package
+		expected         string
+		expectedComments bool
+	}{
+		{
+			name:     "no licenses",
+			input:    pkg.Package{},
+			expected: "NONE",
+		},
+		{
+			name: "declared licenses",
+			input: pkg.Package{
+				Licenses: []string{"MIT"},
+			},
+			expected: "MIT",
+		},
+		{
+			name: "concluded licenses",
+			input: pkg.Package{
+				Licenses:          []string{"MIT"},
+				LicensesConcluded: true,
+			},
+			expected:         "NOASSERTION",
+			expectedComments: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DeclaredLicense(test.input))
+			assert.Equal(t, test.expectedComments, LicenseComments(test.input) != "")
+		})
+	}
+}
+
+func Test_OtherLicenses(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected []OtherLicense
+	}{
+		{
+			name:  "no licenses",
+			input: pkg.Package{},
+		},
+		{
+			name: "only SPDX licenses",
+			input: pkg.Package{
+				Licenses: []string{
+					"MIT",
+					"Apache-2.0 OR GPL-2.0",
+				},
+			},
+		},
+		{
+			name: "non-SPDX licenses",
+			input: pkg.Package{
+				Licenses: []string{
+					"MIT",
+					"made-up",
+					"Apache-2.0 OR another license",
+				},
+			},
+			// free-form names are never split into an expression
+			expected: []OtherLicense{
+				{
+					ID:   "LicenseRef-Apache-2.0-OR-another-license",
+					Name: "Apache-2.0 OR another license",
+				},
+				{
+					ID:   "LicenseRef-made-up",
+					Name: "made-up",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, OtherLicenses(test.input))
+		})
+	}
+}
+
+func Test_CatalogOtherLicenses(t *testing.T) {
+	catalog := pkg.NewCatalog(
+		pkg.Package{
+			Name:     "first",
+			Version:  "1.0",
+			Licenses: []string{"MIT", "made-up"},
+		},
+		pkg.Package{
+			Name:     "second",
+			Version:  "2.0",
+			Licenses: []string{"made-up", "another license"},
+		},
+	)
+
+	expected := []OtherLicense{
+		{
+			ID:   "LicenseRef-another-license",
+			Name: "another license",
+		},
+		{
+			ID:   "LicenseRef-made-up",
+			Name: "made-up",
+		},
+	}
+
+	assert.Equal(t, expected, CatalogOtherLicenses(catalog))
+	assert.Empty(t, CatalogOtherLicenses(nil))
+}
@@ -10,3 +10,10 @@ func NoneIfEmpty(value string) string {
 	}
 	return value
 }
+
+func noAssertionIfEmpty(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "NOASSERTION"
+	}
+	return value
+}
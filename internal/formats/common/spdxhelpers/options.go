@@ -0,0 +1,60 @@
+package spdxhelpers
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Options configure how SPDX documents are created. The zero value results in the default documents.
+type Options struct {
+	// NamespacePrefix is the URI that document namespaces are prefixed with (the source type, name, and a unique ID are
+	// always appended). An empty value uses DefaultDocumentNamespacePrefix.
+	NamespacePrefix string
+	// Minimal omits the optional fields that only describe how the packages were found (e.g. annotations and source
+	// info). This is useful for SPDX consumers that do not cope well with noisy documents. Note that all NONE and
+	// NOASSERTION values syft emits are for mandatory fields, so are always kept.
+	Minimal bool
+	// MetadataComment includes the raw metadata of each package as JSON within the package comment, so that consumers
+	// can recover the ecosystem-specific fields that are not otherwise described by SPDX. This is disabled by default,
+	// since the metadata of some packages (e.g. file listings) considerably grows the document.
+	MetadataComment bool
+	// Created is the fixed creation time of documents (and their annotations), so that documents can be reproduced
+	// byte-for-byte (the unique ID within document namespaces is then derived from the source and its packages). The
+	// zero value uses the time each document is created (see ParseCreated).
+	Created time.Time
+	// UnpackagedFileGlobs select the regular files (relative to the source root, e.g. "*" or "config/**") that are
+	// described as unpackaged files when no package contains them. No unpackaged files are described by default.
+	UnpackagedFileGlobs []string
+}
+
+// Validate checks that the namespace prefix and unpackaged file globs are well-formed.
+func (o Options) Validate() error {
+	if o.NamespacePrefix != "" {
+		if _, err := ParseDocumentNamespacePrefix(o.NamespacePrefix); err != nil {
+			return err
+		}
+	}
+	for _, glob := range o.UnpackagedFileGlobs {
+		// note: doublestar only reports malformed patterns when matching reaches the malformed portion
+		if _, err := path.Match(strings.TrimPrefix(glob, "/"), ""); err != nil {
+			return fmt.Errorf("invalid unpackaged file glob=%q: %w", glob, err)
+		}
+	}
+	return nil
+}
+
+// CreationTime returns the creation time of a new SPDX document (in UTC, without fractional seconds, since the SPDX
+// timestamp format does not allow for them), which is the current time unless a fixed creation time is set.
+func (o Options) CreationTime() time.Time {
+	if o.Created.IsZero() {
+		return time.Now().UTC().Truncate(time.Second)
+	}
+	return o.Created
+}
+
+// UnpackagedFilesEnabled indicates if any files are selected to be described as unpackaged files.
+func (o Options) UnpackagedFilesEnabled() bool {
+	return len(o.UnpackagedFileGlobs) > 0
+}
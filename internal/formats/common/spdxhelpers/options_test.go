@@ -0,0 +1,25 @@
+package spdxhelpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_CreationTime(t *testing.T) {
+	before := time.Now().UTC().Truncate(time.Second)
+	actual := Options{}.CreationTime()
+	assert.False(t, actual.Before(before), "expected the current time by default: %s", actual)
+	assert.Equal(t, time.UTC, actual.Location())
+
+	created := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, created, Options{Created: created}.CreationTime())
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, Options{}.Validate())
+	assert.NoError(t, Options{NamespacePrefix: "https://sbom.example.com", UnpackagedFileGlobs: DefaultUnpackagedFileGlobs}.Validate())
+	assert.Error(t, Options{NamespacePrefix: "sbom.example.com"}.Validate())
+	assert.Error(t, Options{UnpackagedFileGlobs: []string{"[a-"}}.Validate())
+}
@@ -0,0 +1,127 @@
+package spdxhelpers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// ActorType is the kind of entity that originated or supplied a package.
+type ActorType string
+
+const (
+	PersonActor       ActorType = "Person"
+	OrganizationActor ActorType = "Organization"
+)
+
+// Actor is the person or organization that originated or supplied a package (see sections 3.5 and 3.6 of the spec).
+type Actor struct {
+	Type ActorType
+	// Name is the name of the actor, followed by the email address in parentheses when known (e.g. "Jane Doe (jane@example.com)")
+	Name string
+}
+
+// matches people fields such as "Jane Doe <jane@example.com> (https://example.com)", where the email and URL are optional
+var actorPattern = regexp.MustCompile(`^\s*(?P<name>[^<(]*?)\s*(<(?P<email>[^>]*)>)?\s*(\((?P<url>[^)]*)\))?\s*$`)
+
+// organizationWords are words within names that indicate an organization rather than a person (compared in lowercase,
+// without punctuation).
+var organizationWords = internal.NewStringSetFromSlice([]string{
+	"association", "co", "community", "company", "consortium", "contributors", "corp", "corporation", "developers",
+	"foundation", "gmbh", "group", "inc", "incorporated", "labs", "limited", "llc", "ltd", "maintainers", "organisation",
+	"organization", "project", "software", "systems", "team", "technologies", "university",
+})
+
+// String returns the actor as an SPDX value (e.g. "Person: Jane Doe (jane@example.com)"), or an empty string when
+// there is no actor. Since the fields are optional, no value is preferred over NOASSERTION.
+func (a Actor) String() string {
+	if a.Name == "" {
+		return ""
+	}
+	return string(a.Type) + ": " + a.Name
+}
+
+// Originator returns the person or organization that originally created the package (i.e. the package authors), as
+// recorded within the package metadata.
+func Originator(p pkg.Package) Actor {
+	if !hasMetadata(p) {
+		return Actor{}
+	}
+
+	switch metadata := p.Metadata.(type) {
+	case pkg.NpmPackageJSONMetadata:
+		return newActor(metadata.Author)
+	case pkg.PythonPackageMetadata:
+		if metadata.AuthorEmail == "" {
+			return newActor(metadata.Author)
+		}
+		return newActor(metadata.Author + " <" + metadata.AuthorEmail + ">")
+	case pkg.GemMetadata:
+		if len(metadata.Authors) > 0 {
+			return newActor(metadata.Authors[0])
+		}
+	}
+	return Actor{}
+}
+
+// Supplier returns the person or organization that distributes the package (i.e. the maintainer of a distro package),
+// as recorded within the package metadata.
+func Supplier(p pkg.Package) Actor {
+	if !hasMetadata(p) {
+		return Actor{}
+	}
+
+	switch metadata := p.Metadata.(type) {
+	case pkg.ApkMetadata:
+		return newActor(metadata.Maintainer)
+	case pkg.DpkgMetadata:
+		return newActor(metadata.Maintainer)
+	case pkg.RpmdbMetadata:
+		// the RPM vendor is always an organization (e.g. "Red Hat, Inc." or "CentOS")
+		actor := newActor(metadata.Vendor)
+		if actor.Name != "" {
+			actor.Type = OrganizationActor
+		}
+		return actor
+	}
+	return Actor{}
+}
+
+// newActor parses a people field (e.g. "Jane Doe <jane@example.com>") into an actor, which is a person unless the name
+// looks like the name of an organization.
+func newActor(value string) Actor {
+	name := strings.TrimSpace(value)
+	var email string
+	if fields := internal.MatchNamedCaptureGroups(actorPattern, value); fields != nil {
+		name, email = fields["name"], strings.TrimSpace(fields["email"])
+	}
+
+	actorType := PersonActor
+	if isOrganizationName(name) {
+		actorType = OrganizationActor
+	}
+
+	switch {
+	case name == "":
+		// the email address alone still identifies the actor
+		name = email
+	case email != "":
+		name += " (" + email + ")"
+	}
+
+	return Actor{Type: actorType, Name: name}
+}
+
+func isOrganizationName(name string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	for _, word := range words {
+		if organizationWords.Contains(word) {
+			return true
+		}
+	}
+	return false
+}
@@ -29,7 +29,7 @@ func Test_Originator(t *testing.T) {
 					},
 				},
 			},
-			expected: "auth1",
+			expected: "Person: auth1",
 		},
 		{
 			name: "from npm",
@@ -38,16 +38,25 @@ func Test_Originator(t *testing.T) {
 					Author: "auth",
 				},
 			},
-			expected: "auth",
+			expected: "Person: auth",
 		},
 		{
-			name: "from apk",
+			name: "from npm - with email and url",
 			input: pkg.Package{
-				Metadata: pkg.ApkMetadata{
-					Maintainer: "auth",
+				Metadata: pkg.NpmPackageJSONMetadata{
+					Author: "Isaac Z. Schlueter <i@izs.me> (http://blog.izs.me)",
+				},
+			},
+			expected: "Person: Isaac Z. Schlueter (i@izs.me)",
+		},
+		{
+			name: "from npm - organization",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageJSONMetadata{
+					Author: "OpenJS Foundation and other contributors <https://openjsf.org/>",
 				},
 			},
-			expected: "auth",
+			expected: "Organization: OpenJS Foundation and other contributors (https://openjsf.org/)",
 		},
 		{
 			name: "from python - just name",
@@ -56,7 +65,7 @@ func Test_Originator(t *testing.T) {
 					Author: "auth",
 				},
 			},
-			expected: "auth",
+			expected: "Person: auth",
 		},
 		{
 			name: "from python - just email",
@@ -65,7 +74,7 @@ func Test_Originator(t *testing.T) {
 					AuthorEmail: "auth@auth.gov",
 				},
 			},
-			expected: "auth@auth.gov",
+			expected: "Person: auth@auth.gov",
 		},
 		{
 			name: "from python - both name and email",
@@ -75,25 +84,27 @@ func Test_Originator(t *testing.T) {
 					AuthorEmail: "auth@auth.gov",
 				},
 			},
-			expected: "auth <auth@auth.gov>",
+			expected: "Person: auth (auth@auth.gov)",
 		},
 		{
-			name: "from rpm",
+			name: "from python - organization",
 			input: pkg.Package{
-				Metadata: pkg.RpmdbMetadata{
-					Vendor: "auth",
+				Metadata: pkg.PythonPackageMetadata{
+					Author:      "The Python Packaging Authority Team",
+					AuthorEmail: "distutils-sig@python.org",
 				},
 			},
-			expected: "auth",
+			expected: "Organization: The Python Packaging Authority Team (distutils-sig@python.org)",
 		},
 		{
+			// distro package maintainers are suppliers (not originators)
 			name: "from dpkg",
 			input: pkg.Package{
 				Metadata: pkg.DpkgMetadata{
 					Maintainer: "auth",
 				},
 			},
-			expected: "auth",
+			expected: "",
 		},
 		{
 			// note: since this is an optional field, no value is preferred over NONE or NOASSERTION
@@ -108,7 +119,82 @@ func Test_Originator(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			assert.Equal(t, test.expected, Originator(test.input))
+			assert.Equal(t, test.expected, Originator(test.input).String())
+		})
+	}
+}
+
+func Test_Supplier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected string
+	}{
+		{
+			name:     "no metadata",
+			input:    pkg.Package{},
+			expected: "",
+		},
+		{
+			name: "from apk",
+			input: pkg.Package{
+				Metadata: pkg.ApkMetadata{
+					Maintainer: "Natanael Copa <ncopa@alpinelinux.org>",
+				},
+			},
+			expected: "Person: Natanael Copa (ncopa@alpinelinux.org)",
+		},
+		{
+			name: "from dpkg",
+			input: pkg.Package{
+				Metadata: pkg.DpkgMetadata{
+					Maintainer: "auth <auth@auth.gov>",
+				},
+			},
+			expected: "Person: auth (auth@auth.gov)",
+		},
+		{
+			name: "from dpkg - organization",
+			input: pkg.Package{
+				Metadata: pkg.DpkgMetadata{
+					Maintainer: "Ubuntu Developers <ubuntu-devel-discuss@lists.ubuntu.com>",
+				},
+			},
+			expected: "Organization: Ubuntu Developers (ubuntu-devel-discuss@lists.ubuntu.com)",
+		},
+		{
+			name: "from rpm",
+			input: pkg.Package{
+				Metadata: pkg.RpmdbMetadata{
+					Vendor: "Red Hat, Inc.",
+				},
+			},
+			expected: "Organization: Red Hat, Inc.",
+		},
+		{
+			// the RPM vendor is always an organization, even without an organization-like name
+			name: "from rpm - without organization-like name",
+			input: pkg.Package{
+				Metadata: pkg.RpmdbMetadata{
+					Vendor: "CentOS",
+				},
+			},
+			expected: "Organization: CentOS",
+		},
+		{
+			// package authors are originators (not suppliers)
+			name: "from npm",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageJSONMetadata{
+					Author: "auth",
+				},
+			},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Supplier(test.input).String())
 		})
 	}
 }
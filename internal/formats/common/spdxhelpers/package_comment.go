@@ -0,0 +1,35 @@
+package spdxhelpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// packageMetadataComment is the shape of the package comment, which is the same as the metadata of packages within
+// syft JSON documents (so the metadata can be decoded by type).
+type packageMetadataComment struct {
+	MetadataType pkg.MetadataType `json:"metadataType"`
+	Metadata     interface{}      `json:"metadata"`
+}
+
+// PackageComment returns the comment for the given package, which is the package metadata as JSON (when enabled, see
+// Options.MetadataComment) or an empty string otherwise.
+func PackageComment(p pkg.Package, opts Options) string {
+	if !opts.MetadataComment || p.Metadata == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// prevent > and < from being escaped in the payload (e.g. within version constraints)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(packageMetadataComment{MetadataType: p.MetadataType, Metadata: p.Metadata}); err != nil {
+		log.Warnf("unable to encode the metadata of package=%q as a comment: %+v", p.Name, err)
+		return ""
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,44 @@
+package spdxhelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PackageComment(t *testing.T) {
+	p := pkg.Package{
+		Name:         "requests",
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonRequirementsMetadataType,
+		Metadata: pkg.PythonRequirementsMetadata{
+			Extras:            []string{"security"},
+			VersionConstraint: ">=2.8.1",
+			Markers:           `python_version < "3.8"`,
+		},
+	}
+
+	// disabled by default
+	assert.Empty(t, PackageComment(p, Options{}))
+
+	opts := Options{MetadataComment: true}
+	comment := PackageComment(p, opts)
+	require.True(t, json.Valid([]byte(comment)), "invalid JSON: %s", comment)
+	assert.NotContains(t, comment, "\n")
+	// version constraints are kept readable
+	assert.Contains(t, comment, `">=2.8.1"`)
+
+	var actual struct {
+		MetadataType pkg.MetadataType               `json:"metadataType"`
+		Metadata     pkg.PythonRequirementsMetadata `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(comment), &actual))
+	assert.Equal(t, p.MetadataType, actual.MetadataType)
+	assert.Equal(t, p.Metadata, actual.Metadata)
+
+	// packages without metadata have no comment
+	assert.Empty(t, PackageComment(pkg.Package{Name: "no-metadata"}, opts))
+}
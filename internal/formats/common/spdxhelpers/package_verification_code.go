@@ -0,0 +1,42 @@
+package spdxhelpers
+
+import (
+	"crypto/sha1" // nolint:gosec // the SPDX spec requires SHA1 for the package verification code
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+// PackageVerificationCode computes the package verification code from the digests of each file within a package
+// (see https://spdx.github.io/spdx-spec/3-package-information/#39-package-verification-code). If any file does not
+// have a SHA1 digest then an empty string is returned, since a partial verification code cannot be verified.
+func PackageVerificationCode(digestsByFile [][]file.Digest) string {
+	if len(digestsByFile) == 0 {
+		return ""
+	}
+
+	var sha1s []string
+	for _, digests := range digestsByFile {
+		value := DigestValue(digests, "sha1")
+		if value == "" {
+			return ""
+		}
+		sha1s = append(sha1s, strings.ToLower(value))
+	}
+
+	sort.Strings(sha1s)
+
+	return fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(sha1s, ""))))
+}
+
+// DigestValue returns the value of the digest with the given algorithm (e.g. "sha1"), or an empty string if there is no such digest.
+func DigestValue(digests []file.Digest, algorithm string) string {
+	for _, d := range digests {
+		if file.CleanDigestAlgorithmName(d.Algorithm) == algorithm {
+			return d.Value
+		}
+	}
+	return ""
+}
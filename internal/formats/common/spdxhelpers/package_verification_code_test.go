@@ -0,0 +1,52 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PackageVerificationCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    [][]file.Digest
+		expected string
+	}{
+		{
+			name:     "no files",
+			expected: "",
+		},
+		{
+			name: "all files have sha1 digests",
+			input: [][]file.Digest{
+				{
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+					{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+				},
+				{
+					{Algorithm: "sha1", Value: "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2"},
+				},
+			},
+			// sha1("3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2d6a770ba38583ed4bb4525bd96e50461655d2759")
+			expected: "599a7d40130c75c9f8829ae3fe720931e0bc4e9e",
+		},
+		{
+			name: "some files are missing sha1 digests",
+			input: [][]file.Digest{
+				{
+					{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+				},
+				{
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				},
+			},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PackageVerificationCode(test.input))
+		})
+	}
+}
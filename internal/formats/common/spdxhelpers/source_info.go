@@ -1,11 +1,15 @@
 package spdxhelpers
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/syft/pkg"
 )
 
+// SourceInfo returns a human-readable description of how the given package was discovered: the kind of evidence, the
+// paths of the evidence, and the cataloger that discovered the package.
 func SourceInfo(p pkg.Package) string {
 	answer := ""
 	switch p.Type {
@@ -13,6 +17,8 @@ func SourceInfo(p pkg.Package) string {
 		answer = "acquired package info from RPM DB"
 	case pkg.ApkPkg:
 		answer = "acquired package info from APK DB"
+	case pkg.ApkbuildPkg:
+		answer = "acquired package info from APKBUILD file"
 	case pkg.DebPkg:
 		answer = "acquired package info from DPKG DB"
 	case pkg.NpmPkg:
@@ -29,13 +35,47 @@ func SourceInfo(p pkg.Package) string {
 		answer = "acquired package info from rust cargo manifest"
 	case pkg.PhpComposerPkg:
 		answer = "acquired package info from PHP composer manifest"
+	case pkg.CondaPkg:
+		answer = "acquired package info from conda environment metadata"
+	case pkg.DotnetPkg:
+		answer = "acquired package info from dotnet project dependencies file"
+	case pkg.SwiftPkg:
+		answer = "acquired package info from resolved Swift package manifest"
+	case pkg.HackagePkg:
+		answer = "acquired package info from Haskell stack lock or cabal freeze file"
+	case pkg.HexPkg:
+		answer = "acquired package info from Elixir mix lock file"
+	case pkg.DartPubPkg:
+		answer = "acquired package info from Dart pubspec lock file"
+	case pkg.BinaryPkg:
+		answer = "acquired package info from the version embedded within a runtime binary"
+	case pkg.WindowsPkg:
+		answer = "acquired package info from Windows registry SOFTWARE hive"
+	case pkg.LinuxKernelPkg:
+		answer = "acquired package info from the kernel release of a Linux kernel modules directory"
 	default:
 		answer = "acquired package info from the following paths"
 	}
+
+	// note: the paths are the evidence that the package was found by, which allows for tracing the package back to the
+	// source (the same path may be given more than once, e.g. when found in multiple layers)
 	var paths []string
+	observed := internal.NewStringSet()
 	for _, l := range p.Locations {
+		if l.RealPath == "" || observed.Contains(l.RealPath) {
+			continue
+		}
+		observed.Add(l.RealPath)
 		paths = append(paths, l.RealPath)
 	}
 
-	return answer + ": " + strings.Join(paths, ", ")
+	if len(paths) > 0 {
+		answer += ": " + strings.Join(paths, ", ")
+	}
+
+	if p.FoundBy != "" {
+		answer += fmt.Sprintf(" (found by %s)", p.FoundBy)
+	}
+
+	return answer
 }
@@ -54,6 +54,14 @@ func Test_SourceInfo(t *testing.T) {
 				"from APK DB",
 			},
 		},
+		{
+			input: pkg.Package{
+				Type: pkg.ApkbuildPkg,
+			},
+			expected: []string{
+				"from APKBUILD file",
+			},
+		},
 		{
 			input: pkg.Package{
 				Type: pkg.DebPkg,
@@ -126,6 +134,78 @@ func Test_SourceInfo(t *testing.T) {
 				"from PHP composer manifest",
 			},
 		},
+		{
+			input: pkg.Package{
+				Type: pkg.CondaPkg,
+			},
+			expected: []string{
+				"from conda environment metadata",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.DotnetPkg,
+			},
+			expected: []string{
+				"from dotnet project dependencies file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.SwiftPkg,
+			},
+			expected: []string{
+				"from resolved Swift package manifest",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.BinaryPkg,
+			},
+			expected: []string{
+				"from the version embedded within a runtime binary",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.HackagePkg,
+			},
+			expected: []string{
+				"from Haskell stack lock or cabal freeze file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.HexPkg,
+			},
+			expected: []string{
+				"from Elixir mix lock file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.DartPubPkg,
+			},
+			expected: []string{
+				"from Dart pubspec lock file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WindowsPkg,
+			},
+			expected: []string{
+				"from Windows registry SOFTWARE hive",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.LinuxKernelPkg,
+			},
+			expected: []string{
+				"from the kernel release of a Linux kernel modules directory",
+			},
+		},
 	}
 	var pkgTypes []pkg.Type
 	for _, test := range tests {
@@ -141,3 +221,38 @@ func Test_SourceInfo(t *testing.T) {
 	}
 	assert.ElementsMatch(t, pkg.AllPkgs, pkgTypes, "missing one or more package types to test against (maybe a package type was added?)")
 }
+
+func Test_SourceInfo_evidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected string
+	}{
+		{
+			name: "path and cataloger",
+			input: pkg.Package{
+				Type:    pkg.DebPkg,
+				FoundBy: "dpkgdb-cataloger",
+				Locations: []source.Location{
+					source.NewLocation("/var/lib/dpkg/status"),
+					// the same path from another layer is only described once
+					source.NewLocationFromCoordinates(source.Coordinates{RealPath: "/var/lib/dpkg/status", FileSystemID: "sha256:abc"}),
+				},
+			},
+			expected: "acquired package info from DPKG DB: /var/lib/dpkg/status (found by dpkgdb-cataloger)",
+		},
+		{
+			name: "no locations",
+			input: pkg.Package{
+				Type:    pkg.NpmPkg,
+				FoundBy: "javascript-lock-cataloger",
+			},
+			expected: "acquired package info from installed node module manifest file (found by javascript-lock-cataloger)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SourceInfo(test.input))
+		})
+	}
+}
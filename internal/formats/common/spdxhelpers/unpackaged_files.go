@@ -0,0 +1,76 @@
+package spdxhelpers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/bmatcuk/doublestar/v2"
+)
+
+// DefaultUnpackagedFileGlobs selects the top-level files of the cataloged source (e.g. the config files and scripts
+// at the root of an application directory).
+var DefaultUnpackagedFileGlobs = []string{"*"}
+
+// UnpackagedFiles returns the coordinates of the regular files selected by the unpackaged file globs (see
+// Options.UnpackagedFileGlobs) that have digests but are not contained by any package, sorted by path.
+func UnpackagedFiles(s sbom.SBOM, opts Options) (results []source.Coordinates) {
+	if !opts.UnpackagedFilesEnabled() {
+		return nil
+	}
+
+	contained := make(map[source.Coordinates]struct{})
+	for _, r := range s.Relationships {
+		if r.Type != artifact.ContainsRelationship {
+			continue
+		}
+		if _, ok := r.From.(pkg.Package); !ok {
+			continue
+		}
+		if coordinates, ok := r.To.(source.Coordinates); ok {
+			contained[coordinates] = struct{}{}
+		}
+	}
+
+	for coordinates := range s.Artifacts.FileDigests {
+		if _, exists := contained[coordinates]; exists {
+			continue
+		}
+		if metadata, exists := s.Artifacts.FileMetadata[coordinates]; exists && metadata.Type != source.RegularFile {
+			continue
+		}
+		if !matchesUnpackagedFileGlob(coordinates.RealPath, opts.UnpackagedFileGlobs) {
+			continue
+		}
+		results = append(results, coordinates)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].RealPath != results[j].RealPath {
+			return results[i].RealPath < results[j].RealPath
+		}
+		return results[i].FileSystemID < results[j].FileSystemID
+	})
+	return results
+}
+
+func matchesUnpackagedFileGlob(realPath string, globs []string) bool {
+	// image paths are absolute while directory paths are relative to the source root
+	relPath := strings.TrimPrefix(realPath, "/")
+	for _, glob := range globs {
+		pattern := strings.TrimPrefix(glob, "/")
+		matches, err := doublestar.Match(pattern, relPath)
+		if err != nil {
+			log.Debugf("unable to match unpackaged file glob=%q: %+v", pattern, err)
+			continue
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnpackagedFiles(t *testing.T) {
+	p := pkg.Package{Name: "some-package", Version: "1.0"}
+
+	owned := source.Coordinates{RealPath: "/package.json"}
+	config := source.Coordinates{RealPath: "/config.yaml"}
+	script := source.Coordinates{RealPath: "/run.sh"}
+	nested := source.Coordinates{RealPath: "/etc/app/settings.ini"}
+	link := source.Coordinates{RealPath: "/current"}
+	relative := source.Coordinates{RealPath: "Makefile"}
+
+	digests := []file.Digest{{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"}}
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			FileDigests: map[source.Coordinates][]file.Digest{
+				owned:    digests,
+				config:   digests,
+				script:   digests,
+				nested:   digests,
+				link:     digests,
+				relative: digests,
+			},
+			FileMetadata: map[source.Coordinates]source.FileMetadata{
+				config: {Type: source.RegularFile},
+				link:   {Type: source.SymbolicLink},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: owned, Type: artifact.ContainsRelationship},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		globs    []string
+		expected []source.Coordinates
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "top-level files",
+			globs:    DefaultUnpackagedFileGlobs,
+			expected: []source.Coordinates{config, script, relative},
+		},
+		{
+			name:     "selected files",
+			globs:    []string{"/etc/**/*.ini", "*.yaml"},
+			expected: []source.Coordinates{config, nested},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, UnpackagedFiles(s, Options{UnpackagedFileGlobs: test.globs}))
+		})
+	}
+}
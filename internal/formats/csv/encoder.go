@@ -0,0 +1,36 @@
+package csv
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/syft/sbom"
+)
+
+var columns = []string{"Name", "Version", "Type", "PURL", "Licenses"}
+
+func encoder(output io.Writer, s sbom.SBOM) error {
+	writer := csv.NewWriter(output)
+
+	// the header is always written, even when there are no packages
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		row := []string{
+			p.Name,
+			p.Version,
+			string(p.Type),
+			p.PURL,
+			strings.Join(p.Licenses, ","),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
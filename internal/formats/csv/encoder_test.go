@@ -0,0 +1,69 @@
+package csv
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateCSVGoldenFiles = flag.Bool("update-csv", false, "update the *.golden files for csv format")
+
+func TestCSVPresenter(t *testing.T) {
+	testutils.AssertPresenterAgainstGoldenSnapshot(t,
+		Format().Presenter(testutils.DirectoryInput(t)),
+		*updateCSVGoldenFiles,
+	)
+}
+
+func TestCSVEncoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []pkg.Package
+		expected string
+	}{
+		{
+			name:     "header is always written",
+			expected: "Name,Version,Type,PURL,Licenses\n",
+		},
+		{
+			name: "values with commas are quoted",
+			packages: []pkg.Package{
+				{
+					Name:     "package, with a comma",
+					Version:  "1.0",
+					Type:     pkg.NpmPkg,
+					PURL:     "pkg:npm/package@1.0",
+					Licenses: []string{"MIT", "Apache-2.0"},
+				},
+				{
+					Name:     "package-2",
+					Version:  "2.0",
+					Type:     pkg.GemPkg,
+					Licenses: []string{`some "quoted" license`},
+				},
+			},
+			expected: "Name,Version,Type,PURL,Licenses\n" +
+				`package-2,2.0,gem,,"some ""quoted"" license"` + "\n" +
+				`"package, with a comma",1.0,npm,pkg:npm/package@1.0,"MIT,Apache-2.0"` + "\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := sbom.SBOM{
+				Artifacts: sbom.Artifacts{
+					PackageCatalog: pkg.NewCatalog(test.packages...),
+				},
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, encoder(&buf, s))
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package csv
+
+import "github.com/anchore/syft/syft/format"
+
+func Format() format.Format {
+	return format.NewFormat(
+		format.CSVOption,
+		encoder,
+		nil,
+		nil,
+	)
+}
@@ -3,26 +3,50 @@ package formats
 import (
 	"bytes"
 
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/csv"
 	"github.com/anchore/syft/internal/formats/cyclonedx13json"
 	"github.com/anchore/syft/internal/formats/cyclonedx13xml"
+	"github.com/anchore/syft/internal/formats/github"
+	"github.com/anchore/syft/internal/formats/intoto"
+	"github.com/anchore/syft/internal/formats/jsonlines"
 	"github.com/anchore/syft/internal/formats/spdx22json"
 	"github.com/anchore/syft/internal/formats/spdx22tagvalue"
 	"github.com/anchore/syft/internal/formats/syftjson"
 	"github.com/anchore/syft/internal/formats/table"
+	"github.com/anchore/syft/internal/formats/tern"
 	"github.com/anchore/syft/internal/formats/text"
 	"github.com/anchore/syft/syft/format"
 )
 
+// Options configure the formats that have options (such as the SPDX document namespace and the table columns). The
+// zero value results in the default output of every format.
+type Options struct {
+	SPDX  spdxhelpers.Options
+	Table table.Options
+}
+
 // TODO: eventually this is the source of truth for all formatters
 func All() []format.Format {
+	return AllWithOptions(Options{})
+}
+
+// AllWithOptions returns all formats, where the formats that have options are configured with the given options.
+func AllWithOptions(opts Options) []format.Format {
 	return []format.Format{
 		syftjson.Format(),
-		table.Format(),
+		jsonlines.Format(),
+		table.FormatWithOptions(opts.Table),
+		csv.Format(),
 		cyclonedx13xml.Format(),
 		cyclonedx13json.Format(),
-		spdx22json.Format(),
-		spdx22tagvalue.Format(),
+		spdx22json.FormatWithOptions(opts.SPDX),
+		spdx22tagvalue.FormatWithOptions(opts.SPDX),
+		intoto.Format(),
+		github.Format(),
+		tern.Format(),
 		text.Format(),
+		text.GroupedFormat(),
 	}
 }
 
@@ -37,7 +61,13 @@ func Identify(by []byte) (*format.Format, error) {
 }
 
 func ByOption(option format.Option) *format.Format {
-	for _, f := range All() {
+	return ByOptionWithOptions(option, Options{})
+}
+
+// ByOptionWithOptions returns the format for the given option (or nil when unknown), which is configured with the
+// given options.
+func ByOptionWithOptions(option format.Option, opts Options) *format.Format {
+	for _, f := range AllWithOptions(opts) {
 		if f.Option == option {
 			return &f
 		}
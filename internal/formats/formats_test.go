@@ -1,12 +1,17 @@
 package formats
 
 import (
+	"bytes"
 	"io"
 	"os"
+	"sync"
 	"testing"
 
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/internal/formats/table"
 	"github.com/anchore/syft/syft/format"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIdentify(t *testing.T) {
@@ -32,3 +37,26 @@ func TestIdentify(t *testing.T) {
 		})
 	}
 }
+
+func TestByOptionWithOptions_independent(t *testing.T) {
+	s := testutils.DirectoryInput(t)
+	configured := ByOptionWithOptions(format.TableOption, Options{Table: table.Options{Columns: []string{"purl"}}})
+	require.NotNil(t, configured)
+	defaults := ByOption(format.TableOption)
+	require.NotNil(t, defaults)
+
+	// formats with other options may be used at the same time without affecting each other
+	var wg sync.WaitGroup
+	outputs := make([]bytes.Buffer, 2)
+	for i, f := range []*format.Format{configured, defaults} {
+		wg.Add(1)
+		go func(f *format.Format, output *bytes.Buffer) {
+			defer wg.Done()
+			assert.NoError(t, f.Encode(output, s))
+		}(f, &outputs[i])
+	}
+	wg.Wait()
+
+	assert.Contains(t, outputs[0].String(), "PURL")
+	assert.NotContains(t, outputs[1].String(), "PURL")
+}
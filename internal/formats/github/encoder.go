@@ -0,0 +1,23 @@
+package github
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func encoder(output io.Writer, s sbom.SBOM) error {
+	snapshot, err := toSnapshot(s, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(output)
+	// prevent > and < from being escaped in the payload
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+
+	return enc.Encode(&snapshot)
+}
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noEnv is an empty environment (e.g. outside of a GitHub Actions workflow).
+func noEnv(string) string {
+	return ""
+}
+
+// env returns a lookup for the given environment.
+func env(vars map[string]string) func(string) string {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+func TestEncoder_directoryWithMultipleLockfiles(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/multiple-lockfiles")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	catalog, relationships, err := cataloger.Catalog(resolver, nil, cataloger.DefaultConfig(), cataloger.DirectoryCatalogers()...)
+	require.NoError(t, err)
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: catalog,
+		},
+		Relationships: relationships,
+		Source:        src.Metadata,
+	}
+	// a snapshot always describes a commit of a repository
+	s.Source.GitMetadata = source.GitMetadata{
+		Ref:    "refs/heads/main",
+		Commit: "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, s))
+
+	var snapshot DependencySnapshot
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &snapshot))
+
+	assert.Equal(t, 0, snapshot.Version)
+	assert.Equal(t, "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e", snapshot.Sha)
+	assert.Equal(t, "refs/heads/main", snapshot.Ref)
+	assert.Equal(t, "syft", snapshot.Detector.Name)
+	assert.Equal(t, "syft", snapshot.Job.Correlator)
+	assert.NotEmpty(t, snapshot.Scanned)
+
+	expected := map[string]Manifest{
+		"Gemfile.lock": {
+			Name: "Gemfile.lock",
+			File: &FileInfo{SourceLocation: "Gemfile.lock"},
+			Resolved: map[string]DependencyNode{
+				"pkg:gem/rake@13.0.6": {PackageURL: "pkg:gem/rake@13.0.6"},
+			},
+		},
+		"backend/requirements.txt": {
+			Name: "backend/requirements.txt",
+			File: &FileInfo{SourceLocation: "backend/requirements.txt"},
+			Resolved: map[string]DependencyNode{
+				"pkg:pypi/requests@2.26.0": {PackageURL: "pkg:pypi/requests@2.26.0"},
+				"pkg:pypi/six@1.16.0":      {PackageURL: "pkg:pypi/six@1.16.0"},
+			},
+		},
+		"frontend/package-lock.json": {
+			Name: "frontend/package-lock.json",
+			File: &FileInfo{SourceLocation: "frontend/package-lock.json"},
+			Resolved: map[string]DependencyNode{
+				"pkg:npm/left-pad@1.3.0": {PackageURL: "pkg:npm/left-pad@1.3.0"},
+				"pkg:npm/lodash@4.17.21": {PackageURL: "pkg:npm/lodash@4.17.21"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, snapshot.Manifests)
+}
+
+func Test_toSnapshot(t *testing.T) {
+	app := pkg.Package{
+		Name:      "app",
+		Version:   "1.0.0",
+		Type:      pkg.NpmPkg,
+		PURL:      "pkg:npm/app@1.0.0",
+		Locations: []source.Location{source.NewLocation("/package-lock.json")},
+	}
+	lib := pkg.Package{
+		Name:      "lib",
+		Version:   "2.0.0",
+		Type:      pkg.NpmPkg,
+		PURL:      "pkg:npm/lib@2.0.0",
+		Locations: []source.Location{source.NewLocation("/package-lock.json")},
+	}
+	// a package without a location is described under a synthetic manifest
+	binary := pkg.Package{
+		Name:    "github.com/anchore/syft",
+		Version: "v0.30.0",
+		Type:    pkg.GoModulePkg,
+		PURL:    "pkg:golang/github.com/anchore/syft@v0.30.0",
+	}
+	// OS packages are not supported by the GitHub dependency graph
+	osPackage := pkg.Package{
+		Name:      "musl",
+		Version:   "1.2.2-r3",
+		Type:      pkg.ApkPkg,
+		PURL:      "pkg:alpine/musl@1.2.2-r3",
+		Locations: []source.Location{source.NewLocation("/lib/apk/db/installed")},
+	}
+	// packages without a package URL cannot be described
+	noPURL := pkg.Package{
+		Name:      "no-purl",
+		Version:   "1.0.0",
+		Type:      pkg.NpmPkg,
+		Locations: []source.Location{source.NewLocation("/package-lock.json")},
+	}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(app, lib, binary, osPackage, noPURL),
+		},
+		Relationships: []artifact.Relationship{
+			{From: lib, To: app, Type: artifact.DependencyOfRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "/some/repo",
+			GitMetadata: source.GitMetadata{
+				URL:    "https://github.com/anchore/syft",
+				Ref:    "main",
+				Commit: "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e",
+			},
+		},
+	}
+
+	snapshot, err := toSnapshot(s, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e", snapshot.Sha)
+	assert.Equal(t, "main", snapshot.Ref)
+	assert.Equal(t, map[string]Manifest{
+		"package-lock.json": {
+			Name: "package-lock.json",
+			File: &FileInfo{SourceLocation: "package-lock.json"},
+			Resolved: map[string]DependencyNode{
+				"pkg:npm/app@1.0.0": {
+					PackageURL:   "pkg:npm/app@1.0.0",
+					Dependencies: []string{"pkg:npm/lib@2.0.0"},
+				},
+				"pkg:npm/lib@2.0.0": {
+					PackageURL: "pkg:npm/lib@2.0.0",
+				},
+			},
+		},
+		"/some/repo": {
+			Name: "/some/repo",
+			Resolved: map[string]DependencyNode{
+				"pkg:golang/github.com/anchore/syft@v0.30.0": {
+					PackageURL: "pkg:golang/github.com/anchore/syft@v0.30.0",
+				},
+			},
+		},
+	}, snapshot.Manifests)
+}
+
+func Test_toSnapshot_commitAndRef(t *testing.T) {
+	gitSource := source.Metadata{
+		Scheme: source.DirectoryScheme,
+		Path:   "/some/repo",
+		GitMetadata: source.GitMetadata{
+			Ref:    "refs/heads/main",
+			Commit: "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e",
+		},
+	}
+	imageSource := source.Metadata{
+		Scheme: source.ImageScheme,
+		ImageMetadata: source.ImageMetadata{
+			UserInput: "some/image:latest",
+		},
+	}
+	actionsEnv := env(map[string]string{
+		"GITHUB_SHA": "4f2b5e1c0d3a7b9e8f6a5c4d3e2f1a0b9c8d7e6f",
+		"GITHUB_REF": "refs/pull/42/merge",
+	})
+
+	tests := []struct {
+		name        string
+		src         source.Metadata
+		getenv      func(string) string
+		expectedSha string
+		expectedRef string
+		wantErr     string
+	}{
+		{
+			name:        "git source",
+			src:         gitSource,
+			getenv:      noEnv,
+			expectedSha: "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e",
+			expectedRef: "refs/heads/main",
+		},
+		{
+			name:        "git source within a workflow",
+			src:         gitSource,
+			getenv:      actionsEnv,
+			expectedSha: "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e",
+			expectedRef: "refs/heads/main",
+		},
+		{
+			name:        "image source within a workflow",
+			src:         imageSource,
+			getenv:      actionsEnv,
+			expectedSha: "4f2b5e1c0d3a7b9e8f6a5c4d3e2f1a0b9c8d7e6f",
+			expectedRef: "refs/pull/42/merge",
+		},
+		{
+			name:    "image source",
+			src:     imageSource,
+			getenv:  noEnv,
+			wantErr: "set GITHUB_SHA and GITHUB_REF",
+		},
+		{
+			name:    "image source without a ref",
+			src:     imageSource,
+			getenv:  env(map[string]string{"GITHUB_SHA": "4f2b5e1c0d3a7b9e8f6a5c4d3e2f1a0b9c8d7e6f"}),
+			wantErr: "(set GITHUB_REF)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := toSnapshot(sbom.SBOM{Source: test.src}, test.getenv)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSha, snapshot.Sha)
+			assert.Equal(t, test.expectedRef, snapshot.Ref)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package github
+
+import "github.com/anchore/syft/syft/format"
+
+func Format() format.Format {
+	return format.NewFormat(
+		format.GitHubOption,
+		encoder,
+		nil,
+		nil,
+	)
+}
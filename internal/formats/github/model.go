@@ -0,0 +1,55 @@
+package github
+
+// The shape of a GitHub dependency snapshot, as accepted by the dependency submission API
+// (see https://docs.github.com/en/rest/dependency-graph/dependency-submission).
+
+// DependencySnapshot describes all packages found for a single commit of a repository.
+type DependencySnapshot struct {
+	Version   int                 `json:"version"`
+	Job       Job                 `json:"job"`
+	Sha       string              `json:"sha"` // the commit that was cataloged (from the git source or GITHUB_SHA)
+	Ref       string              `json:"ref"` // the git ref that was cataloged (from the git source or GITHUB_REF)
+	Detector  Detector            `json:"detector"`
+	Metadata  Metadata            `json:"metadata,omitempty"`
+	Manifests map[string]Manifest `json:"manifests,omitempty"`
+	Scanned   string              `json:"scanned"`
+}
+
+// Job identifies the process that created the snapshot. Snapshots with the same correlator replace one another.
+type Job struct {
+	Correlator string `json:"correlator"`
+	ID         string `json:"id,omitempty"`
+	HTMLURL    string `json:"html_url,omitempty"`
+}
+
+// Detector describes the tool that created the snapshot.
+type Detector struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+// Metadata is a set of scalar values (strings, numbers, booleans, or null) with additional information.
+type Metadata map[string]interface{}
+
+// Manifest is a collection of packages declared by a single file (e.g. a lockfile).
+type Manifest struct {
+	Name     string                    `json:"name"`
+	File     *FileInfo                 `json:"file,omitempty"`
+	Metadata Metadata                  `json:"metadata,omitempty"`
+	Resolved map[string]DependencyNode `json:"resolved,omitempty"`
+}
+
+// FileInfo is the location of the manifest file, relative to the repository root.
+type FileInfo struct {
+	SourceLocation string `json:"source_location,omitempty"`
+}
+
+// DependencyNode is a single resolved package (keyed by package URL within a manifest).
+type DependencyNode struct {
+	PackageURL   string   `json:"package_url"`
+	Metadata     Metadata `json:"metadata,omitempty"`
+	Relationship string   `json:"relationship,omitempty"`
+	Scope        string   `json:"scope,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
@@ -0,0 +1,163 @@
+package github
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// supportedEcosystems are the package URL types that the GitHub dependency graph supports.
+var supportedEcosystems = map[string]bool{
+	packageurl.TypeComposer: true,
+	packageurl.TypeGem:      true,
+	packageurl.TypeGolang:   true,
+	packageurl.TypeMaven:    true,
+	packageurl.TypeNPM:      true,
+	packageurl.TypeNuget:    true,
+	packageurl.TypePyPi:     true,
+	"cargo":                 true,
+}
+
+// toSnapshot describes the given SBOM as a dependency snapshot of a single commit. The commit and ref are taken from
+// the git metadata of the source, otherwise from the environment of a GitHub Actions workflow (GITHUB_SHA and
+// GITHUB_REF), since the dependency submission API requires both.
+func toSnapshot(s sbom.SBOM, getenv func(string) string) (DependencySnapshot, error) {
+	sha, ref := s.Source.GitMetadata.Commit, s.Source.GitMetadata.Ref
+	if sha == "" {
+		sha = getenv("GITHUB_SHA")
+	}
+	if ref == "" {
+		ref = getenv("GITHUB_REF")
+	}
+
+	var missing []string
+	if sha == "" {
+		missing = append(missing, "GITHUB_SHA")
+	}
+	if ref == "" {
+		missing = append(missing, "GITHUB_REF")
+	}
+	if len(missing) > 0 {
+		return DependencySnapshot{}, fmt.Errorf("a github dependency snapshot requires the commit and ref that were cataloged, which are unknown for this source (set %s)", strings.Join(missing, " and "))
+	}
+
+	return DependencySnapshot{
+		Version: 0,
+		Job: Job{
+			Correlator: internal.ApplicationName,
+		},
+		Sha: sha,
+		Ref: ref,
+		Detector: Detector{
+			Name:    internal.ApplicationName,
+			Version: version.FromBuild().Version,
+			URL:     "https://github.com/anchore/syft",
+		},
+		Manifests: toManifests(s),
+		Scanned:   time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// toManifests groups all packages from a supported ecosystem by the file that the package was found by (e.g. the
+// lockfile). Packages without a location are described under a synthetic manifest named after the source.
+func toManifests(s sbom.SBOM) map[string]Manifest {
+	if s.Artifacts.PackageCatalog == nil {
+		return nil
+	}
+
+	dependencies := toDependencies(s.Relationships)
+	manifests := make(map[string]Manifest)
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		if !supportedEcosystems[p.Type.PackageURLType()] || p.PURL == "" {
+			log.Debugf("skipping package=%s without a package URL supported by the GitHub dependency graph", p)
+			continue
+		}
+
+		path := manifestPath(p, s.Source)
+		name := path
+		if name == "" {
+			name = syntheticManifestName(s.Source)
+		}
+
+		manifest, exists := manifests[name]
+		if !exists {
+			manifest = Manifest{
+				Name:     name,
+				Resolved: make(map[string]DependencyNode),
+			}
+			if path != "" {
+				manifest.File = &FileInfo{
+					SourceLocation: path,
+				}
+			}
+		}
+
+		manifest.Resolved[p.PURL] = DependencyNode{
+			PackageURL:   p.PURL,
+			Dependencies: dependencies[p.ID()],
+		}
+		manifests[name] = manifest
+	}
+
+	return manifests
+}
+
+// manifestPath returns the path of the file that the package was found by, relative to the root of the source (which
+// is the repository root when a repository is cataloged).
+func manifestPath(p pkg.Package, srcMetadata source.Metadata) string {
+	if len(p.Locations) == 0 {
+		return ""
+	}
+	location := p.Locations[0]
+	path := location.VirtualPath
+	if path == "" {
+		path = location.RealPath
+	}
+
+	if srcMetadata.Scheme == source.DirectoryScheme && srcMetadata.Path != "" {
+		// directory scans may report paths that include the directory given by the user
+		path = strings.TrimPrefix(path, filepath.Clean(srcMetadata.Path)+"/")
+	}
+	return strings.TrimPrefix(path, "/")
+}
+
+func syntheticManifestName(srcMetadata source.Metadata) string {
+	switch srcMetadata.Scheme {
+	case source.ImageScheme:
+		return srcMetadata.ImageMetadata.UserInput
+	case source.DirectoryScheme, source.FileScheme:
+		return srcMetadata.Path
+	}
+	return internal.ApplicationName
+}
+
+// toDependencies returns the package URLs of all direct dependencies for each package (keyed by package ID), taken from
+// the package-to-package "dependency-of" relationships.
+func toDependencies(relationships []artifact.Relationship) map[artifact.ID][]string {
+	results := make(map[artifact.ID][]string)
+	for _, r := range relationships {
+		if r.Type != artifact.DependencyOfRelationship {
+			continue
+		}
+		dependency, ok := r.From.(pkg.Package)
+		if !ok || dependency.PURL == "" {
+			continue
+		}
+		results[r.To.ID()] = append(results[r.To.ID()], dependency.PURL)
+	}
+	for _, purls := range results {
+		sort.Strings(purls)
+	}
+	return results
+}
@@ -0,0 +1,95 @@
+package intoto
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/internal/formats/syftjson"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// encoder writes the syft JSON document wrapped within an in-toto statement, where the subject is the cataloged
+// image (by manifest digest). Since this is a complete statement (not a predicate), it must not be given to
+// "cosign attest --predicate" (give the syft JSON document instead), however, it may be signed as a DSSE envelope and
+// attached with "cosign attach attestation".
+func encoder(output io.Writer, s sbom.SBOM) error {
+	subjects, err := toSubjects(s)
+	if err != nil {
+		return err
+	}
+
+	statement := Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       subjects,
+		Predicate:     syftjson.ToFormatModel(s),
+	}
+
+	enc := json.NewEncoder(output)
+	// prevent > and < from being escaped in the payload
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+
+	return enc.Encode(&statement)
+}
+
+// toSubjects describes every cataloged image source as a statement subject (all sources are considered when the
+// results for multiple sources are merged together).
+func toSubjects(s sbom.SBOM) ([]Subject, error) {
+	sources := s.Sources
+	if len(sources) == 0 {
+		sources = []source.Metadata{s.Source}
+	}
+
+	var subjects []Subject
+	for _, src := range sources {
+		subject, err := toSubject(src)
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects, nil
+}
+
+func toSubject(src source.Metadata) (Subject, error) {
+	if src.Scheme != source.ImageScheme {
+		return Subject{}, fmt.Errorf("in-toto statements can only describe image sources (got scheme=%q)", src.Scheme)
+	}
+
+	algorithm, value, err := imageDigest(src.ImageMetadata)
+	if err != nil {
+		return Subject{}, err
+	}
+
+	return Subject{
+		Name: src.ImageMetadata.UserInput,
+		Digest: map[string]string{
+			algorithm: value,
+		},
+	}, nil
+}
+
+// imageDigest returns the algorithm and value of the resolved image manifest digest. The repo digests are considered
+// when the manifest digest is not known (e.g. for some images from the docker daemon).
+func imageDigest(metadata source.ImageMetadata) (string, string, error) {
+	candidates := []string{metadata.ManifestDigest}
+	for _, repoDigest := range metadata.RepoDigests {
+		// repo digests are of the form "<repository>@<algorithm>:<value>"
+		if idx := strings.LastIndex(repoDigest, "@"); idx >= 0 {
+			candidates = append(candidates, repoDigest[idx+1:])
+		}
+	}
+
+	for _, candidate := range candidates {
+		fields := strings.SplitN(candidate, ":", 2)
+		if len(fields) == 2 && fields[0] != "" && fields[1] != "" {
+			return fields[0], fields[1], nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unable to determine the digest of image=%q", metadata.UserInput)
+}
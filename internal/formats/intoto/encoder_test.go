@@ -0,0 +1,95 @@
+package intoto
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateInTotoGoldenFiles = flag.Bool("update-in-toto", false, "update the *.golden files for in-toto format")
+
+func TestInTotoImagePresenter(t *testing.T) {
+	testImage := "image-simple"
+	testutils.AssertPresenterAgainstGoldenImageSnapshot(t,
+		Format().Presenter(testutils.ImageInput(t, testImage, testutils.FromSnapshot())),
+		testImage,
+		*updateInTotoGoldenFiles,
+	)
+}
+
+func TestEncoder_subjectImageDigest(t *testing.T) {
+	s := testutils.ImageInput(t, "image-simple", testutils.FromSnapshot())
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, s))
+
+	var statement Statement
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &statement))
+
+	assert.Equal(t, StatementType, statement.Type)
+	assert.Equal(t, PredicateType, statement.PredicateType)
+	assert.Equal(t, []Subject{
+		{
+			Name: "user-image-input",
+			Digest: map[string]string{
+				"sha256": "2731251dc34951c0e50fcc643b4c5f74922dad1a5d98f302b504cf46cd5d9368",
+			},
+		},
+	}, statement.Subject)
+	assert.NotEmpty(t, statement.Predicate.Artifacts)
+}
+
+func TestEncoder_directorySource(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, encoder(&buf, testutils.DirectoryInput(t)))
+}
+
+func Test_imageDigest(t *testing.T) {
+	tests := []struct {
+		name              string
+		metadata          source.ImageMetadata
+		expectedAlgorithm string
+		expectedValue     string
+		wantErr           bool
+	}{
+		{
+			name: "manifest digest",
+			metadata: source.ImageMetadata{
+				ManifestDigest: "sha256:abc",
+				RepoDigests:    []string{"docker.io/library/alpine@sha256:def"},
+			},
+			expectedAlgorithm: "sha256",
+			expectedValue:     "abc",
+		},
+		{
+			name: "repo digest",
+			metadata: source.ImageMetadata{
+				RepoDigests: []string{"docker.io/library/alpine@sha256:def"},
+			},
+			expectedAlgorithm: "sha256",
+			expectedValue:     "def",
+		},
+		{
+			name:    "no digest",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			algorithm, value, err := imageDigest(test.metadata)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedAlgorithm, algorithm)
+			assert.Equal(t, test.expectedValue, value)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package intoto
+
+import "github.com/anchore/syft/syft/format"
+
+func Format() format.Format {
+	return format.NewFormat(
+		format.InTotoOption,
+		encoder,
+		nil,
+		nil,
+	)
+}
@@ -0,0 +1,27 @@
+package intoto
+
+import (
+	"github.com/anchore/syft/internal/formats/syftjson/model"
+)
+
+const (
+	// StatementType is the in-toto statement layer type (see https://github.com/in-toto/attestation/tree/main/spec).
+	StatementType = "https://in-toto.io/Statement/v0.1"
+
+	// PredicateType identifies the syft JSON document as the predicate of the statement.
+	PredicateType = "https://syft.dev/sbom/json"
+)
+
+// Statement is an in-toto statement that wraps the syft JSON document as the predicate about the cataloged image(s).
+type Statement struct {
+	Type          string         `json:"_type"`
+	PredicateType string         `json:"predicateType"`
+	Subject       []Subject      `json:"subject"`
+	Predicate     model.Document `json:"predicate"`
+}
+
+// Subject is an artifact that the predicate applies to, identified by one or more digests (keyed by algorithm).
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
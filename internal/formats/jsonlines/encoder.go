@@ -0,0 +1,48 @@
+package jsonlines
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// Package is the shape of a single line within the JSON lines output (one line per package)
+type Package struct {
+	Name      string               `json:"name"`
+	Version   string               `json:"version"`
+	Type      pkg.Type             `json:"type"`
+	PURL      string               `json:"purl"`
+	Locations []source.Coordinates `json:"locations"`
+}
+
+// encoder writes each package as an independent JSON document on its own line (see https://jsonlines.org), writing
+// each line as the catalog is traversed instead of building the entire document in memory.
+func encoder(output io.Writer, s sbom.SBOM) error {
+	enc := json.NewEncoder(output)
+	enc.SetEscapeHTML(false)
+
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		if err := enc.Encode(toPackage(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toPackage(p pkg.Package) Package {
+	coordinates := make([]source.Coordinates, len(p.Locations))
+	for i, l := range p.Locations {
+		coordinates[i] = l.Coordinates
+	}
+
+	return Package{
+		Name:      p.Name,
+		Version:   p.Version,
+		Type:      p.Type,
+		PURL:      p.PURL,
+		Locations: coordinates,
+	}
+}
@@ -0,0 +1,50 @@
+package jsonlines
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateJSONLinesGoldenFiles = flag.Bool("update-json-lines", false, "update the *.golden files for json-lines format")
+
+func TestJSONLinesDirectoryPresenter(t *testing.T) {
+	testutils.AssertPresenterAgainstGoldenSnapshot(t,
+		Format().Presenter(testutils.DirectoryInput(t)),
+		*updateJSONLinesGoldenFiles,
+	)
+}
+
+func TestJSONLinesImagePresenter(t *testing.T) {
+	testImage := "image-simple"
+	testutils.AssertPresenterAgainstGoldenImageSnapshot(t,
+		Format().Presenter(testutils.ImageInput(t, testImage, testutils.FromSnapshot())),
+		testImage,
+		*updateJSONLinesGoldenFiles,
+	)
+}
+
+func TestEncoder_eachLineUnmarshals(t *testing.T) {
+	s := testutils.DirectoryInput(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, s))
+
+	var names []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var p Package
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &p), "line: %s", scanner.Text())
+		assert.NotEmpty(t, p.Locations)
+		names = append(names, p.Name)
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Equal(t, []string{"package-2", "package-1"}, names)
+}
@@ -0,0 +1,12 @@
+package jsonlines
+
+import "github.com/anchore/syft/syft/format"
+
+func Format() format.Format {
+	return format.NewFormat(
+		format.JSONLinesOption,
+		encoder,
+		nil,
+		nil,
+	)
+}
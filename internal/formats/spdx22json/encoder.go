@@ -4,19 +4,28 @@ import (
 	"encoding/json"
 	"io"
 
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/format"
 	"github.com/anchore/syft/syft/sbom"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
-	doc, err := toFormatModel(s)
-	if err != nil {
-		return err
-	}
+// newEncoder creates an encoder that writes SPDX JSON documents with the given options.
+func newEncoder(opts spdxhelpers.Options) format.ResolverEncoder {
+	return func(output io.Writer, s sbom.SBOM, resolver *file.CoordinatesResolver) error {
+		// compute the SHA1 digests that are required by the spec from the source, when available (note: s is a copy)
+		s.Artifacts.FileDigests = spdxhelpers.ResolvedFileDigests(s, resolver)
+
+		doc, err := toFormatModel(s, opts)
+		if err != nil {
+			return err
+		}
 
-	enc := json.NewEncoder(output)
-	// prevent > and < from being escaped in the payload
-	enc.SetEscapeHTML(false)
-	enc.SetIndent("", " ")
+		enc := json.NewEncoder(output)
+		// prevent > and < from being escaped in the payload
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", " ")
 
-	return enc.Encode(doc)
+		return enc.Encode(doc)
+	}
 }
@@ -1,13 +1,25 @@
 package spdx22json
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
+	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
 	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
 )
 
+// this is the path to the SPDX json schema relative to this package
+const spdxJSONSchemaPath = "../../../schema/spdx-json/spdx-schema-2.2.json"
+
 var updateSpdxJson = flag.Bool("update-spdx-json", false, "update the *.golden files for spdx-json presenters")
 
 func TestSPDXJSONDirectoryPresenter(t *testing.T) {
@@ -28,9 +40,28 @@ func TestSPDXJSONImagePresenter(t *testing.T) {
 	)
 }
 
+func TestSPDXJSONSchema(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, testutils.DirectoryInput(t)))
+
+	schemaPath, err := filepath.Abs(spdxJSONSchemaPath)
+	require.NoError(t, err)
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewReferenceLoader("file://"+schemaPath),
+		gojsonschema.NewBytesLoader(buf.Bytes()),
+	)
+	require.NoError(t, err)
+
+	for _, desc := range result.Errors() {
+		t.Errorf("failed json schema validation: %s", desc)
+	}
+}
+
 func spdxJsonRedactor(s []byte) []byte {
 	// each SBOM reports the time it was generated, which is not useful during snapshot testing
 	s = regexp.MustCompile(`"created": .*`).ReplaceAll(s, []byte("redacted"))
+	s = regexp.MustCompile(`"annotationDate": .*`).ReplaceAll(s, []byte("redacted"))
 
 	// each SBOM reports a unique documentNamespace when generated, this is not useful for snapshot testing
 	s = regexp.MustCompile(`"documentNamespace": .*`).ReplaceAll(s, []byte("redacted"))
@@ -38,3 +69,106 @@ func spdxJsonRedactor(s []byte) []byte {
 	// the license list will be updated periodically, the value here should not be directly tested in snapshot tests
 	return regexp.MustCompile(`"licenseListVersion": .*`).ReplaceAll(s, []byte("redacted"))
 }
+
+func TestSPDXJSONEncoder_minimal(t *testing.T) {
+	encode := func(minimal bool) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, FormatWithOptions(spdxhelpers.Options{Minimal: minimal}).Encode(&buf, testutils.DirectoryInput(t)))
+		return buf.Bytes()
+	}
+
+	defaultDoc := encode(false)
+	minimalDoc := encode(true)
+
+	assert.Less(t, countJSONFields(t, minimalDoc), countJSONFields(t, defaultDoc))
+	assert.NotContains(t, string(minimalDoc), `"annotations"`)
+	assert.NotContains(t, string(minimalDoc), `"sourceInfo"`)
+
+	// the mandatory fields are always kept (even when NONE or NOASSERTION)
+	for _, field := range []string{`"downloadLocation"`, `"licenseConcluded"`, `"licenseDeclared"`} {
+		assert.Contains(t, string(minimalDoc), field)
+	}
+
+	schemaPath, err := filepath.Abs(spdxJSONSchemaPath)
+	require.NoError(t, err)
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewReferenceLoader("file://"+schemaPath),
+		gojsonschema.NewBytesLoader(minimalDoc),
+	)
+	require.NoError(t, err)
+
+	for _, desc := range result.Errors() {
+		t.Errorf("failed json schema validation: %s", desc)
+	}
+}
+
+func TestSPDXJSONEncoder_metadataComment(t *testing.T) {
+	s := testutils.DirectoryInput(t)
+	var buf bytes.Buffer
+	require.NoError(t, FormatWithOptions(spdxhelpers.Options{MetadataComment: true}).Encode(&buf, s))
+
+	var doc model.Document
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	packages := s.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, doc.Packages, len(packages))
+	for i, p := range packages {
+		expected, err := json.Marshal(map[string]interface{}{
+			"metadataType": p.MetadataType,
+			"metadata":     p.Metadata,
+		})
+		require.NoError(t, err)
+
+		comment := doc.Packages[i].Comment
+		require.True(t, json.Valid([]byte(comment)), "invalid JSON comment for package=%q: %s", p.Name, comment)
+		assert.JSONEq(t, string(expected), comment)
+	}
+}
+
+func TestSPDXJSONEncoder_created(t *testing.T) {
+	f := FormatWithOptions(spdxhelpers.Options{Created: time.Unix(1651406400, 0).UTC()})
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Encode(&buf, testutils.DirectoryInput(t)))
+
+	var doc model.Document
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	expected := time.Unix(1651406400, 0).UTC()
+	assert.Equal(t, expected, doc.CreationInfo.Created)
+	assert.Contains(t, buf.String(), `"created": "2022-05-01T12:00:00Z"`)
+	for _, p := range doc.Packages {
+		for _, a := range p.Annotations {
+			assert.Equal(t, expected, a.AnnotationDate)
+		}
+	}
+
+	// the same input results in the same document
+	var again bytes.Buffer
+	require.NoError(t, f.Encode(&again, testutils.DirectoryInput(t)))
+	assert.Equal(t, buf.String(), again.String())
+}
+
+// countJSONFields returns the number of object fields within the given JSON document (at any depth).
+func countJSONFields(t *testing.T, doc []byte) int {
+	var value interface{}
+	require.NoError(t, json.Unmarshal(doc, &value))
+
+	var count func(v interface{}) int
+	count = func(v interface{}) int {
+		total := 0
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, field := range v {
+				total += 1 + count(field)
+			}
+		case []interface{}:
+			for _, item := range v {
+				total += count(item)
+			}
+		}
+		return total
+	}
+	return count(value)
+}
@@ -1,12 +1,20 @@
 package spdx22json
 
-import "github.com/anchore/syft/syft/format"
+import (
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/syft/format"
+)
 
 // note: this format is LOSSY relative to the syftjson formation, which means that decoding and validation is not supported at this time
 func Format() format.Format {
-	return format.NewFormat(
+	return FormatWithOptions(spdxhelpers.Options{})
+}
+
+// FormatWithOptions creates the SPDX JSON format, where documents are created with the given options.
+func FormatWithOptions(opts spdxhelpers.Options) format.Format {
+	return format.NewResolverFormat(
 		format.SPDXJSONOption,
-		encoder,
+		newEncoder(opts),
 		nil,
 		nil,
 	)
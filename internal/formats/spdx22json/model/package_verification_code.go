@@ -16,7 +16,7 @@ type PackageVerificationCode struct {
 	// SPDX data regarding the package. If a package contains more than one SPDX file all SPDX files must be excluded
 	// from the package verification code. If this is not done it would be impossible to correctly calculate the
 	// verification codes in both files.
-	PackageVerificationCodeExcludedFiles []string `json:"packageVerificationCodeExcludedFiles"`
+	PackageVerificationCodeExcludedFiles []string `json:"packageVerificationCodeExcludedFiles,omitempty"`
 
 	// The actual package verification code as a hex encoded value.
 	PackageVerificationCodeValue string `json:"packageVerificationCodeValue"`
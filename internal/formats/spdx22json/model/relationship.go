@@ -14,6 +14,10 @@ type Relationship struct {
 type RelationshipType string
 
 const (
+	// DescribesRelationship is to be used when SPDXRef-DOCUMENT describes SPDXRef-A.
+	// Example: An SPDX document WildFly.spdx describes package ‘WildFly’.
+	DescribesRelationship RelationshipType = "DESCRIBES"
+
 	// DescribedByRelationship is to be used when SPDXRef-A is described by SPDXREF-Document.
 	// Example: The package 'WildFly' is described by SPDX document WildFly.spdx.
 	DescribedByRelationship RelationshipType = "DESCRIBED_BY"
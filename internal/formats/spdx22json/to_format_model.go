@@ -4,15 +4,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
-	"strings"
 	"time"
 
-	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
 	"github.com/anchore/syft/internal/formats/spdx22json/model"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/internal/spdxlicense"
-	"github.com/anchore/syft/internal/version"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
@@ -21,12 +18,18 @@ import (
 )
 
 // toFormatModel creates and populates a new JSON document struct that follows the SPDX 2.2 spec from the given cataloging results.
-func toFormatModel(s sbom.SBOM) (*model.Document, error) {
-	name, namespace, err := spdxhelpers.DocumentNameAndNamespace(s.Source)
+func toFormatModel(s sbom.SBOM, opts spdxhelpers.Options) (*model.Document, error) {
+	name, namespace, err := spdxhelpers.DocumentNameAndNamespace(s, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	// include digests recorded by package metadata for files that were not otherwise digested (note: s is a copy)
+	s.Artifacts.FileDigests = spdxhelpers.FileDigests(s)
+
+	created := opts.CreationTime()
+	packages := toPackages(s, created, opts)
+
 	return &model.Document{
 		Element: model.Element{
 			SPDXID: model.ElementID("DOCUMENT").String(),
@@ -34,49 +37,86 @@ func toFormatModel(s sbom.SBOM) (*model.Document, error) {
 		},
 		SPDXVersion: model.Version,
 		CreationInfo: model.CreationInfo{
-			Created: time.Now().UTC(),
+			Comment: spdxhelpers.CreatorComment(s.Source, s.Artifacts.Distro),
+			Created: created,
 			Creators: []string{
 				// note: key-value format derived from the JSON example document examples: https://github.com/spdx/spdx-spec/blob/v2.2/examples/SPDXJSONExample-v2.2.spdx.json
 				"Organization: Anchore, Inc",
-				"Tool: " + internal.ApplicationName + "-" + version.FromBuild().Version,
+				toolCreator(),
 			},
 			LicenseListVersion: spdxlicense.Version,
 		},
 		DataLicense:       "CC0-1.0",
 		DocumentNamespace: namespace,
-		Packages:          toPackages(s.Artifacts.PackageCatalog, s.Relationships),
-		Files:             toFiles(s),
-		Relationships:     toRelationships(s.Relationships),
+		Packages:          packages,
+		Files:             toFiles(s, opts),
+		Relationships:     append(toDocumentRelationships(packages), toRelationships(s.Relationships)...),
+
+		HasExtractedLicensingInfos: toHasExtractedLicensingInfos(s.Artifacts.PackageCatalog),
 	}, nil
 }
 
-func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []model.Package {
+// toHasExtractedLicensingInfos describes all licenses that are not on the SPDX license list (which are referenced by
+// "LicenseRef-" identifiers from package license expressions).
+func toHasExtractedLicensingInfos(catalog *pkg.Catalog) []model.HasExtractedLicensingInfo {
+	results := make([]model.HasExtractedLicensingInfo, 0)
+	for _, other := range spdxhelpers.CatalogOtherLicenses(catalog) {
+		results = append(results, model.HasExtractedLicensingInfo{
+			LicenseID: other.ID,
+			// the full license text is not available, only the license value as declared in the package metadata
+			ExtractedText: other.Name,
+			Name:          other.Name,
+		})
+	}
+	return results
+}
+
+func toolCreator() string {
+	return "Tool: " + spdxhelpers.ToolName()
+}
+
+func toPackages(s sbom.SBOM, created time.Time, opts spdxhelpers.Options) []model.Package {
 	packages := make([]model.Package, 0)
 
-	for _, p := range catalog.Sorted() {
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
 		license := spdxhelpers.License(p)
 		packageSpdxID := model.ElementID(p.ID()).String()
+		verificationCode := toPackageVerificationCode(p, s)
+
+		sourceInfo := spdxhelpers.SourceInfo(p)
+		annotations := append(toFoundByAnnotations(p, created), toLayerAnnotations(p, s.Relationships, created)...)
+		if opts.Minimal {
+			// both fields are optional and only describe how the package was found
+			sourceInfo = ""
+			annotations = nil
+		}
 
-		// note: the license concluded and declared should be the same since we are collecting license information
-		// from the project data itself (the installed package files).
+		// note: the license concluded and declared are the same when the licenses are collected from the package
+		// metadata, however, licenses concluded from the package files are not declared by the package authors.
 		packages = append(packages, model.Package{
 			Description:      spdxhelpers.Description(p),
 			DownloadLocation: spdxhelpers.DownloadLocation(p),
 			ExternalRefs:     spdxhelpers.ExternalRefs(p),
-			FilesAnalyzed:    false,
-			HasFiles:         fileIDsForPackage(packageSpdxID, relationships),
+			FilesAnalyzed:    verificationCode != nil,
+			HasFiles:         fileIDsForPackage(packageSpdxID, s.Relationships),
 			Homepage:         spdxhelpers.Homepage(p),
 			// The Declared License is what the authors of a project believe govern the package
-			LicenseDeclared: license,
-			Originator:      spdxhelpers.Originator(p),
-			SourceInfo:      spdxhelpers.SourceInfo(p),
-			VersionInfo:     p.Version,
+			LicenseDeclared: spdxhelpers.DeclaredLicense(p),
+			Originator:      spdxhelpers.Originator(p).String(),
+			// note: the verification code is only provided when all files for the package have SHA1 digests
+			PackageVerificationCode: verificationCode,
+			SourceInfo:              sourceInfo,
+			Supplier:                spdxhelpers.Supplier(p).String(),
+			VersionInfo:             p.Version,
 			Item: model.Item{
 				// The Concluded License field is the license the SPDX file creator believes governs the package
 				LicenseConcluded: license,
+				LicenseComments:  spdxhelpers.LicenseComments(p),
 				Element: model.Element{
-					SPDXID: packageSpdxID,
-					Name:   p.Name,
+					SPDXID:      packageSpdxID,
+					Name:        p.Name,
+					Annotations: annotations,
+					Comment:     spdxhelpers.PackageComment(p, opts),
 				},
 			},
 		})
@@ -85,6 +125,58 @@ func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []m
 	return packages
 }
 
+// toFoundByAnnotations describes the cataloger that discovered the given package (useful for debugging SBOM provenance).
+func toFoundByAnnotations(p pkg.Package, created time.Time) []model.Annotation {
+	comment := spdxhelpers.FoundByAnnotation(p)
+	if comment == "" {
+		return nil
+	}
+
+	return []model.Annotation{
+		{
+			AnnotationDate: created,
+			AnnotationType: model.OtherAnnotationType,
+			Annotator:      toolCreator(),
+			Comment:        comment,
+		},
+	}
+}
+
+// toLayerAnnotations describes the image layer that introduced the given package (see spdxhelpers.LayerAnnotations).
+func toLayerAnnotations(p pkg.Package, relationships []artifact.Relationship, created time.Time) (result []model.Annotation) {
+	for _, comment := range spdxhelpers.LayerAnnotations(p, relationships) {
+		result = append(result, model.Annotation{
+			AnnotationDate: created,
+			AnnotationType: model.OtherAnnotationType,
+			Annotator:      toolCreator(),
+			Comment:        comment,
+		})
+	}
+	return result
+}
+
+func toPackageVerificationCode(p pkg.Package, s sbom.SBOM) *model.PackageVerificationCode {
+	var digestsByFile [][]file.Digest
+	for _, r := range s.Relationships {
+		if r.Type != artifact.ContainsRelationship || r.From.ID() != p.ID() {
+			continue
+		}
+
+		if coordinates, ok := r.To.(source.Coordinates); ok {
+			digestsByFile = append(digestsByFile, s.Artifacts.FileDigests[coordinates])
+		}
+	}
+
+	value := spdxhelpers.PackageVerificationCode(digestsByFile)
+	if value == "" {
+		return nil
+	}
+
+	return &model.PackageVerificationCode{
+		PackageVerificationCodeValue: value,
+	}
+}
+
 func fileIDsForPackage(packageSpdxID string, relationships []artifact.Relationship) (fileIDs []string) {
 	for _, relationship := range relationships {
 		if relationship.Type != artifact.ContainsRelationship {
@@ -106,11 +198,11 @@ func fileIDsForPackage(packageSpdxID string, relationships []artifact.Relationsh
 	return fileIDs
 }
 
-func toFiles(s sbom.SBOM) []model.File {
+func toFiles(s sbom.SBOM, opts spdxhelpers.Options) []model.File {
 	results := make([]model.File, 0)
 	artifacts := s.Artifacts
 
-	for _, coordinates := range sbom.AllCoordinates(s) {
+	for _, coordinates := range toDescribedCoordinates(s, opts) {
 		var metadata *source.FileMetadata
 		if metadataForLocation, exists := artifacts.FileMetadata[coordinates]; exists {
 			metadata = &metadataForLocation
@@ -124,7 +216,7 @@ func toFiles(s sbom.SBOM) []model.File {
 		// TODO: add file classifications (?) and content as a snippet
 
 		var comment string
-		if coordinates.FileSystemID != "" {
+		if coordinates.FileSystemID != "" && !opts.Minimal {
 			comment = fmt.Sprintf("layerID: %s", coordinates.FileSystemID)
 		}
 
@@ -140,7 +232,7 @@ func toFiles(s sbom.SBOM) []model.File {
 			},
 			Checksums: toFileChecksums(digests),
 			FileName:  coordinates.RealPath,
-			FileTypes: toFileTypes(metadata),
+			FileTypes: spdxhelpers.FileTypes(coordinates.RealPath, metadata),
 		})
 	}
 
@@ -151,6 +243,26 @@ func toFiles(s sbom.SBOM) []model.File {
 	return results
 }
 
+// toDescribedCoordinates returns the coordinates of all cataloged files. When unpackaged files are selected (see
+// spdxhelpers.Options.UnpackagedFileGlobs) only the files related to packages and the selected unpackaged files are
+// described, otherwise every file cataloged while looking for unpackaged files would be described as well.
+func toDescribedCoordinates(s sbom.SBOM, opts spdxhelpers.Options) []source.Coordinates {
+	if !opts.UnpackagedFilesEnabled() {
+		return sbom.AllCoordinates(s)
+	}
+
+	set := source.NewCoordinateSet(spdxhelpers.UnpackagedFiles(s, opts)...)
+	for _, r := range s.Relationships {
+		if coordinates, ok := r.From.(source.Coordinates); ok {
+			set.Add(coordinates)
+		}
+		if coordinates, ok := r.To.(source.Coordinates); ok {
+			set.Add(coordinates)
+		}
+	}
+	return set.ToSlice()
+}
+
 func toFileChecksums(digests []file.Digest) (checksums []model.Checksum) {
 	for _, digest := range digests {
 		checksums = append(checksums, model.Checksum{
@@ -161,43 +273,29 @@ func toFileChecksums(digests []file.Digest) (checksums []model.Checksum) {
 	return checksums
 }
 
-func toFileTypes(metadata *source.FileMetadata) (ty []string) {
-	if metadata == nil {
-		return nil
-	}
-
-	mimeTypePrefix := strings.Split(metadata.MIMEType, "/")[0]
-	switch mimeTypePrefix {
-	case "image":
-		ty = append(ty, string(model.ImageFileType))
-	case "video":
-		ty = append(ty, string(model.VideoFileType))
-	case "application":
-		ty = append(ty, string(model.ApplicationFileType))
-	case "text":
-		ty = append(ty, string(model.TextFileType))
-	case "audio":
-		ty = append(ty, string(model.AudioFileType))
-	}
-
-	if internal.IsExecutable(metadata.MIMEType) {
-		ty = append(ty, string(model.BinaryFileType))
-	}
-
-	if internal.IsArchive(metadata.MIMEType) {
-		ty = append(ty, string(model.ArchiveFileType))
-	}
-
-	// TODO: add support for source, spdx, and documentation file types
-	if len(ty) == 0 {
-		ty = append(ty, string(model.OtherFileType))
+// toDocumentRelationships describes every package from the SPDX document itself
+func toDocumentRelationships(packages []model.Package) (result []model.Relationship) {
+	for _, p := range packages {
+		result = append(result, model.Relationship{
+			SpdxElementID:      model.ElementID("DOCUMENT").String(),
+			RelationshipType:   model.DescribesRelationship,
+			RelatedSpdxElement: p.SPDXID,
+		})
 	}
-
-	return ty
+	return result
 }
 
 func toRelationships(relationships []artifact.Relationship) (result []model.Relationship) {
 	for _, r := range relationships {
+		switch r.Type {
+		case artifact.ContainedByLayerRelationship:
+			// layers are not SPDX elements, so these are described as package annotations instead
+			continue
+		case artifact.FoundInSourceRelationship:
+			// sources are not SPDX elements (the document describes all packages from all sources)
+			continue
+		}
+
 		exists, relationshipType, comment := lookupRelationship(r.Type)
 
 		if !exists {
@@ -219,8 +317,12 @@ func lookupRelationship(ty artifact.RelationshipType) (bool, model.RelationshipT
 	switch ty {
 	case artifact.ContainsRelationship:
 		return true, model.ContainsRelationship, ""
+	case artifact.DependencyOfRelationship:
+		return true, model.DependencyOfRelationship, ""
 	case artifact.OwnershipByFileOverlapRelationship:
 		return true, model.OtherRelationship, fmt.Sprintf("%s: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by", ty)
+	case artifact.SharedFileOwnershipRelationship:
+		return true, model.OtherRelationship, fmt.Sprintf("%s: indicates that packages from different package managers claim ownership of the same files, so the files of one package shadow the files of the other", ty)
 	}
 	return false, "", ""
 }
@@ -2,6 +2,7 @@ package spdx22json
 
 import (
 	"testing"
+	"time"
 
 	"github.com/anchore/syft/syft/pkg"
 
@@ -9,91 +10,15 @@ import (
 
 	"github.com/anchore/syft/syft/artifact"
 
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
 	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func Test_toFileTypes(t *testing.T) {
-
-	tests := []struct {
-		name     string
-		metadata source.FileMetadata
-		expected []string
-	}{
-		{
-			name: "application",
-			metadata: source.FileMetadata{
-				MIMEType: "application/vnd.unknown",
-			},
-			expected: []string{
-				string(model.ApplicationFileType),
-			},
-		},
-		{
-			name: "archive",
-			metadata: source.FileMetadata{
-				MIMEType: "application/zip",
-			},
-			expected: []string{
-				string(model.ApplicationFileType),
-				string(model.ArchiveFileType),
-			},
-		},
-		{
-			name: "audio",
-			metadata: source.FileMetadata{
-				MIMEType: "audio/ogg",
-			},
-			expected: []string{
-				string(model.AudioFileType),
-			},
-		},
-		{
-			name: "video",
-			metadata: source.FileMetadata{
-				MIMEType: "video/3gpp",
-			},
-			expected: []string{
-				string(model.VideoFileType),
-			},
-		},
-		{
-			name: "text",
-			metadata: source.FileMetadata{
-				MIMEType: "text/html",
-			},
-			expected: []string{
-				string(model.TextFileType),
-			},
-		},
-		{
-			name: "image",
-			metadata: source.FileMetadata{
-				MIMEType: "image/png",
-			},
-			expected: []string{
-				string(model.ImageFileType),
-			},
-		},
-		{
-			name: "binary",
-			metadata: source.FileMetadata{
-				MIMEType: "application/x-sharedlib",
-			},
-			expected: []string{
-				string(model.ApplicationFileType),
-				string(model.BinaryFileType),
-			},
-		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			assert.ElementsMatch(t, test.expected, toFileTypes(&test.metadata))
-		})
-	}
-}
-
 func Test_lookupRelationship(t *testing.T) {
 
 	tests := []struct {
@@ -107,12 +32,23 @@ func Test_lookupRelationship(t *testing.T) {
 			exists: true,
 			ty:     model.ContainsRelationship,
 		},
+		{
+			input:  artifact.DependencyOfRelationship,
+			exists: true,
+			ty:     model.DependencyOfRelationship,
+		},
 		{
 			input:   artifact.OwnershipByFileOverlapRelationship,
 			exists:  true,
 			ty:      model.OtherRelationship,
 			comment: "ownership-by-file-overlap: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by",
 		},
+		{
+			input:   artifact.SharedFileOwnershipRelationship,
+			exists:  true,
+			ty:      model.OtherRelationship,
+			comment: "shared-file-ownership: indicates that packages from different package managers claim ownership of the same files, so the files of one package shadow the files of the other",
+		},
 		{
 			input:  "made-up",
 			exists: false,
@@ -254,3 +190,365 @@ func Test_fileIDsForPackage(t *testing.T) {
 		})
 	}
 }
+
+func Test_toDocumentRelationships(t *testing.T) {
+	packages := []model.Package{
+		{
+			Item: model.Item{
+				Element: model.Element{
+					SPDXID: "SPDXRef-a",
+				},
+			},
+		},
+		{
+			Item: model.Item{
+				Element: model.Element{
+					SPDXID: "SPDXRef-b",
+				},
+			},
+		},
+	}
+
+	expected := []model.Relationship{
+		{
+			SpdxElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   model.DescribesRelationship,
+			RelatedSpdxElement: "SPDXRef-a",
+		},
+		{
+			SpdxElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   model.DescribesRelationship,
+			RelatedSpdxElement: "SPDXRef-b",
+		},
+	}
+
+	assert.Equal(t, expected, toDocumentRelationships(packages))
+}
+
+func Test_toPackageVerificationCode(t *testing.T) {
+	p := pkg.Package{
+		Name: "bogus",
+	}
+
+	c := source.Coordinates{
+		RealPath: "/path",
+	}
+
+	tests := []struct {
+		name     string
+		digests  map[source.Coordinates][]file.Digest
+		expected *model.PackageVerificationCode
+	}{
+		{
+			name: "no digests",
+		},
+		{
+			name: "no sha1 digests",
+			digests: map[source.Coordinates][]file.Digest{
+				c: {
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				},
+			},
+		},
+		{
+			name: "has sha1 digests",
+			digests: map[source.Coordinates][]file.Digest{
+				c: {
+					{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+				},
+			},
+			expected: &model.PackageVerificationCode{
+				// sha1("d6a770ba38583ed4bb4525bd96e50461655d2759")
+				PackageVerificationCodeValue: "d7ca5c6c2ba6bc2375d79732c57ce8da1ff3663b",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := sbom.SBOM{
+				Artifacts: sbom.Artifacts{
+					FileDigests: test.digests,
+				},
+				Relationships: []artifact.Relationship{
+					{
+						From: p,
+						To:   c,
+						Type: artifact.ContainsRelationship,
+					},
+				},
+			}
+			assert.Equal(t, test.expected, toPackageVerificationCode(p, s))
+		})
+	}
+}
+
+func Test_toLayerAnnotations(t *testing.T) {
+	p := pkg.Package{
+		Name:      "musl",
+		Version:   "1.2.2-r3",
+		Type:      pkg.ApkPkg,
+		Locations: []source.Location{source.NewLocation("/lib/apk/db/installed")},
+	}
+	other := pkg.Package{
+		Name:    "other",
+		Version: "1.0",
+		Type:    pkg.NpmPkg,
+	}
+	layer := source.LayerMetadata{
+		Digest: "sha256:2c617fd5ac4609ec1e501f6f9a72690b43dce3318d0fb2a3a29136c2b910f4c3",
+	}
+	relationships := []artifact.Relationship{
+		{
+			From: p,
+			To:   layer,
+			Type: artifact.ContainedByLayerRelationship,
+		},
+		{
+			From: p,
+			To:   source.NewLocation("/lib/ld-musl-x86_64.so.1").Coordinates,
+			Type: artifact.ContainsRelationship,
+		},
+	}
+	created := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	actual := toLayerAnnotations(p, relationships, created)
+	assert.Equal(t, []model.Annotation{
+		{
+			AnnotationDate: created,
+			AnnotationType: model.OtherAnnotationType,
+			Annotator:      toolCreator(),
+			Comment:        "contained-by-layer: sha256:2c617fd5ac4609ec1e501f6f9a72690b43dce3318d0fb2a3a29136c2b910f4c3",
+		},
+	}, actual)
+	assert.Empty(t, toLayerAnnotations(other, relationships, created))
+
+	// the layer is not an SPDX element, so no relationship should be created
+	assert.Len(t, toRelationships(relationships), 1)
+}
+
+func Test_toHasExtractedLicensingInfos(t *testing.T) {
+	catalog := pkg.NewCatalog(
+		pkg.Package{
+			Name:     "custom-license",
+			Version:  "1.0",
+			Licenses: []string{"MIT", "Acme Corp Proprietary License"},
+		},
+		pkg.Package{
+			Name:     "spdx-only",
+			Version:  "2.0",
+			Licenses: []string{"Apache-2.0"},
+		},
+	)
+
+	expected := []model.HasExtractedLicensingInfo{
+		{
+			LicenseID:     "LicenseRef-Acme-Corp-Proprietary-License",
+			ExtractedText: "Acme Corp Proprietary License",
+			Name:          "Acme Corp Proprietary License",
+		},
+	}
+
+	assert.Equal(t, expected, toHasExtractedLicensingInfos(catalog))
+
+	// the license expression for the package must reference the extracted licensing info
+	for _, p := range toPackages(sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: catalog}}, time.Time{}, spdxhelpers.Options{}) {
+		if p.Name == "custom-license" {
+			assert.Equal(t, "MIT AND LicenseRef-Acme-Corp-Proprietary-License", p.LicenseDeclared)
+		}
+	}
+}
+
+func Test_toFormatModel_packageOnly(t *testing.T) {
+	// when only packages are cataloged there are no package-to-file relationships nor any file artifacts, even for
+	// packages that are able to describe the files that they own
+	p := pkg.Package{
+		Name:         "six",
+		Version:      "1.16.0",
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPackageMetadataType,
+		Metadata: pkg.PythonPackageMetadata{
+			Name:                 "six",
+			Version:              "1.16.0",
+			SitePackagesRootPath: "/usr/lib/python3/site-packages",
+			Files: []pkg.PythonFileRecord{
+				{
+					Path: "six.py",
+					Digest: &pkg.PythonFileDigest{
+						Algorithm: "sha256",
+						Value:     "4Ww6z1zBr3Ttc8YlOWw81nbd0mSEaLZ8xXBUm1E_FdM",
+					},
+				},
+			},
+		},
+	}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	doc, err := toFormatModel(s, spdxhelpers.Options{})
+	require.NoError(t, err)
+
+	assert.Empty(t, doc.Files)
+	require.Len(t, doc.Packages, 1)
+	assert.False(t, doc.Packages[0].FilesAnalyzed)
+	assert.Empty(t, doc.Packages[0].HasFiles)
+	assert.Nil(t, doc.Packages[0].PackageVerificationCode)
+}
+
+func Test_toFormatModel_rpmChecksums(t *testing.T) {
+	// the RPM DB file digests are used for the file checksums (without reading any files), however, they do not describe
+	// the package archive itself, thus are not a package checksum
+	p := pkg.Package{
+		Name:         "dive",
+		Version:      "0.9.2-1",
+		Type:         pkg.RpmPkg,
+		MetadataType: pkg.RpmdbMetadataType,
+		Metadata: pkg.RpmdbMetadata{
+			Name:    "dive",
+			Version: "0.9.2",
+			Release: "1",
+			Files: []pkg.RpmdbFileRecord{
+				{
+					Path: "/usr/local/bin/dive",
+					Digest: file.Digest{
+						Algorithm: "sha256",
+						Value:     "81d29f327ba23096b3c52ff6fe1c425641e618bc87b5c05ee377edc650afaa55",
+					},
+				},
+			},
+		},
+	}
+	coordinates := source.Coordinates{RealPath: "/usr/local/bin/dive"}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: coordinates, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+		},
+	}
+
+	doc, err := toFormatModel(s, spdxhelpers.Options{})
+	require.NoError(t, err)
+
+	require.Len(t, doc.Packages, 1)
+	assert.Empty(t, doc.Packages[0].Checksums)
+
+	require.Len(t, doc.Files, 1)
+	assert.Equal(t, "/usr/local/bin/dive", doc.Files[0].FileName)
+	assert.Equal(t, []model.Checksum{
+		{
+			Algorithm:     "sha256",
+			ChecksumValue: "81d29f327ba23096b3c52ff6fe1c425641e618bc87b5c05ee377edc650afaa55",
+		},
+	}, doc.Files[0].Checksums)
+}
+
+func Test_toFoundByAnnotations(t *testing.T) {
+	created := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	actual := toFoundByAnnotations(pkg.Package{
+		Name:    "musl",
+		Version: "1.2.2-r3",
+		Type:    pkg.ApkPkg,
+		FoundBy: "apkdb-cataloger",
+	}, created)
+
+	require.Len(t, actual, 1)
+	assert.Equal(t, created, actual[0].AnnotationDate)
+	assert.Equal(t, model.OtherAnnotationType, actual[0].AnnotationType)
+	assert.Equal(t, toolCreator(), actual[0].Annotator)
+	assert.Contains(t, actual[0].Comment, "apkdb-cataloger")
+
+	assert.Empty(t, toFoundByAnnotations(pkg.Package{Name: "unknown-origin"}, created))
+}
+
+func Test_toFormatModel_licenseListVersion(t *testing.T) {
+	doc, err := toFormatModel(sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}, spdxhelpers.Options{})
+	require.NoError(t, err)
+
+	// the version of the license list that licenses are validated against
+	require.NotEmpty(t, spdxlicense.Version)
+	assert.Equal(t, spdxlicense.Version, doc.CreationInfo.LicenseListVersion)
+}
+
+func Test_toFormatModel_unpackagedFiles(t *testing.T) {
+	p := pkg.Package{Name: "some-package", Version: "1.0", Type: pkg.NpmPkg}
+	owned := source.Coordinates{RealPath: "/index.js"}
+	standalone := source.Coordinates{RealPath: "/deploy.sh"}
+	unselected := source.Coordinates{RealPath: "/config/app.yaml"}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+			FileDigests: map[source.Coordinates][]file.Digest{
+				owned:      {{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"}},
+				unselected: {{Algorithm: "sha1", Value: "0b7e5c6a1b6d2e3ad8d3f2a3c8e1f0e1f1c1ab5d"}},
+				standalone: {
+					{Algorithm: "sha1", Value: "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2"},
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				},
+			},
+			FileMetadata: map[source.Coordinates]source.FileMetadata{
+				standalone: {Type: source.RegularFile, MIMEType: "text/x-shellscript"},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: owned, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	doc, err := toFormatModel(s, spdxhelpers.Options{UnpackagedFileGlobs: spdxhelpers.DefaultUnpackagedFileGlobs})
+	require.NoError(t, err)
+
+	// only the file owned by the package and the selected unpackaged file are described
+	var fileNames []string
+	for _, f := range doc.Files {
+		fileNames = append(fileNames, f.FileName)
+	}
+	assert.Equal(t, []string{standalone.RealPath, owned.RealPath}, fileNames)
+
+	var f *model.File
+	for i := range doc.Files {
+		if doc.Files[i].FileName == standalone.RealPath {
+			f = &doc.Files[i]
+		}
+	}
+	require.NotNil(t, f, "unpackaged file is not described")
+	assert.Equal(t, string(standalone.ID()), f.SPDXID)
+	assert.Equal(t, []model.Checksum{
+		{Algorithm: "sha1", ChecksumValue: "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2"},
+		{Algorithm: "sha256", ChecksumValue: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+	}, f.Checksums)
+	assert.Equal(t, []string{string(model.TextFileType), string(model.SourceFileType)}, f.FileTypes)
+
+	// the unpackaged file is not claimed by any package
+	require.Len(t, doc.Packages, 1)
+	assert.NotContains(t, doc.Packages[0].HasFiles, f.SPDXID)
+	for _, r := range doc.Relationships {
+		assert.NotEqual(t, f.SPDXID, r.RelatedSpdxElement)
+	}
+}
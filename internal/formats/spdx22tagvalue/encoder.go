@@ -1,16 +1,136 @@
 package spdx22tagvalue
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"sort"
 
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/format"
 	"github.com/anchore/syft/syft/sbom"
+	"github.com/spdx/tools-golang/spdx"
 	"github.com/spdx/tools-golang/tvsaver"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
-	model, err := toFormatModel(s)
-	if err != nil {
+const (
+	// emptyCreationInfo is how the creation info is rendered for partial documents (only the section terminator).
+	emptyCreationInfo = "\n"
+	// fileSectionHeader is the header rendered before files that are not within a package.
+	fileSectionHeader = "##### Unpackaged files\n\n"
+)
+
+// newEncoder creates an encoder that writes the SPDX tag-value document for the given SBOM with the given options.
+// Rather than creating the entire document in memory (see toFormatModel), each package and each of its files is
+// written as soon as it is created, since these make up the bulk of the document (e.g. images with hundreds of
+// thousands of files). The output is the same either way.
+func newEncoder(opts spdxhelpers.Options) format.ResolverEncoder {
+	return func(output io.Writer, s sbom.SBOM, resolver *file.CoordinatesResolver) error {
+		// include digests recorded by package metadata for files that were not otherwise digested, and compute the SHA1
+		// digests that are required by the spec from the source, when available (note: s is a copy)
+		s.Artifacts.FileDigests = spdxhelpers.ResolvedFileDigests(s, resolver)
+
+		doc, err := toFormatDocument(s, opts)
+		if err != nil {
+			return err
+		}
+
+		w := &sectionWriter{output: output}
+
+		// the creation info, followed by the unpackaged files
+		if err := w.write(&spdx.Document2_2{CreationInfo: doc.CreationInfo, UnpackagedFiles: doc.UnpackagedFiles}, ""); err != nil {
+			return err
+		}
+
+		if err := writePackages(w, s, opts); err != nil {
+			return err
+		}
+
+		// all remaining sections
+		return w.write(&spdx.Document2_2{
+			CreationInfo:  &spdx.CreationInfo2_2{},
+			OtherLicenses: doc.OtherLicenses,
+			Relationships: doc.Relationships,
+			Annotations:   doc.Annotations,
+		}, emptyCreationInfo)
+	}
+}
+
+// writePackages writes every package followed by the files it contains, in the same order as the whole document
+// would be rendered (sorted by SPDX ID).
+func writePackages(w *sectionWriter, s sbom.SBOM, opts spdxhelpers.Options) error {
+	// note: per-package warnings are summarized once all packages have been written
+	warnings := log.NewWarningSummary()
+	defer warnings.Flush()
+
+	coordinatesByID := packageCoordinatesByID(s.Relationships)
+	packages := toFormatPackageIDs(s.Artifacts.PackageCatalog)
+	ids := make([]string, 0, len(packages))
+	for id := range packages {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		p := packages[spdx.ElementID(id)]
+		files, verificationCode := packageFiles(p, coordinatesByID[p.ID()], s, warnings)
+		doc := &spdx.Document2_2{
+			CreationInfo: &spdx.CreationInfo2_2{},
+			Packages: map[spdx.ElementID]*spdx.Package2_2{
+				spdx.ElementID(id): toFormatPackage(p, verificationCode, opts),
+			},
+		}
+		if err := w.write(doc, emptyCreationInfo); err != nil {
+			return err
+		}
+
+		// the files of a package are rendered by ID, where each file only has a single entry
+		fileIDs := make(map[string]int)
+		for i, coordinates := range files {
+			fileIDs["File-"+string(coordinates.ID())] = i
+		}
+		sortedFileIDs := make([]string, 0, len(fileIDs))
+		for fileID := range fileIDs {
+			sortedFileIDs = append(sortedFileIDs, fileID)
+		}
+		sort.Strings(sortedFileIDs)
+
+		for _, fileID := range sortedFileIDs {
+			coordinates := files[fileIDs[fileID]]
+			fileElementID, f := toFormatFile(coordinates, s.Artifacts.FileDigests[coordinates], s)
+			doc := &spdx.Document2_2{
+				CreationInfo:    &spdx.CreationInfo2_2{},
+				UnpackagedFiles: map[spdx.ElementID]*spdx.File2_2{fileElementID: f},
+			}
+			if err := w.write(doc, emptyCreationInfo+fileSectionHeader); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sectionWriter writes parts of an SPDX tag-value document. Since only whole documents can be rendered, each part is
+// rendered as a partial document, where the rendering of the (empty) sections that precede the part is skipped.
+type sectionWriter struct {
+	output io.Writer
+	buf    bytes.Buffer
+}
+
+// write renders the given partial document, writing everything that follows the given preamble to the output.
+func (w *sectionWriter) write(doc *spdx.Document2_2, preamble string) error {
+	w.buf.Reset()
+	if err := tvsaver.Save2_2(doc, &w.buf); err != nil {
 		return err
 	}
-	return tvsaver.Save2_2(model, output)
+
+	rendered := w.buf.Bytes()
+	if !bytes.HasPrefix(rendered, []byte(preamble)) {
+		return fmt.Errorf("unexpected SPDX tag-value rendering: missing preamble %q", preamble)
+	}
+
+	_, err := w.output.Write(rendered[len(preamble):])
+	return err
 }
@@ -1,11 +1,30 @@
 package spdx22tagvalue
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/spdx/tools-golang/tvsaver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
 	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
 )
 
 var updateSpdxTagValue = flag.Bool("update-spdx-tv", false, "update the *.golden files for spdx-tv presenters")
@@ -29,9 +48,23 @@ func TestSPDXTagValueImagePresenter(t *testing.T) {
 	)
 }
 
+func TestSPDXTagValueEncoder_deterministic(t *testing.T) {
+	var outputs []string
+	for i := 0; i < 5; i++ {
+		var buf bytes.Buffer
+		require.NoError(t, Format().Encode(&buf, testutils.DirectoryInput(t)))
+		outputs = append(outputs, string(spdxTagValueRedactor(buf.Bytes())))
+	}
+
+	for _, output := range outputs[1:] {
+		assert.Equal(t, outputs[0], output)
+	}
+}
+
 func spdxTagValueRedactor(s []byte) []byte {
 	// each SBOM reports the time it was generated, which is not useful during snapshot testing
 	s = regexp.MustCompile(`Created: .*`).ReplaceAll(s, []byte("redacted"))
+	s = regexp.MustCompile(`AnnotationDate: .*`).ReplaceAll(s, []byte("redacted"))
 
 	// each SBOM reports a unique documentNamespace when generated, this is not useful for snapshot testing
 	s = regexp.MustCompile(`DocumentNamespace: https://anchore.com/syft/.*`).ReplaceAll(s, []byte("redacted"))
@@ -39,3 +72,268 @@ func spdxTagValueRedactor(s []byte) []byte {
 	// the license list will be updated periodically, the value here should not be directly tested in snapshot tests
 	return regexp.MustCompile(`LicenseListVersion: .*`).ReplaceAll(s, []byte("redacted"))
 }
+
+func TestSPDXTagValueEncoder_minimal(t *testing.T) {
+	encode := func(minimal bool) string {
+		var buf bytes.Buffer
+		require.NoError(t, FormatWithOptions(spdxhelpers.Options{Minimal: minimal}).Encode(&buf, testutils.DirectoryInput(t)))
+		return buf.String()
+	}
+
+	defaultDoc := encode(false)
+	minimalDoc := encode(true)
+
+	assert.Less(t, countTagValueFields(minimalDoc), countTagValueFields(defaultDoc))
+	assert.NotContains(t, minimalDoc, "Annotator:")
+	assert.NotContains(t, minimalDoc, "PackageSourceInfo:")
+
+	// the mandatory fields are always kept (even when NONE or NOASSERTION)
+	for _, tag := range []string{"PackageDownloadLocation:", "PackageLicenseConcluded:", "PackageLicenseDeclared:", "PackageCopyrightText:"} {
+		assert.Contains(t, minimalDoc, tag)
+	}
+}
+
+func TestSPDXTagValueEncoder_metadataComment(t *testing.T) {
+	encode := func(metadataComment bool) string {
+		var buf bytes.Buffer
+		require.NoError(t, FormatWithOptions(spdxhelpers.Options{MetadataComment: metadataComment}).Encode(&buf, testutils.DirectoryInput(t)))
+		return buf.String()
+	}
+
+	assert.NotContains(t, encode(false), "PackageComment:")
+
+	var comments []string
+	for _, line := range strings.Split(encode(true), "\n") {
+		if strings.HasPrefix(line, "PackageComment: ") {
+			comments = append(comments, strings.TrimPrefix(line, "PackageComment: "))
+		}
+	}
+
+	packages := testutils.DirectoryInput(t).Artifacts.PackageCatalog.Sorted()
+	require.Len(t, comments, len(packages))
+	for _, comment := range comments {
+		assert.True(t, json.Valid([]byte(comment)), "invalid JSON comment: %s", comment)
+	}
+}
+
+func TestSPDXTagValueEncoder_created(t *testing.T) {
+	f := FormatWithOptions(spdxhelpers.Options{Created: time.Unix(1651406400, 0).UTC()})
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Encode(&buf, testutils.DirectoryInput(t)))
+
+	assert.Contains(t, buf.String(), "\nCreated: 2022-05-01T12:00:00Z\n")
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "AnnotationDate: ") {
+			assert.Equal(t, "AnnotationDate: 2022-05-01T12:00:00Z", line)
+		}
+	}
+}
+
+func TestSPDXTagValuePresenter_resolver(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/image-simple")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	locations, err := resolver.FilesByPath("test-fixtures/image-simple/file-1.txt")
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+
+	p := pkg.Package{
+		Name:      "package-1",
+		Version:   "1.0.1",
+		Locations: locations,
+	}
+
+	// note: the SBOM has no file digests, so the checksum can only be computed from the source
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: locations[0].Coordinates, Type: artifact.ContainsRelationship},
+		},
+		Source: src.Metadata,
+	}
+
+	present := func(resolver *file.CoordinatesResolver) string {
+		var buf bytes.Buffer
+		require.NoError(t, Format().PresenterWithResolver(s, resolver).Present(&buf))
+		return buf.String()
+	}
+
+	const checksum = "FileChecksum: SHA1: f222aa5d30b870046a98f723f4ef7e61f9668776"
+	assert.NotContains(t, present(nil), checksum)
+	assert.Contains(t, present(file.NewCoordinatesResolver(resolver)), checksum)
+}
+
+// countTagValueFields returns the number of tags within the given tag-value document (ignoring comments).
+func countTagValueFields(doc string) int {
+	count := 0
+	for _, line := range strings.Split(doc, "\n") {
+		if strings.HasPrefix(line, "#") || !strings.Contains(line, ": ") {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func TestSPDXTagValueEncoder_sameAsDocumentModel(t *testing.T) {
+	for _, minimal := range []bool{false, true} {
+		t.Run(fmt.Sprintf("minimal=%v", minimal), func(t *testing.T) {
+			opts := spdxhelpers.Options{Minimal: minimal}
+			s := largeSBOM(20, 5)
+
+			var streamed bytes.Buffer
+			require.NoError(t, newEncoder(opts)(&streamed, s, nil))
+
+			doc, err := toFormatModel(s, opts)
+			require.NoError(t, err)
+			var rendered bytes.Buffer
+			require.NoError(t, tvsaver.Save2_2(doc, &rendered))
+
+			assert.Equal(t, string(spdxTagValueRedactor(rendered.Bytes())), string(spdxTagValueRedactor(streamed.Bytes())))
+			assert.Contains(t, streamed.String(), "FileName: /usr/lib/package-3/file-4")
+		})
+	}
+}
+
+// BenchmarkSPDXTagValueEncoder compares writing a document for a large catalog (with many files per package) as it is
+// created against creating the whole document in memory first, reporting the peak heap usage of each.
+func BenchmarkSPDXTagValueEncoder(b *testing.B) {
+	s := largeSBOM(2000, 100)
+
+	benchmarks := []struct {
+		name   string
+		encode func(s sbom.SBOM) error
+	}{
+		{
+			name: "streaming",
+			encode: func(s sbom.SBOM) error {
+				return newEncoder(spdxhelpers.Options{})(ioutil.Discard, s, nil)
+			},
+		},
+		{
+			name: "document model",
+			encode: func(s sbom.SBOM) error {
+				doc, err := toFormatModel(s, spdxhelpers.Options{})
+				if err != nil {
+					return err
+				}
+				return tvsaver.Save2_2(doc, ioutil.Discard)
+			},
+		},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var peak uint64
+			for i := 0; i < b.N; i++ {
+				used, err := peakHeapGrowth(func() error {
+					return bm.encode(s)
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if used > peak {
+					peak = used
+				}
+			}
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MiB")
+		})
+	}
+}
+
+// peakHeapGrowth runs the given function, returning (approximately) the most heap memory in use at any point during
+// the run beyond what was in use beforehand.
+func peakHeapGrowth(fn func() error) (uint64, error) {
+	// collect garbage often, so the heap size is close to the memory that is actually in use
+	defer debug.SetGCPercent(debug.SetGCPercent(10))
+
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := stats.HeapAlloc
+	peak := baseline
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+
+		var sample runtime.MemStats
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				runtime.ReadMemStats(&sample)
+				if sample.HeapAlloc > peak {
+					peak = sample.HeapAlloc
+				}
+			}
+		}
+	}()
+
+	err := fn()
+	close(done)
+	wg.Wait()
+
+	return peak - baseline, err
+}
+
+// largeSBOM creates an SBOM with the given number of (interdependent) packages, each containing the given number of
+// files with digests, which resembles images where the file entries make up the bulk of the SPDX document.
+func largeSBOM(packages, filesPerPackage int) sbom.SBOM {
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+			FileDigests:    make(map[source.Coordinates][]file.Digest),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var previous *pkg.Package
+	for i := 0; i < packages; i++ {
+		p := pkg.Package{
+			Name:     fmt.Sprintf("package-%d", i),
+			Version:  fmt.Sprintf("1.0.%d", i),
+			Type:     pkg.DebPkg,
+			FoundBy:  "dpkgdb-cataloger",
+			Licenses: []string{"MIT", fmt.Sprintf("custom-license-%d", i%3)},
+			PURL:     fmt.Sprintf("pkg:deb/package-%d@1.0.%d", i, i),
+		}
+		s.Artifacts.PackageCatalog.Add(p)
+
+		for j := 0; j < filesPerPackage; j++ {
+			coordinates := source.Coordinates{RealPath: fmt.Sprintf("/usr/lib/package-%d/file-%d", i, j)}
+			s.Artifacts.FileDigests[coordinates] = []file.Digest{
+				{Algorithm: "sha1", Value: fmt.Sprintf("%040x", i*filesPerPackage+j)},
+				{Algorithm: "sha256", Value: fmt.Sprintf("%064x", i*filesPerPackage+j)},
+			}
+			s.Relationships = append(s.Relationships, artifact.Relationship{
+				From: p,
+				To:   coordinates,
+				Type: artifact.ContainsRelationship,
+			})
+		}
+
+		if previous != nil {
+			s.Relationships = append(s.Relationships, artifact.Relationship{
+				From: *previous,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+		previous = &p
+	}
+	return s
+}
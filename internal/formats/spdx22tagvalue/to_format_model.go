@@ -2,25 +2,55 @@ package spdx22tagvalue
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/anchore/syft/syft/sbom"
 
-	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/internal/spdxlicense"
-	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 	"github.com/spdx/tools-golang/spdx"
 )
 
 // toFormatModel creates and populates a new JSON document struct that follows the SPDX 2.2 spec from the given cataloging results.
+func toFormatModel(s sbom.SBOM, opts spdxhelpers.Options) (*spdx.Document2_2, error) {
+	// include digests recorded by package metadata for files that were not otherwise digested (note: s is a copy)
+	s.Artifacts.FileDigests = spdxhelpers.FileDigests(s)
+
+	doc, err := toFormatDocument(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	doc.Packages = toFormatPackages(s, opts)
+	return doc, nil
+}
+
+// toFormatDocument creates a new document struct that follows the SPDX 2.2 spec from the given cataloging results,
+// populating all sections except for the packages (see toFormatPackages), which make up the bulk of the document. The
+// file digests of the given SBOM are expected to include those recorded by package metadata (see
+// spdxhelpers.FileDigests).
 // nolint:funlen
-func toFormatModel(s sbom.SBOM) (*spdx.Document2_2, error) {
-	name, namespace, err := spdxhelpers.DocumentNameAndNamespace(s.Source)
+func toFormatDocument(s sbom.SBOM, opts spdxhelpers.Options) (*spdx.Document2_2, error) {
+	name, namespace, err := spdxhelpers.DocumentNameAndNamespace(s, opts)
 	if err != nil {
 		return nil, err
 	}
+
+	created := opts.CreationTime().Format(time.RFC3339)
+
+	var annotations []*spdx.Annotation2_2
+	if !opts.Minimal {
+		// annotations are optional and only describe how each package was found
+		annotations = toFormatAnnotations(s, created)
+	}
+
 	return &spdx.Document2_2{
 		CreationInfo: &spdx.CreationInfo2_2{
 			// 2.1: SPDX Version; should be in the format "SPDX-2.2"
@@ -71,203 +101,520 @@ func toFormatModel(s sbom.SBOM) (*spdx.Document2_2, error) {
 			// Cardinality: mandatory, one or many
 			CreatorPersons:       nil,
 			CreatorOrganizations: []string{"Anchore, Inc"},
-			CreatorTools:         []string{internal.ApplicationName + "-" + version.FromBuild().Version},
+			CreatorTools:         []string{spdxhelpers.ToolName()},
 
 			// 2.9: Created: data format YYYY-MM-DDThh:mm:ssZ
 			// Cardinality: mandatory, one
-			Created: time.Now().UTC().Format(time.RFC3339),
+			Created: created,
 
 			// 2.10: Creator Comment
 			// Cardinality: optional, one
-			CreatorComment: "",
+			CreatorComment: spdxhelpers.CreatorComment(s.Source, s.Artifacts.Distro),
 
 			// 2.11: Document Comment
 			// Cardinality: optional, one
 			DocumentComment: "",
 		},
-		Packages: toFormatPackages(s.Artifacts.PackageCatalog),
+		UnpackagedFiles: toFormatUnpackagedFiles(s, opts),
+		OtherLicenses:   toFormatOtherLicenses(s.Artifacts.PackageCatalog),
+		Relationships:   toFormatRelationships(toFormatPackageIDs(s.Artifacts.PackageCatalog), s.Relationships),
+		Annotations:     annotations,
 	}, nil
 }
 
+// toSPDXID returns a spec-valid SPDX element ID for the given package (without the "SPDXRef-" prefix).
+func toSPDXID(p pkg.Package) spdx.ElementID {
+	// the type and name keep the ID semantically useful, however, are not unique on their own (e.g. the same package
+	// may be installed at multiple locations or versions). A hash of the version and locations is added to make the
+	// ID unique while remaining stable across runs.
+	id := fmt.Sprintf("Package-%s-%s", p.Type, p.Name)
+
+	hash, err := artifact.IDFromHash(struct {
+		Version   string
+		Locations []source.Location
+	}{
+		Version:   p.Version,
+		Locations: p.Locations,
+	})
+	if err != nil {
+		log.Warnf("unable to generate unique SPDX ID for package=%s: %+v", p, err)
+	} else {
+		id += "-" + string(hash)
+	}
+
+	return spdx.ElementID(spdxhelpers.SanitizeElementID(id))
+}
+
+// toFormatPackageIDs returns every package from the given catalog by its SPDX ID (where packages that are later in the
+// sort order take precedence on any ID collision).
+func toFormatPackageIDs(catalog *pkg.Catalog) map[spdx.ElementID]pkg.Package {
+	results := make(map[spdx.ElementID]pkg.Package)
+	for _, p := range catalog.Sorted() {
+		results[toSPDXID(p)] = p
+	}
+	return results
+}
+
 // packages populates all Package Information from the package Catalog (see https://spdx.github.io/spdx-spec/3-package-information/)
-// nolint: funlen
-func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2 {
+func toFormatPackages(s sbom.SBOM, opts spdxhelpers.Options) map[spdx.ElementID]*spdx.Package2_2 {
 	results := make(map[spdx.ElementID]*spdx.Package2_2)
 
-	for p := range catalog.Enumerate() {
-		// name should be guaranteed to be unique, but semantically useful and stable
-		id := fmt.Sprintf("Package-%+v-%s", p.Type, p.Name)
-
-		// If the Concluded License is not the same as the Declared License, a written explanation should be provided
-		// in the Comments on License field (section 3.16). With respect to NOASSERTION, a written explanation in
-		// the Comments on License field (section 3.16) is preferred.
-		license := spdxhelpers.License(p)
+	// note: per-package warnings are summarized once all packages have been converted
+	warnings := log.NewWarningSummary()
+	defer warnings.Flush()
 
-		results[spdx.ElementID(id)] = &spdx.Package2_2{
+	coordinatesByID := packageCoordinatesByID(s.Relationships)
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		files, verificationCode := toFormatFiles(p, coordinatesByID[p.ID()], s, warnings)
+		result := toFormatPackage(p, verificationCode, opts)
+		result.Files = files
+		results[result.PackageSPDXIdentifier] = result
+	}
+	return results
+}
 
-			// NOT PART OF SPEC
-			// flag: does this "package" contain files that were in fact "unpackaged",
-			// e.g. included directly in the Document without being in a Package?
-			IsUnpackaged: false,
+// toFormatPackage populates the Package Information for a single package, without any of the files the package
+// contains (see toFormatFiles). The files are only analyzed when there is a verification code, which requires a SHA1
+// digest for every file the package contains (as with the SPDX JSON format).
+// nolint: funlen
+func toFormatPackage(p pkg.Package, verificationCode string, opts spdxhelpers.Options) *spdx.Package2_2 {
+	id := toSPDXID(p)
+	filesAnalyzed := verificationCode != ""
+
+	var licenseInfoFromFiles []string
+	if filesAnalyzed {
+		// no attempt is made to determine license information from the files themselves
+		licenseInfoFromFiles = []string{"NOASSERTION"}
+	}
 
-			// 3.1: Package Name
-			// Cardinality: mandatory, one
-			PackageName: p.Name,
+	// If the Concluded License is not the same as the Declared License, a written explanation should be provided
+	// in the Comments on License field (section 3.16). With respect to NOASSERTION, a written explanation in
+	// the Comments on License field (section 3.16) is preferred.
+	license := spdxhelpers.License(p)
 
-			// 3.2: Package SPDX Identifier: "SPDXRef-[idstring]"
-			// Cardinality: mandatory, one
-			PackageSPDXIdentifier: spdx.ElementID(id),
+	supplier := spdxhelpers.Supplier(p)
+	originator := spdxhelpers.Originator(p)
 
-			// 3.3: Package Version
-			// Cardinality: optional, one
-			PackageVersion: p.Version,
+	sourceInfo := spdxhelpers.SourceInfo(p)
+	// the FilesAnalyzed tag defaults to true, so it only needs to be present when false
+	filesAnalyzedTagPresent := true
+	if opts.Minimal {
+		sourceInfo = ""
+		filesAnalyzedTagPresent = !filesAnalyzed
+	}
 
-			// 3.4: Package File Name
-			// Cardinality: optional, one
-			PackageFileName: "",
+	return &spdx.Package2_2{
+
+		// NOT PART OF SPEC
+		// flag: does this "package" contain files that were in fact "unpackaged",
+		// e.g. included directly in the Document without being in a Package?
+		IsUnpackaged: false,
+
+		// 3.1: Package Name
+		// Cardinality: mandatory, one
+		PackageName: p.Name,
+
+		// 3.2: Package SPDX Identifier: "SPDXRef-[idstring]"
+		// Cardinality: mandatory, one
+		PackageSPDXIdentifier: id,
+
+		// 3.3: Package Version
+		// Cardinality: optional, one
+		PackageVersion: p.Version,
+
+		// 3.4: Package File Name
+		// Cardinality: optional, one
+		PackageFileName: "",
+
+		// 3.5: Package Supplier: may have single result for either Person or Organization,
+		//                        or NOASSERTION
+		// Cardinality: optional, one
+		PackageSupplierPerson:       actorName(supplier, spdxhelpers.PersonActor),
+		PackageSupplierOrganization: actorName(supplier, spdxhelpers.OrganizationActor),
+		PackageSupplierNOASSERTION:  false,
+
+		// 3.6: Package Originator: may have single result for either Person or Organization,
+		//                          or NOASSERTION
+		// Cardinality: optional, one
+		PackageOriginatorPerson:       actorName(originator, spdxhelpers.PersonActor),
+		PackageOriginatorOrganization: actorName(originator, spdxhelpers.OrganizationActor),
+		PackageOriginatorNOASSERTION:  false,
+
+		// 3.7: Package Download Location
+		// Cardinality: mandatory, one
+		// NONE if there is no download location whatsoever.
+		// NOASSERTION if:
+		//   (i) the SPDX file creator has attempted to but cannot reach a reasonable objective determination;
+		//   (ii) the SPDX file creator has made no attempt to determine this field; or
+		//   (iii) the SPDX file creator has intentionally provided no information (no meaning should be implied by doing so).
+		PackageDownloadLocation: spdxhelpers.DownloadLocation(p),
+
+		// 3.8: FilesAnalyzed
+		// Cardinality: optional, one; default value is "true" if omitted
+
+		// Purpose: Indicates whether the file content of this package has been available for or subjected to
+		// analysis when creating the SPDX document. If false, indicates packages that represent metadata or
+		// URI references to a project, product, artifact, distribution or a component. If false, the package
+		// must not contain any files.
+
+		// Intent: A package can refer to a project, product, artifact, distribution or a component that is
+		// external to the SPDX document.
+		FilesAnalyzed: filesAnalyzed,
+		// NOT PART OF SPEC: did FilesAnalyzed tag appear?
+		IsFilesAnalyzedTagPresent: filesAnalyzedTagPresent,
+
+		// 3.9: Package Verification Code
+		// Cardinality: mandatory, one if filesAnalyzed is true / omitted;
+		//              zero (must be omitted) if filesAnalyzed is false
+		// note: when only some of the files have SHA1 digests the verification code is omitted, since a partial
+		// verification code cannot be verified by consumers.
+		PackageVerificationCode: verificationCode,
+		// Spec also allows specifying a single file to exclude from the
+		// verification code algorithm; intended to enable exclusion of
+		// the SPDX document file itself.
+		PackageVerificationCodeExcludedFile: "",
+
+		// 3.10: Package Checksum: may have keys for SHA1, SHA256 and/or MD5
+		// Cardinality: optional, one or many
+
+		// 3.10.1 Purpose: Provide an independently reproducible mechanism that permits unique identification of
+		// a specific package that correlates to the data in this SPDX file. This identifier enables a recipient
+		// to determine if any file in the original package has been changed. If the SPDX file is to be included
+		// in a package, this value should not be calculated. The SHA-1 algorithm will be used to provide the
+		// checksum by default.
+
+		// note: based on the purpose above no discovered checksums should be provided, but instead, only
+		// tool-derived checksums (the file digests recorded by a package manager, such as the RPM DB, only describe
+		// the installed files, which are described by the file entries instead).
+		PackageChecksumSHA1:   "",
+		PackageChecksumSHA256: "",
+		PackageChecksumMD5:    "",
+
+		// 3.11: Package Home Page
+		// Cardinality: optional, one
+		PackageHomePage: spdxhelpers.Homepage(p),
+
+		// 3.12: Source Information
+		// Cardinality: optional, one
+		PackageSourceInfo: sourceInfo,
+
+		// 3.13: Concluded License: SPDX License Expression, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one
+		// Purpose: Contain the license the SPDX file creator has concluded as governing the
+		// package or alternative values, if the governing license cannot be determined.
+		PackageLicenseConcluded: license,
+
+		// 3.14: All Licenses Info from Files: SPDX License Expression, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one or many if filesAnalyzed is true / omitted;
+		//              zero (must be omitted) if filesAnalyzed is false
+		PackageLicenseInfoFromFiles: licenseInfoFromFiles,
+
+		// 3.15: Declared License: SPDX License Expression, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one
+		// Purpose: List the licenses that have been declared by the authors of the package.
+		// Any license information that does not originate from the package authors, e.g. license
+		// information from a third party repository, should not be included in this field.
+		PackageLicenseDeclared: spdxhelpers.DeclaredLicense(p),
+
+		// 3.16: Comments on License
+		// Cardinality: optional, one
+		PackageLicenseComments: spdxhelpers.LicenseComments(p),
+
+		// 3.17: Copyright Text: copyright notice(s) text, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one
+		// Purpose: Identify the copyright holders of the package, as well as any dates present. This will be a free form text field extracted from package information files. The options to populate this field are limited to:
+		//
+		// Any text related to a copyright notice, even if not complete;
+		// NONE if the package contains no copyright information whatsoever; or
+		// NOASSERTION, if
+		//   (i) the SPDX document creator has made no attempt to determine this field; or
+		//   (ii) the SPDX document creator has intentionally provided no information (no meaning should be implied by doing so).
+		//
+		PackageCopyrightText: "NOASSERTION",
+
+		// 3.18: Package Summary Description
+		// Cardinality: optional, one
+		PackageSummary: "",
+
+		// 3.19: Package Detailed Description
+		// Cardinality: optional, one
+		PackageDescription: "",
+
+		// 3.20: Package Comment
+		// Cardinality: optional, one
+		PackageComment: spdxhelpers.PackageComment(p, opts),
+
+		// 3.21: Package External Reference
+		// Cardinality: optional, one or many
+		PackageExternalReferences: formatSPDXExternalRefs(p),
+
+		// 3.22: Package External Reference Comment
+		// Cardinality: conditional (optional, one) for each External Reference
+		// contained within PackageExternalReference2_1 struct, if present
+
+		// 3.23: Package Attribution Text
+		// Cardinality: optional, one or many
+		PackageAttributionTexts: nil,
+
+		// Files contained in this Package
+		// note: these are added separately, since they may be written without keeping them all in memory
+		Files: nil,
+	}
+}
 
-			// 3.5: Package Supplier: may have single result for either Person or Organization,
-			//                        or NOASSERTION
-			// Cardinality: optional, one
-			PackageSupplierPerson:       "",
-			PackageSupplierOrganization: "",
-			PackageSupplierNOASSERTION:  false,
+// actorName returns the name of the given actor when it is of the given type (the tag-value document has separate
+// fields for people and organizations).
+func actorName(actor spdxhelpers.Actor, actorType spdxhelpers.ActorType) string {
+	if actor.Type != actorType {
+		return ""
+	}
+	return actor.Name
+}
 
-			// 3.6: Package Originator: may have single result for either Person or Organization,
-			//                          or NOASSERTION
-			// Cardinality: optional, one
-			PackageOriginatorPerson:       "",
-			PackageOriginatorOrganization: "",
-			PackageOriginatorNOASSERTION:  false,
+func formatSPDXExternalRefs(p pkg.Package) (refs []*spdx.PackageExternalReference2_2) {
+	for _, ref := range spdxhelpers.ExternalRefs(p) {
+		refs = append(refs, &spdx.PackageExternalReference2_2{
+			Category:           toFormatReferenceCategory(ref.ReferenceCategory),
+			RefType:            string(ref.ReferenceType),
+			Locator:            ref.ReferenceLocator,
+			ExternalRefComment: ref.Comment,
+		})
+	}
+	return refs
+}
 
-			// 3.7: Package Download Location
-			// Cardinality: mandatory, one
-			// NONE if there is no download location whatsoever.
-			// NOASSERTION if:
-			//   (i) the SPDX file creator has attempted to but cannot reach a reasonable objective determination;
-			//   (ii) the SPDX file creator has made no attempt to determine this field; or
-			//   (iii) the SPDX file creator has intentionally provided no information (no meaning should be implied by doing so).
-			PackageDownloadLocation: "NOASSERTION",
-
-			// 3.8: FilesAnalyzed
-			// Cardinality: optional, one; default value is "true" if omitted
-
-			// Purpose: Indicates whether the file content of this package has been available for or subjected to
-			// analysis when creating the SPDX document. If false, indicates packages that represent metadata or
-			// URI references to a project, product, artifact, distribution or a component. If false, the package
-			// must not contain any files.
-
-			// Intent: A package can refer to a project, product, artifact, distribution or a component that is
-			// external to the SPDX document.
-			FilesAnalyzed: false,
-			// NOT PART OF SPEC: did FilesAnalyzed tag appear?
-			IsFilesAnalyzedTagPresent: true,
-
-			// 3.9: Package Verification Code
-			// Cardinality: mandatory, one if filesAnalyzed is true / omitted;
-			//              zero (must be omitted) if filesAnalyzed is false
-			PackageVerificationCode: "",
-			// Spec also allows specifying a single file to exclude from the
-			// verification code algorithm; intended to enable exclusion of
-			// the SPDX document file itself.
-			PackageVerificationCodeExcludedFile: "",
-
-			// 3.10: Package Checksum: may have keys for SHA1, SHA256 and/or MD5
-			// Cardinality: optional, one or many
+// toFormatReferenceCategory converts the JSON reference category into the tag-value representation. Though the
+// SPDX JSON schema uses "PACKAGE_MANAGER", the tag-value format uses "PACKAGE-MANAGER" (see section 3.21).
+func toFormatReferenceCategory(category model.ReferenceCategory) string {
+	return strings.ReplaceAll(string(category), "_", "-")
+}
 
-			// 3.10.1 Purpose: Provide an independently reproducible mechanism that permits unique identification of
-			// a specific package that correlates to the data in this SPDX file. This identifier enables a recipient
-			// to determine if any file in the original package has been changed. If the SPDX file is to be included
-			// in a package, this value should not be calculated. The SHA-1 algorithm will be used to provide the
-			// checksum by default.
+// toFormatFiles populates File Information for the given files owned by the given package that have known digests
+// (see https://spdx.github.io/spdx-spec/4-file-information/), returning the files and the package verification code.
+// Files that are skipped are recorded in the given warning summary (or logged individually when no summary is given).
+func toFormatFiles(p pkg.Package, coordinates []source.Coordinates, s sbom.SBOM, warnings *log.WarningSummary) (map[spdx.ElementID]*spdx.File2_2, string) {
+	files, verificationCode := packageFiles(p, coordinates, s, warnings)
+	if len(files) == 0 {
+		return nil, ""
+	}
 
-			// note: based on the purpose above no discovered checksums should be provided, but instead, only
-			// tool-derived checksums.
-			PackageChecksumSHA1:   "",
-			PackageChecksumSHA256: "",
-			PackageChecksumMD5:    "",
+	results := make(map[spdx.ElementID]*spdx.File2_2)
+	for _, coordinates := range files {
+		id, f := toFormatFile(coordinates, s.Artifacts.FileDigests[coordinates], s)
+		results[id] = f
+	}
+	return results, verificationCode
+}
 
-			// 3.11: Package Home Page
-			// Cardinality: optional, one
-			PackageHomePage: "",
+// missingSHA1Warning summarizes the package files that are not described by a file entry, since the mandatory SHA1
+// checksum is unknown.
+var missingSHA1Warning = log.WarningKind{
+	Singular: "file was skipped without a SHA1 digest",
+	Plural:   "files were skipped without a SHA1 digest",
+}
 
-			// 3.12: Source Information
-			// Cardinality: optional, one
-			PackageSourceInfo: "",
+// packageFiles returns the coordinates of the given files owned by the given package that are described by a file
+// entry (see toFormatFiles), along with the package verification code.
+func packageFiles(p pkg.Package, files []source.Coordinates, s sbom.SBOM, warnings *log.WarningSummary) ([]source.Coordinates, string) {
+	var results []source.Coordinates
+	var digestsByFile [][]file.Digest
+
+	for _, coordinates := range files {
+		digests := s.Artifacts.FileDigests[coordinates]
+		digestsByFile = append(digestsByFile, digests)
+		if len(digests) == 0 {
+			continue
+		}
 
-			// 3.13: Concluded License: SPDX License Expression, "NONE" or "NOASSERTION"
-			// Cardinality: mandatory, one
-			// Purpose: Contain the license the SPDX file creator has concluded as governing the
-			// package or alternative values, if the governing license cannot be determined.
-			PackageLicenseConcluded: license,
+		if spdxhelpers.DigestValue(digests, "sha1") == "" {
+			// the SHA1 checksum is mandatory for all file entries, so don't emit an entry with an empty checksum
+			warnings.Warnf(missingSHA1Warning, "unable to find SHA1 digest for file=%q (package=%s), skipping SPDX file entry", coordinates.RealPath, p)
+			continue
+		}
 
-			// 3.14: All Licenses Info from Files: SPDX License Expression, "NONE" or "NOASSERTION"
-			// Cardinality: mandatory, one or many if filesAnalyzed is true / omitted;
-			//              zero (must be omitted) if filesAnalyzed is false
-			PackageLicenseInfoFromFiles: nil,
+		results = append(results, coordinates)
+	}
 
-			// 3.15: Declared License: SPDX License Expression, "NONE" or "NOASSERTION"
-			// Cardinality: mandatory, one
-			// Purpose: List the licenses that have been declared by the authors of the package.
-			// Any license information that does not originate from the package authors, e.g. license
-			// information from a third party repository, should not be included in this field.
-			PackageLicenseDeclared: license,
+	if len(results) == 0 {
+		return nil, ""
+	}
 
-			// 3.16: Comments on License
-			// Cardinality: optional, one
-			PackageLicenseComments: "",
+	return results, spdxhelpers.PackageVerificationCode(digestsByFile)
+}
 
-			// 3.17: Copyright Text: copyright notice(s) text, "NONE" or "NOASSERTION"
-			// Cardinality: mandatory, one
-			// Purpose: Identify the copyright holders of the package, as well as any dates present. This will be a free form text field extracted from package information files. The options to populate this field are limited to:
-			//
-			// Any text related to a copyright notice, even if not complete;
-			// NONE if the package contains no copyright information whatsoever; or
-			// NOASSERTION, if
-			//   (i) the SPDX document creator has made no attempt to determine this field; or
-			//   (ii) the SPDX document creator has intentionally provided no information (no meaning should be implied by doing so).
-			//
-			PackageCopyrightText: "NOASSERTION",
-
-			// 3.18: Package Summary Description
-			// Cardinality: optional, one
-			PackageSummary: "",
+// toFormatFile populates the File Information for a single file with the given digests, which must include a SHA1
+// digest (see https://spdx.github.io/spdx-spec/4-file-information/).
+func toFormatFile(coordinates source.Coordinates, digests []file.Digest, s sbom.SBOM) (spdx.ElementID, *spdx.File2_2) {
+	var metadata *source.FileMetadata
+	if metadataForLocation, exists := s.Artifacts.FileMetadata[coordinates]; exists {
+		metadata = &metadataForLocation
+	}
 
-			// 3.19: Package Detailed Description
-			// Cardinality: optional, one
-			PackageDescription: "",
+	id := spdx.ElementID("File-" + string(coordinates.ID()))
+	return id, &spdx.File2_2{
+		// 4.1: File Name
+		// Cardinality: mandatory, one
+		FileName: coordinates.RealPath,
+
+		// 4.2: File SPDX Identifier: "SPDXRef-[idstring]"
+		// Cardinality: mandatory, one
+		FileSPDXIdentifier: id,
+
+		// 4.3: File Type: SOURCE, BINARY, ARCHIVE, APPLICATION, AUDIO, IMAGE, TEXT, VIDEO, DOCUMENTATION, SPDX, OTHER
+		// Cardinality: optional, multiple
+		FileType: spdxhelpers.FileTypes(coordinates.RealPath, metadata),
+
+		// 4.4: File Checksum: may have keys for SHA1, SHA256 and/or MD5
+		// Cardinality: mandatory, one SHA1, others may be optionally provided
+		FileChecksumSHA1:   spdxhelpers.DigestValue(digests, "sha1"),
+		FileChecksumSHA256: spdxhelpers.DigestValue(digests, "sha256"),
+		FileChecksumMD5:    spdxhelpers.DigestValue(digests, "md5"),
+
+		// 4.5: Concluded License: SPDX License Expression, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one
+		LicenseConcluded: "NOASSERTION",
+
+		// 4.6: License Information in File: SPDX License Expression, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one or many
+		LicenseInfoInFile: []string{"NOASSERTION"},
+
+		// 4.8: Copyright Text: copyright notice(s) text, "NONE" or "NOASSERTION"
+		// Cardinality: mandatory, one
+		FileCopyrightText: "NOASSERTION",
+	}
+}
 
-			// 3.20: Package Comment
-			// Cardinality: optional, one
-			PackageComment: "",
+// toFormatUnpackagedFiles populates File Information for the selected files that are not contained by any package
+// (see spdxhelpers.Options.UnpackagedFileGlobs), which are otherwise missing from the document (e.g. config files and
+// standalone scripts). Files without a SHA1 digest are skipped, since the SHA1 checksum is mandatory.
+func toFormatUnpackagedFiles(s sbom.SBOM, opts spdxhelpers.Options) map[spdx.ElementID]*spdx.File2_2 {
+	results := make(map[spdx.ElementID]*spdx.File2_2)
+	for _, coordinates := range spdxhelpers.UnpackagedFiles(s, opts) {
+		digests := s.Artifacts.FileDigests[coordinates]
+		if spdxhelpers.DigestValue(digests, "sha1") == "" {
+			log.Debugf("unable to find SHA1 digest for unpackaged file=%q, skipping SPDX file entry", coordinates.RealPath)
+			continue
+		}
 
-			// 3.21: Package External Reference
-			// Cardinality: optional, one or many
-			PackageExternalReferences: formatSPDXExternalRefs(p),
+		id, f := toFormatFile(coordinates, digests, s)
+		results[id] = f
+	}
 
-			// 3.22: Package External Reference Comment
-			// Cardinality: conditional (optional, one) for each External Reference
-			// contained within PackageExternalReference2_1 struct, if present
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
 
-			// 3.23: Package Attribution Text
-			// Cardinality: optional, one or many
-			PackageAttributionTexts: nil,
+// packageCoordinatesByID returns the coordinates for all files that each package contains (by package ID).
+func packageCoordinatesByID(relationships []artifact.Relationship) map[artifact.ID][]source.Coordinates {
+	results := make(map[artifact.ID][]source.Coordinates)
+	for _, r := range relationships {
+		if r.Type != artifact.ContainsRelationship {
+			continue
+		}
 
-			// Files contained in this Package
-			Files: nil,
+		if coordinates, ok := r.To.(source.Coordinates); ok {
+			id := r.From.ID()
+			results[id] = append(results[id], coordinates)
 		}
 	}
 	return results
 }
 
-func formatSPDXExternalRefs(p pkg.Package) (refs []*spdx.PackageExternalReference2_2) {
-	for _, ref := range spdxhelpers.ExternalRefs(p) {
-		refs = append(refs, &spdx.PackageExternalReference2_2{
-			Category:           string(ref.ReferenceCategory),
-			RefType:            string(ref.ReferenceType),
-			Locator:            ref.ReferenceLocator,
-			ExternalRefComment: ref.Comment,
+// toFormatOtherLicenses populates all Other Licensing Information for licenses that are not on the SPDX license list
+// (see https://spdx.github.io/spdx-spec/6-other-licensing-information-detected/)
+func toFormatOtherLicenses(catalog *pkg.Catalog) (results []*spdx.OtherLicense2_2) {
+	for _, other := range spdxhelpers.CatalogOtherLicenses(catalog) {
+		results = append(results, &spdx.OtherLicense2_2{
+			// 6.1: License Identifier: "LicenseRef-[idstring]"
+			// Cardinality: conditional (mandatory, one) if license is not on SPDX License List
+			LicenseIdentifier: other.ID,
+
+			// 6.2: Extracted Text
+			// Cardinality: conditional (mandatory, one) if there is a License Identifier assigned
+			// note: the full license text is not available, only the license value as declared in the package metadata
+			ExtractedText: other.Name,
+
+			// 6.3: License Name: single line of text or "NOASSERTION"
+			// Cardinality: conditional (mandatory, one) if license is not on SPDX License List
+			LicenseName: other.Name,
 		})
 	}
-	return refs
+	return results
+}
+
+// toFormatAnnotations describes the cataloger that discovered each package (useful for debugging SBOM provenance) and
+// the image layer that introduced each package, see https://spdx.github.io/spdx-spec/8-annotations/
+func toFormatAnnotations(s sbom.SBOM, created string) (annotations []*spdx.Annotation2_2) {
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		var comments []string
+		if comment := spdxhelpers.FoundByAnnotation(p); comment != "" {
+			comments = append(comments, comment)
+		}
+		comments = append(comments, spdxhelpers.LayerAnnotations(p, s.Relationships)...)
+
+		for _, comment := range comments {
+			annotations = append(annotations, &spdx.Annotation2_2{
+				Annotator:                spdxhelpers.ToolName(),
+				AnnotatorType:            "Tool",
+				AnnotationDate:           created,
+				AnnotationType:           "OTHER",
+				AnnotationSPDXIdentifier: spdx.MakeDocElementID("", string(toSPDXID(p))),
+				AnnotationComment:        comment,
+			})
+		}
+	}
+	return annotations
+}
+
+// toFormatRelationships describes every package from the SPDX document itself, followed by the dependencies between
+// the packages (see https://spdx.github.io/spdx-spec/7-relationships-between-SPDX-elements/)
+func toFormatRelationships(packages map[spdx.ElementID]pkg.Package, relationships []artifact.Relationship) (results []*spdx.Relationship2_2) {
+	// note: the packages are keyed in a map, so sort by ID to keep the document stable across runs
+	ids := make([]string, 0, len(packages))
+	for id := range packages {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		results = append(results, &spdx.Relationship2_2{
+			RefA:         spdx.MakeDocElementID("", "DOCUMENT"),
+			RefB:         spdx.MakeDocElementID("", id),
+			Relationship: "DESCRIBES",
+		})
+	}
+
+	var dependencies []*spdx.Relationship2_2
+	for _, r := range relationships {
+		if r.Type != artifact.DependencyOfRelationship {
+			continue
+		}
+		from, fromOk := r.From.(pkg.Package)
+		to, toOk := r.To.(pkg.Package)
+		if !fromOk || !toOk {
+			continue
+		}
+		fromID, toID := toSPDXID(from), toSPDXID(to)
+		if _, ok := packages[fromID]; !ok {
+			continue
+		}
+		if _, ok := packages[toID]; !ok {
+			continue
+		}
+		dependencies = append(dependencies, &spdx.Relationship2_2{
+			RefA:         spdx.MakeDocElementID("", string(fromID)),
+			RefB:         spdx.MakeDocElementID("", string(toID)),
+			Relationship: "DEPENDENCY_OF",
+		})
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		if dependencies[i].RefA.ElementRefID != dependencies[j].RefA.ElementRefID {
+			return dependencies[i].RefA.ElementRefID < dependencies[j].RefA.ElementRefID
+		}
+		return dependencies[i].RefB.ElementRefID < dependencies[j].RefB.ElementRefID
+	})
+
+	return append(results, dependencies...)
 }
@@ -0,0 +1,741 @@
+package spdx22tagvalue
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/anchore/stereoscope/pkg/imagetest"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/tvloader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toFormatModel_describesAllPackages(t *testing.T) {
+	s := testutils.DirectoryInput(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, s))
+
+	doc, err := tvloader.Load2_2(&buf)
+	require.NoError(t, err)
+
+	var described []spdx.ElementID
+	for _, r := range doc.Relationships {
+		if r.Relationship != "DESCRIBES" {
+			continue
+		}
+		assert.Equal(t, spdx.ElementID("DOCUMENT"), r.RefA.ElementRefID)
+		described = append(described, r.RefB.ElementRefID)
+	}
+
+	var expected []spdx.ElementID
+	for p := range s.Artifacts.PackageCatalog.Enumerate() {
+		expected = append(expected, toSPDXID(p))
+	}
+
+	assert.Len(t, described, s.Artifacts.PackageCatalog.PackageCount())
+	assert.ElementsMatch(t, expected, described)
+}
+
+func Test_toFormatModel_packageOnly(t *testing.T) {
+	// when only packages are cataloged there are no package-to-file relationships nor any file artifacts
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(pkg.Package{
+				Name:     "six",
+				Version:  "1.16.0",
+				Type:     pkg.PythonPkg,
+				Licenses: []string{"MIT"},
+			}),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, s))
+
+	doc, err := tvloader.Load2_2(&buf)
+	require.NoError(t, err)
+
+	assert.Empty(t, doc.UnpackagedFiles)
+	require.Len(t, doc.Packages, 1)
+	for _, p := range doc.Packages {
+		assert.False(t, p.FilesAnalyzed)
+		assert.Empty(t, p.Files)
+		assert.Empty(t, p.PackageVerificationCode)
+		assert.Empty(t, p.PackageLicenseInfoFromFiles)
+	}
+}
+
+func Test_toFormatModel_unpackagedFiles(t *testing.T) {
+	p := pkg.Package{Name: "some-package", Version: "1.0", Type: pkg.NpmPkg}
+	owned := source.Coordinates{RealPath: "/index.js"}
+	standalone := source.Coordinates{RealPath: "/deploy.sh"}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+			FileDigests: map[source.Coordinates][]file.Digest{
+				owned: {{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"}},
+				standalone: {
+					{Algorithm: "sha1", Value: "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2"},
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				},
+			},
+			FileMetadata: map[source.Coordinates]source.FileMetadata{
+				standalone: {Type: source.RegularFile, MIMEType: "text/x-shellscript"},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: owned, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	encode := func(opts spdxhelpers.Options) *spdx.Document2_2 {
+		var buf bytes.Buffer
+		require.NoError(t, newEncoder(opts)(&buf, s, nil))
+		doc, err := tvloader.Load2_2(&buf)
+		require.NoError(t, err)
+		return doc
+	}
+
+	// unpackaged files are not described by default
+	assert.Empty(t, encode(spdxhelpers.Options{}).UnpackagedFiles)
+
+	doc := encode(spdxhelpers.Options{UnpackagedFileGlobs: spdxhelpers.DefaultUnpackagedFileGlobs})
+
+	require.Len(t, doc.UnpackagedFiles, 1)
+	for _, f := range doc.UnpackagedFiles {
+		assert.Equal(t, standalone.RealPath, f.FileName)
+		assert.Equal(t, "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2", f.FileChecksumSHA1)
+		assert.Equal(t, "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a", f.FileChecksumSHA256)
+		assert.Equal(t, []string{string(model.TextFileType), string(model.SourceFileType)}, f.FileType)
+	}
+
+	// the file owned by the package is only described within the package
+	for _, p := range doc.Packages {
+		require.Len(t, p.Files, 1)
+		for _, f := range p.Files {
+			assert.Equal(t, owned.RealPath, f.FileName)
+		}
+	}
+}
+
+func Test_toFormatModel_dependencies(t *testing.T) {
+	app := pkg.Package{Name: "app", Version: "1.0.0", Type: pkg.NpmPkg}
+	lib := pkg.Package{Name: "lib", Version: "2.0.0", Type: pkg.NpmPkg}
+	missing := pkg.Package{Name: "missing", Version: "3.0.0", Type: pkg.NpmPkg}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(app, lib),
+		},
+		Relationships: []artifact.Relationship{
+			{From: lib, To: app, Type: artifact.DependencyOfRelationship},
+			// cycles are rendered as-is
+			{From: app, To: lib, Type: artifact.DependencyOfRelationship},
+			// packages that are not within the document are not referenced
+			{From: missing, To: app, Type: artifact.DependencyOfRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, s))
+
+	doc, err := tvloader.Load2_2(&buf)
+	require.NoError(t, err)
+
+	var actual [][2]spdx.ElementID
+	for _, r := range doc.Relationships {
+		if r.Relationship != "DEPENDENCY_OF" {
+			continue
+		}
+		actual = append(actual, [2]spdx.ElementID{r.RefA.ElementRefID, r.RefB.ElementRefID})
+	}
+
+	assert.ElementsMatch(t, [][2]spdx.ElementID{
+		{toSPDXID(lib), toSPDXID(app)},
+		{toSPDXID(app), toSPDXID(lib)},
+	}, actual)
+}
+
+func Test_toSPDXID(t *testing.T) {
+	validID := regexp.MustCompile(`^[a-zA-Z0-9.\-]+$`)
+
+	tests := []struct {
+		name   string
+		input  pkg.Package
+		prefix string
+	}{
+		{
+			name: "simple",
+			input: pkg.Package{
+				Name:    "bash",
+				Version: "5.1",
+				Type:    pkg.DebPkg,
+			},
+			prefix: "Package-deb-bash-",
+		},
+		{
+			name: "name with slashes",
+			input: pkg.Package{
+				Name:    "github.com/anchore/syft",
+				Version: "v0.32.0",
+				Type:    pkg.GoModulePkg,
+			},
+			prefix: "Package-go-module-github.com-anchore-syft-",
+		},
+		{
+			name: "name with spaces",
+			input: pkg.Package{
+				Name:    "a package name",
+				Version: "1.0",
+				Type:    pkg.JavaPkg,
+			},
+			prefix: "Package-java-archive-a-package-name-",
+		},
+		{
+			name: "name with plus signs",
+			input: pkg.Package{
+				Name:    "libstdc++",
+				Version: "10.2.1",
+				Type:    pkg.ApkPkg,
+			},
+			prefix: "Package-apk-libstdc---",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := string(toSPDXID(test.input))
+			assert.True(t, validID.MatchString(actual), "invalid SPDX ID: %q", actual)
+			assert.Regexp(t, "^"+regexp.QuoteMeta(test.prefix)+"[0-9a-f]+$", actual)
+			// the ID must be stable across invocations
+			assert.Equal(t, actual, string(toSPDXID(test.input)))
+		})
+	}
+}
+
+func Test_toFormatPackages_uniqueIDs(t *testing.T) {
+	catalog := pkg.NewCatalog(
+		pkg.Package{
+			Name:      "lodash",
+			Version:   "4.17.20",
+			Type:      pkg.NpmPkg,
+			Locations: []source.Location{source.NewLocation("/a/node_modules/lodash/package.json")},
+		},
+		pkg.Package{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			Type:      pkg.NpmPkg,
+			Locations: []source.Location{source.NewLocation("/b/node_modules/lodash/package.json")},
+		},
+		pkg.Package{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			Type:      pkg.NpmPkg,
+			Locations: []source.Location{source.NewLocation("/c/node_modules/lodash/package.json")},
+		},
+	)
+
+	assert.Len(t, toFormatPackages(sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: catalog}}, spdxhelpers.Options{}), catalog.PackageCount())
+}
+
+func Test_toFormatPackages_supplierAndOriginator(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata interface{}
+		expected spdx.Package2_2
+	}{
+		{
+			name:     "npm author",
+			metadata: pkg.NpmPackageJSONMetadata{Author: "Isaac Z. Schlueter <i@izs.me> (http://blog.izs.me)"},
+			expected: spdx.Package2_2{PackageOriginatorPerson: "Isaac Z. Schlueter (i@izs.me)"},
+		},
+		{
+			name:     "python author",
+			metadata: pkg.PythonPackageMetadata{Author: "Python Packaging Authority Team", AuthorEmail: "distutils-sig@python.org"},
+			expected: spdx.Package2_2{PackageOriginatorOrganization: "Python Packaging Authority Team (distutils-sig@python.org)"},
+		},
+		{
+			name:     "gem authors",
+			metadata: pkg.GemMetadata{Authors: []string{"Jeremy Evans", "Aaron Patterson"}},
+			expected: spdx.Package2_2{PackageOriginatorPerson: "Jeremy Evans"},
+		},
+		{
+			name:     "deb maintainer",
+			metadata: pkg.DpkgMetadata{Maintainer: "Debian Python Team <team+python@tracker.debian.org>"},
+			expected: spdx.Package2_2{PackageSupplierOrganization: "Debian Python Team (team+python@tracker.debian.org)"},
+		},
+		{
+			name:     "rpm vendor",
+			metadata: pkg.RpmdbMetadata{Vendor: "CentOS"},
+			expected: spdx.Package2_2{PackageSupplierOrganization: "CentOS"},
+		},
+		{
+			name:     "no metadata",
+			expected: spdx.Package2_2{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := pkg.Package{Name: "some-package", Version: "1.0", Metadata: test.metadata}
+
+			packages := toFormatPackages(sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog(p)}}, spdxhelpers.Options{})
+
+			require.Len(t, packages, 1)
+			for _, actual := range packages {
+				assert.Equal(t, test.expected.PackageSupplierPerson, actual.PackageSupplierPerson)
+				assert.Equal(t, test.expected.PackageSupplierOrganization, actual.PackageSupplierOrganization)
+				assert.Equal(t, test.expected.PackageOriginatorPerson, actual.PackageOriginatorPerson)
+				assert.Equal(t, test.expected.PackageOriginatorOrganization, actual.PackageOriginatorOrganization)
+			}
+		})
+	}
+}
+
+func Test_toFormatPackages_sourceInfo(t *testing.T) {
+	p := pkg.Package{
+		Name:      "lodash",
+		Version:   "4.17.21",
+		Type:      pkg.NpmPkg,
+		FoundBy:   "javascript-lock-cataloger",
+		Locations: []source.Location{source.NewLocation("/app/package-lock.json")},
+	}
+
+	packages := toFormatPackages(sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog(p)}}, spdxhelpers.Options{})
+
+	require.Len(t, packages, 1)
+	for _, actual := range packages {
+		assert.Contains(t, actual.PackageSourceInfo, "/app/package-lock.json")
+		assert.Contains(t, actual.PackageSourceInfo, "javascript-lock-cataloger")
+	}
+}
+
+func Test_toFormatAnnotations(t *testing.T) {
+	p := pkg.Package{
+		Name:      "lodash",
+		Version:   "4.17.21",
+		Type:      pkg.NpmPkg,
+		FoundBy:   "javascript-lock-cataloger",
+		Locations: []source.Location{source.NewLocation("/app/package-lock.json")},
+	}
+
+	annotations := toFormatAnnotations(sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog(p)}}, "2021-12-01T00:00:00Z")
+
+	require.Len(t, annotations, 1)
+	assert.Equal(t, "OTHER", annotations[0].AnnotationType)
+	assert.Equal(t, "Tool", annotations[0].AnnotatorType)
+	assert.Equal(t, "2021-12-01T00:00:00Z", annotations[0].AnnotationDate)
+	assert.Equal(t, spdx.MakeDocElementID("", string(toSPDXID(p))), annotations[0].AnnotationSPDXIdentifier)
+	assert.Contains(t, annotations[0].AnnotationComment, "javascript-lock-cataloger")
+}
+
+func Test_toFormatAnnotations_layer(t *testing.T) {
+	p := pkg.Package{
+		Name:      "musl",
+		Version:   "1.2.2-r3",
+		Type:      pkg.ApkPkg,
+		FoundBy:   "apkdb-cataloger",
+		Locations: []source.Location{source.NewLocation("/lib/apk/db/installed")},
+	}
+	layer := source.LayerMetadata{
+		Digest: "sha256:2c617fd5ac4609ec1e501f6f9a72690b43dce3318d0fb2a3a29136c2b910f4c3",
+	}
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog(p)},
+		Relationships: []artifact.Relationship{
+			{
+				From: p,
+				To:   layer,
+				Type: artifact.ContainedByLayerRelationship,
+			},
+		},
+	}
+
+	// the same annotations are described as within SPDX JSON documents
+	annotations := toFormatAnnotations(s, "2021-12-01T00:00:00Z")
+	require.Len(t, annotations, 2)
+	assert.Contains(t, annotations[0].AnnotationComment, "apkdb-cataloger")
+	assert.Equal(t, "contained-by-layer: sha256:2c617fd5ac4609ec1e501f6f9a72690b43dce3318d0fb2a3a29136c2b910f4c3", annotations[1].AnnotationComment)
+	assert.Equal(t, spdx.MakeDocElementID("", string(toSPDXID(p))), annotations[1].AnnotationSPDXIdentifier)
+
+	// the layer is not an SPDX element, so no relationship is created
+	for _, r := range toFormatRelationships(map[spdx.ElementID]pkg.Package{toSPDXID(p): p}, s.Relationships) {
+		assert.Equal(t, "DESCRIBES", r.Relationship)
+	}
+}
+
+func Test_toFormatOtherLicenses(t *testing.T) {
+	catalog := pkg.NewCatalog(
+		pkg.Package{
+			Name:     "multi-license",
+			Version:  "1.0",
+			Licenses: []string{"MIT OR Apache-2.0", "custom license"},
+		},
+		pkg.Package{
+			Name:     "same-custom-license",
+			Version:  "2.0",
+			Licenses: []string{"custom license"},
+		},
+		pkg.Package{
+			Name:     "spdx-only",
+			Version:  "3.0",
+			Licenses: []string{"BSD-3-Clause"},
+		},
+	)
+
+	expected := []*spdx.OtherLicense2_2{
+		{
+			LicenseIdentifier: "LicenseRef-custom-license",
+			ExtractedText:     "custom license",
+			LicenseName:       "custom license",
+		},
+	}
+
+	assert.Equal(t, expected, toFormatOtherLicenses(catalog))
+
+	packages := toFormatPackages(sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: catalog}}, spdxhelpers.Options{})
+	for _, p := range packages {
+		if p.PackageName == "multi-license" {
+			assert.Equal(t, "(MIT OR Apache-2.0) AND LicenseRef-custom-license", p.PackageLicenseDeclared)
+			assert.Equal(t, p.PackageLicenseDeclared, p.PackageLicenseConcluded)
+		}
+	}
+}
+
+func Test_toFormatFiles(t *testing.T) {
+	p := pkg.Package{
+		Name:    "some-package",
+		Version: "1.0",
+	}
+
+	fileA := source.Coordinates{RealPath: "/a"}
+	fileB := source.Coordinates{RealPath: "/b"}
+	fileC := source.Coordinates{RealPath: "/c"}
+
+	relationships := []artifact.Relationship{
+		{From: p, To: fileA, Type: artifact.ContainsRelationship},
+		{From: p, To: fileB, Type: artifact.ContainsRelationship},
+		// not a file owned by the package
+		{From: p, To: fileC, Type: artifact.OwnershipByFileOverlapRelationship},
+	}
+
+	tests := []struct {
+		name             string
+		digests          map[source.Coordinates][]file.Digest
+		expectedFiles    []string
+		expectedCode     string
+		expectedChecksum map[string]string
+	}{
+		{
+			name: "no digests",
+		},
+		{
+			name: "all files have SHA1 digests",
+			digests: map[source.Coordinates][]file.Digest{
+				fileA: {
+					{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				},
+				fileB: {
+					{Algorithm: "sha1", Value: "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2"},
+				},
+				fileC: {
+					{Algorithm: "sha1", Value: "0000000000000000000000000000000000000000"},
+				},
+			},
+			expectedFiles: []string{"/a", "/b"},
+			expectedCode:  "599a7d40130c75c9f8829ae3fe720931e0bc4e9e",
+			expectedChecksum: map[string]string{
+				"/a": "d6a770ba38583ed4bb4525bd96e50461655d2759",
+				"/b": "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2",
+			},
+		},
+		{
+			name: "only some files have digests",
+			digests: map[source.Coordinates][]file.Digest{
+				fileA: {
+					{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+				},
+			},
+			expectedFiles: []string{"/a"},
+			// a partial verification code should never be emitted
+			expectedCode: "",
+			expectedChecksum: map[string]string{
+				"/a": "d6a770ba38583ed4bb4525bd96e50461655d2759",
+			},
+		},
+		{
+			name: "files without a SHA1 digest are skipped",
+			digests: map[source.Coordinates][]file.Digest{
+				fileA: {
+					{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+				},
+				fileB: {
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				},
+			},
+			expectedFiles: []string{"/a"},
+			expectedCode:  "",
+			expectedChecksum: map[string]string{
+				"/a": "d6a770ba38583ed4bb4525bd96e50461655d2759",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := sbom.SBOM{
+				Artifacts: sbom.Artifacts{
+					FileDigests: test.digests,
+				},
+				Relationships: relationships,
+			}
+
+			files, code := toFormatFiles(p, packageCoordinatesByID(s.Relationships)[p.ID()], s, nil)
+
+			var actualFiles []string
+			for _, f := range files {
+				actualFiles = append(actualFiles, f.FileName)
+				assert.Equal(t, test.expectedChecksum[f.FileName], f.FileChecksumSHA1)
+			}
+
+			assert.ElementsMatch(t, test.expectedFiles, actualFiles)
+			assert.Equal(t, test.expectedCode, code)
+		})
+	}
+}
+
+func Test_toFormatFiles_fileTypes(t *testing.T) {
+	p := pkg.Package{
+		Name:    "some-package",
+		Version: "1.0",
+	}
+
+	library := source.Coordinates{RealPath: "/usr/lib/python3.9/site-packages/some_package/_speedups.so"}
+	module := source.Coordinates{RealPath: "/usr/lib/python3.9/site-packages/some_package/__init__.py"}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			FileDigests: map[source.Coordinates][]file.Digest{
+				library: {{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"}},
+				module:  {{Algorithm: "sha1", Value: "3e3fc9ca3a76f4d2f0cbc4c8f0ee5cad8e0cd6d2"}},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: library, Type: artifact.ContainsRelationship},
+			{From: p, To: module, Type: artifact.ContainsRelationship},
+		},
+	}
+
+	files, _ := toFormatFiles(p, packageCoordinatesByID(s.Relationships)[p.ID()], s, nil)
+
+	actual := make(map[string][]string)
+	for _, f := range files {
+		actual[f.FileName] = f.FileType
+	}
+
+	assert.Equal(t, map[string][]string{
+		library.RealPath: {string(model.BinaryFileType)},
+		module.RealPath:  {string(model.SourceFileType)},
+	}, actual)
+}
+
+func Test_toFormatFiles_imageSHA1Checksums(t *testing.T) {
+	img := imagetest.GetGoldenFixtureImage(t, "image-simple")
+
+	src, err := source.NewFromImage(img, "user-image-input")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	cataloger, err := file.NewDigestsCataloger([]crypto.Hash{crypto.SHA1})
+	require.NoError(t, err)
+
+	digests, err := cataloger.Catalog(resolver)
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"/somefile-1.txt": "f222aa5d30b870046a98f723f4ef7e61f9668776",
+		"/somefile-2.txt": "cae1718be979a1928505fa18824814c2f0c97a5e",
+	}
+
+	for path, checksum := range expected {
+		locations, err := resolver.FilesByPath(path)
+		require.NoError(t, err)
+		require.Len(t, locations, 1)
+
+		p := pkg.Package{
+			Name:      "package-" + path,
+			Locations: locations,
+		}
+
+		s := sbom.SBOM{
+			Artifacts: sbom.Artifacts{
+				FileDigests: digests,
+			},
+			Relationships: []artifact.Relationship{
+				{From: p, To: locations[0].Coordinates, Type: artifact.ContainsRelationship},
+			},
+		}
+
+		files, code := toFormatFiles(p, packageCoordinatesByID(s.Relationships)[p.ID()], s, nil)
+		require.Len(t, files, 1)
+		assert.NotEmpty(t, code)
+
+		for _, f := range files {
+			assert.Equal(t, path, f.FileName)
+			assert.NotEmpty(t, f.FileChecksumSHA1)
+			assert.Equal(t, checksum, f.FileChecksumSHA1)
+		}
+	}
+}
+
+func Test_formatSPDXExternalRefs(t *testing.T) {
+	p := pkg.Package{
+		Name: "some-package",
+		PURL: "pkg:pypi/some-package@1.0",
+		CPEs: []pkg.CPE{
+			pkg.MustCPE("cpe:2.3:a:some:package:1.0:*:*:*:*:*:*:*"),
+		},
+	}
+
+	expected := []*spdx.PackageExternalReference2_2{
+		{
+			Category: "SECURITY",
+			RefType:  "cpe23Type",
+			Locator:  "cpe:2.3:a:some:package:1.0:*:*:*:*:*:*:*",
+		},
+		{
+			Category: "PACKAGE-MANAGER",
+			RefType:  "purl",
+			Locator:  "pkg:pypi/some-package@1.0",
+		},
+	}
+
+	assert.Equal(t, expected, formatSPDXExternalRefs(p))
+}
+
+func Test_toFormatModel_licenseListVersion(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, testutils.DirectoryInput(t)))
+
+	doc, err := tvloader.Load2_2(&buf)
+	require.NoError(t, err)
+
+	// the version of the license list that licenses are validated against
+	require.NotEmpty(t, spdxlicense.Version)
+	assert.Equal(t, spdxlicense.Version, doc.CreationInfo.LicenseListVersion)
+}
+
+func Test_toFormatPackages_summarizesSkippedFiles(t *testing.T) {
+	tests := []struct {
+		packages int
+		expected string
+	}{
+		{packages: 1, expected: "1 file was skipped without a SHA1 digest"},
+		{packages: 12, expected: "12 files were skipped without a SHA1 digest"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			capture, restore := log.Capture()
+			t.Cleanup(restore)
+
+			s := sbom.SBOM{
+				Artifacts: sbom.Artifacts{
+					PackageCatalog: pkg.NewCatalog(),
+					FileDigests:    make(map[source.Coordinates][]file.Digest),
+				},
+			}
+			for i := 0; i < test.packages; i++ {
+				p := pkg.Package{Name: fmt.Sprintf("package-%d", i), Version: "1.0"}
+				s.Artifacts.PackageCatalog.Add(p)
+
+				coordinates := source.Coordinates{RealPath: fmt.Sprintf("/package-%d", i)}
+				s.Artifacts.FileDigests[coordinates] = []file.Digest{
+					{Algorithm: "sha256", Value: "a4ab7a17b3a2a4ea0d17cd5a4a5d3e8a5d1a4e4b3fa6f6cdfbd2bab1ce8f9a9a"},
+				}
+				s.Relationships = append(s.Relationships, artifact.Relationship{From: p, To: coordinates, Type: artifact.ContainsRelationship})
+			}
+
+			packages := toFormatPackages(s, spdxhelpers.Options{})
+
+			assert.Len(t, packages, test.packages)
+			// each skipped file is only described at the debug level, then summarized in a single warning
+			assert.Len(t, capture.Debugs, test.packages)
+			assert.Equal(t, []string{test.expected}, capture.Warnings)
+		})
+	}
+}
+
+func Test_toFormatPackages_filesAnalyzedRequiresVerificationCode(t *testing.T) {
+	// symlinks owned by a package (e.g. listed by dpkg) have no digests, so there is no verification code
+	p := pkg.Package{Name: "some-package", Version: "1.0", Type: pkg.DebPkg}
+	regular := source.Coordinates{RealPath: "/usr/bin/some-tool"}
+	symlink := source.Coordinates{RealPath: "/usr/bin/some-tool-link"}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+			FileDigests: map[source.Coordinates][]file.Digest{
+				regular: {{Algorithm: "sha1", Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"}},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: p, To: regular, Type: artifact.ContainsRelationship},
+			{From: p, To: symlink, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	assertNotAnalyzed := func(t *testing.T, packages map[spdx.ElementID]*spdx.Package2_2) {
+		require.Len(t, packages, 1)
+		for _, result := range packages {
+			assert.False(t, result.FilesAnalyzed)
+			assert.Empty(t, result.PackageVerificationCode)
+			assert.Empty(t, result.PackageLicenseInfoFromFiles)
+		}
+	}
+
+	packages := toFormatPackages(s, spdxhelpers.Options{})
+	assertNotAnalyzed(t, packages)
+	for _, result := range packages {
+		// the file with a SHA1 digest is still described
+		require.Len(t, result.Files, 1)
+	}
+
+	// the streaming encoder must describe the package the same way
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, s))
+	doc, err := tvloader.Load2_2(&buf)
+	require.NoError(t, err)
+	assertNotAnalyzed(t, doc.Packages)
+}
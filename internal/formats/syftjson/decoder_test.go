@@ -6,8 +6,15 @@ import (
 	"testing"
 
 	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
 	"github.com/go-test/deep"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncodeDecodeCycle(t *testing.T) {
@@ -44,3 +51,281 @@ func TestEncodeDecodeCycle(t *testing.T) {
 		}
 	}
 }
+
+func TestEncodeDecodeCycle_apkMetadata(t *testing.T) {
+	// note: these values mirror the musl-utils entry within the apkdb cataloger test fixtures
+	p := pkg.Package{
+		Name:         "musl-utils",
+		Version:      "1.1.24-r2",
+		Type:         pkg.ApkPkg,
+		MetadataType: pkg.ApkMetadataType,
+		Metadata: pkg.ApkMetadata{
+			Package:       "musl-utils",
+			OriginPackage: "musl",
+			Version:       "1.1.24-r2",
+			Architecture:  "x86_64",
+			PullChecksum:  "Q1bTtF5526tETKfL+lnigzIDvm+2o=",
+		},
+	}
+	originalSBOM := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, originalSBOM))
+	assert.Contains(t, buf.String(), `"originPackage": "musl"`)
+	assert.Contains(t, buf.String(), `"pullChecksum": "Q1bTtF5526tETKfL+lnigzIDvm+2o="`)
+
+	actualSBOM, err := decoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	actualPackages := actualSBOM.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, actualPackages, 1)
+	metadata, ok := actualPackages[0].Metadata.(pkg.ApkMetadata)
+	require.True(t, ok, "unexpected metadata type: %T", actualPackages[0].Metadata)
+	assert.Equal(t, "musl", metadata.OriginPackage)
+	assert.Equal(t, "Q1bTtF5526tETKfL+lnigzIDvm+2o=", metadata.PullChecksum)
+}
+
+func TestEncodeDecodeCycle_normalizedVersion(t *testing.T) {
+	p := pkg.Package{
+		Name:              "github.com/google/uuid",
+		Version:           "v1.3.0",
+		NormalizedVersion: "1.3.0",
+		Type:              pkg.GoModulePkg,
+		Language:          pkg.Go,
+	}
+	unnormalized := pkg.Package{
+		Name:     "github.com/anchore/stereoscope",
+		Version:  "v0.0.0-20220217141419-c6f02aed9ed2",
+		Type:     pkg.GoModulePkg,
+		Language: pkg.Go,
+	}
+	originalSBOM := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p, unnormalized),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, originalSBOM))
+	assert.Contains(t, buf.String(), `"normalizedVersion": "1.3.0"`)
+	// packages without a normalized version (i.e. when not configured) omit the field
+	assert.Equal(t, 1, strings.Count(buf.String(), `"normalizedVersion"`))
+
+	actualSBOM, err := decoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	actualPackages := actualSBOM.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, actualPackages, 2)
+	assert.Empty(t, actualPackages[0].NormalizedVersion)
+	assert.Equal(t, "v1.3.0", actualPackages[1].Version)
+	assert.Equal(t, "1.3.0", actualPackages[1].NormalizedVersion)
+}
+
+func TestEncodeDecodeCycle_relationships(t *testing.T) {
+	parent := pkg.Package{
+		Name:      "parent",
+		Version:   "1.0.0",
+		Type:      pkg.RpmPkg,
+		Locations: []source.Location{source.NewLocation("/var/lib/rpm/Packages")},
+	}
+	child := pkg.Package{
+		Name:      "child",
+		Version:   "2.0.0",
+		Type:      pkg.JavaPkg,
+		Locations: []source.Location{source.NewLocation("/usr/share/java/child.jar")},
+	}
+	childFile := source.Coordinates{RealPath: "/usr/share/java/child.jar"}
+
+	originalSBOM := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(parent, child),
+			FileDigests: map[source.Coordinates][]file.Digest{
+				childFile: {{Algorithm: "sha256", Value: "abc123"}},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From: parent,
+				To:   child,
+				Type: artifact.OwnershipByFileOverlapRelationship,
+			},
+			{
+				From: parent,
+				To:   childFile,
+				Type: artifact.ContainsRelationship,
+			},
+			{
+				// relationships to artifacts that are not packages or files cannot be restored
+				From: child,
+				To:   source.Metadata{Scheme: source.DirectoryScheme, Path: "some/path"},
+				Type: artifact.FoundInSourceRelationship,
+			},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, originalSBOM))
+
+	actualSBOM, err := decoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	require.Len(t, actualSBOM.Relationships, 2)
+	for i, expected := range originalSBOM.Relationships[:2] {
+		actual := actualSBOM.Relationships[i]
+		assert.Equal(t, expected.From.ID(), actual.From.ID())
+		assert.Equal(t, expected.To.ID(), actual.To.ID())
+		assert.Equal(t, expected.Type, actual.Type)
+	}
+
+	// the relationships refer to the decoded packages (not just the package IDs)
+	_, isPackage := actualSBOM.Relationships[0].To.(pkg.Package)
+	assert.True(t, isPackage)
+	assert.Equal(t, childFile, actualSBOM.Relationships[1].To)
+}
+
+func TestEncodeDecodeCycle_fileSource(t *testing.T) {
+	originalSBOM := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(pkg.Package{
+				Name:    "requests",
+				Version: "2.26.0",
+				Type:    pkg.PythonPkg,
+			}),
+		},
+		Source: source.Metadata{
+			Scheme: source.FileScheme,
+			Path:   "some/requirements.txt",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, originalSBOM))
+
+	actualSBOM, err := decoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, originalSBOM.Source, actualSBOM.Source)
+	assert.Equal(t, 1, actualSBOM.Artifacts.PackageCatalog.PackageCount())
+}
+
+func TestDecoder_unsupportedSourceType(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, sbom.SBOM{
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}))
+	document := strings.Replace(buf.String(), `"type": "directory"`, `"type": "something-else"`, 1)
+	require.NotEqual(t, buf.String(), document)
+
+	_, err := decoder(strings.NewReader(document))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported source type")
+}
+
+func TestEncodeDecodeCycle_layerRelationships(t *testing.T) {
+	layer := source.LayerMetadata{
+		MediaType: "application/vnd.docker.image.rootfs.diff.tar.gzip",
+		Digest:    "sha256:3c9a1b3c8a2d0e4f56b3e6b3f1d2e4a5c6b7d8e9f0a1b2c3d4e5f6a7b8c9d0e1",
+		Size:      22,
+	}
+	p := pkg.Package{
+		Name:      "musl",
+		Version:   "1.2.3-r0",
+		Type:      pkg.ApkPkg,
+		Locations: []source.Location{source.NewLocation("/lib/apk/db/installed")},
+	}
+
+	originalSBOM := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From: p,
+				To:   layer,
+				Type: artifact.ContainedByLayerRelationship,
+			},
+		},
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: "alpine:3.16",
+				Layers:    []source.LayerMetadata{layer},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, originalSBOM))
+
+	actualSBOM, err := decoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	require.Len(t, actualSBOM.Relationships, 1)
+	assert.Equal(t, artifact.ContainedByLayerRelationship, actualSBOM.Relationships[0].Type)
+	assert.Equal(t, p.ID(), actualSBOM.Relationships[0].From.ID())
+	assert.Equal(t, layer, actualSBOM.Relationships[0].To)
+
+	// the layer ID of each package is kept when encoding the decoded document again
+	var reencoded bytes.Buffer
+	require.NoError(t, encoder(&reencoded, *actualSBOM))
+	assert.Contains(t, reencoded.String(), `"layerID": "`+layer.Digest+`"`)
+}
+
+func TestDecoder_unknownMetadataType(t *testing.T) {
+	capture, restore := log.Capture()
+	t.Cleanup(restore)
+
+	p := pkg.Package{
+		Name:         "github.com/anchore/hello",
+		Type:         pkg.GoModulePkg,
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			GoCompiledVersion: "go1.18",
+			Architecture:      "amd64",
+			MainModule:        "github.com/anchore/hello",
+		},
+	}
+	originalSBOM := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, originalSBOM))
+
+	// e.g. a document from a newer version of syft
+	document := strings.Replace(buf.String(), `"metadataType": "GolangBinMetadata"`, `"metadataType": "SomeFutureMetadata"`, 1)
+
+	actualSBOM, err := decoder(strings.NewReader(document))
+	require.NoError(t, err)
+
+	actualPackages := actualSBOM.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, actualPackages, 1)
+	assert.Nil(t, actualPackages[0].Metadata)
+	require.Len(t, capture.Warnings, 1)
+	assert.Contains(t, capture.Warnings[0], `unknown package metadata type="SomeFutureMetadata"`)
+}
@@ -0,0 +1,8 @@
+package model
+
+// Location represents a source.Location specialized for JSON marshaling and unmarshalling.
+type Location struct {
+	Path     string `json:"path"`               // the path the file was accessed by (which may contain symlinks)
+	LayerID  string `json:"layerID,omitempty"`  // the digest of the image layer the file was found in (image sources only)
+	RealPath string `json:"realPath,omitempty"` // the resolved path of the file (only when it differs from the path)
+}
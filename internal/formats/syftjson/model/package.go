@@ -6,7 +6,6 @@ import (
 
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/source"
 )
 
 // Package represents a pkg.Package object specialized for JSON marshaling and unmarshalling.
@@ -17,16 +16,19 @@ type Package struct {
 
 // PackageBasicData contains non-ambiguous values (type-wise) from pkg.Package.
 type PackageBasicData struct {
-	ID        string               `json:"id"`
-	Name      string               `json:"name"`
-	Version   string               `json:"version"`
-	Type      pkg.Type             `json:"type"`
-	FoundBy   string               `json:"foundBy"`
-	Locations []source.Coordinates `json:"locations"`
-	Licenses  []string             `json:"licenses"`
-	Language  pkg.Language         `json:"language"`
-	CPEs      []string             `json:"cpes"`
-	PURL      string               `json:"purl"`
+	ID                string       `json:"id"`
+	Name              string       `json:"name"`
+	Version           string       `json:"version"`
+	NormalizedVersion string       `json:"normalizedVersion,omitempty"` // the version comparable across ecosystems (only when configured)
+	Type              pkg.Type     `json:"type"`
+	FoundBy           string       `json:"foundBy"`
+	Locations         []Location   `json:"locations"`
+	Licenses          []string     `json:"licenses"`
+	LicensesConcluded bool         `json:"licensesConcluded,omitempty"` // the licenses were concluded from the package files instead of declared within the package metadata
+	Language          pkg.Language `json:"language"`
+	CPEs              []string     `json:"cpes"`
+	PURL              string       `json:"purl"`
+	LayerID           string       `json:"layerID,omitempty"` // the digest of the image layer that introduced the package (image sources only)
 }
 
 // PackageCustomData contains ambiguous values (type-wise) from pkg.Package.
@@ -99,6 +101,54 @@ func (p *Package) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		p.Metadata = payload
+	case pkg.BinaryMetadataType:
+		var payload pkg.BinaryMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.HaskellMetadataType:
+		var payload pkg.HaskellMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.MixLockMetadataType:
+		var payload pkg.MixLockMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.DartPubMetadataType:
+		var payload pkg.DartPubMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.WindowsRegistryMetadataType:
+		var payload pkg.WindowsRegistryMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.LinuxKernelMetadataType:
+		var payload pkg.LinuxKernelMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.ApkbuildMetadataType:
+		var payload pkg.ApkbuildMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.GemfileLockMetadataType:
+		var payload pkg.GemfileLockMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
 	case pkg.KbPackageMetadataType:
 		var payload pkg.KbPackageMetadata
 		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
@@ -117,6 +167,65 @@ func (p *Package) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		p.Metadata = payload
+	case pkg.NpmPackageLockJSONMetadataType:
+		var payload pkg.NpmPackageLockJSONMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.PythonRequirementsMetadataType:
+		var payload pkg.PythonRequirementsMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.PythonPoetryLockMetadataType:
+		var payload pkg.PythonPoetryLockMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.GolangBinMetadataType:
+		var payload pkg.GolangBinMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.GolangModMetadataType:
+		var payload pkg.GolangModMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.PhpComposerMetadataType:
+		var payload pkg.PhpComposerMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.CondaMetadataType:
+		var payload pkg.CondaMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.DotnetDepsMetadataType:
+		var payload pkg.DotnetDepsMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.SwiftPackageResolvedMetadataType:
+		var payload pkg.SwiftPackageResolvedMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case "", pkg.UnknownMetadataType:
+		// the package has no metadata
+	default:
+		// the metadata cannot be decoded faithfully (e.g. the document is from a newer version of syft)
+		log.Warnf("unknown package metadata type=%q for package=%q, dropping metadata", p.MetadataType, p.Name)
 	}
 
 	return nil
@@ -10,12 +10,14 @@ import (
 
 // Source object represents the thing that was cataloged
 type Source struct {
+	ID     string      `json:"id,omitempty"` // only provided when multiple sources were cataloged (referenced by relationships)
 	Type   string      `json:"type"`
 	Target interface{} `json:"target"`
 }
 
 // sourceUnpacker is used to unmarshal Source objects
 type sourceUnpacker struct {
+	ID     string          `json:"id,omitempty"`
 	Type   string          `json:"type"`
 	Target json.RawMessage `json:"target"`
 }
@@ -27,10 +29,11 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	s.ID = unpacker.ID
 	s.Type = unpacker.Type
 
 	switch s.Type {
-	case "directory":
+	case "directory", "file":
 		if target, err := strconv.Unquote(string(unpacker.Target)); err == nil {
 			s.Target = target
 		} else {
@@ -44,8 +47,22 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 		}
 		s.Target = payload
 
+	case "git":
+		var payload source.GitMetadata
+		if err := json.Unmarshal(unpacker.Target, &payload); err != nil {
+			return err
+		}
+		s.Target = payload
+
+	case "sif":
+		var payload source.SifMetadata
+		if err := json.Unmarshal(unpacker.Target, &payload); err != nil {
+			return err
+		}
+		s.Target = payload
+
 	default:
-		return fmt.Errorf("unsupported package metadata type: %+v", s.Type)
+		return fmt.Errorf("unsupported source type: %+v", s.Type)
 	}
 
 	return nil
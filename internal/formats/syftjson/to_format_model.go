@@ -27,11 +27,12 @@ func ToFormatModel(s sbom.SBOM) model.Document {
 	}
 
 	return model.Document{
-		Artifacts:             toPackageModels(s.Artifacts.PackageCatalog),
+		Artifacts:             toPackageModels(s.Artifacts.PackageCatalog, s.Relationships),
 		ArtifactRelationships: toRelationshipModel(s.Relationships),
 		Files:                 toFile(s),
 		Secrets:               toSecrets(s.Artifacts.Secrets),
 		Source:                src,
+		Sources:               toSourceModels(s.Sources),
 		Distro:                toDistroModel(s.Artifacts.Distro),
 		Descriptor:            toDescriptor(s.Descriptor),
 		Schema: model.Schema{
@@ -128,19 +129,42 @@ func toFileMetadataEntry(coordinates source.Coordinates, metadata *source.FileMe
 	}
 }
 
-func toPackageModels(catalog *pkg.Catalog) []model.Package {
+func toPackageModels(catalog *pkg.Catalog, relationships []artifact.Relationship) []model.Package {
 	artifacts := make([]model.Package, 0)
 	if catalog == nil {
 		return artifacts
 	}
+
+	layerIDs := make(map[artifact.ID]string)
+	for _, r := range relationships {
+		if r.Type != artifact.ContainedByLayerRelationship {
+			continue
+		}
+		layerIDs[r.From.ID()] = string(r.To.ID())
+	}
+
 	for _, p := range catalog.Sorted() {
-		artifacts = append(artifacts, toPackageModel(p))
+		artifacts = append(artifacts, toPackageModel(p, layerIDs[p.ID()]))
 	}
 	return artifacts
 }
 
+// toLocationModel creates a new Location from the given source.Location, where the path is the path the file was
+// accessed by and the real path is only included when it differs (e.g. the accessed path contains a symlink).
+func toLocationModel(l source.Location) model.Location {
+	location := model.Location{
+		Path:    l.RealPath,
+		LayerID: l.FileSystemID,
+	}
+	if l.VirtualPath != "" && l.VirtualPath != l.RealPath {
+		location.Path = l.VirtualPath
+		location.RealPath = l.RealPath
+	}
+	return location
+}
+
 // toPackageModel crates a new Package from the given pkg.Package.
-func toPackageModel(p pkg.Package) model.Package {
+func toPackageModel(p pkg.Package, layerID string) model.Package {
 	var cpes = make([]string, len(p.CPEs))
 	for i, c := range p.CPEs {
 		cpes[i] = c.BindToFmtString()
@@ -151,23 +175,26 @@ func toPackageModel(p pkg.Package) model.Package {
 		licenses = p.Licenses
 	}
 
-	var coordinates = make([]source.Coordinates, len(p.Locations))
+	var locations = make([]model.Location, len(p.Locations))
 	for i, l := range p.Locations {
-		coordinates[i] = l.Coordinates
+		locations[i] = toLocationModel(l)
 	}
 
 	return model.Package{
 		PackageBasicData: model.PackageBasicData{
-			ID:        string(p.ID()),
-			Name:      p.Name,
-			Version:   p.Version,
-			Type:      p.Type,
-			FoundBy:   p.FoundBy,
-			Locations: coordinates,
-			Licenses:  licenses,
-			Language:  p.Language,
-			CPEs:      cpes,
-			PURL:      p.PURL,
+			ID:                string(p.ID()),
+			Name:              p.Name,
+			Version:           p.Version,
+			NormalizedVersion: p.NormalizedVersion,
+			Type:              p.Type,
+			FoundBy:           p.FoundBy,
+			Locations:         locations,
+			Licenses:          licenses,
+			LicensesConcluded: p.LicensesConcluded,
+			Language:          p.Language,
+			CPEs:              cpes,
+			PURL:              p.PURL,
+			LayerID:           layerID,
 		},
 		PackageCustomData: model.PackageCustomData{
 			MetadataType: p.MetadataType,
@@ -189,6 +216,21 @@ func toRelationshipModel(relationships []artifact.Relationship) []model.Relation
 	return result
 }
 
+// toSourceModels creates source objects (identified so they may be referenced by relationships) for every source.
+func toSourceModels(sources []source.Metadata) []model.Source {
+	var results []model.Source
+	for _, src := range sources {
+		m, err := toSourceModel(src)
+		if err != nil {
+			log.Warnf("unable to create syft-json source object: %+v", err)
+			continue
+		}
+		m.ID = string(src.ID())
+		results = append(results, m)
+	}
+	return results
+}
+
 // toSourceModel creates a new source object to be represented into JSON.
 func toSourceModel(src source.Metadata) (model.Source, error) {
 	switch src.Scheme {
@@ -198,6 +240,18 @@ func toSourceModel(src source.Metadata) (model.Source, error) {
 			Target: src.ImageMetadata,
 		}, nil
 	case source.DirectoryScheme:
+		if src.GitMetadata.URL != "" {
+			return model.Source{
+				Type:   "git",
+				Target: src.GitMetadata,
+			}, nil
+		}
+		if src.SifMetadata.Path != "" {
+			return model.Source{
+				Type:   "sif",
+				Target: src.SifMetadata,
+			}, nil
+		}
 		return model.Source{
 			Type:   "directory",
 			Target: src.Path,
@@ -6,6 +6,8 @@ import (
 	"github.com/scylladb/go-set/strset"
 
 	"github.com/anchore/syft/internal/formats/syftjson/model"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/source"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,6 +36,26 @@ func Test_toSourceModel(t *testing.T) {
 				Target: "some/path",
 			},
 		},
+		{
+			name: "git",
+			src: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "https://github.com/anchore/syft",
+				GitMetadata: source.GitMetadata{
+					URL:    "https://github.com/anchore/syft",
+					Ref:    "v0.30.0",
+					Commit: "commit...",
+				},
+			},
+			expected: model.Source{
+				Type: "git",
+				Target: source.GitMetadata{
+					URL:    "https://github.com/anchore/syft",
+					Ref:    "v0.30.0",
+					Commit: "commit...",
+				},
+			},
+		},
 		{
 			name: "file",
 			src: source.Metadata{
@@ -82,3 +104,69 @@ func Test_toSourceModel(t *testing.T) {
 	// assert all possible schemes were under test
 	assert.ElementsMatch(t, allSchemes.List(), testedSchemes.List(), "not all source.Schemes are under test")
 }
+
+func Test_toPackageModels_layerID(t *testing.T) {
+	layered := pkg.Package{
+		Name:      "layered",
+		Version:   "1.0",
+		Type:      pkg.ApkPkg,
+		Locations: []source.Location{source.NewLocation("/lib/apk/db/installed")},
+	}
+	other := pkg.Package{
+		Name:    "other",
+		Version: "2.0",
+		Type:    pkg.NpmPkg,
+	}
+	layer := source.LayerMetadata{
+		MediaType: "application/vnd.docker.image.rootfs.diff.tar.gzip",
+		Digest:    "sha256:2c617fd5ac4609ec1e501f6f9a72690b43dce3318d0fb2a3a29136c2b910f4c3",
+	}
+
+	actual := toPackageModels(pkg.NewCatalog(layered, other), []artifact.Relationship{
+		{
+			From: layered,
+			To:   layer,
+			Type: artifact.ContainedByLayerRelationship,
+		},
+	})
+
+	require.Len(t, actual, 2)
+	assert.Equal(t, "layered", actual[0].Name)
+	assert.Equal(t, layer.Digest, actual[0].LayerID)
+	assert.Equal(t, "other", actual[1].Name)
+	assert.Empty(t, actual[1].LayerID)
+}
+
+func Test_toPackageModel_symlinkedLocation(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/symlinked-config")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	locations, err := resolver.FilesByPath("/app.conf", "/etc/app/config.yaml")
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+
+	actual := toPackageModel(pkg.Package{
+		Name:      "app",
+		Version:   "1.0",
+		Locations: locations,
+	}, "")
+
+	require.Len(t, actual.Locations, 2)
+
+	symlinked := actual.Locations[0]
+	assert.Equal(t, "test-fixtures/symlinked-config/app.conf", symlinked.Path)
+	assert.Equal(t, "test-fixtures/symlinked-config/etc/app/config.yaml", symlinked.RealPath)
+	assert.NotEqual(t, symlinked.Path, symlinked.RealPath)
+
+	// the real path is only included when it differs from the path
+	regular := actual.Locations[1]
+	assert.Equal(t, "test-fixtures/symlinked-config/etc/app/config.yaml", regular.Path)
+	assert.Empty(t, regular.RealPath)
+
+	// the virtual path is kept when converting back to the syft model
+	assert.Equal(t, locations[0].Coordinates, toSyftLocation(symlinked).Coordinates)
+	assert.Equal(t, locations[0].VirtualPath, toSyftLocation(symlinked).VirtualPath)
+}
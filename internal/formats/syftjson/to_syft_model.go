@@ -1,8 +1,11 @@
 package syftjson
 
 import (
+	"fmt"
+
 	"github.com/anchore/syft/internal/formats/syftjson/model"
 	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/distro"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
@@ -15,16 +18,97 @@ func toSyftModel(doc model.Document) (*sbom.SBOM, error) {
 		return nil, err
 	}
 
+	src := toSyftSourceData(doc.Source)
+	if src == nil {
+		return nil, fmt.Errorf("unsupported source type: %q", doc.Source.Type)
+	}
+
+	catalog, idMap := toSyftCatalog(doc.Artifacts)
+
 	return &sbom.SBOM{
 		Artifacts: sbom.Artifacts{
-			PackageCatalog: toSyftCatalog(doc.Artifacts),
+			PackageCatalog: catalog,
 			Distro:         &dist,
 		},
-		Source:     *toSyftSourceData(doc.Source),
-		Descriptor: toSyftDescriptor(doc.Descriptor),
+		Relationships: toSyftRelationships(doc, idMap),
+		Source:        *src,
+		Sources:       toSyftSources(doc.Sources),
+		Descriptor:    toSyftDescriptor(doc.Descriptor),
 	}, nil
 }
 
+// toSyftRelationships creates relationships between the packages (by the original package IDs within the given map),
+// files, image layers, and sources (when more than one source was cataloged) within the document. The layer that
+// introduced each package is restored from the package layer ID as well (when the relationship itself is not listed).
+// Relationships referring to any other artifacts cannot be restored and are dropped.
+func toSyftRelationships(doc model.Document, idMap map[string]artifact.Identifiable) []artifact.Relationship {
+	identifiables := make(map[string]artifact.Identifiable)
+	for id, p := range idMap {
+		identifiables[id] = p
+	}
+	for _, f := range doc.Files {
+		identifiables[f.ID] = f.Location
+	}
+	for _, s := range append([]model.Source{doc.Source}, doc.Sources...) {
+		src := toSyftSourceData(s)
+		if src == nil {
+			continue
+		}
+		if s.ID != "" {
+			identifiables[s.ID] = *src
+		}
+		for _, layer := range src.ImageMetadata.Layers {
+			identifiables[string(layer.ID())] = layer
+		}
+	}
+
+	type relationshipKey struct {
+		from, to         string
+		relationshipType string
+	}
+	observed := make(map[relationshipKey]struct{})
+
+	var relationships []artifact.Relationship
+	for _, r := range doc.ArtifactRelationships {
+		from, fromExists := identifiables[r.Parent]
+		to, toExists := identifiables[r.Child]
+		if !fromExists || !toExists {
+			log.Debugf("dropping relationship (type=%s) between unknown artifacts: parent=%s child=%s", r.Type, r.Parent, r.Child)
+			continue
+		}
+
+		observed[relationshipKey{from: r.Parent, to: r.Child, relationshipType: r.Type}] = struct{}{}
+		relationships = append(relationships, artifact.Relationship{
+			From: from,
+			To:   to,
+			Type: artifact.RelationshipType(r.Type),
+			Data: r.Metadata,
+		})
+	}
+
+	for _, p := range doc.Artifacts {
+		if p.LayerID == "" {
+			continue
+		}
+		key := relationshipKey{from: p.ID, to: p.LayerID, relationshipType: string(artifact.ContainedByLayerRelationship)}
+		if _, exists := observed[key]; exists {
+			continue
+		}
+
+		layer, exists := identifiables[p.LayerID]
+		if !exists {
+			// the layer is not listed within the image metadata, but is still identified by its digest
+			layer = source.LayerMetadata{Digest: p.LayerID}
+		}
+		relationships = append(relationships, artifact.Relationship{
+			From: idMap[p.ID],
+			To:   layer,
+			Type: artifact.ContainedByLayerRelationship,
+		})
+	}
+	return relationships
+}
+
 func toSyftDescriptor(d model.Descriptor) sbom.Descriptor {
 	return sbom.Descriptor{
 		Name:          d.Name,
@@ -33,6 +117,17 @@ func toSyftDescriptor(d model.Descriptor) sbom.Descriptor {
 	}
 }
 
+// toSyftSources creates the metadata of every source listed within the document (which are only listed when more than
+// one source was cataloged).
+func toSyftSources(sources []model.Source) (results []source.Metadata) {
+	for _, s := range sources {
+		if src := toSyftSourceData(s); src != nil {
+			results = append(results, *src)
+		}
+	}
+	return results
+}
+
 func toSyftSourceData(s model.Source) *source.Metadata {
 	switch s.Type {
 	case "directory":
@@ -50,16 +145,35 @@ func toSyftSourceData(s model.Source) *source.Metadata {
 			Scheme:        source.ImageScheme,
 			ImageMetadata: s.Target.(source.ImageMetadata),
 		}
+	case "git":
+		gitMetadata := s.Target.(source.GitMetadata)
+		return &source.Metadata{
+			Scheme:      source.DirectoryScheme,
+			Path:        gitMetadata.URL,
+			GitMetadata: gitMetadata,
+		}
+	case "sif":
+		sifMetadata := s.Target.(source.SifMetadata)
+		return &source.Metadata{
+			Scheme:      source.DirectoryScheme,
+			Path:        sifMetadata.Path,
+			SifMetadata: sifMetadata,
+		}
 	}
 	return nil
 }
 
-func toSyftCatalog(pkgs []model.Package) *pkg.Catalog {
+// toSyftCatalog creates a catalog from the given packages, additionally returning each package keyed by the package ID
+// within the document (so relationships may be restored).
+func toSyftCatalog(pkgs []model.Package) (*pkg.Catalog, map[string]artifact.Identifiable) {
 	catalog := pkg.NewCatalog()
+	idMap := make(map[string]artifact.Identifiable)
 	for _, p := range pkgs {
-		catalog.Add(toSyftPackage(p))
+		syftPkg := toSyftPackage(p)
+		idMap[p.ID] = syftPkg
+		catalog.Add(syftPkg)
 	}
-	return catalog
+	return catalog, idMap
 }
 
 func toSyftPackage(p model.Package) pkg.Package {
@@ -75,21 +189,41 @@ func toSyftPackage(p model.Package) pkg.Package {
 	}
 
 	var locations = make([]source.Location, len(p.Locations))
-	for i, c := range p.Locations {
-		locations[i] = source.NewLocationFromCoordinates(c)
+	for i, l := range p.Locations {
+		locations[i] = toSyftLocation(l)
 	}
 
 	return pkg.Package{
-		Name:         p.Name,
-		Version:      p.Version,
-		FoundBy:      p.FoundBy,
-		Locations:    locations,
-		Licenses:     p.Licenses,
-		Language:     p.Language,
-		Type:         p.Type,
-		CPEs:         cpes,
-		PURL:         p.PURL,
-		MetadataType: p.MetadataType,
-		Metadata:     p.Metadata,
+		Name:              p.Name,
+		Version:           p.Version,
+		NormalizedVersion: p.NormalizedVersion,
+		FoundBy:           p.FoundBy,
+		Locations:         locations,
+		Licenses:          p.Licenses,
+		LicensesConcluded: p.LicensesConcluded,
+		Language:          p.Language,
+		Type:              p.Type,
+		CPEs:              cpes,
+		PURL:              p.PURL,
+		MetadataType:      p.MetadataType,
+		Metadata:          p.Metadata,
+	}
+}
+
+// toSyftLocation creates a new source.Location from the given Location, where the path is kept as the virtual path
+// when a different real path was recorded.
+func toSyftLocation(l model.Location) source.Location {
+	if l.RealPath == "" {
+		return source.NewLocationFromCoordinates(source.Coordinates{
+			RealPath:     l.Path,
+			FileSystemID: l.LayerID,
+		})
+	}
+	return source.Location{
+		Coordinates: source.Coordinates{
+			RealPath:     l.RealPath,
+			FileSystemID: l.LayerID,
+		},
+		VirtualPath: l.Path,
 	}
 }
@@ -4,9 +4,11 @@ import (
 	"testing"
 
 	"github.com/anchore/syft/internal/formats/syftjson/model"
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/source"
 	"github.com/scylladb/go-set/strset"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_toSyftSourceData(t *testing.T) {
@@ -32,6 +34,26 @@ func Test_toSyftSourceData(t *testing.T) {
 				Target: "some/path",
 			},
 		},
+		{
+			name: "git",
+			expected: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "https://github.com/anchore/syft",
+				GitMetadata: source.GitMetadata{
+					URL:    "https://github.com/anchore/syft",
+					Ref:    "v0.30.0",
+					Commit: "commit...",
+				},
+			},
+			src: model.Source{
+				Type: "git",
+				Target: source.GitMetadata{
+					URL:    "https://github.com/anchore/syft",
+					Ref:    "v0.30.0",
+					Commit: "commit...",
+				},
+			},
+		},
 		{
 			name: "file",
 			expected: source.Metadata{
@@ -79,3 +101,38 @@ func Test_toSyftSourceData(t *testing.T) {
 	// assert all possible schemes were under test
 	assert.ElementsMatch(t, allSchemes.List(), testedSchemes.List(), "not all source.Schemes are under test")
 }
+
+func Test_toSyftModel_unsupportedSource(t *testing.T) {
+	_, err := toSyftModel(model.Document{
+		Source: model.Source{Type: "something-else"},
+	})
+	assert.Error(t, err)
+}
+
+func Test_toSyftRelationships_layerID(t *testing.T) {
+	layer := source.LayerMetadata{Digest: "sha256:abc123", Size: 22}
+	doc := model.Document{
+		Source: model.Source{
+			Type:   "image",
+			Target: source.ImageMetadata{Layers: []source.LayerMetadata{layer}},
+		},
+		Artifacts: []model.Package{
+			{PackageBasicData: model.PackageBasicData{ID: "listed-layer", Name: "musl", LayerID: layer.Digest}},
+			{PackageBasicData: model.PackageBasicData{ID: "unlisted-layer", Name: "busybox", LayerID: "sha256:def456"}},
+			{PackageBasicData: model.PackageBasicData{ID: "no-layer", Name: "zlib"}},
+		},
+	}
+	catalog, idMap := toSyftCatalog(doc.Artifacts)
+	require.Equal(t, 3, catalog.PackageCount())
+
+	// the layer that introduced each package is restored from the package layer ID (without any listed relationships)
+	relationships := toSyftRelationships(doc, idMap)
+	require.Len(t, relationships, 2)
+	for _, r := range relationships {
+		assert.Equal(t, artifact.ContainedByLayerRelationship, r.Type)
+	}
+	assert.Equal(t, idMap["listed-layer"], relationships[0].From)
+	assert.Equal(t, layer, relationships[0].To)
+	assert.Equal(t, idMap["unlisted-layer"], relationships[1].From)
+	assert.Equal(t, source.LayerMetadata{Digest: "sha256:def456"}, relationships[1].To)
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
+	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/formats/syftjson/model"
 )
 
@@ -22,10 +24,29 @@ func validator(reader io.Reader) error {
 		return fmt.Errorf("unable to decode: %w", err)
 	}
 
-	// note: we accept all schema versions
+	if !strings.Contains(doc.Schema.URL, "anchore/syft") {
+		return fmt.Errorf("could not extract syft schema")
+	}
+
+	// note: we accept all schema versions up to the current one, however, a newer major version indicates a breaking
+	// change to the document shape that this version of syft does not know how to read.
 	// TODO: add per-schema version parsing
-	if strings.Contains(doc.Schema.URL, "anchore/syft") {
-		return nil
+	if major, ok := schemaMajorVersion(doc.Schema.Version); ok && major > currentSchemaMajorVersion() {
+		return fmt.Errorf("unsupported syft schema version %q (supports up to %q)", doc.Schema.Version, internal.JSONSchemaVersion)
+	}
+	return nil
+}
+
+func currentSchemaMajorVersion() int {
+	major, _ := schemaMajorVersion(internal.JSONSchemaVersion)
+	return major
+}
+
+// schemaMajorVersion returns the MODEL (major) portion of the given MODEL.REVISION.ADDITION schema version.
+func schemaMajorVersion(version string) (int, bool) {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, false
 	}
-	return fmt.Errorf("could not extract syft schema")
+	return major, true
 }
@@ -0,0 +1,88 @@
+package syftjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/syftjson/model"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodedDocumentSchema(t *testing.T) {
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "some/path",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Format().Presenter(s).Present(&buf))
+
+	var doc model.Document
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, internal.JSONSchemaVersion, doc.Schema.Version)
+	assert.Equal(t, fmt.Sprintf("https://raw.githubusercontent.com/anchore/syft/main/schema/json/schema-%s.json", internal.JSONSchemaVersion), doc.Schema.URL)
+
+	// every schema version bump must be accompanied by the generated schema document that the URL refers to
+	_, err := os.Stat(filepath.Join("..", "..", "..", "schema", "json", fmt.Sprintf("schema-%s.json", internal.JSONSchemaVersion)))
+	assert.NoError(t, err, "missing JSON schema document for version %q (see schema/json/README.md)", internal.JSONSchemaVersion)
+
+	assert.NoError(t, validator(&buf))
+}
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "current schema version",
+			input: fmt.Sprintf(`{"schema":{"version":%q,"url":"https://raw.githubusercontent.com/anchore/syft/main/schema/json/schema-%s.json"}}`, internal.JSONSchemaVersion, internal.JSONSchemaVersion),
+		},
+		{
+			name:  "older schema version",
+			input: `{"schema":{"version":"1.0.0","url":"https://raw.githubusercontent.com/anchore/syft/main/schema/json/schema-1.0.0.json"}}`,
+		},
+		{
+			name:    "newer major schema version",
+			input:   fmt.Sprintf(`{"schema":{"version":"%d.0.0","url":"https://raw.githubusercontent.com/anchore/syft/main/schema/json/schema-next.json"}}`, currentSchemaMajorVersion()+1),
+			wantErr: true,
+		},
+		{
+			name:    "not a syft document",
+			input:   `{"schema":{"version":"1.0.0","url":"https://example.com/schema.json"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			input:   `not json`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validator(strings.NewReader(test.input))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// column is an optional column of the table, in addition to the name, version, and type of each package.
+type column struct {
+	name   string
+	header string
+	value  func(p pkg.Package) string
+}
+
+// AllColumns are the names of all optional columns that can be added to the table.
+var AllColumns = []string{"purl", "cpe"}
+
+var availableColumns = map[string]column{
+	"purl": {
+		name:   "purl",
+		header: "PURL",
+		value: func(p pkg.Package) string {
+			return p.PURL
+		},
+	},
+	"cpe": {
+		name:   "cpe",
+		header: "CPE",
+		value: func(p pkg.Package) string {
+			// generated CPEs are ordered from most to least specific, so show the best candidate only
+			if len(p.CPEs) == 0 {
+				return ""
+			}
+			return p.CPEs[0].BindToFmtString()
+		},
+	},
+}
+
+// ellipsis marks values that were truncated to fit the column width.
+const ellipsis = "..."
+
+// Options configure the table output. The zero value results in the default table.
+type Options struct {
+	// Columns are the optional columns to show after the name, version, and type of each package (see AllColumns).
+	// Names are case-insensitive and may be repeated, where only the first occurrence is kept.
+	Columns []string
+	// ColumnWidth limits the width of the values within optional columns, which may get very long (e.g. purls with
+	// qualifiers). Longer values are truncated, or split over several lines when Wrap is enabled. A width of 0 removes
+	// the limit.
+	ColumnWidth int
+	// Wrap splits values wider than the ColumnWidth over several lines (instead of truncating them).
+	Wrap bool
+}
+
+// Validate checks that all columns are known and that the column width is not negative.
+func (o Options) Validate() error {
+	if o.ColumnWidth < 0 {
+		return fmt.Errorf("bad table column width %d: must not be negative", o.ColumnWidth)
+	}
+	return ValidateColumns(o.Columns)
+}
+
+// extraColumns returns the optional columns shown after the type of each package, in the order they were given.
+func (o Options) extraColumns() ([]column, error) {
+	if err := ValidateColumns(o.Columns); err != nil {
+		return nil, err
+	}
+
+	var columns []column
+	seen := make(map[string]bool)
+	for _, name := range o.Columns {
+		name = normalizeColumnName(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		columns = append(columns, availableColumns[name])
+	}
+	return columns, nil
+}
+
+// ValidateColumns checks that all the given names are optional columns (see AllColumns).
+func ValidateColumns(names []string) error {
+	for _, name := range names {
+		if _, ok := availableColumns[normalizeColumnName(name)]; !ok {
+			return fmt.Errorf("unknown table column %q (options: %s)", name, strings.Join(AllColumns, ", "))
+		}
+	}
+	return nil
+}
+
+func normalizeColumnName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// fitColumnWidth shortens the given value to the configured column width.
+func (o Options) fitColumnWidth(value string) string {
+	maxColumnWidth := o.ColumnWidth
+	runes := []rune(value)
+	if maxColumnWidth <= 0 || len(runes) <= maxColumnWidth {
+		return value
+	}
+
+	if !o.Wrap {
+		// note: an ASCII ellipsis is used since the width of "…" is ambiguous (and would misalign the table)
+		if maxColumnWidth <= len(ellipsis) {
+			return string(runes[:maxColumnWidth])
+		}
+		return string(runes[:maxColumnWidth-len(ellipsis)]) + ellipsis
+	}
+
+	var lines []string
+	for len(runes) > maxColumnWidth {
+		lines = append(lines, string(runes[:maxColumnWidth]))
+		runes = runes[maxColumnWidth:]
+	}
+	lines = append(lines, string(runes))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_extraColumns(t *testing.T) {
+	columns, err := Options{Columns: []string{"CPE", " purl", "cpe"}}.extraColumns()
+	require.NoError(t, err)
+	var headers []string
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+	assert.Equal(t, []string{"CPE", "PURL"}, headers)
+
+	_, err = Options{Columns: []string{"purl", "bogus"}}.extraColumns()
+	assert.Error(t, err)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, Options{}.Validate())
+	assert.NoError(t, Options{Columns: AllColumns, ColumnWidth: 20, Wrap: true}.Validate())
+	assert.Error(t, Options{Columns: []string{"bogus"}}.Validate())
+	assert.Error(t, Options{ColumnWidth: -1}.Validate())
+}
+
+func TestOptions_fitColumnWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		wrap     bool
+		value    string
+		expected string
+	}{
+		{
+			name:     "no limit",
+			value:    "pkg:pypi/requests@2.26.0",
+			expected: "pkg:pypi/requests@2.26.0",
+		},
+		{
+			name:     "fits",
+			width:    24,
+			value:    "pkg:pypi/requests@2.26.0",
+			expected: "pkg:pypi/requests@2.26.0",
+		},
+		{
+			name:     "truncate",
+			width:    20,
+			value:    "pkg:pypi/requests@2.26.0",
+			expected: "pkg:pypi/requests...",
+		},
+		{
+			name:     "truncate below the ellipsis width",
+			width:    2,
+			value:    "pkg:pypi/requests@2.26.0",
+			expected: "pk",
+		},
+		{
+			name:     "wrap",
+			width:    10,
+			wrap:     true,
+			value:    "pkg:pypi/requests@2.26.0",
+			expected: "pkg:pypi/r\nequests@2.\n26.0",
+		},
+		{
+			name:     "wrap at an exact multiple of the width",
+			width:    4,
+			wrap:     true,
+			value:    "pkg:pypi",
+			expected: "pkg:\npypi",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := Options{ColumnWidth: test.width, Wrap: test.wrap}
+			assert.Equal(t, test.expected, opts.fitColumnWidth(test.value))
+		})
+	}
+}
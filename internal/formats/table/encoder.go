@@ -6,21 +6,41 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/anchore/syft/syft/format"
 	"github.com/anchore/syft/syft/sbom"
 
 	"github.com/olekukonko/tablewriter"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
+// newEncoder creates an encoder that writes a table of all packages with the given options.
+func newEncoder(opts Options) format.Encoder {
+	return func(output io.Writer, s sbom.SBOM) error {
+		return encode(output, s, opts)
+	}
+}
+
+func encode(output io.Writer, s sbom.SBOM, opts Options) error {
+	extraColumns, err := opts.extraColumns()
+	if err != nil {
+		return err
+	}
+
 	var rows [][]string
 
 	columns := []string{"Name", "Version", "Type"}
+	for _, c := range extraColumns {
+		columns = append(columns, c.header)
+	}
+
 	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
 		row := []string{
 			p.Name,
 			p.Version,
 			string(p.Type),
 		}
+		for _, c := range extraColumns {
+			row = append(row, opts.fitColumnWidth(c.value(p)))
+		}
 		rows = append(rows, row)
 	}
 
@@ -58,7 +78,40 @@ func encoder(output io.Writer, s sbom.SBOM) error {
 	table.AppendBulk(rows)
 	table.Render()
 
-	return nil
+	_, err = fmt.Fprintf(output, "\n%s\n", summarize(rows))
+	return err
+}
+
+// summarize describes the total number of packages shown in the table, including a count for each package type when
+// more than one type is present (e.g. "42 packages (deb: 30, python: 12)").
+func summarize(rows [][]string) string {
+	counts := make(map[string]int)
+	for _, row := range rows {
+		counts[row[2]]++
+	}
+
+	noun := "packages"
+	if len(rows) == 1 {
+		noun = "package"
+	}
+	summary := fmt.Sprintf("%d %s", len(rows), noun)
+
+	if len(counts) < 2 {
+		return summary
+	}
+
+	var types []string
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var breakdown []string
+	for _, t := range types {
+		breakdown = append(breakdown, fmt.Sprintf("%s: %d", t, counts[t]))
+	}
+
+	return fmt.Sprintf("%s (%s)", summary, strings.Join(breakdown, ", "))
 }
 
 func removeDuplicateRows(items [][]string) [][]string {
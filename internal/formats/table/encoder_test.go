@@ -6,6 +6,7 @@ import (
 
 	"github.com/anchore/syft/internal/formats/common/testutils"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 )
 
 var updateTableGoldenFiles = flag.Bool("update-table", false, "update the *.golden files for table format")
@@ -17,6 +18,13 @@ func TestTablePresenter(t *testing.T) {
 	)
 }
 
+func TestTablePresenter_columns(t *testing.T) {
+	testutils.AssertPresenterAgainstGoldenSnapshot(t,
+		FormatWithOptions(Options{Columns: []string{"purl", "cpe"}}).Presenter(testutils.DirectoryInput(t)),
+		*updateTableGoldenFiles,
+	)
+}
+
 func TestRemoveDuplicateRows(t *testing.T) {
 	data := [][]string{
 		{"1", "2", "3"},
@@ -45,3 +53,45 @@ func TestRemoveDuplicateRows(t *testing.T) {
 	}
 
 }
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]string
+		expected string
+	}{
+		{
+			name: "single package",
+			rows: [][]string{
+				{"package-1", "1.0.1", "python"},
+			},
+			expected: "1 package",
+		},
+		{
+			name: "single package type",
+			rows: [][]string{
+				{"package-1", "1.0.1", "python"},
+				{"package-2", "2.0.1", "python"},
+			},
+			expected: "2 packages",
+		},
+		{
+			name: "mixed package types",
+			rows: [][]string{
+				{"package-1", "1.0.1", "python"},
+				{"package-2", "2.0.1", "deb"},
+				{"package-3", "3.0.1", "python"},
+				{"package-4", "4.0.1", "java-archive"},
+				{"package-5", "5.0.1", "deb"},
+				{"package-6", "6.0.1", "python"},
+			},
+			expected: "6 packages (deb: 2, java-archive: 1, python: 3)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, summarize(test.rows))
+		})
+	}
+}
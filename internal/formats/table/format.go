@@ -3,9 +3,14 @@ package table
 import "github.com/anchore/syft/syft/format"
 
 func Format() format.Format {
+	return FormatWithOptions(Options{})
+}
+
+// FormatWithOptions creates the table format, where the table is written with the given options.
+func FormatWithOptions(opts Options) format.Format {
 	return format.NewFormat(
 		format.TableOption,
-		encoder,
+		newEncoder(opts),
 		nil,
 		nil,
 	)
@@ -0,0 +1,166 @@
+package tern
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateTernGoldenFiles = flag.Bool("update-tern", false, "update the *.golden files for tern format")
+
+func TestTernDirectoryPresenter(t *testing.T) {
+	testutils.AssertPresenterAgainstGoldenSnapshot(t,
+		Format().Presenter(testutils.DirectoryInput(t)),
+		*updateTernGoldenFiles,
+	)
+}
+
+func TestTernImagePresenter(t *testing.T) {
+	testImage := "image-simple"
+	testutils.AssertPresenterAgainstGoldenImageSnapshot(t,
+		Format().Presenter(testutils.ImageInput(t, testImage, testutils.FromSnapshot())),
+		testImage,
+		*updateTernGoldenFiles,
+	)
+}
+
+// TestEncoder_ternReportStructure asserts that every field of a sample Tern report is present within the encoded
+// document (as null when unknown), with the same JSON type whenever both values are known.
+func TestEncoder_ternReportStructure(t *testing.T) {
+	sample, err := ioutil.ReadFile("test-fixtures/tern-report.json")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, encoder(&buf, testutils.ImageInput(t, "image-simple", testutils.FromSnapshot())))
+
+	var expected, actual map[string]interface{}
+	require.NoError(t, json.Unmarshal(sample, &expected))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+
+	expectedImage := firstObject(t, expected["images"])["image"].(map[string]interface{})
+	actualImage := firstObject(t, actual["images"])["image"].(map[string]interface{})
+	assertSameFields(t, "image", expectedImage, actualImage)
+
+	expectedLayer := firstObject(t, expectedImage["layers"])
+	actualLayer := firstObject(t, actualImage["layers"])
+	assertSameFields(t, "layer", expectedLayer, actualLayer)
+
+	assertSameFields(t, "package", firstObject(t, expectedLayer["packages"]), firstObject(t, actualLayer["packages"]))
+	assertSameFields(t, "origin", firstObject(t, expectedLayer["origins"]), firstObject(t, firstObject(t, actualLayer["packages"])["origins"]))
+}
+
+func firstObject(t *testing.T, value interface{}) map[string]interface{} {
+	t.Helper()
+	list, ok := value.([]interface{})
+	require.True(t, ok, "expected a list, got: %#v", value)
+	require.NotEmpty(t, list)
+	object, ok := list[0].(map[string]interface{})
+	require.True(t, ok, "expected an object, got: %#v", list[0])
+	return object
+}
+
+func assertSameFields(t *testing.T, kind string, expected, actual map[string]interface{}) {
+	t.Helper()
+	for field, expectedValue := range expected {
+		actualValue, exists := actual[field]
+		if !assert.True(t, exists, "missing %s field: %q", kind, field) {
+			continue
+		}
+		if expectedValue != nil && actualValue != nil {
+			assert.IsType(t, expectedValue, actualValue, "unexpected type of %s field: %q", kind, field)
+		}
+	}
+}
+
+func TestToFormatModel_layerAttribution(t *testing.T) {
+	base := source.Location{Coordinates: source.Coordinates{RealPath: "/lib/apk/db/installed", FileSystemID: "sha256:base"}}
+	upper := source.Location{Coordinates: source.Coordinates{RealPath: "/lib/apk/db/installed", FileSystemID: "sha256:upper"}}
+	unknown := source.Location{Coordinates: source.Coordinates{RealPath: "/somewhere", FileSystemID: "sha256:unknown"}}
+
+	catalog := pkg.NewCatalog(
+		pkg.Package{Name: "musl", Version: "1.2.2-r7", Type: pkg.ApkPkg, Locations: []source.Location{upper, base}},
+		pkg.Package{Name: "curl", Version: "7.80.0-r0", Type: pkg.ApkPkg, Locations: []source.Location{upper}},
+		pkg.Package{Name: "mystery", Version: "1.0", Locations: []source.Location{unknown}},
+	)
+
+	doc := toFormatModel(sbom.SBOM{
+		Artifacts: sbom.Artifacts{PackageCatalog: catalog},
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				Tags:   []string{"example.com:5000/app:1.0"},
+				Layers: []source.LayerMetadata{{Digest: "sha256:base"}, {Digest: "sha256:upper"}},
+				RawConfig: []byte(`{"history": [
+					{"created_by": "/bin/sh -c #(nop) ADD file:1234 in / "},
+					{"created_by": "/bin/sh -c #(nop)  CMD [\"/bin/sh\"]", "empty_layer": true},
+					{"created_by": "/bin/sh -c apk add curl"}
+				]}`),
+			},
+		},
+	})
+
+	require.Len(t, doc.Images, 1)
+	image := doc.Images[0].Image
+	assert.Equal(t, "example.com:5000/app", *image.Name)
+	assert.Equal(t, "1.0", *image.Tag)
+	require.Len(t, image.Layers, 2)
+
+	layerPackages := func(l Layer) (names []string) {
+		for _, p := range l.Packages {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	// packages are attributed to the lowest layer they were found within, otherwise to the last layer
+	assert.Equal(t, 1, image.Layers[0].LayerIndex)
+	assert.Equal(t, "sha256:base", *image.Layers[0].DiffID)
+	assert.Equal(t, "/bin/sh -c #(nop) ADD file:1234 in / ", *image.Layers[0].CreatedBy)
+	assert.Equal(t, []string{"musl"}, layerPackages(image.Layers[0]))
+
+	assert.Equal(t, 2, image.Layers[1].LayerIndex)
+	assert.Equal(t, "/bin/sh -c apk add curl", *image.Layers[1].CreatedBy)
+	assert.Equal(t, []string{"mystery", "curl"}, layerPackages(image.Layers[1]))
+}
+
+func TestToFormatModel_directory(t *testing.T) {
+	doc := toFormatModel(testutils.DirectoryInput(t))
+
+	require.Len(t, doc.Images, 1)
+	image := doc.Images[0].Image
+	require.Len(t, image.Layers, 1)
+	assert.Nil(t, image.Layers[0].DiffID)
+	assert.Len(t, image.Layers[0].Packages, 2)
+}
+
+func TestSplitRepoTag(t *testing.T) {
+	tests := []struct {
+		reference string
+		name      string
+		tag       string
+	}{
+		{reference: "alpine", name: "alpine"},
+		{reference: "alpine:3.15", name: "alpine", tag: "3.15"},
+		{reference: "docker.io/library/alpine:3.15", name: "docker.io/library/alpine", tag: "3.15"},
+		{reference: "localhost:5000/alpine", name: "localhost:5000/alpine"},
+		{reference: "localhost:5000/alpine:edge", name: "localhost:5000/alpine", tag: "edge"},
+		{reference: "alpine@sha256:e7d88de73db3d3fd9b2d63aa7f447a10fd0220b7cbf39803c803f2af9ba256b3", name: "alpine"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			name, tag := splitRepoTag(test.reference)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.tag, tag)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package tern
+
+import "github.com/anchore/syft/syft/format"
+
+func Format() format.Format {
+	return format.NewFormat(
+		format.TernJSONOption,
+		encoder,
+		nil,
+		nil,
+	)
+}
@@ -0,0 +1,66 @@
+package tern
+
+// The shape of the JSON report of Tern (see https://github.com/tern-tools/tern), as written by "tern report -f json".
+// Fields that syft does not know (e.g. the copyright of each package, or the command that created each layer) are
+// always present as null, since tools that read Tern reports may expect every field.
+
+// Document describes the packages installed by each layer of the analyzed images.
+type Document struct {
+	Images []ImageEntry `json:"images"`
+}
+
+// ImageEntry wraps each analyzed image.
+type ImageEntry struct {
+	Image Image `json:"image"`
+}
+
+// Image is an analyzed image (or any other source, which is described as an image with a single layer).
+type Image struct {
+	ImageID  *string  `json:"image_id"`
+	Name     *string  `json:"name"`
+	Tag      *string  `json:"tag"`
+	RepoTags []string `json:"repotags"`
+	Layers   []Layer  `json:"layers"`
+	Origins  []Origin `json:"origins"`
+}
+
+// Layer is a single filesystem layer of an image, with the packages that were installed by the layer.
+type Layer struct {
+	DiffID        *string   `json:"diff_id"`
+	TarFile       *string   `json:"tar_file"`
+	CreatedBy     *string   `json:"created_by"`
+	LayerIndex    int       `json:"layer_index"` // starts at 1 (the base layer)
+	PkgFormat     *string   `json:"pkg_format"`
+	OSGuess       *string   `json:"os_guess"`
+	FilesAnalyzed bool      `json:"files_analyzed"`
+	Packages      []Package `json:"packages"` // empty (rather than null) for layers without packages
+	Origins       []Origin  `json:"origins"`
+}
+
+// Package is a single package installed by a layer.
+type Package struct {
+	Name        string   `json:"name"`
+	Version     *string  `json:"version"`
+	PkgLicense  *string  `json:"pkg_license"`
+	PkgLicenses []string `json:"pkg_licenses"`
+	Copyright   *string  `json:"copyright"`
+	ProjURL     *string  `json:"proj_url"`
+	DownloadURL *string  `json:"download_url"`
+	Checksum    *string  `json:"checksum"`
+	PkgFormat   *string  `json:"pkg_format"`
+	SrcName     *string  `json:"src_name"`
+	SrcVersion  *string  `json:"src_version"`
+	Origins     []Origin `json:"origins"`
+}
+
+// Origin records where the information about an item came from, as a list of notices.
+type Origin struct {
+	OriginStr string   `json:"origin_str"`
+	Notices   []Notice `json:"notices"`
+}
+
+// Notice is a single message about an item (with a level of "info", "warning", "error", or "hint").
+type Notice struct {
+	Message string `json:"message"`
+	Level   string `json:"level"`
+}
@@ -0,0 +1,217 @@
+package tern
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// toFormatModel describes the source of the given SBOM as a single image, where each package is attributed to the
+// layer that it was found within. Sources without layers (e.g. directories) are described with a single layer.
+func toFormatModel(s sbom.SBOM) Document {
+	image := toImage(s.Source)
+
+	// packages are attributed to the lowest layer that they were found within (where the package was installed)
+	layerIndexes := make(map[string]int)
+	for i, l := range image.Layers {
+		if l.DiffID != nil {
+			layerIndexes[*l.DiffID] = i
+		}
+	}
+
+	if s.Artifacts.PackageCatalog != nil {
+		for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+			idx := packageLayerIndex(p, layerIndexes, len(image.Layers))
+			image.Layers[idx].Packages = append(image.Layers[idx].Packages, toPackage(p))
+		}
+	}
+
+	return Document{
+		Images: []ImageEntry{
+			{Image: image},
+		},
+	}
+}
+
+// packageLayerIndex returns the index of the lowest layer that the given package was found within. Packages found
+// outside of any known layer are attributed to the last layer (the layer that completes the squashed filesystem).
+func packageLayerIndex(p pkg.Package, layerIndexes map[string]int, layers int) int {
+	result := -1
+	for _, l := range p.Locations {
+		if idx, ok := layerIndexes[l.FileSystemID]; ok && (result < 0 || idx < result) {
+			result = idx
+		}
+	}
+	if result < 0 {
+		return layers - 1
+	}
+	return result
+}
+
+func toImage(srcMetadata source.Metadata) Image {
+	switch srcMetadata.Scheme {
+	case source.ImageScheme:
+		metadata := srcMetadata.ImageMetadata
+
+		name, tag := splitRepoTag(metadata.UserInput)
+		if len(metadata.Tags) > 0 {
+			name, tag = splitRepoTag(metadata.Tags[0])
+		}
+
+		createdBy := layerHistory(metadata.RawConfig)
+		var layers []Layer
+		for i, l := range metadata.Layers {
+			layer := Layer{
+				DiffID:     nullIfEmpty(l.Digest),
+				LayerIndex: i + 1,
+				Packages:   []Package{},
+			}
+			if i < len(createdBy) {
+				layer.CreatedBy = nullIfEmpty(createdBy[i])
+			}
+			layers = append(layers, layer)
+		}
+		if len(layers) == 0 {
+			layers = []Layer{{LayerIndex: 1, Packages: []Package{}}}
+		}
+
+		return Image{
+			ImageID:  nullIfEmpty(metadata.ID),
+			Name:     nullIfEmpty(name),
+			Tag:      nullIfEmpty(tag),
+			RepoTags: metadata.Tags,
+			Layers:   layers,
+		}
+	default:
+		return Image{
+			Name:   nullIfEmpty(srcMetadata.Path),
+			Layers: []Layer{{LayerIndex: 1, Packages: []Package{}}},
+		}
+	}
+}
+
+// splitRepoTag splits the given image reference (e.g. "docker.io/library/alpine:3.15") into the repository and the
+// tag, where the tag is empty when not given (e.g. "localhost:5000/alpine").
+func splitRepoTag(reference string) (string, string) {
+	// a digest is not a tag
+	if idx := strings.Index(reference, "@"); idx >= 0 {
+		reference = reference[:idx]
+	}
+
+	idx := strings.LastIndex(reference, ":")
+	if idx < 0 || strings.Contains(reference[idx+1:], "/") {
+		return reference, ""
+	}
+	return reference[:idx], reference[idx+1:]
+}
+
+// layerHistory returns the command that created each layer, as recorded by the history within the given image
+// config (in the same order as the layers, where history entries that did not create a layer are skipped).
+func layerHistory(rawConfig []byte) []string {
+	if len(rawConfig) == 0 {
+		return nil
+	}
+
+	var config struct {
+		History []struct {
+			CreatedBy  string `json:"created_by"`
+			EmptyLayer bool   `json:"empty_layer"`
+		} `json:"history"`
+	}
+	if err := json.Unmarshal(rawConfig, &config); err != nil {
+		log.Warnf("unable to read the layer history from the image config: %+v", err)
+		return nil
+	}
+
+	var results []string
+	for _, h := range config.History {
+		if !h.EmptyLayer {
+			results = append(results, h.CreatedBy)
+		}
+	}
+	return results
+}
+
+func toPackage(p pkg.Package) Package {
+	var license *string
+	if len(p.Licenses) > 0 {
+		// all licenses are assumed to apply (as with the SPDX formats)
+		joined := strings.Join(p.Licenses, " AND ")
+		license = &joined
+	}
+
+	srcName, srcVersion := sourcePackage(p)
+
+	return Package{
+		Name:        p.Name,
+		Version:     nullIfEmpty(p.Version),
+		PkgLicense:  license,
+		PkgLicenses: p.Licenses,
+		ProjURL:     nullIfEmpty(spdxhelpers.Homepage(p)),
+		DownloadURL: nullIfEmpty(downloadURL(p)),
+		PkgFormat:   nullIfEmpty(string(p.Type)),
+		SrcName:     nullIfEmpty(srcName),
+		SrcVersion:  nullIfEmpty(srcVersion),
+		Origins:     toOrigins(p),
+	}
+}
+
+// downloadURL returns the location that the given package can be downloaded from, if known.
+func downloadURL(p pkg.Package) string {
+	switch location := spdxhelpers.DownloadLocation(p); location {
+	case "NONE", "NOASSERTION":
+		return ""
+	default:
+		return location
+	}
+}
+
+// sourcePackage returns the name and version of the source package that the given package was built from, when
+// recorded by the package metadata.
+func sourcePackage(p pkg.Package) (string, string) {
+	switch metadata := p.Metadata.(type) {
+	case pkg.DpkgMetadata:
+		if metadata.Source == "" {
+			return "", ""
+		}
+		version := metadata.SourceVersion
+		if version == "" {
+			version = p.Version
+		}
+		return metadata.Source, version
+	case pkg.ApkMetadata:
+		return metadata.OriginPackage, ""
+	}
+	return "", ""
+}
+
+// toOrigins describes how the given package was found, as a notice for each location (from the cataloger that found
+// the package).
+func toOrigins(p pkg.Package) []Origin {
+	if p.FoundBy == "" && len(p.Locations) == 0 {
+		return nil
+	}
+
+	origin := Origin{OriginStr: p.FoundBy}
+	for _, l := range p.Locations {
+		message := fmt.Sprintf("found at %s", l.RealPath)
+		if l.FileSystemID != "" {
+			message = fmt.Sprintf("%s in layer %s", message, l.FileSystemID)
+		}
+		origin.Notices = append(origin.Notices, Notice{Message: message, Level: "info"})
+	}
+	return []Origin{origin}
+}
+
+func nullIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
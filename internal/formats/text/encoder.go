@@ -15,22 +15,8 @@ func encoder(output io.Writer, s sbom.SBOM) error {
 	w := new(tabwriter.Writer)
 	w.Init(output, 0, 8, 0, '\t', tabwriter.AlignRight)
 
-	switch s.Source.Scheme {
-	case source.DirectoryScheme, source.FileScheme:
-		fmt.Fprintf(w, "[Path: %s]\n", s.Source.Path)
-	case source.ImageScheme:
-		fmt.Fprintln(w, "[Image]")
-
-		for idx, l := range s.Source.ImageMetadata.Layers {
-			fmt.Fprintln(w, " Layer:\t", idx)
-			fmt.Fprintln(w, " Digest:\t", l.Digest)
-			fmt.Fprintln(w, " Size:\t", l.Size)
-			fmt.Fprintln(w, " MediaType:\t", l.MediaType)
-			fmt.Fprintln(w)
-			w.Flush()
-		}
-	default:
-		return fmt.Errorf("unsupported source: %T", s.Source.Scheme)
+	if err := writeSource(w, s.Source); err != nil {
+		return err
 	}
 
 	// populate artifacts...
@@ -52,3 +38,25 @@ func encoder(output io.Writer, s sbom.SBOM) error {
 
 	return nil
 }
+
+// writeSource writes a description of the cataloged source (and all image layers, if applicable).
+func writeSource(w *tabwriter.Writer, srcMetadata source.Metadata) error {
+	switch srcMetadata.Scheme {
+	case source.DirectoryScheme, source.FileScheme:
+		fmt.Fprintf(w, "[Path: %s]\n", srcMetadata.Path)
+	case source.ImageScheme:
+		fmt.Fprintln(w, "[Image]")
+
+		for idx, l := range srcMetadata.ImageMetadata.Layers {
+			fmt.Fprintln(w, " Layer:\t", idx)
+			fmt.Fprintln(w, " Digest:\t", l.Digest)
+			fmt.Fprintln(w, " Size:\t", l.Size)
+			fmt.Fprintln(w, " MediaType:\t", l.MediaType)
+			fmt.Fprintln(w)
+			w.Flush()
+		}
+	default:
+		return fmt.Errorf("unsupported source: %T", srcMetadata.Scheme)
+	}
+	return nil
+}
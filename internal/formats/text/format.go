@@ -10,3 +10,13 @@ func Format() format.Format {
 		nil,
 	)
 }
+
+// GroupedFormat is a variant of the text format where packages are grouped by package type.
+func GroupedFormat() format.Format {
+	return format.NewFormat(
+		format.TextGroupedOption,
+		groupedEncoder,
+		nil,
+		nil,
+	)
+}
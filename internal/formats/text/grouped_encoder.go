@@ -0,0 +1,64 @@
+package text
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// groupedEncoder writes a header for each package type (ecosystem) followed by all packages of that type, sorted by
+// name and version.
+func groupedEncoder(output io.Writer, s sbom.SBOM) error {
+	// init the tabular writer
+	w := new(tabwriter.Writer)
+	w.Init(output, 0, 8, 1, ' ', 0)
+
+	if err := writeSource(w, s.Source); err != nil {
+		return err
+	}
+
+	groups := groupByType(s.Artifacts.PackageCatalog)
+	if len(groups) == 0 {
+		fmt.Fprintln(output, "No packages discovered")
+		return nil
+	}
+
+	types := make([]string, 0, len(groups))
+	for t := range groups {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		packages := groups[pkg.Type(t)]
+		noun := "packages"
+		if len(packages) == 1 {
+			noun = "package"
+		}
+		fmt.Fprintf(w, "[%s: %d %s]\n", t, len(packages), noun)
+		for _, p := range packages {
+			fmt.Fprintf(w, " %s\t%s\t%s\n", p.Name, p.Version, p.FoundBy)
+		}
+		fmt.Fprintln(w)
+		w.Flush()
+	}
+
+	return nil
+}
+
+// groupByType partitions all packages by type, where packages within each group retain the catalog sort order
+// (by name then version).
+func groupByType(catalog *pkg.Catalog) map[pkg.Type][]pkg.Package {
+	groups := make(map[pkg.Type][]pkg.Package)
+	if catalog == nil {
+		return groups
+	}
+	for _, p := range catalog.Sorted() {
+		groups[p.Type] = append(groups[p.Type], p)
+	}
+	return groups
+}
@@ -0,0 +1,60 @@
+package text
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+var updateTextGroupedPresenterGoldenFiles = flag.Bool("update-text-grouped", false, "update the *.golden files for grouped text presenters")
+
+func TestTextGroupedDirectoryPresenter(t *testing.T) {
+	s := testutils.DirectoryInput(t)
+
+	// add packages from more ecosystems (and more than one package per ecosystem) to exercise grouping and sorting
+	s.Artifacts.PackageCatalog.Add(pkg.Package{
+		Name:    "left-pad",
+		Version: "1.3.0",
+		Type:    pkg.NpmPkg,
+		FoundBy: "the-cataloger-3",
+		Locations: []source.Location{
+			source.NewLocation("/some/path/node_modules/left-pad/package.json"),
+		},
+	})
+	s.Artifacts.PackageCatalog.Add(pkg.Package{
+		Name:    "apt",
+		Version: "1.8.2",
+		Type:    pkg.DebPkg,
+		FoundBy: "the-cataloger-2",
+		Locations: []source.Location{
+			source.NewLocation("/var/lib/dpkg/status"),
+		},
+	})
+	s.Artifacts.PackageCatalog.Add(pkg.Package{
+		Name:    "apt",
+		Version: "1.8.1",
+		Type:    pkg.DebPkg,
+		FoundBy: "the-cataloger-2",
+		Locations: []source.Location{
+			source.NewLocation("/var/lib/dpkg/status.d/apt"),
+		},
+	})
+
+	testutils.AssertPresenterAgainstGoldenSnapshot(t,
+		GroupedFormat().Presenter(s),
+		*updateTextGroupedPresenterGoldenFiles,
+	)
+}
+
+func TestTextGroupedNoPackages(t *testing.T) {
+	s := testutils.DirectoryInput(t)
+	s.Artifacts.PackageCatalog = pkg.NewCatalog()
+
+	testutils.AssertPresenterAgainstGoldenSnapshot(t,
+		GroupedFormat().Presenter(s),
+		*updateTextGroupedPresenterGoldenFiles,
+	)
+}
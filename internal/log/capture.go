@@ -0,0 +1,42 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CapturingLogger records the warning and debug messages logged (discarding all other levels), which is useful for
+// asserting what is logged.
+type CapturingLogger struct {
+	nopLogger
+	lock     sync.Mutex
+	Warnings []string
+	Debugs   []string
+}
+
+// Capture replaces the logger with a new CapturingLogger, returning it along with a function that restores the
+// original logger.
+func Capture() (*CapturingLogger, func()) {
+	original := Log
+	capture := &CapturingLogger{}
+	Log = capture
+	return capture, func() {
+		Log = original
+	}
+}
+
+func (l *CapturingLogger) Warnf(format string, args ...interface{}) {
+	l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l *CapturingLogger) Warn(args ...interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.Warnings = append(l.Warnings, fmt.Sprint(args...))
+}
+
+func (l *CapturingLogger) Debugf(format string, args ...interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.Debugs = append(l.Debugs, fmt.Sprintf(format, args...))
+}
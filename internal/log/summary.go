@@ -0,0 +1,78 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WarningKind describes all occurrences of a kind of warning within a WarningSummary, for a single occurrence (e.g.
+// "file was skipped without a SHA1 digest") and for many occurrences (e.g. "files were skipped without a SHA1 digest").
+type WarningKind struct {
+	Singular string
+	Plural   string
+}
+
+// WarningSummary aggregates warnings that may be repeated many times over (e.g. once per package) into a single
+// warning per kind. Every individual occurrence is logged at the debug level (so it is only shown when requested with
+// increased verbosity), while the number of occurrences of each kind is logged as a warning when flushed.
+type WarningSummary struct {
+	lock   sync.Mutex
+	kinds  []WarningKind
+	counts map[WarningKind]int
+}
+
+// NewWarningSummary creates an empty WarningSummary.
+func NewWarningSummary() *WarningSummary {
+	return &WarningSummary{
+		counts: make(map[WarningKind]int),
+	}
+}
+
+// Warnf records an occurrence of the given kind of warning, logging the occurrence details at the debug level. A nil
+// summary logs the occurrence details as a warning instead.
+func (s *WarningSummary) Warnf(kind WarningKind, format string, args ...interface{}) {
+	if s == nil {
+		Warnf(format, args...)
+		return
+	}
+
+	Debugf(format, args...)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.counts[kind]; !exists {
+		s.kinds = append(s.kinds, kind)
+	}
+	s.counts[kind]++
+}
+
+// Flush logs a single warning for each kind of warning recorded (in the order first recorded) with the number of
+// occurrences (e.g. "12 files were skipped without a SHA1 digest"), clearing the summary.
+func (s *WarningSummary) Flush() {
+	if s == nil {
+		return
+	}
+
+	for _, message := range s.messages() {
+		Warn(message)
+	}
+}
+
+func (s *WarningSummary) messages() (results []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, kind := range s.kinds {
+		count := s.counts[kind]
+		description := kind.Plural
+		if count == 1 {
+			description = kind.Singular
+		}
+		results = append(results, fmt.Sprintf("%d %s", count, description))
+	}
+
+	s.kinds = nil
+	s.counts = make(map[WarningKind]int)
+	return results
+}
@@ -0,0 +1,50 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	unrecognizedLicenses = WarningKind{Singular: "package had unrecognized licenses", Plural: "packages had unrecognized licenses"}
+	missingDigests       = WarningKind{Singular: "file had no SHA1 digest", Plural: "files had no SHA1 digest"}
+)
+
+func TestWarningSummary(t *testing.T) {
+	capture, restore := Capture()
+	t.Cleanup(restore)
+
+	summary := NewWarningSummary()
+	for i := 0; i < 12; i++ {
+		summary.Warnf(unrecognizedLicenses, "package=%d has an unrecognized license", i)
+	}
+	summary.Warnf(missingDigests, "file=%d has no SHA1 digest", 0)
+
+	// individual occurrences are only logged at the debug level
+	assert.Empty(t, capture.Warnings)
+	assert.Len(t, capture.Debugs, 13)
+	assert.Equal(t, "package=0 has an unrecognized license", capture.Debugs[0])
+
+	summary.Flush()
+	assert.Equal(t, []string{
+		"12 packages had unrecognized licenses",
+		"1 file had no SHA1 digest",
+	}, capture.Warnings)
+
+	// flushing clears the summary
+	summary.Flush()
+	assert.Len(t, capture.Warnings, 2)
+}
+
+func TestWarningSummary_nil(t *testing.T) {
+	capture, restore := Capture()
+	t.Cleanup(restore)
+
+	var summary *WarningSummary
+	summary.Warnf(unrecognizedLicenses, "package=%s has an unrecognized license", "musl")
+	summary.Flush()
+
+	assert.Equal(t, []string{"package=musl has an unrecognized license"}, capture.Warnings)
+	assert.Empty(t, capture.Debugs)
+}
@@ -0,0 +1,109 @@
+/*
+Package registrytest provides a stub container registry (serving a single image) for tests that pull images.
+*/
+package registrytest
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+)
+
+// Middleware wraps the handler of the stub registry (e.g. to require authorization or to fail requests).
+type Middleware func(next http.Handler) http.Handler
+
+// Registry is a stub registry (served over plain HTTP) with a single random image.
+type Registry struct {
+	// Host is the host (and port) of the registry.
+	Host string
+	// Image is the reference of the image served by the registry.
+	Image string
+
+	lock    sync.RWMutex
+	handler http.Handler
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.RLock()
+	handler := r.handler
+	r.lock.RUnlock()
+	handler.ServeHTTP(w, req)
+}
+
+// New starts a stub registry serving a single random image for the given test, which is stopped once the test
+// completes. The image is pushed before the given middleware (if any) is installed, so the middleware only handles the
+// requests made by the test.
+func New(t testing.TB, middleware Middleware) *Registry {
+	t.Helper()
+
+	return start(t, middleware, func(ref name.Reference) error {
+		img, err := random.Image(64, 1)
+		if err != nil {
+			return err
+		}
+		return remote.Write(ref, img)
+	})
+}
+
+// NewWithIndex is the same as New, however, the registry serves the given image index (e.g. a multi-platform image).
+func NewWithIndex(t testing.TB, index v1.ImageIndex, middleware Middleware) *Registry {
+	t.Helper()
+
+	return start(t, middleware, func(ref name.Reference) error {
+		return remote.WriteIndex(ref, index)
+	})
+}
+
+func start(t testing.TB, middleware Middleware, push func(ref name.Reference) error) *Registry {
+	t.Helper()
+
+	handler := registry.New(registry.Logger(log.New(ioutil.Discard, "", 0)))
+	stub := &Registry{handler: handler}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	stub.Host = u.Host
+	stub.Image = u.Host + "/some/image:latest"
+
+	ref, err := name.ParseReference(stub.Image, name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, push(ref))
+
+	if middleware != nil {
+		stub.lock.Lock()
+		stub.handler = middleware(handler)
+		stub.lock.Unlock()
+	}
+
+	return stub
+}
+
+// Setenv sets the given environment variable (e.g. the docker config or temp dir used when pulling an image) for the
+// duration of the given test, restoring the original value once the test completes.
+func Setenv(t testing.TB, key, value string) {
+	t.Helper()
+
+	original, set := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if set {
+			_ = os.Setenv(key, original)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package spdxlicense
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// matches an explicit license identifier within a file (e.g. "SPDX-License-Identifier: MIT")
+	identifierPattern = regexp.MustCompile(`(?i)SPDX-License-Identifier:\s*([^\s*/#]+)`)
+	// matches everything but letters, numbers, and the "." within version numbers (used to normalize license text)
+	nonWordPattern = regexp.MustCompile(`[^a-z0-9.]+`)
+)
+
+// licenseText describes how to recognize the full text of a license, where all of the given phrases (normalized with
+// normalizeText) must be present.
+type licenseText struct {
+	id      string
+	phrases []string
+}
+
+// licenseTexts are checked in order, so licenses whose text contains the phrases of another license (e.g. the
+// BSD-3-Clause is the BSD-2-Clause with an additional clause) must come first. The (L/A)GPL family of licenses refer to
+// each other, so are recognized by the title and version of the license text instead.
+var licenseTexts = []licenseText{
+	{id: "AGPL-3.0-only", phrases: []string{"gnu affero general public license version 3 19 november 2007"}},
+	{id: "LGPL-3.0-only", phrases: []string{"gnu lesser general public license version 3 29 june 2007"}},
+	{id: "LGPL-2.1-only", phrases: []string{"gnu lesser general public license version 2.1 february 1999"}},
+	{id: "LGPL-2.0-only", phrases: []string{"gnu library general public license version 2 june 1991"}},
+	{id: "GPL-3.0-only", phrases: []string{"gnu general public license version 3 29 june 2007"}},
+	{id: "GPL-2.0-only", phrases: []string{"gnu general public license version 2 june 1991"}},
+	{id: "Apache-2.0", phrases: []string{"apache license version 2.0 january 2004"}},
+	{id: "MPL-2.0", phrases: []string{"mozilla public license version 2.0"}},
+	{id: "EPL-2.0", phrases: []string{"eclipse public license v 2.0"}},
+	{id: "EPL-1.0", phrases: []string{"eclipse public license v 1.0"}},
+	{id: "Unlicense", phrases: []string{"this is free and unencumbered software released into the public domain"}},
+	{id: "ISC", phrases: []string{"permission to use copy modify and or distribute this software for any purpose with or without fee is hereby granted"}},
+	{id: "MIT", phrases: []string{
+		"permission is hereby granted free of charge to any person obtaining a copy",
+		"the above copyright notice and this permission notice shall be included",
+	}},
+	{id: "BSD-3-Clause", phrases: []string{
+		"redistribution and use in source and binary forms with or without modification are permitted",
+		"neither the name of",
+	}},
+	{id: "BSD-2-Clause", phrases: []string{"redistribution and use in source and binary forms with or without modification are permitted"}},
+	{id: "Zlib", phrases: []string{
+		"this software is provided as is without any express or implied warranty",
+		"altered source versions must be plainly marked as such",
+	}},
+}
+
+// Classify returns the SPDX license list identifier for the license within the given file contents (e.g. the contents
+// of a LICENSE file), either explicitly identified with an "SPDX-License-Identifier" tag or recognized from the text
+// of the license itself. This is a lightweight classifier that only recognizes a set of commonly used licenses.
+func Classify(contents string) (string, bool) {
+	if match := identifierPattern.FindStringSubmatch(contents); match != nil {
+		if id, exists := ID(match[1]); exists {
+			return id, true
+		}
+	}
+
+	text := normalizeText(contents)
+	for _, license := range licenseTexts {
+		if containsAll(text, license.phrases) {
+			return license.id, true
+		}
+	}
+	return "", false
+}
+
+// normalizeText lowercases the given text and collapses all punctuation and whitespace into single spaces, such that
+// line wrapping, indentation, and quoting do not affect matching ("." is only kept within version numbers).
+func normalizeText(value string) string {
+	var words []string
+	for _, word := range strings.Fields(nonWordPattern.ReplaceAllString(strings.ToLower(value), " ")) {
+		if word = strings.Trim(word, "."); word != "" {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func containsAll(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if !strings.Contains(text, phrase) {
+			return false
+		}
+	}
+	return true
+}
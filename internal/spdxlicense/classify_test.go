@@ -0,0 +1,94 @@
+package spdxlicense
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected string
+	}{
+		{
+			name: "MIT license text",
+			contents: `Copyright (c) 2010-2020 Benjamin Peterson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction...
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.`,
+			expected: "MIT",
+		},
+		{
+			name: "Apache 2.0 license text",
+			contents: `
+                                 Apache License
+                           Version 2.0, January 2004
+                        http://www.apache.org/licenses/`,
+			expected: "Apache-2.0",
+		},
+		{
+			name: "GPL 2.0 license text",
+			contents: `		    GNU GENERAL PUBLIC LICENSE
+		       Version 2, June 1991
+
+ Copyright (C) 1989, 1991 Free Software Foundation, Inc.`,
+			expected: "GPL-2.0-only",
+		},
+		{
+			// the LGPL refers to the GPL, which must not be mistaken for the GPL itself
+			name: "LGPL 2.1 license text",
+			contents: `                  GNU LESSER GENERAL PUBLIC LICENSE
+                       Version 2.1, February 1999
+
+ [This is the first released version of the Lesser GPL.  It also counts
+ as the successor of the GNU Library Public License, version 2, hence
+ the version number 2.1.]
+
+  The licenses for most software are designed to take away your freedom to share and change it.  By contrast, the GNU
+General Public Licenses are intended to guarantee your freedom...`,
+			expected: "LGPL-2.1-only",
+		},
+		{
+			name: "BSD 3 clause license text",
+			contents: `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+...
+3. Neither the name of the copyright holder nor the names of its
+   contributors may be used to endorse or promote products derived from
+   this software without specific prior written permission.`,
+			expected: "BSD-3-Clause",
+		},
+		{
+			name: "BSD 2 clause license text",
+			contents: `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:`,
+			expected: "BSD-2-Clause",
+		},
+		{
+			name:     "SPDX license identifier",
+			contents: "// SPDX-License-Identifier: mpl-2.0\n",
+			expected: "MPL-2.0",
+		},
+		{
+			name:     "unknown license",
+			contents: "All rights reserved. Do not distribute.",
+		},
+		{
+			name:     "unknown SPDX license identifier",
+			contents: "SPDX-License-Identifier: Proprietary",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, ok := Classify(test.contents)
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
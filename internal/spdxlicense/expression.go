@@ -0,0 +1,159 @@
+package spdxlicense
+
+import "strings"
+
+const licenseRefPrefix = "LicenseRef-"
+
+type tokenKind int
+
+const (
+	operandToken tokenKind = iota
+	operatorToken
+	openToken
+	closeToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// ParseExpression normalizes the given license value into an SPDX license expression (see
+// https://spdx.github.io/spdx-spec/appendix-IV-SPDX-license-expressions/), where each license within the expression is
+// converted with the given function. All converted licenses are returned in the order found, and the returned bool
+// indicates if the expression is compound (uses any AND, OR, or WITH operators).
+//
+// The value is only treated as an expression when it is well-formed and every license within it is an SPDX license
+// list identifier or a "LicenseRef-" identifier. Otherwise (e.g. free-form names such as "GPL or Artistic" or
+// "BSD (3 clause)") the entire value is converted as a single license.
+func ParseExpression(value string, licenseID func(name string) string) (string, []string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil, false
+	}
+
+	tokens := tokenize(value)
+	if !isValidExpression(tokens) {
+		id := licenseID(strings.Join(strings.Fields(value), " "))
+		return id, []string{id}, false
+	}
+
+	var parts, licenses []string
+	var compound, isException bool
+	for _, t := range tokens {
+		switch t.kind {
+		case operatorToken:
+			compound = true
+			isException = t.value == "WITH"
+			parts = append(parts, t.value)
+		case operandToken:
+			if isException {
+				// exceptions (found after a WITH operator) are from a separate list and are not converted
+				parts = append(parts, t.value)
+				continue
+			}
+			id := licenseID(t.value)
+			parts = append(parts, id)
+			licenses = append(licenses, id)
+		default:
+			parts = append(parts, t.value)
+		}
+	}
+
+	expression := strings.Join(parts, " ")
+	expression = strings.ReplaceAll(expression, "( ", "(")
+	expression = strings.ReplaceAll(expression, " )", ")")
+	return expression, licenses, compound
+}
+
+// tokenize splits the given license value into operands, operators, and parentheses, where consecutive words that are
+// not operators form a single operand (e.g. the exception "Classpath exception 2.0" becomes "Classpath-exception-2.0").
+func tokenize(value string) []token {
+	var tokens []token
+	var operand []string
+	var isException bool
+
+	flush := func() {
+		if len(operand) == 0 {
+			return
+		}
+		separator := " "
+		if isException {
+			separator = "-"
+		}
+		tokens = append(tokens, token{kind: operandToken, value: strings.Join(operand, separator)})
+		operand = nil
+	}
+
+	fields := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(value))
+	for _, field := range fields {
+		switch upper := strings.ToUpper(field); upper {
+		case "AND", "OR", "WITH":
+			flush()
+			isException = upper == "WITH"
+			tokens = append(tokens, token{kind: operatorToken, value: upper})
+		case "(":
+			flush()
+			tokens = append(tokens, token{kind: openToken, value: field})
+		case ")":
+			flush()
+			isException = false
+			tokens = append(tokens, token{kind: closeToken, value: field})
+		default:
+			operand = append(operand, field)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// isValidExpression indicates if the given tokens form a well-formed license expression where every license is an SPDX
+// license list identifier or a "LicenseRef-" identifier.
+func isValidExpression(tokens []token) bool {
+	depth := 0
+	expectOperand := true
+	afterWith := false
+	for i, t := range tokens {
+		switch t.kind {
+		case operandToken:
+			if !expectOperand {
+				return false
+			}
+			if !afterWith && !isKnownLicense(t.value) {
+				return false
+			}
+			expectOperand = false
+		case operatorToken:
+			if expectOperand {
+				return false
+			}
+			// only a single license (not a sub-expression or another exception) may have an exception
+			if t.value == "WITH" && (tokens[i-1].kind != operandToken || i >= 2 && tokens[i-2].value == "WITH") {
+				return false
+			}
+			afterWith = t.value == "WITH"
+			expectOperand = true
+			continue
+		case openToken:
+			if !expectOperand || afterWith {
+				return false
+			}
+			depth++
+		case closeToken:
+			if expectOperand || depth == 0 {
+				return false
+			}
+			depth--
+		}
+		afterWith = false
+	}
+	return depth == 0 && !expectOperand
+}
+
+func isKnownLicense(name string) bool {
+	if strings.HasPrefix(name, licenseRefPrefix) && !strings.Contains(name, " ") {
+		return true
+	}
+	_, exists := ID(name)
+	return exists
+}
@@ -0,0 +1,86 @@
+package spdxlicense
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		value            string
+		expression       string
+		expectedLicenses []string
+		compound         bool
+	}{
+		{
+			value: "",
+		},
+		{
+			value:            "MIT",
+			expression:       "mit",
+			expectedLicenses: []string{"mit"},
+		},
+		{
+			value:            "Some License",
+			expression:       "some license",
+			expectedLicenses: []string{"some license"},
+		},
+		{
+			value:            "(MIT or Apache-2.0)",
+			expression:       "(mit OR apache-2.0)",
+			expectedLicenses: []string{"mit", "apache-2.0"},
+			compound:         true,
+		},
+		{
+			value:            "(LGPL-2.1 OR LicenseRef-My-License) AND GPL-2.0 with Classpath exception 2.0",
+			expression:       "(lgpl-2.1 OR licenseref-my-license) AND gpl-2.0 WITH Classpath-exception-2.0",
+			expectedLicenses: []string{"lgpl-2.1", "licenseref-my-license", "gpl-2.0"},
+			compound:         true,
+		},
+		{
+			value:            "(LGPL-2.1 OR My License) AND GPL-2.0",
+			expression:       "(lgpl-2.1 or my license) and gpl-2.0",
+			expectedLicenses: []string{"(lgpl-2.1 or my license) and gpl-2.0"},
+		},
+		{
+			value:            "GPL or Artistic",
+			expression:       "gpl or artistic",
+			expectedLicenses: []string{"gpl or artistic"},
+		},
+		{
+			value:            "BSD (3 clause)",
+			expression:       "bsd (3 clause)",
+			expectedLicenses: []string{"bsd (3 clause)"},
+		},
+		{
+			value:            "Apache  with exceptions",
+			expression:       "apache with exceptions",
+			expectedLicenses: []string{"apache with exceptions"},
+		},
+		{
+			value:            "(MIT OR Apache-2.0",
+			expression:       "(mit or apache-2.0",
+			expectedLicenses: []string{"(mit or apache-2.0"},
+		},
+		{
+			value:            "MIT OR",
+			expression:       "mit or",
+			expectedLicenses: []string{"mit or"},
+		},
+		{
+			value:            "(MIT OR Apache-2.0) WITH Classpath-exception-2.0",
+			expression:       "(mit or apache-2.0) with classpath-exception-2.0",
+			expectedLicenses: []string{"(mit or apache-2.0) with classpath-exception-2.0"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			expression, licenses, compound := ParseExpression(test.value, strings.ToLower)
+			assert.Equal(t, test.expression, expression)
+			assert.Equal(t, test.expectedLicenses, licenses)
+			assert.Equal(t, test.compound, compound)
+		})
+	}
+}
@@ -27,15 +27,31 @@ can be extended to include specific package metadata struct shapes in the future
 // When a new package metadata definition is created it will need to be manually added here. The variable name does
 // not matter as long as it is exported.
 type artifactMetadataContainer struct {
-	Apk    pkg.ApkMetadata
-	Dpkg   pkg.DpkgMetadata
-	Gem    pkg.GemMetadata
-	Java   pkg.JavaMetadata
-	Npm    pkg.NpmPackageJSONMetadata
-	Python pkg.PythonPackageMetadata
-	Rpm    pkg.RpmdbMetadata
-	Cargo  pkg.CargoPackageMetadata
-	Go     pkg.GolangBinMetadata
+	Apk        pkg.ApkMetadata
+	Dpkg       pkg.DpkgMetadata
+	Gem        pkg.GemMetadata
+	Java       pkg.JavaMetadata
+	Npm        pkg.NpmPackageJSONMetadata
+	Python     pkg.PythonPackageMetadata
+	Rpm        pkg.RpmdbMetadata
+	Cargo      pkg.CargoPackageMetadata
+	Go         pkg.GolangBinMetadata
+	GoMod      pkg.GolangModMetadata
+	Php        pkg.PhpComposerMetadata
+	Conda      pkg.CondaMetadata
+	Dotnet     pkg.DotnetDepsMetadata
+	Swift      pkg.SwiftPackageResolvedMetadata
+	NpmLock    pkg.NpmPackageLockJSONMetadata
+	PoetryLock pkg.PythonPoetryLockMetadata
+	Requires   pkg.PythonRequirementsMetadata
+	GemLock    pkg.GemfileLockMetadata
+	Binary     pkg.BinaryMetadata
+	Haskell    pkg.HaskellMetadata
+	MixLock    pkg.MixLockMetadata
+	DartPub    pkg.DartPubMetadata
+	Windows    pkg.WindowsRegistryMetadata
+	Kernel     pkg.LinuxKernelMetadata
+	Apkbuild   pkg.ApkbuildMetadata
 }
 
 func main() {
@@ -7,8 +7,27 @@ const (
 	// has been completed.
 	OwnershipByFileOverlapRelationship RelationshipType = "ownership-by-file-overlap"
 
+	// SharedFileOwnershipRelationship (supports package-to-package linkages) indicates that packages from different
+	// package managers both claim ownership of the same files (e.g. a python package installed with pip over the files
+	// of the same library installed as an OS package), so the files of one package shadow the files of the other. The
+	// direction of the relationship carries no meaning. This relationship must be created only after all package
+	// cataloging has been completed.
+	SharedFileOwnershipRelationship RelationshipType = "shared-file-ownership"
+
 	// ContainsRelationship (supports any-to-any linkages) is a proxy for the SPDX 2.2 CONTAINS relationship.
 	ContainsRelationship RelationshipType = "contains"
+
+	// ContainedByLayerRelationship (supports package-to-layer linkages) indicates the image layer where the files that
+	// a package owns (or otherwise, was discovered by) first appear (that is, the layer that introduced the package).
+	ContainedByLayerRelationship RelationshipType = "contained-by-layer"
+
+	// FoundInSourceRelationship (supports package-to-source linkages) indicates which of several cataloged sources a
+	// package was discovered in. This is only created when the results for multiple sources are merged together.
+	FoundInSourceRelationship RelationshipType = "found-in-source"
+
+	// DependencyOfRelationship (supports package-to-package linkages) indicates that the "from" package is a dependency
+	// of the "to" package.
+	DependencyOfRelationship RelationshipType = "dependency-of"
 )
 
 type RelationshipType string
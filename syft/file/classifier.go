@@ -89,6 +89,12 @@ func (c Classifier) Classify(resolver source.FileResolver, location source.Locat
 		return nil, err
 	}
 
+	// the values captured from the path are literal text within the evidence patterns (e.g. the "." of a version)
+	templateValues := make(map[string]string, len(filepathNamedGroupValues))
+	for name, value := range filepathNamedGroupValues {
+		templateValues[name] = regexp.QuoteMeta(value)
+	}
+
 	var result *Classification
 	for _, patternTemplate := range c.EvidencePatternTemplates {
 		tmpl, err := template.New("").Parse(patternTemplate)
@@ -97,7 +103,7 @@ func (c Classifier) Classify(resolver source.FileResolver, location source.Locat
 		}
 
 		patternBuf := &bytes.Buffer{}
-		err = tmpl.Execute(patternBuf, filepathNamedGroupValues)
+		err = tmpl.Execute(patternBuf, templateValues)
 		if err != nil {
 			return nil, fmt.Errorf("unable to render template: %w", err)
 		}
@@ -0,0 +1,111 @@
+package file
+
+import (
+	"crypto"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+// CoordinatesResolver looks up the files of a source by their coordinates, which is all that an SBOM records about
+// each file. This allows for reading files after cataloging (e.g. while encoding an SBOM), such as to compute the
+// digests of files that were not otherwise digested.
+type CoordinatesResolver struct {
+	resolver source.FileResolver
+
+	lock      sync.Mutex
+	locations map[source.Coordinates]source.Location
+	digests   map[string][]Digest
+}
+
+// NewCoordinatesResolver creates a CoordinatesResolver for the files of the given resolver, which must remain usable
+// (i.e. the source must not be cleaned up) for as long as files are looked up.
+func NewCoordinatesResolver(resolver source.FileResolver) *CoordinatesResolver {
+	return &CoordinatesResolver{
+		resolver: resolver,
+		digests:  make(map[string][]Digest),
+	}
+}
+
+// Location returns the location of the file with the given coordinates (e.g. to determine the virtual path of the
+// file), indicating if the file was found.
+func (r *CoordinatesResolver) Location(coordinates source.Coordinates) (source.Location, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.locations == nil {
+		// note: locations cannot be recreated from coordinates alone (the file reference is missing), so all locations
+		// are indexed when the first file is looked up
+		r.locations = make(map[source.Coordinates]source.Location)
+		for location := range r.resolver.AllLocations() {
+			if _, exists := r.locations[location.Coordinates]; !exists {
+				r.locations[location.Coordinates] = location
+			}
+		}
+	}
+
+	location, exists := r.locations[coordinates]
+	return location, exists
+}
+
+// FileContents returns the contents of the file with the given coordinates.
+func (r *CoordinatesResolver) FileContents(coordinates source.Coordinates) (io.ReadCloser, error) {
+	location, err := r.location(coordinates)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolver.FileContentsByLocation(location)
+}
+
+// FileMetadata returns the metadata of the file with the given coordinates.
+func (r *CoordinatesResolver) FileMetadata(coordinates source.Coordinates) (source.FileMetadata, error) {
+	location, err := r.location(coordinates)
+	if err != nil {
+		return source.FileMetadata{}, err
+	}
+	return r.resolver.FileMetadataByLocation(location)
+}
+
+// FileDigests returns the given digests of the file with the given coordinates (which are empty when the file has no
+// contents, see DigestsCataloger). Digests are only computed once per file.
+func (r *CoordinatesResolver) FileDigests(coordinates source.Coordinates, hashes ...crypto.Hash) ([]Digest, error) {
+	algorithms := make([]string, len(hashes))
+	for i, hash := range hashes {
+		algorithms[i] = DigestAlgorithmName(hash)
+	}
+	key := fmt.Sprintf("%s:%s:%s", coordinates.FileSystemID, coordinates.RealPath, strings.Join(algorithms, ","))
+
+	r.lock.Lock()
+	digests, exists := r.digests[key]
+	r.lock.Unlock()
+	if exists {
+		return digests, nil
+	}
+
+	location, err := r.location(coordinates)
+	if err != nil {
+		return nil, err
+	}
+
+	digests, err = digestsOfLocation(r.resolver, location, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	r.digests[key] = digests
+	r.lock.Unlock()
+	return digests, nil
+}
+
+func (r *CoordinatesResolver) location(coordinates source.Coordinates) (source.Location, error) {
+	location, exists := r.Location(coordinates)
+	if !exists {
+		return source.Location{}, fmt.Errorf("unable to find file %q: %w", coordinates, os.ErrNotExist)
+	}
+	return location, nil
+}
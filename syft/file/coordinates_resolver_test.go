@@ -0,0 +1,52 @@
+package file
+
+import (
+	"crypto"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCoordinatesResolver(t *testing.T) {
+	files := []string{"test-fixtures/last/path.txt", "test-fixtures/a-path.txt"}
+	resolver := NewCoordinatesResolver(source.NewMockResolverForPaths(files...))
+
+	coordinates := source.NewLocation("test-fixtures/a-path.txt").Coordinates
+
+	location, exists := resolver.Location(coordinates)
+	require.True(t, exists)
+	assert.Equal(t, coordinates, location.Coordinates)
+
+	reader, err := resolver.FileContents(coordinates)
+	require.NoError(t, err)
+	actual, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	expected, err := ioutil.ReadFile("test-fixtures/a-path.txt")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	digests, err := resolver.FileDigests(coordinates, crypto.SHA1, crypto.SHA256)
+	require.NoError(t, err)
+	assert.Equal(t, testDigests(t, []string{"test-fixtures/a-path.txt"}, crypto.SHA1, crypto.SHA256)[coordinates], digests)
+
+	// digests are only computed once per file (and set of hashes)
+	cached, err := resolver.FileDigests(coordinates, crypto.SHA1, crypto.SHA256)
+	require.NoError(t, err)
+	assert.Equal(t, digests, cached)
+	assert.Len(t, resolver.digests, 1)
+
+	missing := source.NewLocation("test-fixtures/missing.txt").Coordinates
+	_, exists = resolver.Location(missing)
+	assert.False(t, exists)
+	_, err = resolver.FileContents(missing)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	_, err = resolver.FileDigests(missing, crypto.SHA1)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
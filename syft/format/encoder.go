@@ -3,8 +3,14 @@ package format
 import (
 	"io"
 
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/sbom"
 )
 
 // Encoder is a function that can transform Syft native objects into an SBOM document of a specific format written to the given writer.
 type Encoder func(io.Writer, sbom.SBOM) error
+
+// ResolverEncoder is an Encoder that may also read the files of the cataloged source by their coordinates (e.g. to
+// compute file digests that were not cataloged). The resolver is nil when the source is not available (e.g. when
+// converting an existing SBOM), in which case only the contents of the SBOM are encoded.
+type ResolverEncoder func(io.Writer, sbom.SBOM, *file.CoordinatesResolver) error
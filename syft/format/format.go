@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/sbom"
 )
 
@@ -14,10 +15,11 @@ var (
 )
 
 type Format struct {
-	Option    Option
-	encoder   Encoder
-	decoder   Decoder
-	validator Validator
+	Option          Option
+	encoder         Encoder
+	resolverEncoder ResolverEncoder
+	decoder         Decoder
+	validator       Validator
 }
 
 func NewFormat(option Option, encoder Encoder, decoder Decoder, validator Validator) Format {
@@ -29,11 +31,31 @@ func NewFormat(option Option, encoder Encoder, decoder Decoder, validator Valida
 	}
 }
 
+// NewResolverFormat creates a Format that encodes SBOMs with the given ResolverEncoder, which may read the files of the
+// cataloged source when a resolver is given (see EncodeWithResolver).
+func NewResolverFormat(option Option, encoder ResolverEncoder, decoder Decoder, validator Validator) Format {
+	return Format{
+		Option:          option,
+		resolverEncoder: encoder,
+		decoder:         decoder,
+		validator:       validator,
+	}
+}
+
 func (f Format) Encode(output io.Writer, s sbom.SBOM) error {
-	if f.encoder == nil {
-		return ErrEncodingNotSupported
+	return f.EncodeWithResolver(output, s, nil)
+}
+
+// EncodeWithResolver encodes the given SBOM, where formats that describe file details may look up the files of the
+// cataloged source with the given resolver (which may be nil). Other formats ignore the resolver.
+func (f Format) EncodeWithResolver(output io.Writer, s sbom.SBOM, resolver *file.CoordinatesResolver) error {
+	switch {
+	case f.resolverEncoder != nil:
+		return f.resolverEncoder(output, s, resolver)
+	case f.encoder != nil:
+		return f.encoder(output, s)
 	}
-	return f.encoder(output, s)
+	return ErrEncodingNotSupported
 }
 
 func (f Format) Decode(reader io.Reader) (*sbom.SBOM, error) {
@@ -52,8 +74,16 @@ func (f Format) Validate(reader io.Reader) error {
 }
 
 func (f Format) Presenter(s sbom.SBOM) *Presenter {
-	if f.encoder == nil {
+	return f.PresenterWithResolver(s, nil)
+}
+
+// PresenterWithResolver creates a presenter for the given SBOM, where formats that describe file details may look up
+// the files of the cataloged source with the given resolver (see EncodeWithResolver).
+func (f Format) PresenterWithResolver(s sbom.SBOM, resolver *file.CoordinatesResolver) *Presenter {
+	if f.encoder == nil && f.resolverEncoder == nil {
 		return nil
 	}
-	return NewPresenter(f.encoder, s)
+	return NewPresenter(func(output io.Writer, s sbom.SBOM) error {
+		return f.EncodeWithResolver(output, s, resolver)
+	}, s)
 }
@@ -5,22 +5,34 @@ import "strings"
 const (
 	UnknownFormatOption Option = "UnknownFormatOption"
 	JSONOption          Option = "json"
+	JSONLinesOption     Option = "json-lines"
 	TextOption          Option = "text"
+	TextGroupedOption   Option = "text-grouped"
 	TableOption         Option = "table"
+	CSVOption           Option = "csv"
 	CycloneDxXMLOption  Option = "cyclonedx"
 	CycloneDxJSONOption Option = "cyclonedx-json"
 	SPDXTagValueOption  Option = "spdx-tag-value"
 	SPDXJSONOption      Option = "spdx-json"
+	InTotoOption        Option = "in-toto"
+	GitHubOption        Option = "github"
+	TernJSONOption      Option = "tern-json"
 )
 
 var AllOptions = []Option{
 	JSONOption,
+	JSONLinesOption,
 	TextOption,
+	TextGroupedOption,
 	TableOption,
+	CSVOption,
 	CycloneDxXMLOption,
 	CycloneDxJSONOption,
 	SPDXTagValueOption,
 	SPDXJSONOption,
+	InTotoOption,
+	GitHubOption,
+	TernJSONOption,
 }
 
 type Option string
@@ -29,10 +41,16 @@ func ParseOption(userStr string) Option {
 	switch strings.ToLower(userStr) {
 	case string(JSONOption):
 		return JSONOption
+	case string(JSONLinesOption), "jsonlines", "jsonl", "ndjson":
+		return JSONLinesOption
 	case string(TextOption):
 		return TextOption
+	case string(TextGroupedOption), "grouped-text", "textgrouped":
+		return TextGroupedOption
 	case string(TableOption):
 		return TableOption
+	case string(CSVOption):
+		return CSVOption
 	case string(CycloneDxXMLOption), "cyclone", "cyclone-dx", "cyclone-dx-xml", "cyclone-xml":
 		// NOTE(jonasagx): setting "cyclone" to XML by default for retro-compatibility.
 		// If we want to show no preference between XML and JSON please remove it.
@@ -43,6 +61,12 @@ func ParseOption(userStr string) Option {
 		return SPDXTagValueOption
 	case string(SPDXJSONOption), "spdxjson":
 		return SPDXJSONOption
+	case string(InTotoOption), "intoto", "in-toto-json", "attestation":
+		return InTotoOption
+	case string(GitHubOption), "github-json", "github-dependency-snapshot":
+		return GitHubOption
+	case string(TernJSONOption), "tern", "ternjson":
+		return TernJSONOption
 	default:
 		return UnknownFormatOption
 	}
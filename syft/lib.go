@@ -33,9 +33,19 @@ import (
 
 // CatalogPackages takes an inventory of packages from the given image from a particular perspective
 // (e.g. squashed source, all-layers source). Returns the discovered  set of packages, the identified Linux
-// distribution, and the source object used to wrap the data source.
+// distribution, and the source object used to wrap the data source. All other options are the defaults (see
+// CatalogPackagesWithConfig).
 func CatalogPackages(src *source.Source, scope source.Scope) (*pkg.Catalog, []artifact.Relationship, *distro.Distro, error) {
-	resolver, err := src.FileResolver(scope)
+	cfg := cataloger.DefaultConfig()
+	cfg.Scope = scope
+	return CatalogPackagesWithConfig(src, cfg)
+}
+
+// CatalogPackagesWithConfig takes an inventory of packages from the given image from a particular perspective
+// (e.g. squashed source, all-layers source) as described by the given config. Returns the discovered  set of packages,
+// the identified Linux distribution, and the source object used to wrap the data source.
+func CatalogPackagesWithConfig(src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []artifact.Relationship, *distro.Distro, error) {
+	resolver, err := src.FileResolver(cfg.Scope)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("unable to determine resolver while cataloging packages: %w", err)
 	}
@@ -64,11 +74,20 @@ func CatalogPackages(src *source.Source, scope source.Scope) (*pkg.Catalog, []ar
 		return nil, nil, nil, fmt.Errorf("unable to determine cataloger set from scheme=%+v", src.Metadata.Scheme)
 	}
 
-	catalog, relationships, err := cataloger.Catalog(resolver, theDistro, catalogers...)
+	catalogers, err = cataloger.SelectCatalogers(catalogers, cfg.Catalogers)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	catalog, relationships, err := cataloger.Catalog(resolver, theDistro, cfg, catalogers...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	if src.Metadata.Scheme == source.ImageScheme {
+		relationships = append(relationships, pkg.RelationshipsByIntroducingLayer(catalog, src)...)
+	}
+
 	return catalog, relationships, theDistro, nil
 }
 
@@ -0,0 +1,23 @@
+package syft
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// MergeSBOMs combines the given SBOMs (e.g. the SBOMs produced by each stage of a build) into a single SBOM with the
+// packages and sources of every SBOM (see sbom.Merge). The same package found within several SBOMs (by type, name,
+// version, and PURL) is reported once with the locations, licenses, and CPEs of every duplicate. Package metadata is
+// never combined: when the metadata of duplicates differs, the metadata of the first duplicate (by location) is kept.
+func MergeSBOMs(sboms ...sbom.SBOM) sbom.SBOM {
+	merged := sbom.Merge(sboms...)
+	if merged.Artifacts.PackageCatalog == nil {
+		return merged
+	}
+
+	packages, relationships := cataloger.Deduplicate(merged.Artifacts.PackageCatalog.Sorted(), merged.Relationships)
+	merged.Artifacts.PackageCatalog = pkg.NewCatalog(packages...)
+	merged.Relationships = relationships
+	return merged
+}
@@ -0,0 +1,82 @@
+package syft
+
+import (
+	"os"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeFixture(t *testing.T, path string) sbom.SBOM {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	s, _, err := Decode(f)
+	require.NoError(t, err)
+	return *s
+}
+
+func TestMergeSBOMs(t *testing.T) {
+	// both stages have requests, otherwise the build stage has flask and the runtime stage has gunicorn
+	build := decodeFixture(t, "test-fixtures/merge/build.syft.json")
+	runtime := decodeFixture(t, "test-fixtures/merge/runtime.syft.json")
+	require.Equal(t, 2, build.Artifacts.PackageCatalog.PackageCount())
+	require.Equal(t, 2, runtime.Artifacts.PackageCatalog.PackageCount())
+
+	merged := MergeSBOMs(build, runtime)
+
+	assert.Equal(t, build.Source, merged.Source)
+	assert.Equal(t, []source.Metadata{build.Source, runtime.Source}, merged.Sources)
+
+	packages := make(map[string]pkg.Package)
+	for _, p := range merged.Artifacts.PackageCatalog.Sorted() {
+		packages[p.Name] = p
+	}
+	require.Equal(t, 3, merged.Artifacts.PackageCatalog.PackageCount())
+	require.Len(t, packages, 3)
+
+	// the duplicate package is reported once, with the locations from both SBOMs
+	var requestsLocations []string
+	for _, l := range packages["requests"].Locations {
+		requestsLocations = append(requestsLocations, l.RealPath)
+	}
+	assert.ElementsMatch(t, []string{"build/requirements.txt", "runtime/requirements.txt"}, requestsLocations)
+	assert.Len(t, packages["flask"].Locations, 1)
+	assert.Len(t, packages["gunicorn"].Locations, 1)
+
+	// every package is related to each source it was found in
+	foundIn := make(map[string][]artifact.ID)
+	for _, r := range merged.Relationships {
+		if r.Type != artifact.FoundInSourceRelationship {
+			continue
+		}
+		p := r.From.(pkg.Package)
+		assert.Equal(t, packages[p.Name].ID(), p.ID(), "relationship to a duplicate package=%s", p.Name)
+		foundIn[p.Name] = append(foundIn[p.Name], r.To.ID())
+	}
+	assert.ElementsMatch(t, []artifact.ID{build.Source.ID(), runtime.Source.ID()}, foundIn["requests"])
+	assert.Equal(t, []artifact.ID{build.Source.ID()}, foundIn["flask"])
+	assert.Equal(t, []artifact.ID{runtime.Source.ID()}, foundIn["gunicorn"])
+}
+
+func TestMergeSBOMs_sameSBOM(t *testing.T) {
+	build := decodeFixture(t, "test-fixtures/merge/build.syft.json")
+
+	// merging the same SBOM again (or an SBOM that was already merged) adds nothing
+	merged := MergeSBOMs(build, build)
+	assert.Equal(t, 2, merged.Artifacts.PackageCatalog.PackageCount())
+	assert.Equal(t, []source.Metadata{build.Source}, merged.Sources)
+
+	runtime := decodeFixture(t, "test-fixtures/merge/runtime.syft.json")
+	merged = MergeSBOMs(MergeSBOMs(build, runtime), build)
+	assert.Equal(t, 3, merged.Artifacts.PackageCatalog.PackageCount())
+	assert.Equal(t, []source.Metadata{build.Source, runtime.Source}, merged.Sources)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/anchore/syft/syft/file"
 
 	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/distro"
 	"github.com/scylladb/go-set/strset"
 )
 
@@ -45,7 +46,14 @@ type ApkFileRecord struct {
 }
 
 // PackageURL returns the PURL for the specific Alpine package (see https://github.com/package-url/purl-spec)
-func (m ApkMetadata) PackageURL() string {
+func (m ApkMetadata) PackageURL(d *distro.Distro) string {
+	// subpackages (e.g. "musl-utils") point to the origin package they were built from (e.g. "musl"), which is the
+	// package name used within the Alpine secdb for vulnerability matching.
+	var upstream string
+	if m.OriginPackage != m.Package {
+		upstream = m.OriginPackage
+	}
+
 	pURL := packageurl.NewPackageURL(
 		// note: this is currently a candidate and not technically within spec
 		// see https://github.com/package-url/purl-spec#other-candidate-types-to-define
@@ -53,12 +61,10 @@ func (m ApkMetadata) PackageURL() string {
 		"",
 		m.Package,
 		m.Version,
-		packageurl.Qualifiers{
-			{
-				Key:   "arch",
-				Value: m.Architecture,
-			},
-		},
+		purlQualifiers(d, map[string]string{
+			purlArchQualifier:     m.Architecture,
+			purlUpstreamQualifier: upstream,
+		}),
 		"")
 	return pURL.ToString()
 }
@@ -5,12 +5,14 @@ import (
 	"testing"
 
 	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/distro"
 	"github.com/go-test/deep"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
 func TestApkMetadata_pURL(t *testing.T) {
 	tests := []struct {
+		distro   *distro.Distro
 		metadata ApkMetadata
 		expected string
 	}{
@@ -39,11 +41,42 @@ func TestApkMetadata_pURL(t *testing.T) {
 			},
 			expected: "pkg:alpine/g%20plus%20plus@v84?arch=am86",
 		},
+		{
+			metadata: ApkMetadata{
+				Package:       "musl-utils",
+				OriginPackage: "musl",
+				Version:       "1.1.24-r2",
+				Architecture:  "x86_64",
+			},
+			expected: "pkg:alpine/musl-utils@1.1.24-r2?arch=x86_64&upstream=musl",
+		},
+		{
+			metadata: ApkMetadata{
+				Package:       "musl",
+				OriginPackage: "musl",
+				Version:       "1.1.24-r2",
+				Architecture:  "x86_64",
+			},
+			expected: "pkg:alpine/musl@1.1.24-r2?arch=x86_64",
+		},
+		{
+			distro: &distro.Distro{
+				Type:       distro.Alpine,
+				RawVersion: "3.14.2",
+			},
+			metadata: ApkMetadata{
+				Package:       "musl-utils",
+				OriginPackage: "musl",
+				Version:       "1.2.2-r3",
+				Architecture:  "x86_64",
+			},
+			expected: "pkg:alpine/musl-utils@1.2.2-r3?arch=x86_64&distro=alpine-3.14.2&upstream=musl",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.expected, func(t *testing.T) {
-			actual := test.metadata.PackageURL()
+			actual := test.metadata.PackageURL(test.distro)
 			if actual != test.expected {
 				dmp := diffmatchpatch.New()
 				diffs := dmp.DiffMain(test.expected, actual, true)
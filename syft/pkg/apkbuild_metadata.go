@@ -0,0 +1,16 @@
+package pkg
+
+// ApkbuildMetadata represents all captured data for an Alpine package build recipe (an APKBUILD file), which describes
+// a package to be built from source rather than an installed package. Values that depend on the build environment
+// (e.g. command substitutions or variables that are only set conditionally) are not resolved, see
+// https://wiki.alpinelinux.org/wiki/APKBUILD_Reference for all fields.
+type ApkbuildMetadata struct {
+	Package     string   `json:"package"`
+	Version     string   `json:"version"`
+	Release     string   `json:"release"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Depends     []string `json:"depends,omitempty"`     // runtime dependencies of the package
+	MakeDepends []string `json:"makeDepends,omitempty"` // dependencies only needed to build the package
+}
@@ -0,0 +1,7 @@
+package pkg
+
+// BinaryMetadata represents all captured data for a runtime binary (e.g. a python interpreter) that was identified
+// from the binary itself, without any package manager metadata.
+type BinaryMetadata struct {
+	Classifier string `json:"classifier"`
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
 	"github.com/jinzhu/copier"
 
 	"github.com/anchore/syft/internal"
@@ -128,25 +129,49 @@ func (c *Catalog) Enumerate(types ...Type) <-chan Package {
 	return channel
 }
 
-// Sorted enumerates all packages for the given types sorted by package name. Enumerates all packages if no type
-// is specified.
+// Filter returns a new catalog with only the packages for which the given function returns true. The receiving catalog
+// is not modified (the given function is called with a copy of each package).
+func (c *Catalog) Filter(keep func(*Package) bool) *Catalog {
+	filtered := NewCatalog()
+	for p := range c.Enumerate() {
+		if keep(&p) {
+			filtered.Add(p)
+		}
+	}
+	return filtered
+}
+
+// Sorted enumerates all packages for the given types sorted by package type, name, version, and then locations
+// (starting with the first location). Enumerates all packages if no type is specified. Unlike Enumerate, the order is
+// stable between identical catalogs.
 func (c *Catalog) Sorted(types ...Type) (pkgs []Package) {
 	for p := range c.Enumerate(types...) {
 		pkgs = append(pkgs, p)
 	}
 
 	sort.SliceStable(pkgs, func(i, j int) bool {
-		if pkgs[i].Name == pkgs[j].Name {
-			if pkgs[i].Version == pkgs[j].Version {
-				if pkgs[i].Type == pkgs[j].Type {
-					return pkgs[i].Locations[0].String() < pkgs[j].Locations[0].String()
+		if pkgs[i].Type == pkgs[j].Type {
+			if pkgs[i].Name == pkgs[j].Name {
+				if pkgs[i].Version == pkgs[j].Version {
+					return lessLocations(pkgs[i].Locations, pkgs[j].Locations)
 				}
-				return pkgs[i].Type < pkgs[j].Type
+				return pkgs[i].Version < pkgs[j].Version
 			}
-			return pkgs[i].Version < pkgs[j].Version
+			return pkgs[i].Name < pkgs[j].Name
 		}
-		return pkgs[i].Name < pkgs[j].Name
+		return pkgs[i].Type < pkgs[j].Type
 	})
 
 	return pkgs
 }
+
+// lessLocations orders location lists by the first differing location, where a shorter list (e.g. no locations) is
+// ordered first.
+func lessLocations(a, b []source.Location) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].String() != b[i].String() {
+			return a[i].String() < b[i].String()
+		}
+	}
+	return len(a) < len(b)
+}
@@ -1,9 +1,12 @@
 package pkg
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/scylladb/go-set/strset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/source"
 )
@@ -144,3 +147,94 @@ func TestCatalog_PathIndexDeduplicatesRealVsVirtualPaths(t *testing.T) {
 	}
 
 }
+
+func TestCatalog_Sorted(t *testing.T) {
+	pkgs := []Package{
+		{
+			Name:      "b",
+			Version:   "1.0",
+			Type:      NpmPkg,
+			Locations: []source.Location{source.NewLocation("/z/path")},
+		},
+		{
+			Name:      "a",
+			Version:   "2.0",
+			Type:      NpmPkg,
+			Locations: []source.Location{source.NewLocation("/a/path")},
+		},
+		{
+			Name:    "a",
+			Version: "1.0",
+			Type:    RpmPkg,
+		},
+		{
+			Name:      "a",
+			Version:   "1.0",
+			Type:      NpmPkg,
+			Locations: []source.Location{source.NewLocation("/b/path")},
+		},
+		{
+			Name:      "a",
+			Version:   "1.0",
+			Type:      NpmPkg,
+			Locations: []source.Location{source.NewLocation("/a/path")},
+		},
+		{
+			Name:    "a",
+			Version: "1.0",
+			Type:    NpmPkg,
+		},
+	}
+
+	// a different insertion order must not affect the sorted result
+	forward := NewCatalog(pkgs...)
+	var reversed []Package
+	for i := len(pkgs) - 1; i >= 0; i-- {
+		reversed = append(reversed, pkgs[i])
+	}
+	backward := NewCatalog(reversed...)
+
+	expected := []string{
+		"a@1.0 npm []",
+		"a@1.0 npm [/a/path]",
+		"a@1.0 npm [/b/path]",
+		"a@2.0 npm [/a/path]",
+		"b@1.0 npm [/z/path]",
+		"a@1.0 rpm []",
+	}
+
+	for _, c := range []*Catalog{forward, backward} {
+		var actual []string
+		for _, p := range c.Sorted() {
+			var paths []string
+			for _, l := range p.Locations {
+				paths = append(paths, l.RealPath)
+			}
+			actual = append(actual, fmt.Sprintf("%s@%s %s %v", p.Name, p.Version, p.Type, paths))
+		}
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestCatalog_Filter(t *testing.T) {
+	c := NewCatalog(catalogAddAndRemoveTestPkgs...)
+
+	filtered := c.Filter(func(p *Package) bool {
+		return p.Type == RpmPkg
+	})
+
+	// the original catalog is not modified
+	assert.Equal(t, len(catalogAddAndRemoveTestPkgs), c.PackageCount())
+	for _, p := range catalogAddAndRemoveTestPkgs {
+		assert.NotNil(t, c.Package(p.ID()))
+	}
+
+	// the retained package is unchanged (including all locations) and is still indexed by path
+	require.Equal(t, 1, filtered.PackageCount())
+	expected := catalogAddAndRemoveTestPkgs[0]
+	actual := filtered.Package(expected.ID())
+	require.NotNil(t, actual)
+	assert.Equal(t, expected.Locations, actual.Locations)
+	assert.Len(t, filtered.PackagesByPath("/b/path"), 1)
+	assert.Empty(t, filtered.PackagesByPath("/c/path"))
+}
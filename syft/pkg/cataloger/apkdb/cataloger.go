@@ -1,5 +1,5 @@
 /*
-Package apkdb provides a concrete Cataloger implementation for Alpine DB files.
+Package apkdb provides concrete Cataloger implementations for Alpine DB files and APKBUILD files.
 */
 package apkdb
 
@@ -16,3 +16,12 @@ func NewApkdbCataloger() *common.GenericCataloger {
 
 	return common.NewGenericCataloger(nil, globParsers, "apkdb-cataloger")
 }
+
+// NewApkbuildCataloger returns a new cataloger object for the (source) packages declared by Alpine APKBUILD files.
+func NewApkbuildCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/APKBUILD": parseApkbuild,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "apkbuild-cataloger")
+}
@@ -0,0 +1,372 @@
+package apkdb
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseApkbuild
+
+// unresolved replaces the parts of an expanded value that cannot be known without running the APKBUILD (e.g. command
+// substitutions), so that values depending on them are never guessed.
+const unresolved = "\x00"
+
+var (
+	// functionPattern matches the start of a function definition (e.g. "build() {"), where the body of each function
+	// ends with a closing brace at the start of a line (as required by the APKBUILD style guide).
+	functionPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\s*\(\)`)
+	// assignmentPattern matches a variable assignment, capturing the variable name.
+	assignmentPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=`)
+	// variableNamePattern matches the name of a variable within a "$name" reference.
+	variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// compoundCommands are the shell keywords that open (and close) blocks, where assignments are conditional.
+var compoundCommands = map[string]int{
+	"if": 1, "case": 1, "for": 1, "while": 1, "until": 1,
+	"fi": -1, "esac": -1, "done": -1,
+}
+
+// parseApkbuild parses the package declared by an Alpine APKBUILD file (see
+// https://wiki.alpinelinux.org/wiki/APKBUILD_Reference). APKBUILD files are shell scripts, however, only top-level
+// variable assignments are interpreted: references to other variables are expanded, while values that depend on
+// anything else (such as command substitutions, parameter expansion operators, or variables that are only assigned
+// conditionally) are left unresolved. An unresolved version is omitted, and unresolved dependencies are skipped.
+func parseApkbuild(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read APKBUILD file: %w", err)
+	}
+
+	vars := parseApkbuildVariables(withoutFunctions(string(contents)))
+
+	name, ok := vars.resolved("pkgname")
+	if !ok || name == "" {
+		return nil, nil, fmt.Errorf("unable to determine the package name of the APKBUILD file")
+	}
+
+	metadata := pkg.ApkbuildMetadata{
+		Package:     name,
+		Version:     vars.resolvedOrEmpty("pkgver"),
+		Release:     vars.resolvedOrEmpty("pkgrel"),
+		Description: vars.resolvedOrEmpty("pkgdesc"),
+		URL:         vars.resolvedOrEmpty("url"),
+		License:     vars.resolvedOrEmpty("license"),
+		Depends:     vars.resolvedFields("depends"),
+		MakeDepends: vars.resolvedFields("makedepends"),
+	}
+
+	// the version of the built package is the same as the version of the installed package (e.g. "1.2.3-r0")
+	version := metadata.Version
+	if version != "" && metadata.Release != "" {
+		version = fmt.Sprintf("%s-r%s", metadata.Version, metadata.Release)
+	}
+
+	var licenses []string
+	if metadata.License != "" {
+		licenses = strings.Fields(metadata.License)
+	}
+
+	return []pkg.Package{
+		{
+			Name:         name,
+			Version:      version,
+			Licenses:     licenses,
+			Type:         pkg.ApkbuildPkg,
+			MetadataType: pkg.ApkbuildMetadataType,
+			Metadata:     metadata,
+		},
+	}, nil, nil
+}
+
+// withoutFunctions removes the body of every function definition (e.g. the build and package functions), since the
+// assignments within functions only apply while building the package.
+func withoutFunctions(contents string) string {
+	var sb strings.Builder
+	inFunction := false
+	for _, line := range strings.Split(contents, "\n") {
+		switch {
+		case inFunction:
+			inFunction = !strings.HasPrefix(line, "}")
+		case functionPattern.MatchString(line):
+			// note: single line functions (e.g. "f() { :; }") end on the same line
+			inFunction = !strings.HasSuffix(strings.TrimSpace(line), "}")
+		default:
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// apkbuildVariables are the expanded values of the variables assigned within an APKBUILD file, where values that could
+// not be fully expanded contain the unresolved marker.
+type apkbuildVariables map[string]string
+
+// parseApkbuildVariables interprets every variable assignment outside of functions, in order. Assignments that may
+// not always happen (e.g. within an if or case block, or after "&&") leave the variable unresolved, except for values
+// that are appended to (e.g. makedepends="$makedepends libatomic"), where only the appended values are unresolved.
+func parseApkbuildVariables(contents string) apkbuildVariables {
+	vars := make(apkbuildVariables)
+	depth := 0
+	for _, words := range splitStatements(contents) {
+		if change, ok := compoundCommands[words[0]]; ok {
+			depth += change
+		}
+
+		conditional := depth > 0
+		for _, word := range words {
+			match := assignmentPattern.FindStringSubmatch(word)
+			if match == nil {
+				// a command or keyword, where any (later) assignments depend on the outcome
+				conditional = true
+				continue
+			}
+
+			name := match[1]
+			value := vars.expand(strings.TrimPrefix(word, name+"="))
+			if conditional {
+				previous, exists := vars[name]
+				if exists && previous != "" && strings.HasPrefix(value, previous) {
+					value = previous + " " + unresolved
+				} else {
+					value = unresolved
+				}
+			}
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// resolved returns the value of the given variable, indicating if the variable was assigned and fully resolved.
+func (v apkbuildVariables) resolved(name string) (string, bool) {
+	value, exists := v[name]
+	if !exists || strings.Contains(value, unresolved) {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+// resolvedOrEmpty returns the value of the given variable, which is empty unless the value is fully resolved.
+func (v apkbuildVariables) resolvedOrEmpty(name string) string {
+	value, _ := v.resolved(name)
+	return value
+}
+
+// resolvedFields returns the whitespace separated values of the given variable (e.g. the dependencies of the
+// package), skipping any value that is not fully resolved.
+func (v apkbuildVariables) resolvedFields(name string) (results []string) {
+	for _, field := range strings.Fields(v[name]) {
+		if !strings.Contains(field, unresolved) {
+			results = append(results, field)
+		}
+	}
+	return results
+}
+
+// expand removes the quoting of the given (raw) shell word and expands references to the variables assigned so far.
+func (v apkbuildVariables) expand(word string) string {
+	var sb strings.Builder
+	inDoubleQuotes := false
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		switch {
+		case c == '\'' && !inDoubleQuotes:
+			// no expansion within single quotes
+			end := i + 1 + strings.IndexByte(word[i+1:], '\'')
+			if end <= i {
+				end = len(word)
+			}
+			sb.WriteString(word[i+1 : end])
+			i = end
+		case c == '"':
+			inDoubleQuotes = !inDoubleQuotes
+		case c == '\\' && i+1 < len(word):
+			// within double quotes, a backslash only escapes characters that are otherwise special
+			next := word[i+1]
+			if inDoubleQuotes && !strings.ContainsRune("$`\"\\", rune(next)) {
+				sb.WriteByte(c)
+			}
+			sb.WriteByte(next)
+			i++
+		case c == '`':
+			// a command substitution
+			end := i + 1 + strings.IndexByte(word[i+1:], '`')
+			if end <= i {
+				end = len(word)
+			}
+			sb.WriteString(unresolved)
+			i = end
+		case c == '$':
+			value, length := v.expandReference(word[i+1:])
+			sb.WriteString(value)
+			i += length
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// expandReference expands the variable reference that follows a "$" (e.g. "name" or "{name}"), returning the value
+// and the length of the reference.
+func (v apkbuildVariables) expandReference(s string) (string, int) {
+	switch {
+	case strings.HasPrefix(s, "{"):
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return unresolved, len(s)
+		}
+		name := s[1:end]
+		if !variableNamePattern.MatchString(name) || variableNamePattern.FindString(name) != name {
+			// a parameter expansion operator (e.g. "${pkgver%.*}" or "${pkgver//./_}")
+			return unresolved, end + 1
+		}
+		return v.lookup(name), end + 1
+	case strings.HasPrefix(s, "("):
+		// a command substitution (or arithmetic expansion), which may be nested
+		depth := 0
+		for i := 0; i < len(s); i++ {
+			switch s[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					return unresolved, i + 1
+				}
+			}
+		}
+		return unresolved, len(s)
+	}
+
+	name := variableNamePattern.FindString(s)
+	if name == "" {
+		if s != "" && strings.ContainsRune("0123456789@*#?$!-", rune(s[0])) {
+			// a positional or special parameter
+			return unresolved, 1
+		}
+		return "$", 0
+	}
+	return v.lookup(name), len(name)
+}
+
+// lookup returns the value of the given variable, which is unresolved when the variable has not been assigned (e.g. a
+// variable set by abuild, such as $srcdir or $CARCH).
+func (v apkbuildVariables) lookup(name string) string {
+	value, exists := v[name]
+	if !exists {
+		return unresolved
+	}
+	return value
+}
+
+// splitStatements splits the given shell script into statements (separated by unquoted newlines or semicolons), each
+// of which is a list of raw (unexpanded) words. Comments are removed.
+func splitStatements(script string) (statements [][]string) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endStatement := func() {
+		endWord()
+		if len(words) > 0 {
+			statements = append(statements, words)
+			words = nil
+		}
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// quoted strings (which may span lines) are part of the current word, as-is
+			end := closingQuote(script, i)
+			word.WriteString(script[i:end])
+			inWord = true
+			i = end - 1
+		case c == '$' && i+1 < len(script) && (script[i+1] == '(' || script[i+1] == '{'):
+			// command substitutions and parameter expansions (which may contain spaces) are part of the current word
+			end := closingBracket(script, i+1)
+			word.WriteString(script[i:end])
+			inWord = true
+			i = end - 1
+		case c == '\\' && i+1 < len(script):
+			if script[i+1] != '\n' {
+				word.WriteString(script[i : i+2])
+				inWord = true
+			}
+			i++
+		case c == '#' && !inWord:
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			endStatement()
+		case c == '\n' || c == ';':
+			endStatement()
+		case c == ' ' || c == '\t':
+			endWord()
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	endStatement()
+
+	return statements
+}
+
+// closingQuote returns the index after the quote that closes the quote at the given index (or the end of the script
+// if the quote is never closed). Within double quotes, a backslash escapes the next character.
+func closingQuote(script string, start int) int {
+	quote := script[start]
+	for i := start + 1; i < len(script); i++ {
+		switch {
+		case script[i] == '\\' && quote == '"':
+			i++
+		case script[i] == quote:
+			return i + 1
+		}
+	}
+	return len(script)
+}
+
+// closingBracket returns the index after the bracket that closes the bracket at the given index (or the end of the
+// script if the bracket is never closed), accounting for nested brackets.
+func closingBracket(script string, start int) int {
+	open := script[start]
+	closing := byte(')')
+	if open == '{' {
+		closing = '}'
+	}
+
+	depth := 0
+	for i := start; i < len(script); i++ {
+		switch script[i] {
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(script)
+}
@@ -0,0 +1,146 @@
+package apkdb
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseApkbuild(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected pkg.Package
+	}{
+		{
+			fixture: "test-fixtures/apkbuild/curl/APKBUILD",
+			expected: pkg.Package{
+				Name:         "curl",
+				Version:      "7.83.1-r0",
+				Licenses:     []string{"MIT"},
+				Type:         pkg.ApkbuildPkg,
+				MetadataType: pkg.ApkbuildMetadataType,
+				Metadata: pkg.ApkbuildMetadata{
+					Package:     "curl",
+					Version:     "7.83.1",
+					Release:     "0",
+					Description: "URL retrieval utility and library",
+					URL:         "https://curl.se/",
+					License:     "MIT",
+					Depends:     []string{"ca-certificates"},
+					// note: the makedepends conditionally appended for some architectures are skipped
+					MakeDepends: []string{"brotli-dev", "nghttp2-dev", "openssl-dev", "zlib-dev", "autoconf", "automake", "groff", "libtool", "perl"},
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/apkbuild/unresolved-version/APKBUILD",
+			expected: pkg.Package{
+				Name:         "py3-example",
+				Licenses:     []string{"Apache-2.0"},
+				Type:         pkg.ApkbuildPkg,
+				MetadataType: pkg.ApkbuildMetadataType,
+				Metadata: pkg.ApkbuildMetadata{
+					Package:     "py3-example",
+					Release:     "1",
+					Description: `An "example" package; with a semicolon`,
+					URL:         "https://example.com/py3-example/",
+					License:     "Apache-2.0",
+					Depends:     []string{"python3", "py3-setuptools"},
+					MakeDepends: []string{"py3-gpep517"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			if err != nil {
+				t.Fatalf("failed to open fixture: %+v", err)
+			}
+			defer fixture.Close()
+
+			actual, _, err := parseApkbuild(fixture.Name(), fixture)
+			if err != nil {
+				t.Fatalf("failed to parse APKBUILD: %+v", err)
+			}
+
+			if len(actual) != 1 {
+				t.Fatalf("unexpected number of packages: %d", len(actual))
+			}
+
+			for _, d := range deep.Equal(actual[0], test.expected) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
+
+func TestParseApkbuild_unresolvedName(t *testing.T) {
+	contents := "pkgname=$(basename $PWD)\npkgver=1.0.0\npkgrel=0\n"
+
+	if _, _, err := parseApkbuild("APKBUILD", strings.NewReader(contents)); err == nil {
+		t.Errorf("expected an error for an APKBUILD without a resolvable package name")
+	}
+}
+
+func TestParseApkbuildVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected apkbuildVariables
+	}{
+		{
+			name:     "references to earlier variables",
+			contents: "_name=libfoo\npkgname=$_name-dev\npkgver=1.2\n_dir=\"${pkgname}-$pkgver\"",
+			expected: apkbuildVariables{"_name": "libfoo", "pkgname": "libfoo-dev", "pkgver": "1.2", "_dir": "libfoo-dev-1.2"},
+		},
+		{
+			name:     "quoting",
+			contents: `a='$single "quoted"' b="double 'quoted' \$x \n" c=un\ quoted`,
+			expected: apkbuildVariables{"a": `$single "quoted"`, "b": `double 'quoted' $x \n`, "c": "un quoted"},
+		},
+		{
+			name:     "statements separated by semicolons and comments",
+			contents: "a=1; b=2 # c=3\n#d=4\ne=\"#5\"",
+			expected: apkbuildVariables{"a": "1", "b": "2", "e": "#5"},
+		},
+		{
+			name:     "unassigned variables and parameter expansion operators",
+			contents: "a=$srcdir/x b=${a%/*} c=${d:-default} e=$1",
+			expected: apkbuildVariables{"a": unresolved + "/x", "b": unresolved, "c": unresolved, "e": unresolved},
+		},
+		{
+			name:     "command substitutions",
+			contents: "a=$(echo \"x; y\") b=`date` c=\"v$(cat VERSION)\"",
+			expected: apkbuildVariables{"a": unresolved, "b": unresolved, "c": "v" + unresolved},
+		},
+		{
+			name:     "conditional assignments",
+			contents: "a=1\nb=x\nif true; then\n\ta=2\n\tb=\"$b y\"\nfi\n[ -n \"$a\" ] && c=3\nd=4",
+			expected: apkbuildVariables{"a": unresolved, "b": "x " + unresolved, "c": unresolved, "d": "4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := parseApkbuildVariables(test.contents)
+			for _, d := range deep.Equal(actual, test.expected) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
+
+func TestWithoutFunctions(t *testing.T) {
+	contents := "pkgname=a\nbuild() {\n\tpkgname=b\n}\nf() { :; }\npkgver=1\npackage()\n{\n\tmake\n}\npkgrel=0\n"
+	expected := "pkgname=a\npkgver=1\npkgrel=0\n\n"
+
+	if actual := withoutFunctions(contents); actual != expected {
+		t.Errorf("unexpected contents: %q", actual)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Package binary provides a concrete Cataloger implementation for runtime binaries (e.g. python, node, and java) and C
+libraries (glibc and musl) that are not otherwise described by any package manager metadata, such as within scratch or
+distroless images.
+*/
+package binary
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
+)
+
+const catalogerName = "binary-cataloger"
+
+// Cataloger identifies runtime binaries by file type (executables and shared libraries, determined by magic) and
+// path, extracting the version from the binary contents on a best-effort basis (the version is omitted when unknown).
+type Cataloger struct {
+	classifiers []classifier
+}
+
+// NewCataloger returns a new cataloger object for runtime binaries.
+func NewCataloger() *Cataloger {
+	return &Cataloger{
+		classifiers: defaultClassifiers,
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *Cataloger) Name() string {
+	return catalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns any discovered Packages
+// after analyzing executables and shared libraries that look like runtime binaries.
+func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find binaries by mime types: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	var parseErrs common.ParseErrors
+	for _, location := range locations {
+		for _, cls := range c.classifiers {
+			if !cls.matchesPath(location.RealPath, location.VirtualPath) {
+				continue
+			}
+
+			p, err := c.catalogBinary(resolver, location, cls)
+			if err != nil {
+				log.Warnf("cataloger '%s' failed to classify binary (location=%+v): %+v", c.Name(), location, err)
+				parseErrs = append(parseErrs, common.ParseError{Cataloger: c.Name(), Location: location, Err: err})
+				continue
+			}
+			pkgs = append(pkgs, p)
+		}
+	}
+
+	return pkgs, nil, parseErrs.OrNil()
+}
+
+func (c *Cataloger) catalogBinary(resolver source.FileResolver, location source.Location, cls classifier) (pkg.Package, error) {
+	classification, err := cls.Classify(resolver, location)
+	if err != nil {
+		return pkg.Package{}, err
+	}
+
+	// note: the binary is still reported when no version could be found (it is only omitted)
+	var version string
+	if classification != nil {
+		version = classification.Metadata["version"]
+	}
+
+	return pkg.Package{
+		Name:         cls.Package,
+		Version:      version,
+		FoundBy:      c.Name(),
+		Locations:    []source.Location{location},
+		Type:         pkg.BinaryPkg,
+		MetadataType: pkg.BinaryMetadataType,
+		Metadata: pkg.BinaryMetadata{
+			Classifier: cls.Class,
+		},
+	}, nil
+}
@@ -0,0 +1,137 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCataloger_python(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/python")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, _, err := NewCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	// note: the script named like a python binary is not an executable (by magic), so it is not reported
+	expected := map[string]string{
+		"test-fixtures/python/usr/bin/python3.9": "3.9.7",
+		// the version cannot be determined, so it is omitted
+		"test-fixtures/python/usr/local/bin/python3.10": "",
+		// the version from the path is matched literally (the "." is not a wildcard matching "3a7.12")
+		"test-fixtures/python/usr/bin/python3.7": "",
+	}
+
+	actual := make(map[string]string)
+	for _, p := range pkgs {
+		require.Len(t, p.Locations, 1)
+		actual[p.Locations[0].RealPath] = p.Version
+
+		assert.Equal(t, "python", p.Name)
+		assert.Equal(t, pkg.BinaryPkg, p.Type)
+		assert.Equal(t, "binary-cataloger", p.FoundBy)
+		assert.Equal(t, pkg.BinaryMetadataType, p.MetadataType)
+		assert.Equal(t, pkg.BinaryMetadata{Classifier: "python-binary"}, p.Metadata)
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestCataloger_libc(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			fixture: "test-fixtures/libc/alpine",
+			expected: []pkg.Package{
+				{
+					Name:     "musl",
+					Version:  "1.2.3",
+					Metadata: pkg.BinaryMetadata{Classifier: "musl-binary"},
+				},
+			},
+		},
+		{
+			// any other NUL-delimited x.y.z (without the banner of the loader) is not the version of musl
+			fixture: "test-fixtures/libc/unanchored",
+			expected: []pkg.Package{
+				{
+					Name:     "musl",
+					Metadata: pkg.BinaryMetadata{Classifier: "musl-binary"},
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/libc/debian",
+			expected: []pkg.Package{
+				{
+					Name:     "glibc",
+					Version:  "2.31",
+					Metadata: pkg.BinaryMetadata{Classifier: "glibc-binary"},
+				},
+			},
+		},
+		{
+			// the libc within a statically linked binary is not reported (there is no shared library to identify)
+			fixture: "test-fixtures/libc/static",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			src, err := source.NewFromDirectory(test.fixture)
+			require.NoError(t, err)
+
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			pkgs, _, err := NewCataloger().Catalog(resolver)
+			require.NoError(t, err)
+			require.Len(t, pkgs, len(test.expected))
+
+			for i, p := range pkgs {
+				expected := test.expected[i]
+				assert.Equal(t, expected.Name, p.Name)
+				assert.Equal(t, expected.Version, p.Version)
+				assert.Equal(t, expected.Metadata, p.Metadata)
+				assert.Equal(t, pkg.BinaryPkg, p.Type)
+			}
+		})
+	}
+}
+
+func Test_classifier_matchesPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/usr/bin/python3.9", expected: "python"},
+		{path: "/usr/lib/libpython3.9.so.1.0", expected: "python"},
+		{path: "/usr/bin/python3.9-config", expected: ""},
+		{path: "/usr/local/bin/node", expected: "node"},
+		{path: "/usr/local/bin/nodejs-helper", expected: ""},
+		{path: "/usr/lib/jvm/java-11-openjdk/bin/java", expected: "java"},
+		{path: "/usr/lib/jvm/java-11-openjdk/bin/javac", expected: ""},
+		{path: "/lib/x86_64-linux-gnu/libc.so.6", expected: "glibc"},
+		{path: "/lib/x86_64-linux-gnu/libc-2.28.so", expected: "glibc"},
+		{path: "/usr/lib/x86_64-linux-gnu/libc.so", expected: ""},
+		{path: "/lib/ld-musl-x86_64.so.1", expected: "musl"},
+		{path: "/lib/libc.musl-aarch64.so.1", expected: "musl"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			var actual string
+			for _, cls := range defaultClassifiers {
+				if cls.matchesPath(test.path) {
+					actual = cls.Package
+				}
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
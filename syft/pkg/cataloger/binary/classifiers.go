@@ -0,0 +1,102 @@
+package binary
+
+import (
+	"regexp"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+// classifier identifies a runtime binary by path (and extracts the version from the binary contents where feasible).
+type classifier struct {
+	// Package is the name of the package that is reported for matching binaries
+	Package string
+	file.Classifier
+}
+
+var defaultClassifiers = []classifier{
+	{
+		Package: "python",
+		Classifier: file.Classifier{
+			Class: "python-binary",
+			FilepathPatterns: []*regexp.Regexp{
+				regexp.MustCompile(`(.*/|^)python(?P<version>[0-9]+\.[0-9]+)$`),
+				regexp.MustCompile(`(.*/|^)libpython(?P<version>[0-9]+\.[0-9]+)\.so.*$`),
+			},
+			EvidencePatternTemplates: []string{
+				`(?m)(?P<version>{{ .version }}\.[0-9]+[-_a-zA-Z0-9]*)`,
+			},
+		},
+	},
+	{
+		Package: "node",
+		Classifier: file.Classifier{
+			Class: "node-binary",
+			FilepathPatterns: []*regexp.Regexp{
+				regexp.MustCompile(`(.*/|^)node$`),
+			},
+			EvidencePatternTemplates: []string{
+				`(?m)node\.js/v(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`,
+			},
+		},
+	},
+	{
+		Package: "java",
+		Classifier: file.Classifier{
+			Class: "java-binary",
+			FilepathPatterns: []*regexp.Regexp{
+				regexp.MustCompile(`(.*/|^)java$`),
+			},
+			EvidencePatternTemplates: []string{
+				// the launcher embeds the launcher name, the release, and the full version as consecutive strings
+				`(?m)\x00java\x00(?P<release>[0-9]+[.0-9]*)\x00(?P<version>[0-9]+[^\x00]+)\x00`,
+			},
+		},
+	},
+	{
+		// note: only the shared library is considered, so statically linked binaries (which embed libc) do not report
+		// a libc package, since the version they embed cannot be reliably determined
+		Package: "glibc",
+		Classifier: file.Classifier{
+			Class: "glibc-binary",
+			FilepathPatterns: []*regexp.Regexp{
+				regexp.MustCompile(`(.*/|^)libc\.so\.6$`),
+				regexp.MustCompile(`(.*/|^)libc-[0-9]+\.[0-9]+\.so$`),
+			},
+			EvidencePatternTemplates: []string{
+				// e.g. "GNU C Library (Debian GLIBC 2.31-13+deb11u5) stable release version 2.31."
+				`(?m)GNU C Library [^\x00\n]*release version (?P<version>[0-9]+\.[0-9]+(\.[0-9]+)?)`,
+			},
+		},
+	},
+	{
+		Package: "musl",
+		Classifier: file.Classifier{
+			Class: "musl-binary",
+			FilepathPatterns: []*regexp.Regexp{
+				// the dynamic loader is also the C library (alpine links libc.musl-<arch>.so.1 to it)
+				regexp.MustCompile(`(.*/|^)ld-musl-[^/]+\.so\.1$`),
+				regexp.MustCompile(`(.*/|^)libc\.musl-[^/]+\.so\.1$`),
+			},
+			EvidencePatternTemplates: []string{
+				// the version is a standalone string (printed as "Version 1.2.3" when the loader is run directly), which
+				// is only trusted after the banner of the loader (any other NUL-delimited x.y.z could be anything)
+				`(?s)musl libc \([^)\x00\n]+\)\nVersion %s\n.*?\x00(?P<version>[0-9]+\.[0-9]+\.[0-9]+)\x00`,
+			},
+		},
+	},
+}
+
+// matchesPath indicates if the given path is a candidate binary for this classifier.
+func (c classifier) matchesPath(paths ...string) bool {
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		for _, pattern := range c.FilepathPatterns {
+			if pattern.MatchString(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package cataloger
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/anchore/syft/internal/bus"
 	"github.com/anchore/syft/internal/log"
@@ -9,6 +11,7 @@ import (
 	"github.com/anchore/syft/syft/distro"
 	"github.com/anchore/syft/syft/event"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
 	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
 	"github.com/anchore/syft/syft/source"
 	"github.com/hashicorp/go-multierror"
@@ -37,65 +40,251 @@ func newMonitor() (*progress.Manual, *progress.Manual) {
 	return &filesProcessed, &packagesDiscovered
 }
 
+// parallelCataloger is implemented by catalogers that are able to parse several files concurrently (reusing the parse
+// results for each file from the given cache, if any).
+type parallelCataloger interface {
+	CatalogInParallel(resolver source.FileResolver, cache common.ParseCache, parallelism int) ([]pkg.Package, []artifact.Relationship, error)
+}
+
+// importingCataloger is implemented by catalogers that import packages as declared by another document (e.g. an
+// embedded SBOM), where the declared CPEs and PURL are kept (rather than generated).
+type importingCataloger interface {
+	ImportsPackages() bool
+}
+
+// catalogResult is the output of a single cataloger run.
+type catalogResult struct {
+	packages      []pkg.Package
+	relationships []artifact.Relationship
+	parseErrors   common.ParseErrors // the files that failed to parse (where the results of all other files are kept)
+	err           error
+}
+
 // Catalog a given source (container image or filesystem) with the given catalogers, returning all discovered packages.
 // In order to efficiently retrieve contents from a underlying container image the content fetch requests are
 // done in bulk. Specifically, all files of interest are collected from each catalogers and accumulated into a single
-// request.
-func Catalog(resolver source.FileResolver, theDistro *distro.Distro, catalogers ...Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+// request. Up to the configured parallelism, catalogers are run concurrently (results are always merged in the order
+// that the catalogers are given, so the results are the same regardless of parallelism). Files that fail to parse are
+// skipped with a warning, unless configured to fail (see Config.FailOnError), where common.ParseErrors describing every
+// such file are returned instead.
+func Catalog(resolver source.FileResolver, theDistro *distro.Distro, cfg Config, catalogers ...Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
 	catalog := pkg.NewCatalog()
+	var allPackages []pkg.Package
 	var allRelationships []artifact.Relationship
 
 	filesProcessed, packagesDiscovered := newMonitor()
 
+	var cache *layerCache
+	if cfg.LayerCacheDir != "" {
+		var err error
+		cache, err = newLayerCache(cfg.LayerCacheDir)
+		if err != nil {
+			log.Warnf("cataloging without the layer cache: %+v", err)
+		} else {
+			defer cache.logStatistics()
+		}
+	}
+
 	// perform analysis, accumulating errors for each failed analysis
 	var errs error
-	for _, theCataloger := range catalogers {
-		// find packages from the underlying raw data
-		packages, relationships, err := theCataloger.Catalog(resolver)
-		if err != nil {
-			errs = multierror.Append(errs, err)
+	var parseErrs common.ParseErrors
+	for idx, result := range runCatalogers(resolver, theDistro, cfg, cache, catalogers) {
+		if result.err != nil {
+			errs = multierror.Append(errs, result.err)
 			continue
 		}
+		parseErrs = append(parseErrs, result.parseErrors...)
 
-		catalogedPackages := len(packages)
+		catalogedPackages := len(result.packages)
 
-		log.Debugf("package cataloger %q discovered %d packages", theCataloger.Name(), catalogedPackages)
+		log.Debugf("package cataloger %q discovered %d packages", catalogers[idx].Name(), catalogedPackages)
 		packagesDiscovered.N += int64(catalogedPackages)
 
-		for _, p := range packages {
-			// generate CPEs
-			p.CPEs = cpe.Generate(p)
-
-			// generate PURL
-			p.PURL = generatePackageURL(p, theDistro)
+		allPackages = append(allPackages, result.packages...)
+		allRelationships = append(allRelationships, result.relationships...)
+	}
 
-			// create file-to-package relationships for files owned by the package
-			owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
-			if err != nil {
-				log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
-			} else {
-				allRelationships = append(allRelationships, owningRelationships...)
-			}
+	if errs != nil {
+		return nil, nil, errs
+	}
 
-			// add to catalog
-			catalog.Add(p)
+	if len(parseErrs) > 0 {
+		if cfg.FailOnError {
+			return nil, nil, parseErrs
 		}
-
-		allRelationships = append(allRelationships, relationships...)
+		log.Warnf("cataloging is incomplete, since %d files failed to parse (see the warnings above)", len(parseErrs))
 	}
 
-	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
+	allPackages, allRelationships = reconcileOSOwnedPackages(allPackages, allRelationships)
 
-	if errs != nil {
-		return nil, nil, errs
+	if !cfg.SkipDeduplication {
+		allPackages, allRelationships = Deduplicate(allPackages, allRelationships)
 	}
 
+	for _, p := range allPackages {
+		// add to catalog
+		catalog.Add(p)
+	}
+
+	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
+
 	filesProcessed.SetCompleted()
 	packagesDiscovered.SetCompleted()
 
 	return catalog, allRelationships, nil
 }
 
+// runCatalogers runs all given catalogers with a bounded pool of workers, returning the results in the same order as
+// the given catalogers.
+func runCatalogers(resolver source.FileResolver, theDistro *distro.Distro, cfg Config, cache *layerCache, catalogers []Cataloger) []catalogResult {
+	parallelism := cfg.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(catalogers) {
+		parallelism = len(catalogers)
+	}
+
+	results := make([]catalogResult, len(catalogers))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// each worker only writes to the result slots for the catalogers it has been given
+			for idx := range indexes {
+				results[idx] = runCataloger(resolver, theDistro, cfg, cache, catalogers[idx])
+			}
+		}()
+	}
+
+	for idx := range catalogers {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// runCataloger finds packages with the given cataloger (reusing cached parse results when a cache is given and parsing
+// up to the configured parallelism of files concurrently, when the cataloger supports it), enriching each package with CPEs and a PURL unless imported (and a normalized version and
+// licenses concluded from the package files, if configured) and creating relationships to all files owned by each
+// package (unless configured otherwise).
+func runCataloger(resolver source.FileResolver, theDistro *distro.Distro, cfg Config, cache *layerCache, theCataloger Cataloger) catalogResult {
+	// find packages from the underlying raw data
+	var packages []pkg.Package
+	var relationships []artifact.Relationship
+	var err error
+	var parseCache common.ParseCache
+	if cache != nil {
+		parseCache = cache
+	}
+	if parallel, ok := theCataloger.(parallelCataloger); ok {
+		packages, relationships, err = parallel.CatalogInParallel(resolver, parseCache, cfg.Parallelism)
+	} else if cacheable, ok := theCataloger.(cacheableCataloger); ok && parseCache != nil {
+		packages, relationships, err = cacheable.CatalogWithCache(resolver, parseCache)
+	} else {
+		packages, relationships, err = theCataloger.Catalog(resolver)
+	}
+
+	// the files that failed to parse are only reported, keeping the packages found within all other files
+	var parseErrs common.ParseErrors
+	if errors.As(err, &parseErrs) {
+		err = nil
+	}
+	if err != nil {
+		return catalogResult{err: err}
+	}
+
+	// packages imported from another document keep the declared CPEs and PURL
+	imported := false
+	if i, ok := theCataloger.(importingCataloger); ok {
+		imported = i.ImportsPackages()
+	}
+
+	var allRelationships []artifact.Relationship
+	var results []pkg.Package
+	enriched := make(map[artifact.ID]pkg.Package)
+	observed := make(map[artifact.ID]artifact.ID) // canonical package IDs to the original ID of the package kept
+	aliases := make(map[artifact.ID]artifact.ID)  // original IDs of packages not kept to the original ID of the package kept
+	for _, p := range packages {
+		originalID := p.ID()
+
+		// the same file may be reached through symlinks (e.g. a symlinked project directory), where only the canonical
+		// location is kept. A package that is then the same as another package was only found again through an alias
+		// of the same file, thus is not reported again (even when packages are not deduplicated).
+		p.Locations = source.CanonicalLocations(p.Locations)
+		if keptID, exists := observed[p.ID()]; exists {
+			aliases[originalID] = keptID
+			continue
+		}
+		observed[p.ID()] = originalID
+
+		// generate CPEs (without any suppressed by the configured rules), unless the package was imported with CPEs
+		// already (e.g. as declared by an embedded SBOM)
+		if !imported || len(p.CPEs) == 0 {
+			p.CPEs = cpe.Generate(p)
+		}
+		p.CPEs = cfg.CPERules.Apply(p.CPEs, p)
+
+		// generate PURL (unless the package was imported with a PURL already)
+		if !imported || p.PURL == "" {
+			p.PURL = generatePackageURL(p, theDistro)
+		}
+
+		if cfg.NormalizeVersions {
+			p.NormalizedVersion = normalizeVersion(p)
+		}
+
+		if cfg.DetectLicenses {
+			p = concludeLicenses(p, resolver)
+		}
+
+		results = append(results, p)
+		enriched[originalID] = p
+
+		if cfg.SkipFileOwnership {
+			continue
+		}
+
+		// create file-to-package relationships for files owned by the package
+		owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
+		if err != nil {
+			log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
+		} else {
+			allRelationships = append(allRelationships, owningRelationships...)
+		}
+	}
+
+	for aliasID, keptID := range aliases {
+		enriched[aliasID] = enriched[keptID]
+	}
+
+	// the relationships found by the cataloger refer to packages without CPEs and PURLs (thus with different IDs)
+	for _, r := range relationships {
+		if p, ok := r.From.(pkg.Package); ok {
+			if e, exists := enriched[p.ID()]; exists {
+				r.From = e
+			}
+		}
+		if p, ok := r.To.(pkg.Package); ok {
+			if e, exists := enriched[p.ID()]; exists {
+				r.To = e
+			}
+		}
+		allRelationships = append(allRelationships, r)
+	}
+
+	return catalogResult{
+		packages:      results,
+		relationships: allRelationships,
+		parseErrors:   parseErrs,
+	}
+}
+
 func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathResolver) ([]artifact.Relationship, error) {
 	fileOwner, ok := p.Metadata.(pkg.FileOwner)
 	if !ok {
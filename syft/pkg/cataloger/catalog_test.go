@@ -0,0 +1,765 @@
+package cataloger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+	"github.com/anchore/syft/syft/pkg/cataloger/kernel"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockfiles are written into every project directory of a generated lockfile tree, along with the number of packages
+// that should be discovered from each. The contents are formatted with the project number, so that every project has
+// distinct package versions (otherwise the same packages found in each project would be deduplicated).
+var lockfiles = map[string]struct {
+	contents string
+	packages int
+}{
+	"requirements.txt": {
+		contents: "requests==2.26.%d\nflask==2.0.%d\n",
+		packages: 2,
+	},
+	"Gemfile.lock": {
+		contents: "GEM\n  remote: https://rubygems.org/\n  specs:\n    rake (13.0.%d)\n    rack (2.2.%d)\n",
+		packages: 2,
+	},
+	"go.mod": {
+		contents: "module example.com/project\n\ngo 1.17\n\nrequire github.com/google/uuid v1.3.%d\n",
+		packages: 1,
+	},
+}
+
+// writeLockfileTree creates a directory with the given number of project directories (each with every lockfile),
+// returning the root path and the number of packages that should be discovered within it.
+func writeLockfileTree(tb testing.TB, projects int) (string, int) {
+	tb.Helper()
+
+	root := tb.TempDir()
+	expected := 0
+	for i := 0; i < projects; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("project-%d", i))
+		require.NoError(tb, os.MkdirAll(dir, 0755))
+		for name, lockfile := range lockfiles {
+			contents := strings.ReplaceAll(lockfile.contents, "%d", strconv.Itoa(i))
+			require.NoError(tb, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+			expected += lockfile.packages
+		}
+	}
+	return root, expected
+}
+
+func newDirectoryResolver(tb testing.TB, root string) source.FileResolver {
+	tb.Helper()
+
+	src, err := source.NewFromDirectory(root)
+	require.NoError(tb, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(tb, err)
+	return resolver
+}
+
+func TestCatalog_Parallelism(t *testing.T) {
+	root, expectedPackages := writeLockfileTree(t, 25)
+	resolver := newDirectoryResolver(t, root)
+
+	sequential, sequentialRelationships, err := Catalog(resolver, nil, DefaultConfig(), DirectoryCatalogers()...)
+	require.NoError(t, err)
+	assert.Equal(t, expectedPackages, sequential.PackageCount())
+
+	for _, parallelism := range []int{0, 2, 4, 32} {
+		t.Run(fmt.Sprintf("parallelism=%d", parallelism), func(t *testing.T) {
+			actual, actualRelationships, err := Catalog(resolver, nil, Config{Parallelism: parallelism}, DirectoryCatalogers()...)
+			require.NoError(t, err)
+
+			// no packages may be lost (or duplicated) when catalogers run concurrently...
+			assert.Equal(t, expectedPackages, actual.PackageCount())
+			assert.Len(t, actualRelationships, len(sequentialRelationships))
+
+			// ...and the results must be the same as cataloging sequentially
+			assert.Equal(t, packageIDs(sequential), packageIDs(actual))
+		})
+	}
+}
+
+func TestCatalog_ParallelismWithErrors(t *testing.T) {
+	root, _ := writeLockfileTree(t, 1)
+	resolver := newDirectoryResolver(t, root)
+
+	catalogers := append(DirectoryCatalogers(), &failingCataloger{})
+
+	for _, parallelism := range []int{1, 4} {
+		t.Run(fmt.Sprintf("parallelism=%d", parallelism), func(t *testing.T) {
+			_, _, err := Catalog(resolver, nil, Config{Parallelism: parallelism}, catalogers...)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCatalog_FailOnError(t *testing.T) {
+	root, expectedPackages := writeLockfileTree(t, 1)
+	corruptPath := filepath.Join("project-0", "package-lock.json")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, corruptPath), []byte(`{"lockfileVersion": 2, "packages": {`), 0644))
+	resolver := newDirectoryResolver(t, root)
+
+	// by default, the corrupt lockfile is skipped and all other lockfiles are still cataloged
+	catalog, _, err := Catalog(resolver, nil, DefaultConfig(), DirectoryCatalogers()...)
+	require.NoError(t, err)
+	assert.Equal(t, expectedPackages, catalog.PackageCount())
+
+	cfg := DefaultConfig()
+	cfg.FailOnError = true
+	catalog, _, err = Catalog(resolver, nil, cfg, DirectoryCatalogers()...)
+	assert.Nil(t, catalog)
+
+	var parseErrs common.ParseErrors
+	require.True(t, errors.As(err, &parseErrs), "expected parse errors, got: %+v", err)
+	require.Len(t, parseErrs, 1)
+	assert.Equal(t, "javascript-lock-cataloger", parseErrs[0].Cataloger)
+	assert.Equal(t, corruptPath, parseErrs[0].Location.RealPath)
+	assert.Contains(t, err.Error(), "failed to parse 1 file:")
+}
+
+func TestCatalog_FailOnError_nonGenericCataloger(t *testing.T) {
+	root := t.TempDir()
+	// the ELF identification is valid (so the file is selected as an executable), however, the ELF version is not
+	header := make([]byte, 64)
+	copy(header, "\x7fELF\x02\x01\x01")
+	header[16] = 2 // ET_EXEC
+	header[20] = 0xff
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "app"), header, 0755))
+	resolver := newDirectoryResolver(t, root)
+
+	// by default, the corrupt binary is only reported as a warning
+	catalog, _, err := Catalog(resolver, nil, DefaultConfig(), DirectoryCatalogers()...)
+	require.NoError(t, err)
+	assert.Equal(t, 0, catalog.PackageCount())
+
+	cfg := DefaultConfig()
+	cfg.FailOnError = true
+	_, _, err = Catalog(resolver, nil, cfg, DirectoryCatalogers()...)
+
+	var parseErrs common.ParseErrors
+	require.True(t, errors.As(err, &parseErrs), "expected parse errors, got: %+v", err)
+	require.Len(t, parseErrs, 1)
+	assert.Equal(t, "go-module-binary-cataloger", parseErrs[0].Cataloger)
+	assert.Equal(t, "app", parseErrs[0].Location.RealPath)
+}
+
+func TestCatalog_SkipFileOwnership(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"site-packages/six-1.16.0.dist-info/METADATA": "Metadata-Version: 2.1\nName: six\nVersion: 1.16.0\n",
+		"site-packages/six-1.16.0.dist-info/RECORD":   "six.py,,\nsix-1.16.0.dist-info/METADATA,,\n",
+		"site-packages/six.py":                        "# six\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	resolver := newDirectoryResolver(t, root)
+
+	tests := []struct {
+		name          string
+		cfg           Config
+		expectedFiles int
+	}{
+		{
+			name:          "relate packages to owned files",
+			cfg:           DefaultConfig(),
+			expectedFiles: 2,
+		},
+		{
+			name: "skip file ownership",
+			cfg: Config{
+				Parallelism:       1,
+				SkipFileOwnership: true,
+			},
+			expectedFiles: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog, relationships, err := Catalog(resolver, nil, test.cfg, DirectoryCatalogers()...)
+			require.NoError(t, err)
+
+			// the package itself is always found...
+			assert.Equal(t, 1, catalog.PackageCount())
+
+			// ...but the files it owns are only related when asked for
+			var files int
+			for _, r := range relationships {
+				if _, ok := r.To.(source.Coordinates); ok && r.Type == artifact.ContainsRelationship {
+					files++
+				}
+			}
+			assert.Equal(t, test.expectedFiles, files)
+		})
+	}
+}
+
+func TestCatalog_Deduplication(t *testing.T) {
+	javaPackage := func(foundBy string, licenses ...string) pkg.Package {
+		return pkg.Package{
+			Name:         "log4j-core",
+			Version:      "2.17.0",
+			FoundBy:      foundBy,
+			Locations:    []source.Location{source.NewLocation("/app/lib/log4j-core-2.17.0.jar")},
+			Licenses:     licenses,
+			Language:     pkg.Java,
+			Type:         pkg.JavaPkg,
+			MetadataType: pkg.JavaMetadataType,
+			Metadata: pkg.JavaMetadata{
+				VirtualPath: "/app/lib/log4j-core-2.17.0.jar",
+			},
+		}
+	}
+
+	first := &staticCataloger{
+		name:     "first-cataloger",
+		packages: []pkg.Package{javaPackage("first-cataloger", "Apache-2.0")},
+	}
+	second := &staticCataloger{
+		name:     "second-cataloger",
+		packages: []pkg.Package{javaPackage("second-cataloger", "Apache-2.0", "MIT")},
+		// the relationship must refer to the merged package afterwards
+		relateTo: source.NewLocation("/app/lib/log4j-core-2.17.0.jar").Coordinates,
+	}
+	resolver := source.NewMockResolverForPaths()
+
+	t.Run("merge packages", func(t *testing.T) {
+		catalog, relationships, err := Catalog(resolver, nil, DefaultConfig(), first, second)
+		require.NoError(t, err)
+		require.Equal(t, 1, catalog.PackageCount())
+
+		actual := catalog.Sorted()[0]
+		assert.Equal(t, "first-cataloger", actual.FoundBy)
+		assert.Len(t, actual.Locations, 1)
+		assert.Equal(t, []string{"Apache-2.0", "MIT"}, actual.Licenses)
+		assert.NotEmpty(t, actual.CPEs)
+
+		require.Len(t, relationships, 1)
+		assert.Equal(t, actual.ID(), relationships[0].From.ID())
+		assert.NotNil(t, catalog.Package(relationships[0].From.ID()))
+	})
+
+	t.Run("prefer declared licenses", func(t *testing.T) {
+		concluded := javaPackage("concluding-cataloger", "MIT")
+		concluded.LicensesConcluded = true
+		first := &staticCataloger{
+			name:     "concluding-cataloger",
+			packages: []pkg.Package{concluded},
+		}
+
+		catalog, _, err := Catalog(resolver, nil, DefaultConfig(), first, second)
+		require.NoError(t, err)
+		require.Equal(t, 1, catalog.PackageCount())
+
+		// licenses concluded from the package files are never mixed with the licenses declared in the package metadata
+		actual := catalog.Sorted()[0]
+		assert.Equal(t, []string{"Apache-2.0", "MIT"}, actual.Licenses)
+		assert.False(t, actual.LicensesConcluded)
+	})
+
+	t.Run("skip deduplication", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SkipDeduplication = true
+
+		catalog, _, err := Catalog(resolver, nil, cfg, first, second)
+		require.NoError(t, err)
+		assert.Equal(t, 2, catalog.PackageCount())
+	})
+
+	t.Run("keep build recipes apart from installed packages", func(t *testing.T) {
+		installed := &staticCataloger{
+			name: "apkdb-cataloger",
+			packages: []pkg.Package{{
+				Name:         "curl",
+				Version:      "7.83.1-r0",
+				Type:         pkg.ApkPkg,
+				MetadataType: pkg.ApkMetadataType,
+				Metadata:     pkg.ApkMetadata{Package: "curl", Version: "7.83.1-r0"},
+			}},
+		}
+		recipe := &staticCataloger{
+			name: "apkbuild-cataloger",
+			packages: []pkg.Package{{
+				Name:         "curl",
+				Version:      "7.83.1-r0",
+				Type:         pkg.ApkbuildPkg,
+				MetadataType: pkg.ApkbuildMetadataType,
+				Metadata:     pkg.ApkbuildMetadata{Package: "curl", Version: "7.83.1", Release: "0"},
+			}},
+		}
+
+		catalog, _, err := Catalog(resolver, nil, DefaultConfig(), installed, recipe)
+		require.NoError(t, err)
+		require.Equal(t, 2, catalog.PackageCount())
+
+		for p := range catalog.Enumerate(pkg.ApkPkg) {
+			assert.True(t, strings.HasPrefix(p.PURL, "pkg:alpine/curl@7.83.1-r0"), "unexpected purl %q", p.PURL)
+		}
+		for p := range catalog.Enumerate(pkg.ApkbuildPkg) {
+			// a build recipe does not identify an installed package
+			assert.Empty(t, p.PURL)
+		}
+	})
+}
+
+func TestCatalog_DetectLicenses(t *testing.T) {
+	resolver := newDirectoryResolver(t, "test-fixtures/license-detection")
+
+	tests := []struct {
+		name     string
+		detect   bool
+		expected map[string]pkg.Package
+	}{
+		{
+			name: "licenses only from package metadata",
+			expected: map[string]pkg.Package{
+				"six":  {},
+				"idna": {Licenses: []string{"BSD-3-Clause"}},
+			},
+		},
+		{
+			name:   "conclude licenses from license files",
+			detect: true,
+			expected: map[string]pkg.Package{
+				// the package metadata declares no license, however, the package owns an MIT LICENSE file
+				"six": {Licenses: []string{"MIT"}, LicensesConcluded: true},
+				// licenses declared within the package metadata are never replaced
+				"idna": {Licenses: []string{"BSD-3-Clause"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.DetectLicenses = test.detect
+
+			catalog, _, err := Catalog(resolver, nil, cfg, DirectoryCatalogers()...)
+			require.NoError(t, err)
+			require.Equal(t, len(test.expected), catalog.PackageCount())
+
+			for _, p := range catalog.Sorted() {
+				expected, exists := test.expected[p.Name]
+				require.True(t, exists, "unexpected package: %s", p)
+				assert.Equal(t, expected.Licenses, p.Licenses, p.Name)
+				assert.Equal(t, expected.LicensesConcluded, p.LicensesConcluded, p.Name)
+			}
+		})
+	}
+}
+
+func TestCatalog_CPERules(t *testing.T) {
+	resolver := source.NewMockResolverForPaths()
+	rack := pkg.Package{Name: "rack", Version: "2.2.3", Type: pkg.GemPkg, Language: pkg.Ruby}
+
+	cfg := DefaultConfig()
+	catalog, _, err := Catalog(resolver, nil, cfg, &staticCataloger{name: "static", packages: []pkg.Package{rack}})
+	require.NoError(t, err)
+	unfiltered := cpeStrings(catalog.Sorted()[0].CPEs)
+	require.Contains(t, unfiltered, "cpe:2.3:a:*:rack:2.2.3:*:*:*:*:*:*:*")
+
+	cfg.CPERules = cpe.Rules{
+		Deny: []cpe.Rule{{PackageType: pkg.GemPkg, Vendor: "*"}},
+	}
+	catalog, _, err = Catalog(resolver, nil, cfg, &staticCataloger{name: "static", packages: []pkg.Package{rack}})
+	require.NoError(t, err)
+	filtered := cpeStrings(catalog.Sorted()[0].CPEs)
+
+	// only the denied CPE is removed
+	assert.NotContains(t, filtered, "cpe:2.3:a:*:rack:2.2.3:*:*:*:*:*:*:*")
+	assert.Len(t, filtered, len(unfiltered)-1)
+	assert.Contains(t, filtered, "cpe:2.3:a:rack:rack:2.2.3:*:*:*:*:*:*:*")
+}
+
+func TestCatalog_importedCPEs(t *testing.T) {
+	fixture := "test-fixtures/embedded-sbom"
+	resolver := newDirectoryResolver(t, fixture)
+	declared := pkg.MustCPE("cpe:2.3:a:someone:rack:2.2.3:*:*:*:*:*:*:*")
+	rack := pkg.Package{Name: "rack", Version: "2.2.3", Type: pkg.GemPkg, Language: pkg.Ruby, CPEs: []pkg.CPE{declared}}
+
+	// CPEs are generated for packages that are not imported (even when the cataloger provides any)
+	catalog, _, err := Catalog(resolver, nil, DefaultConfig(), &staticCataloger{name: "static", packages: []pkg.Package{rack}})
+	require.NoError(t, err)
+	cpes := cpeStrings(catalog.Sorted()[0].CPEs)
+	assert.Contains(t, cpes, "cpe:2.3:a:rack:rack:2.2.3:*:*:*:*:*:*:*")
+	assert.NotContains(t, cpes, "cpe:2.3:a:someone:rack:2.2.3:*:*:*:*:*:*:*")
+
+	// the CPEs declared for imported packages are kept, without any suppressed by the configured rules
+	cfg := DefaultConfig()
+	cfg.CPERules = cpe.Rules{
+		Deny: []cpe.Rule{{Vendor: "wordpress"}},
+	}
+	catalog, _, err = Catalog(resolver, nil, cfg, OptInCatalogers()...)
+	require.NoError(t, err)
+	for _, p := range catalog.Sorted() {
+		if p.Name == "wordpress" {
+			assert.Empty(t, p.CPEs)
+			assert.Equal(t, "pkg:bitnami/wordpress@6.0.1", p.PURL)
+		}
+	}
+}
+
+func TestCatalog_NormalizeVersions(t *testing.T) {
+	resolver := source.NewMockResolverForPaths()
+	packages := []pkg.Package{
+		{Name: "github.com/google/uuid", Version: "v1.3.0", Type: pkg.GoModulePkg, Language: pkg.Go},
+		{Name: "libc6", Version: "1:2.31-13", Type: pkg.DebPkg},
+	}
+
+	cfg := DefaultConfig()
+	catalog, _, err := Catalog(resolver, nil, cfg, &staticCataloger{name: "static", packages: packages})
+	require.NoError(t, err)
+	for _, p := range catalog.Sorted() {
+		assert.Empty(t, p.NormalizedVersion, p.Name)
+	}
+	unnormalizedIDs := packageIDs(catalog)
+
+	cfg.NormalizeVersions = true
+	catalog, _, err = Catalog(resolver, nil, cfg, &staticCataloger{name: "static", packages: packages})
+	require.NoError(t, err)
+	sorted := catalog.Sorted()
+	require.Len(t, sorted, 2)
+
+	// the raw version is kept as-is
+	assert.Equal(t, "1:2.31-13", sorted[0].Version)
+	assert.Equal(t, "2.31", sorted[0].NormalizedVersion)
+	assert.Equal(t, "v1.3.0", sorted[1].Version)
+	assert.Equal(t, "1.3.0", sorted[1].NormalizedVersion)
+
+	// the normalized version is derived from the version, so it does not change the package IDs
+	assert.Equal(t, unnormalizedIDs, packageIDs(catalog))
+}
+
+func TestCatalog_sharedFileOwnership(t *testing.T) {
+	fixture := "test-fixtures/shared-file-ownership"
+	resolver := newDirectoryResolver(t, fixture)
+	sixPath := filepath.Join(fixture, "usr/lib/python3.9/site-packages/six.py")
+
+	// the OS package of the same library owns the same file as the pip-installed package found within the fixture
+	rpm := pkg.Package{
+		Name:         "python3-six",
+		Version:      "1.15.0-8.el9",
+		Type:         pkg.RpmPkg,
+		MetadataType: pkg.RpmdbMetadataType,
+		Metadata: pkg.RpmdbMetadata{
+			Name: "python3-six",
+			Files: []pkg.RpmdbFileRecord{
+				{Path: sixPath},
+				{Path: "/usr/share/doc/python3-six/README.rst"},
+			},
+		},
+	}
+
+	catalogers := []Cataloger{&staticCataloger{name: "static", packages: []pkg.Package{rpm}}}
+	catalogers = append(catalogers, DirectoryCatalogers()...)
+	catalog, relationships, err := Catalog(resolver, nil, DefaultConfig(), catalogers...)
+	require.NoError(t, err)
+	require.Equal(t, 2, catalog.PackageCount())
+
+	var shared []artifact.Relationship
+	for _, r := range relationships {
+		if r.Type == artifact.SharedFileOwnershipRelationship {
+			shared = append(shared, r)
+		}
+	}
+	require.Len(t, shared, 1)
+
+	names := []string{shared[0].From.(pkg.Package).Name, shared[0].To.(pkg.Package).Name}
+	assert.ElementsMatch(t, []string{"python3-six", "six"}, names)
+
+	data, err := json.Marshal(shared[0].Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"files": [%q]}`, sixPath), string(data))
+}
+
+func TestCatalog_osOwnedKernel(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"/lib/modules/5.10.0-8-amd64/modules.builtin",
+		"/lib/modules/5.10.0-8-amd64/modules.dep",
+		"/lib/modules/5.10.0-8-amd64/modules.order",
+		"/lib/modules/5.15.0-custom/modules.builtin",
+		"/lib/modules/5.15.0-custom/modules.dep",
+	)
+
+	// the deb package that installed the kernel owns the module lists installed with the kernel
+	image := pkg.Package{
+		Name:         "linux-image-5.10.0-8-amd64",
+		Version:      "5.10.46-4",
+		Type:         pkg.DebPkg,
+		MetadataType: pkg.DpkgMetadataType,
+		Metadata: pkg.DpkgMetadata{
+			Package: "linux-image-5.10.0-8-amd64",
+			Files: []pkg.DpkgFileRecord{
+				{Path: "/lib/modules/5.10.0-8-amd64/modules.builtin"},
+				{Path: "/lib/modules/5.10.0-8-amd64/modules.order"},
+			},
+		},
+	}
+
+	catalog, relationships, err := Catalog(resolver, nil, DefaultConfig(), &staticCataloger{name: "static", packages: []pkg.Package{image}}, kernel.NewCataloger())
+	require.NoError(t, err)
+
+	var kernels []pkg.Package
+	for _, p := range catalog.Sorted() {
+		if p.Type == pkg.LinuxKernelPkg {
+			kernels = append(kernels, p)
+		}
+	}
+	require.Len(t, kernels, 2)
+
+	// the kernel installed by the deb package takes on the version of the deb package (without CPEs)
+	owned := kernels[0]
+	assert.Equal(t, "5.10.46-4", owned.Version)
+	assert.Empty(t, owned.CPEs)
+	assert.Equal(t, pkg.LinuxKernelMetadata{Release: "5.10.0-8-amd64", Version: "5.10.0"}, owned.Metadata)
+
+	// the kernel that is not installed by an OS package is still described by the kernel release
+	unowned := kernels[1]
+	assert.Equal(t, "5.15.0-custom", unowned.Version)
+	assert.Contains(t, cpeStrings(unowned.CPEs), "cpe:2.3:o:linux:linux_kernel:5.15.0-custom:*:*:*:*:*:*:*")
+
+	// relationships refer to the updated kernel package
+	var ownership []artifact.Relationship
+	for _, r := range relationships {
+		if r.Type == artifact.OwnershipByFileOverlapRelationship {
+			ownership = append(ownership, r)
+		}
+	}
+	require.Len(t, ownership, 1)
+	assert.Equal(t, image.Name, ownership[0].From.(pkg.Package).Name)
+	assert.Equal(t, owned.ID(), ownership[0].To.ID())
+}
+
+func TestCatalog_osOwnedBinary(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   string
+		owner     string
+		ownedPath string
+		// the names of the packages found without and with the OS package that owns the binary
+		unowned []string
+		owned   []string
+	}{
+		{
+			name:      "shared library",
+			fixture:   "binary/test-fixtures/libc/debian",
+			owner:     "libc6",
+			ownedPath: "lib/x86_64-linux-gnu/libc.so.6",
+			unowned:   []string{"glibc"},
+			owned:     []string{"libc6"},
+		},
+		{
+			name:      "runtime binary",
+			fixture:   "binary/test-fixtures/python",
+			owner:     "python3.9-minimal",
+			ownedPath: "usr/bin/python3.9",
+			// note: the binaries without a version are the same package
+			unowned: []string{"python", "python"},
+			// the binaries not installed by the OS package are still identified by classification
+			owned: []string{"python", "python3.9-minimal"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := newDirectoryResolver(t, test.fixture)
+
+			catalog, _, err := Catalog(resolver, nil, DefaultConfig(), binary.NewCataloger())
+			require.NoError(t, err)
+			assert.Equal(t, test.unowned, packageNames(catalog))
+
+			owner := pkg.Package{
+				Name:         test.owner,
+				Version:      "1.0-1",
+				Type:         pkg.DebPkg,
+				MetadataType: pkg.DpkgMetadataType,
+				Metadata: pkg.DpkgMetadata{
+					Package: test.owner,
+					Files: []pkg.DpkgFileRecord{
+						{Path: filepath.Join(test.fixture, test.ownedPath)},
+					},
+				},
+			}
+
+			// the binary installed by the deb package is only described by the deb package
+			catalog, relationships, err := Catalog(resolver, nil, DefaultConfig(), &staticCataloger{name: "static", packages: []pkg.Package{owner}}, binary.NewCataloger())
+			require.NoError(t, err)
+			assert.Equal(t, test.owned, packageNames(catalog))
+			for _, r := range relationships {
+				for _, identifiable := range []artifact.Identifiable{r.From, r.To} {
+					if p, ok := identifiable.(pkg.Package); ok {
+						assert.Equal(t, test.owner, p.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func packageNames(catalog *pkg.Catalog) (results []string) {
+	for _, p := range catalog.Sorted() {
+		results = append(results, p.Name)
+	}
+	sort.Strings(results)
+	return results
+}
+
+func TestCatalog_embeddedSBOM(t *testing.T) {
+	fixture := "test-fixtures/embedded-sbom"
+	sbomPath := filepath.Join(fixture, "opt/bitnami/wordpress/.spdx-wordpress.spdx")
+	lockPath := filepath.Join(fixture, "opt/bitnami/wordpress/composer.lock")
+
+	resolver := newDirectoryResolver(t, fixture)
+
+	// embedded SBOMs are not imported by default
+	catalog, _, err := Catalog(resolver, nil, DefaultConfig(), DirectoryCatalogers()...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"guzzlehttp/guzzle"}, packageNames(catalog))
+
+	catalogers, err := SelectCatalogers(DirectoryCatalogers(), []string{"+sbom-cataloger"})
+	require.NoError(t, err)
+	catalog, _, err = Catalog(resolver, nil, DefaultConfig(), catalogers...)
+	require.NoError(t, err)
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range catalog.Sorted() {
+		byName[p.Name] = p
+	}
+	require.Len(t, byName, 3)
+
+	// packages that are only declared by the embedded SBOM are found there, keeping the declared PURL and CPEs
+	wordpress := byName["wordpress"]
+	assert.Equal(t, "6.0.1", wordpress.Version)
+	assert.Equal(t, "sbom-cataloger", wordpress.FoundBy)
+	assert.Equal(t, []string{sbomPath}, locationPaths(wordpress))
+	assert.Equal(t, "pkg:bitnami/wordpress@6.0.1", wordpress.PURL)
+	assert.Equal(t, []string{"cpe:2.3:a:wordpress:wordpress:6.0.1:*:*:*:*:*:*:*"}, cpeStrings(wordpress.CPEs))
+	assert.Contains(t, byName, "php")
+
+	// packages that are also found by another cataloger are merged
+	guzzle := byName["guzzlehttp/guzzle"]
+	assert.Equal(t, pkg.PhpComposerPkg, guzzle.Type)
+	assert.ElementsMatch(t, []string{lockPath, sbomPath}, locationPaths(guzzle))
+}
+
+func TestCatalog_symlinkedProject(t *testing.T) {
+	// note: "current" is a symlink to the "projects/app" directory
+	fixture := "test-fixtures/symlinked-project"
+	resolver := newDirectoryResolver(t, fixture)
+
+	for _, skipDeduplication := range []bool{false, true} {
+		t.Run(fmt.Sprintf("skipDeduplication=%v", skipDeduplication), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.SkipDeduplication = skipDeduplication
+
+			catalog, _, err := Catalog(resolver, nil, cfg, DirectoryCatalogers()...)
+			require.NoError(t, err)
+
+			// every package is only found once, at the canonical path of the lockfile (not through the symlink)
+			expected := map[string]string{
+				"requests": filepath.Join(fixture, "projects/app/requirements.txt"),
+				"rake":     filepath.Join(fixture, "projects/app/Gemfile.lock"),
+			}
+			require.Equal(t, len(expected), catalog.PackageCount())
+			for _, p := range catalog.Sorted() {
+				require.Contains(t, expected, p.Name)
+				require.Len(t, p.Locations, 1, "package %q", p.Name)
+				assert.Equal(t, expected[p.Name], p.Locations[0].RealPath)
+				assert.True(t, p.Locations[0].IsCanonical(), "package %q found at %+v", p.Name, p.Locations[0])
+			}
+		})
+	}
+}
+
+func locationPaths(p pkg.Package) (results []string) {
+	for _, l := range p.Locations {
+		results = append(results, l.RealPath)
+	}
+	return results
+}
+
+func cpeStrings(cpes []pkg.CPE) (results []string) {
+	for _, c := range cpes {
+		results = append(results, c.BindToFmtString())
+	}
+	return results
+}
+
+func BenchmarkCatalog_Parallelism(b *testing.B) {
+	root, expectedPackages := writeLockfileTree(b, 250)
+	resolver := newDirectoryResolver(b, root)
+
+	for _, parallelism := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("parallelism=%d", parallelism), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				catalog, _, err := Catalog(resolver, nil, Config{Parallelism: parallelism}, DirectoryCatalogers()...)
+				if err != nil {
+					b.Fatalf("failure during benchmark: %+v", err)
+				}
+				if catalog.PackageCount() != expectedPackages {
+					b.Fatalf("unexpected package count: %d != %d", catalog.PackageCount(), expectedPackages)
+				}
+			}
+		})
+	}
+}
+
+func packageIDs(catalog *pkg.Catalog) (ids []artifact.ID) {
+	for _, p := range catalog.Sorted() {
+		ids = append(ids, p.ID())
+	}
+	return ids
+}
+
+type failingCataloger struct{}
+
+func (c *failingCataloger) Name() string {
+	return "failing-cataloger"
+}
+
+func (c *failingCataloger) Catalog(source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	return nil, nil, errors.New("cataloging failed")
+}
+
+// staticCataloger always finds the given packages (optionally relating each package to the given coordinates).
+type staticCataloger struct {
+	name     string
+	packages []pkg.Package
+	relateTo artifact.Identifiable
+}
+
+func (c *staticCataloger) Name() string {
+	return c.name
+}
+
+func (c *staticCataloger) Catalog(source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	var relationships []artifact.Relationship
+	if c.relateTo != nil {
+		for _, p := range c.packages {
+			relationships = append(relationships, artifact.Relationship{
+				From: p,
+				To:   c.relateTo,
+				Type: artifact.ContainsRelationship,
+			})
+		}
+	}
+	return c.packages, relationships, nil
+}
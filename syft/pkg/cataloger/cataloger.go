@@ -9,15 +9,25 @@ import (
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/apkdb"
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+	"github.com/anchore/syft/syft/pkg/cataloger/conda"
+	"github.com/anchore/syft/syft/pkg/cataloger/dart"
 	"github.com/anchore/syft/syft/pkg/cataloger/deb"
+	"github.com/anchore/syft/syft/pkg/cataloger/dotnet"
+	"github.com/anchore/syft/syft/pkg/cataloger/elixir"
 	"github.com/anchore/syft/syft/pkg/cataloger/golang"
+	"github.com/anchore/syft/syft/pkg/cataloger/haskell"
 	"github.com/anchore/syft/syft/pkg/cataloger/java"
 	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+	"github.com/anchore/syft/syft/pkg/cataloger/kernel"
 	"github.com/anchore/syft/syft/pkg/cataloger/php"
 	"github.com/anchore/syft/syft/pkg/cataloger/python"
 	"github.com/anchore/syft/syft/pkg/cataloger/rpmdb"
 	"github.com/anchore/syft/syft/pkg/cataloger/ruby"
 	"github.com/anchore/syft/syft/pkg/cataloger/rust"
+	"github.com/anchore/syft/syft/pkg/cataloger/sbom"
+	"github.com/anchore/syft/syft/pkg/cataloger/swift"
+	"github.com/anchore/syft/syft/pkg/cataloger/windows"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -43,6 +53,11 @@ func ImageCatalogers() []Cataloger {
 		java.NewJavaCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModuleBinaryCataloger(),
+		conda.NewCondaMetaCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
+		windows.NewRegistryCataloger(),
+		binary.NewCataloger(),
+		kernel.NewCataloger(),
 	}
 }
 
@@ -58,9 +73,19 @@ func DirectoryCatalogers() []Cataloger {
 		rpmdb.NewRpmdbCataloger(),
 		java.NewJavaCataloger(),
 		apkdb.NewApkdbCataloger(),
+		apkdb.NewApkbuildCataloger(),
 		golang.NewGoModuleBinaryCataloger(),
 		golang.NewGoModFileCataloger(),
 		rust.NewCargoLockCataloger(),
+		conda.NewCondaMetaCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
+		haskell.NewHaskellCataloger(),
+		elixir.NewMixLockCataloger(),
+		dart.NewPubspecLockCataloger(),
+		windows.NewRegistryCataloger(),
+		binary.NewCataloger(),
+		kernel.NewCataloger(),
 	}
 }
 
@@ -77,8 +102,27 @@ func AllCatalogers() []Cataloger {
 		rpmdb.NewRpmdbCataloger(),
 		java.NewJavaCataloger(),
 		apkdb.NewApkdbCataloger(),
+		apkdb.NewApkbuildCataloger(),
 		golang.NewGoModuleBinaryCataloger(),
 		golang.NewGoModFileCataloger(),
 		rust.NewCargoLockCataloger(),
+		conda.NewCondaMetaCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
+		haskell.NewHaskellCataloger(),
+		elixir.NewMixLockCataloger(),
+		dart.NewPubspecLockCataloger(),
+		windows.NewRegistryCataloger(),
+		binary.NewCataloger(),
+		kernel.NewCataloger(),
+	}
+}
+
+// OptInCatalogers returns the catalogers that are never run by default, which are only run when selected by name (see
+// SelectCatalogers). The packages declared by embedded SBOM documents are not necessarily accurate (e.g. the document
+// may describe a build that differs from what is installed), so they are only imported when requested.
+func OptInCatalogers() []Cataloger {
+	return []Cataloger{
+		sbom.NewSBOMCataloger(),
 	}
 }
@@ -12,9 +12,9 @@ import (
 	"github.com/facebookincubator/nvdtools/wfn"
 )
 
-func newCPE(product, vendor, version, targetSW string) wfn.Attributes {
+func newCPE(part, product, vendor, version, targetSW string) wfn.Attributes {
 	cpe := *(wfn.NewAttributesWithAny())
-	cpe.Part = "a"
+	cpe.Part = part
 	cpe.Product = product
 	cpe.Vendor = vendor
 	cpe.Version = version
@@ -27,6 +27,12 @@ func newCPE(product, vendor, version, targetSW string) wfn.Attributes {
 // generate the minimal set of representative CPEs, which implies that optional fields should not be included
 // (such as target SW).
 func Generate(p pkg.Package) []pkg.CPE {
+	if p.Version == "" {
+		// a CPE without a version matches every version of the product, which does not describe the package (e.g. an
+		// unpinned requirement) and would only result in false positives
+		return nil
+	}
+
 	vendors := candidateVendors(p)
 	products := candidateProducts(p)
 
@@ -46,7 +52,7 @@ func Generate(p pkg.Package) []pkg.CPE {
 			keys.Add(key)
 
 			// add a new entry...
-			cpes = append(cpes, newCPE(product, vendor, p.Version, wfn.Any))
+			cpes = append(cpes, newCPE(candidatePart(p), product, vendor, p.Version, wfn.Any))
 		}
 	}
 
@@ -58,6 +64,15 @@ func Generate(p pkg.Package) []pkg.CPE {
 	return cpes
 }
 
+// candidatePart returns the CPE part for the given package, which is an application unless the package is an
+// operating system (e.g. the Linux kernel is described by "cpe:2.3:o:linux:linux_kernel").
+func candidatePart(p pkg.Package) string {
+	if p.Type == pkg.LinuxKernelPkg {
+		return "o"
+	}
+	return "a"
+}
+
 func candidateVendors(p pkg.Package) []string {
 	// in ecosystems where the packaging metadata does not have a clear field to indicate a vendor (or a field that
 	// could be interpreted indirectly as such) the project name tends to be a common stand in. Examples of this
@@ -95,6 +110,17 @@ func candidateVendors(p pkg.Package) []string {
 		vendors.union(candidateVendorsForPython(p))
 	case pkg.JavaMetadataType:
 		vendors.union(candidateVendorsForJava(p))
+	case pkg.WindowsRegistryMetadataType:
+		// the publisher is the vendor (program names are descriptive, so make for poor vendor candidates)
+		if publishers := candidateVendorsForWindows(p); len(publishers) > 0 {
+			vendors.clear()
+			vendors.union(publishers)
+		}
+	case pkg.LinuxKernelMetadataType:
+		// there is a single well-known CPE for the kernel
+		vendors.clear()
+		vendors.addValue("linux")
+		return vendors.uniqueValues()
 	}
 
 	// try swapping hyphens for underscores, vice versa, and removing separators altogether
@@ -129,6 +155,14 @@ func candidateProducts(p pkg.Package) []string {
 		if prod != "" {
 			products.addValue(prod)
 		}
+	case p.MetadataType == pkg.WindowsRegistryMetadataType:
+		// display names are not suitable as-is (e.g. they may contain the version and architecture)
+		products.clear()
+		products.addValue(candidateProductsForWindows(p)...)
+	case p.MetadataType == pkg.LinuxKernelMetadataType:
+		products.clear()
+		products.addValue("linux_kernel")
+		return products.uniqueValues()
 	}
 	// it is never OK to have candidates with these values ["" and "*"] (since CPEs will match any other value)
 	products.removeByValue("")
@@ -513,6 +513,111 @@ func TestGeneratePackageCPEs(t *testing.T) {
 			},
 			expected: []string{},
 		},
+		{
+			name: "glibc binary",
+			p: pkg.Package{
+				Name:    "glibc",
+				Version: "2.31",
+				FoundBy: "binary-cataloger",
+				Type:    pkg.BinaryPkg,
+			},
+			expected: []string{
+				"cpe:2.3:a:glibc:glibc:2.31:*:*:*:*:*:*:*",
+				"cpe:2.3:a:gnu:glibc:2.31:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "linux kernel",
+			p: pkg.Package{
+				Name:         "linux-kernel",
+				Version:      "5.10.0-8-amd64",
+				FoundBy:      "linux-kernel-cataloger",
+				Type:         pkg.LinuxKernelPkg,
+				MetadataType: pkg.LinuxKernelMetadataType,
+				Metadata: pkg.LinuxKernelMetadata{
+					Release: "5.10.0-8-amd64",
+					Version: "5.10.0",
+				},
+			},
+			expected: []string{
+				"cpe:2.3:o:linux:linux_kernel:5.10.0-8-amd64:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "windows program with publisher",
+			p: pkg.Package{
+				Name:         "7-Zip 21.07 (x64)",
+				Version:      "21.07",
+				FoundBy:      "windows-registry-cataloger",
+				Type:         pkg.WindowsPkg,
+				MetadataType: pkg.WindowsRegistryMetadataType,
+				Metadata: pkg.WindowsRegistryMetadata{
+					Key:            "7-Zip",
+					DisplayName:    "7-Zip 21.07 (x64)",
+					DisplayVersion: "21.07",
+					Publisher:      "Igor Pavlov",
+				},
+			},
+			expected: []string{
+				"cpe:2.3:a:igor-pavlov:7-zip:21.07:*:*:*:*:*:*:*",
+				"cpe:2.3:a:igor-pavlov:7_zip:21.07:*:*:*:*:*:*:*",
+				"cpe:2.3:a:igor_pavlov:7-zip:21.07:*:*:*:*:*:*:*",
+				"cpe:2.3:a:igor_pavlov:7_zip:21.07:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "windows program with publisher suffix",
+			p: pkg.Package{
+				Name:         "Microsoft Edge",
+				Version:      "96.0.1054.62",
+				FoundBy:      "windows-registry-cataloger",
+				Type:         pkg.WindowsPkg,
+				MetadataType: pkg.WindowsRegistryMetadataType,
+				Metadata: pkg.WindowsRegistryMetadata{
+					Key:            "Microsoft Edge",
+					DisplayName:    "Microsoft Edge",
+					DisplayVersion: "96.0.1054.62",
+					Publisher:      "Microsoft Corporation",
+				},
+			},
+			expected: []string{
+				"cpe:2.3:a:microsoft:microsoft-edge:96.0.1054.62:*:*:*:*:*:*:*",
+				"cpe:2.3:a:microsoft:microsoft_edge:96.0.1054.62:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "windows program without publisher",
+			p: pkg.Package{
+				Name:         "Notepad++ (32-bit x86)",
+				Version:      "8.1.9.3",
+				FoundBy:      "windows-registry-cataloger",
+				Type:         pkg.WindowsPkg,
+				MetadataType: pkg.WindowsRegistryMetadataType,
+				Metadata: pkg.WindowsRegistryMetadata{
+					Key:            "Notepad++",
+					DisplayName:    "Notepad++ (32-bit x86)",
+					DisplayVersion: "8.1.9.3",
+				},
+			},
+			expected: []string{
+				"cpe:2.3:a:notepad++:notepad++:8.1.9.3:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "unpinned python requirement",
+			p: pkg.Package{
+				Name:         "requests",
+				FoundBy:      "python-package-cataloger",
+				Language:     pkg.Python,
+				Type:         pkg.PythonPkg,
+				MetadataType: pkg.PythonRequirementsMetadataType,
+				Metadata: pkg.PythonRequirementsMetadata{
+					VersionConstraint: ">=2.8.1",
+				},
+			},
+			// without a version, every CPE would be a wildcard over all versions
+			expected: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -37,10 +37,40 @@ var (
 		"Specification-Vendor",
 		"Implementation-Vendor",
 	}
+	javaManifestTitleFields = []string{
+		"Implementation-Title",
+		"Specification-Title",
+	}
 )
 
 func candidateProductsForJava(p pkg.Package) []string {
-	return productsFromArtifactAndGroupIDs(artifactIDFromJavaPackage(p), groupIDsFromJavaPackage(p))
+	products := productsFromArtifactAndGroupIDs(artifactIDFromJavaPackage(p), groupIDsFromJavaPackage(p))
+	return append(products, productsFromJavaManifestTitles(p)...)
+}
+
+// productsFromJavaManifestTitles returns product candidates from the main section title fields of the manifest. Only
+// titles that look like a project identifier are considered (e.g. "commons-io" but not "Apache Commons IO"), since
+// free-form titles are rarely used as CPE products.
+func productsFromJavaManifestTitles(p pkg.Package) []string {
+	metadata, ok := p.Metadata.(pkg.JavaMetadata)
+	if !ok {
+		return nil
+	}
+
+	if metadata.Manifest == nil || metadata.Manifest.Main == nil {
+		return nil
+	}
+
+	products := strset.New()
+	for _, name := range javaManifestTitleFields {
+		value := strings.TrimSpace(metadata.Manifest.Main[name])
+		if value == "" || strings.ContainsAny(value, " \t") || startsWithTopLevelDomain(value) {
+			continue
+		}
+		products.Add(strings.ToLower(value))
+	}
+
+	return products.List()
 }
 
 func candidateVendorsForJava(p pkg.Package) fieldCandidateSet {
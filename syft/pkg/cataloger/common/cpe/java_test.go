@@ -426,3 +426,50 @@ func Test_vendorsFromJavaManifestNames(t *testing.T) {
 		})
 	}
 }
+
+func Test_productsFromJavaManifestTitles(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     pkg.Package
+		expects []string
+	}{
+		{
+			name: "identifier-like titles",
+			pkg: pkg.Package{
+				Metadata: pkg.JavaMetadata{
+					Manifest: &pkg.JavaManifest{
+						Main: map[string]string{
+							"Implementation-Title": "Jackson-Databind",
+							"Specification-Title":  "jackson-databind",
+						},
+					},
+				},
+			},
+			expects: []string{"jackson-databind"},
+		},
+		{
+			name: "free-form and group ID titles are ignored",
+			pkg: pkg.Package{
+				Metadata: pkg.JavaMetadata{
+					Manifest: &pkg.JavaManifest{
+						Main: map[string]string{
+							"Implementation-Title": "Apache Commons IO",
+							"Specification-Title":  "org.apache.commons.io",
+						},
+						NamedSections: map[string]map[string]string{
+							"section": {
+								"Implementation-Title": "other-thing",
+							},
+						},
+					},
+				},
+			},
+			expects: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ElementsMatch(t, test.expects, productsFromJavaManifestTitles(test.pkg))
+		})
+	}
+}
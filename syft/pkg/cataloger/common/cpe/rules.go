@@ -0,0 +1,85 @@
+package cpe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/facebookincubator/nvdtools/wfn"
+)
+
+// Rule matches generated CPEs by the type of the package and the vendor and product of the CPE, where empty fields
+// match any value. Note that a vendor (or product) of "*" only matches CPEs with the wildcard value (which match any
+// vendor or product when matching vulnerabilities), not every CPE.
+type Rule struct {
+	PackageType pkg.Type
+	Vendor      string
+	Product     string
+}
+
+// Rules suppress generated CPEs that are known to be poor candidates (e.g. wildcard or overly generic vendors), where
+// CPEs matching any Deny rule are removed unless they also match an Allow rule.
+type Rules struct {
+	Allow []Rule
+	Deny  []Rule
+}
+
+// Validate returns an error if any rule has an unknown package type or matches every CPE.
+func (r Rules) Validate() error {
+	for _, rule := range append(append([]Rule{}, r.Allow...), r.Deny...) {
+		if rule.PackageType == "" && rule.Vendor == "" && rule.Product == "" {
+			return fmt.Errorf("CPE rules must have a package type, vendor, or product")
+		}
+		if rule.PackageType != "" && !isKnownPackageType(rule.PackageType) {
+			return fmt.Errorf("bad package type given for CPE rule: %q (options: %v)", rule.PackageType, pkg.AllPkgs)
+		}
+	}
+	return nil
+}
+
+// Apply returns the given CPEs (generated for the given package) without the CPEs suppressed by the rules.
+func (r Rules) Apply(cpes []pkg.CPE, p pkg.Package) []pkg.CPE {
+	if len(r.Deny) == 0 {
+		return cpes
+	}
+	return filter(cpes, p, r.denied)
+}
+
+func (r Rules) denied(cpe pkg.CPE, p pkg.Package) bool {
+	return matchesAnyRule(r.Deny, cpe, p) && !matchesAnyRule(r.Allow, cpe, p)
+}
+
+func (r Rule) matches(cpe pkg.CPE, p pkg.Package) bool {
+	if r.PackageType != "" && r.PackageType != p.Type {
+		return false
+	}
+	return matchesField(r.Vendor, cpe.Vendor) && matchesField(r.Product, cpe.Product)
+}
+
+func matchesField(ruleValue, cpeValue string) bool {
+	switch ruleValue {
+	case "":
+		return true
+	case "*":
+		return cpeValue == wfn.Any || cpeValue == "*"
+	}
+	return strings.EqualFold(ruleValue, cpeValue)
+}
+
+func matchesAnyRule(rules []Rule, cpe pkg.CPE, p pkg.Package) bool {
+	for _, rule := range rules {
+		if rule.matches(cpe, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownPackageType(t pkg.Type) bool {
+	for _, known := range pkg.AllPkgs {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
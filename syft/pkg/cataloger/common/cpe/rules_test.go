@@ -0,0 +1,96 @@
+package cpe
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules_Apply(t *testing.T) {
+	lodash := pkg.Package{Name: "lodash", Version: "4.17.21", Type: pkg.NpmPkg}
+	cpes := []pkg.CPE{
+		mustCPE("cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*"),
+		mustCPE("cpe:2.3:a:*:lodash:4.17.21:*:*:*:*:*:*:*"),
+		mustCPE("cpe:2.3:a:nodejs:lodash:4.17.21:*:*:*:*:*:*:*"),
+	}
+
+	tests := []struct {
+		name     string
+		rules    Rules
+		pkg      pkg.Package
+		expected []string
+	}{
+		{
+			name: "no rules",
+			pkg:  lodash,
+			expected: []string{
+				"cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:*:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:nodejs:lodash:4.17.21:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "deny wildcard vendor for the package type",
+			rules: Rules{
+				Deny: []Rule{{PackageType: pkg.NpmPkg, Vendor: "*"}},
+			},
+			pkg: lodash,
+			expected: []string{
+				"cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:nodejs:lodash:4.17.21:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "deny for another package type",
+			rules: Rules{
+				Deny: []Rule{{PackageType: pkg.GemPkg, Vendor: "*"}},
+			},
+			pkg: lodash,
+			expected: []string{
+				"cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:*:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:nodejs:lodash:4.17.21:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "deny vendor for all package types (not case sensitive)",
+			rules: Rules{
+				Deny: []Rule{{Vendor: "NodeJS"}},
+			},
+			pkg: lodash,
+			expected: []string{
+				"cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:*:lodash:4.17.21:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "allow overrides deny",
+			rules: Rules{
+				Allow: []Rule{{Vendor: "*", Product: "lodash"}},
+				Deny:  []Rule{{PackageType: pkg.NpmPkg, Vendor: "*"}, {Vendor: "nodejs"}},
+			},
+			pkg: lodash,
+			expected: []string{
+				"cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*",
+				"cpe:2.3:a:*:lodash:4.17.21:*:*:*:*:*:*:*",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var actual []string
+			for _, c := range test.rules.Apply(cpes, test.pkg) {
+				actual = append(actual, c.BindToFmtString())
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRules_Validate(t *testing.T) {
+	assert.NoError(t, Rules{Deny: []Rule{{PackageType: pkg.NpmPkg, Vendor: "*"}}}.Validate())
+	assert.Error(t, Rules{Deny: []Rule{{}}}.Validate())
+	assert.Error(t, Rules{Allow: []Rule{{PackageType: "not-a-type", Vendor: "*"}}}.Validate())
+}
@@ -0,0 +1,61 @@
+package cpe
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+var (
+	// matches details within parentheses that are commonly added to program display names (e.g. "7-Zip 21.07 (x64)")
+	windowsDisplayNameDetailsPattern = regexp.MustCompile(`\([^)]*\)`)
+	// matches the legal entity (or similar) suffix of a publisher name (e.g. "Microsoft Corporation")
+	windowsPublisherSuffixPattern = regexp.MustCompile(`(?i)[\s,]+(corporation|corp|incorporated|inc|limited|ltd|llc|gmbh|co|team)\.?$`)
+)
+
+// candidateProductsForWindows returns the display name of a Windows program without the version or any details in
+// parentheses (e.g. "7-Zip 21.07 (x64)" -> "7-zip").
+func candidateProductsForWindows(p pkg.Package) []string {
+	metadata, ok := p.Metadata.(pkg.WindowsRegistryMetadata)
+	if !ok {
+		return nil
+	}
+
+	name := windowsDisplayNameDetailsPattern.ReplaceAllString(metadata.DisplayName, " ")
+	if metadata.DisplayVersion != "" {
+		name = strings.ReplaceAll(name, metadata.DisplayVersion, " ")
+	}
+
+	product := normalizeWindowsName(name)
+	if product == "" {
+		return nil
+	}
+	return []string{product}
+}
+
+// candidateVendorsForWindows returns the publisher of a Windows program without any legal entity suffix (e.g.
+// "Microsoft Corporation" -> "microsoft").
+func candidateVendorsForWindows(p pkg.Package) fieldCandidateSet {
+	metadata, ok := p.Metadata.(pkg.WindowsRegistryMetadata)
+	if !ok {
+		return nil
+	}
+
+	vendors := newFieldCandidateSet()
+
+	vendor := normalizeWindowsName(windowsPublisherSuffixPattern.ReplaceAllString(strings.TrimSpace(metadata.Publisher), ""))
+	if vendor != "" {
+		vendors.add(fieldCandidate{
+			value:                 vendor,
+			disallowSubSelections: true,
+		})
+	}
+
+	return vendors
+}
+
+// normalizeWindowsName lowercases the given name and joins the words of the name with underscores.
+func normalizeWindowsName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "_")
+}
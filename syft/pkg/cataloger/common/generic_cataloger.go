@@ -5,6 +5,8 @@ package common
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/anchore/syft/syft/artifact"
 
@@ -38,39 +40,151 @@ func (c *GenericCataloger) Name() string {
 
 // Catalog is given an object to resolve file references and content, this function returns any discovered Packages after analyzing the catalog source.
 func (c *GenericCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	return c.CatalogWithCache(resolver, nil)
+}
+
+// CatalogWithCache is the same as Catalog, however, the parse results for each file are loaded from the given cache
+// when present (otherwise the file is parsed and the results are stored). Since each parser only depends on the path
+// and contents of a single file, the parse results may be reused for the same file within another scan. Files that
+// fail to parse are skipped, and are returned as ParseErrors along with the packages found within all other files.
+func (c *GenericCataloger) CatalogWithCache(resolver source.FileResolver, cache ParseCache) ([]pkg.Package, []artifact.Relationship, error) {
+	return c.CatalogInParallel(resolver, cache, 1)
+}
+
+// fileResult is the output of parsing a single file.
+type fileResult struct {
+	packages      []pkg.Package
+	relationships []artifact.Relationship
+	parseErr      *ParseError
+	err           error
+}
+
+// CatalogInParallel is the same as CatalogWithCache (where the cache is optional), however, up to the given number of
+// files are parsed concurrently (values less than 1 are treated as 1). The results are always merged in the same order
+// (sorted by file location), so the results are the same regardless of parallelism.
+func (c *GenericCataloger) CatalogInParallel(resolver source.FileResolver, cache ParseCache, parallelism int) ([]pkg.Package, []artifact.Relationship, error) {
+	parserByLocation := c.selectFiles(resolver)
+	locations := make([]source.Location, 0, len(parserByLocation))
+	for location := range parserByLocation {
+		locations = append(locations, location)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].RealPath != locations[j].RealPath {
+			return locations[i].RealPath < locations[j].RealPath
+		}
+		return locations[i].FileSystemID < locations[j].FileSystemID
+	})
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(locations) {
+		parallelism = len(locations)
+	}
+
+	results := make([]fileResult, len(locations))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// each worker only writes to the result slots for the files it has been given
+			for idx := range indexes {
+				results[idx] = c.catalogFile(resolver, cache, locations[idx], parserByLocation[locations[idx]])
+			}
+		}()
+	}
+
+	for idx := range locations {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
 	var packages []pkg.Package
 	var relationships []artifact.Relationship
+	var parseErrs ParseErrors
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			return nil, nil, result.err
+		case result.parseErr != nil:
+			parseErrs = append(parseErrs, *result.parseErr)
+			continue
+		}
+		packages = append(packages, result.packages...)
+		relationships = append(relationships, result.relationships...)
+	}
+
+	parseErrs.Sort()
+	return packages, relationships, parseErrs.OrNil()
+}
+
+// catalogFile finds the packages within a single file with the given parser (loading the parse results from the given
+// cache when present).
+func (c *GenericCataloger) catalogFile(resolver source.FileResolver, cache ParseCache, location source.Location, parser ParserFn) fileResult {
+	var discoveredPackages []pkg.Package
+	var discoveredRelationships []artifact.Relationship
+	cached := false
+	if cache != nil {
+		discoveredPackages, discoveredRelationships, cached = cache.Load(c.upstreamCataloger, location)
+	}
 
-	for location, parser := range c.selectFiles(resolver) {
+	if !cached {
 		contentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			// TODO: fail or log?
-			return nil, nil, fmt.Errorf("unable to fetch contents for location=%v : %w", location, err)
+			return fileResult{err: fmt.Errorf("unable to fetch contents for location=%v : %w", location, err)}
 		}
 
-		discoveredPackages, discoveredRelationships, err := parser(location.RealPath, contentReader)
+		discoveredPackages, discoveredRelationships, err = parser(location.RealPath, contentReader)
 		internal.CloseAndLogError(contentReader, location.VirtualPath)
 		if err != nil {
-			// TODO: should we fail? or only log?
 			log.Warnf("cataloger '%s' failed to parse entries (location=%+v): %+v", c.upstreamCataloger, location, err)
-			continue
+			return fileResult{parseErr: &ParseError{Cataloger: c.upstreamCataloger, Location: location, Err: err}}
 		}
 
-		for _, p := range discoveredPackages {
-			p.FoundBy = c.upstreamCataloger
-			p.Locations = append(p.Locations, location)
-
-			packages = append(packages, p)
+		if cache != nil {
+			cache.Store(c.upstreamCataloger, location, discoveredPackages, discoveredRelationships)
 		}
+	}
+
+	// the relationships found by the parser refer to packages without a cataloger or location (thus different IDs)
+	var result fileResult
+	found := make(map[artifact.ID]pkg.Package)
+	for _, p := range discoveredPackages {
+		originalID := p.ID()
+		p.FoundBy = c.upstreamCataloger
+		p.Locations = append(p.Locations, location)
 
-		relationships = append(relationships, discoveredRelationships...)
+		found[originalID] = p
+		result.packages = append(result.packages, p)
 	}
-	return packages, relationships, nil
+
+	for _, r := range discoveredRelationships {
+		if p, ok := r.From.(pkg.Package); ok {
+			if f, exists := found[p.ID()]; exists {
+				r.From = f
+			}
+		}
+		if p, ok := r.To.(pkg.Package); ok {
+			if f, exists := found[p.ID()]; exists {
+				r.To = f
+			}
+		}
+		result.relationships = append(result.relationships, r)
+	}
+	return result
 }
 
-// SelectFiles takes a set of file trees and resolves and file references of interest for future cataloging
+// SelectFiles takes a set of file trees and resolves and file references of interest for future cataloging. Each file
+// is only selected once, by its canonical location, even when also reached through symlinks (e.g. a symlinked project
+// directory), since parsing every alias of the same file would only find the same packages again.
 func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[source.Location]ParserFn {
-	var parserByLocation = make(map[source.Location]ParserFn)
+	var locations []source.Location
+	var parserByCoordinates = make(map[source.Coordinates]ParserFn)
 
 	// select by exact path
 	for path, parser := range c.pathParsers {
@@ -79,7 +193,8 @@ func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[sou
 			log.Warnf("cataloger failed to select files by path: %+v", err)
 		}
 		for _, f := range files {
-			parserByLocation[f] = parser
+			locations = append(locations, f)
+			parserByCoordinates[f.Coordinates] = parser
 		}
 	}
 
@@ -90,9 +205,15 @@ func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[sou
 			log.Warnf("failed to find files by glob: %s", globPattern)
 		}
 		for _, f := range fileMatches {
-			parserByLocation[f] = parser
+			locations = append(locations, f)
+			parserByCoordinates[f.Coordinates] = parser
 		}
 	}
 
+	var parserByLocation = make(map[source.Location]ParserFn)
+	for _, l := range source.CanonicalLocations(locations) {
+		parserByLocation[l] = parserByCoordinates[l.Coordinates]
+	}
+
 	return parserByLocation
 }
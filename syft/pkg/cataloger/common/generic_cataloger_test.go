@@ -1,12 +1,14 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
@@ -69,3 +71,81 @@ func TestGenericCataloger(t *testing.T) {
 		}
 	}
 }
+
+func TestGenericCataloger_relationships(t *testing.T) {
+	dependencyParser := func(_ string, _ io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+		app := pkg.Package{Name: "app"}
+		lib := pkg.Package{Name: "lib"}
+		return []pkg.Package{app, lib}, []artifact.Relationship{
+			{From: lib, To: app, Type: artifact.DependencyOfRelationship},
+		}, nil
+	}
+
+	resolver := source.NewMockResolverForPaths("test-fixtures/a-path.txt")
+	cataloger := NewGenericCataloger(nil, map[string]ParserFn{"**/a-path.txt": dependencyParser}, "some-cataloger")
+
+	packages, relationships, err := cataloger.Catalog(resolver)
+	assert.NoError(t, err)
+	assert.Len(t, packages, 2)
+
+	// the relationships must refer to the packages as returned by the cataloger (with the cataloger name and location)
+	assert.Equal(t, []artifact.Relationship{
+		{From: packages[1], To: packages[0], Type: artifact.DependencyOfRelationship},
+	}, relationships)
+}
+
+func TestGenericCataloger_parseErrors(t *testing.T) {
+	failingParser := func(_ string, _ io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+		return nil, nil, fmt.Errorf("corrupt file")
+	}
+
+	resolver := source.NewMockResolverForPaths("test-fixtures/a-path.txt", "test-fixtures/another-path.txt", "test-fixtures/last/path.txt")
+	cataloger := NewGenericCataloger(
+		map[string]ParserFn{
+			"test-fixtures/a-path.txt":    parser,
+			"test-fixtures/last/path.txt": failingParser,
+		},
+		map[string]ParserFn{
+			"**/another-path.txt": failingParser,
+		},
+		"some-cataloger",
+	)
+
+	packages, _, err := cataloger.Catalog(resolver)
+
+	// the files that parse are still cataloged
+	require.Len(t, packages, 1)
+	assert.Equal(t, "test-fixtures/a-path.txt file contents!", packages[0].Name)
+
+	var parseErrs ParseErrors
+	require.True(t, errors.As(err, &parseErrs))
+	require.Len(t, parseErrs, 2)
+	for i, expected := range []string{"test-fixtures/another-path.txt", "test-fixtures/last/path.txt"} {
+		assert.Equal(t, expected, parseErrs[i].Location.RealPath)
+		assert.Equal(t, "some-cataloger", parseErrs[i].Cataloger)
+		assert.EqualError(t, parseErrs[i].Err, "corrupt file")
+	}
+
+	assert.EqualError(t, err, `failed to parse 2 files:
+  - test-fixtures/another-path.txt (cataloger="some-cataloger"): corrupt file
+  - test-fixtures/last/path.txt (cataloger="some-cataloger"): corrupt file`)
+}
+
+func TestGenericCataloger_CatalogInParallel(t *testing.T) {
+	paths := []string{"test-fixtures/last/path.txt", "test-fixtures/another-path.txt", "test-fixtures/a-path.txt"}
+	resolver := source.NewMockResolverForPaths(paths...)
+	cataloger := NewGenericCataloger(nil, map[string]ParserFn{"**/*.txt": parser}, "some-cataloger")
+
+	expected, _, err := cataloger.Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, expected, len(paths))
+
+	for _, parallelism := range []int{0, 1, 2, 10} {
+		t.Run(fmt.Sprintf("parallelism=%d", parallelism), func(t *testing.T) {
+			actual, _, err := cataloger.CatalogInParallel(resolver, nil, parallelism)
+			require.NoError(t, err)
+			// the results (and their order) must not depend on the parallelism
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
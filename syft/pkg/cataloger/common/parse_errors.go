@@ -0,0 +1,78 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+// ParseError describes a file that a cataloger selected, however, failed to parse (e.g. a corrupt lockfile).
+type ParseError struct {
+	Cataloger string
+	Location  source.Location
+	Err       error
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("cataloger %q failed to parse %s: %v", e.Cataloger, e.path(), e.Err)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// path returns the path of the file that failed to parse, as seen from the source (and the layer, when within an image).
+func (e ParseError) path() string {
+	path := e.Location.RealPath
+	if e.Location.VirtualPath != "" && e.Location.VirtualPath != path {
+		path = fmt.Sprintf("%s (via %s)", path, e.Location.VirtualPath)
+	}
+	if e.Location.FileSystemID != "" {
+		path = fmt.Sprintf("%s in layer %s", path, e.Location.FileSystemID)
+	}
+	return path
+}
+
+// ParseErrors are all files that failed to parse. Since a file that fails to parse should not stop the remaining files
+// from being cataloged, catalogers return ParseErrors along with the packages found within all other files (callers
+// should check for ParseErrors with errors.As before discarding the results of a cataloger that returns an error).
+type ParseErrors []ParseError
+
+// Error summarizes every file that failed to parse, one per line.
+func (e ParseErrors) Error() string {
+	var sb strings.Builder
+	if len(e) == 1 {
+		sb.WriteString("failed to parse 1 file:")
+	} else {
+		fmt.Fprintf(&sb, "failed to parse %d files:", len(e))
+	}
+	for _, err := range e {
+		fmt.Fprintf(&sb, "\n  - %s (cataloger=%q): %v", err.path(), err.Cataloger, err.Err)
+	}
+	return sb.String()
+}
+
+// Sort orders the errors by path (and by cataloger for the same path), since catalogers may not select files in any
+// particular order.
+func (e ParseErrors) Sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		if e[i].Location.RealPath != e[j].Location.RealPath {
+			return e[i].Location.RealPath < e[j].Location.RealPath
+		}
+		if e[i].Location.FileSystemID != e[j].Location.FileSystemID {
+			return e[i].Location.FileSystemID < e[j].Location.FileSystemID
+		}
+		return e[i].Cataloger < e[j].Cataloger
+	})
+}
+
+// OrNil returns the errors as an error, which is nil when there are no errors (avoiding a non-nil error interface
+// holding an empty slice).
+func (e ParseErrors) OrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
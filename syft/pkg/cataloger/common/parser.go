@@ -5,7 +5,18 @@ import (
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 )
 
 // ParserFn standardizes a function signature for parser functions that accept the virtual file path (not usable for file reads) and contents and return any discovered packages from that file
 type ParserFn func(string, io.Reader) ([]pkg.Package, []artifact.Relationship, error)
+
+// ParseCache stores the packages and relationships that a cataloger parsed from a single file, so the file does not
+// need to be parsed again (e.g. the same image layer within another image). Implementations decide which locations
+// can be cached, and must be safe for concurrent use.
+type ParseCache interface {
+	// Load returns the cached parse results for the given file, if there are any.
+	Load(cataloger string, location source.Location) ([]pkg.Package, []artifact.Relationship, bool)
+	// Store records the parse results for the given file.
+	Store(cataloger string, location source.Location, packages []pkg.Package, relationships []artifact.Relationship)
+}
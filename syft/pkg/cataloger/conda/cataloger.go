@@ -0,0 +1,17 @@
+/*
+Package conda provides a concrete Cataloger implementation for packages installed within conda environments.
+*/
+package conda
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// NewCondaMetaCataloger returns a new cataloger for the conda-meta/*.json files within a conda environment prefix.
+func NewCondaMetaCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/conda-meta/*.json": parseCondaMeta,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "conda-meta-cataloger")
+}
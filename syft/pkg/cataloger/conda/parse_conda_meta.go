@@ -0,0 +1,93 @@
+package conda
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseCondaMeta
+
+// well-known channel locations, where the channel name is the remainder of the channel URL
+var channelURLPrefixes = []string{
+	"https://conda.anaconda.org/",
+	"https://repo.anaconda.com/",
+}
+
+// condaMeta is the package record written by conda for each package installed into an environment
+// (see https://docs.conda.io/projects/conda/en/latest/user-guide/concepts/environments.html).
+type condaMeta struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Build       string   `json:"build"`
+	BuildNumber int      `json:"build_number"`
+	Channel     string   `json:"channel"`
+	Subdir      string   `json:"subdir"`
+	License     string   `json:"license"`
+	URL         string   `json:"url"`
+	MD5         string   `json:"md5"`
+	SHA256      string   `json:"sha256"`
+	Depends     []string `json:"depends"`
+}
+
+// parseCondaMeta is a parser function for conda-meta/*.json contents, returning the single conda package described.
+func parseCondaMeta(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var meta condaMeta
+	if err := json.NewDecoder(reader).Decode(&meta); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse conda-meta file: %w", err)
+	}
+
+	if meta.Name == "" || meta.Version == "" {
+		return nil, nil, nil
+	}
+
+	var licenses []string
+	if meta.License != "" {
+		licenses = []string{meta.License}
+	}
+
+	return []pkg.Package{
+		{
+			Name:         meta.Name,
+			Version:      meta.Version,
+			Licenses:     licenses,
+			Type:         pkg.CondaPkg,
+			MetadataType: pkg.CondaMetadataType,
+			Metadata: pkg.CondaMetadata{
+				Name:        meta.Name,
+				Version:     meta.Version,
+				Build:       meta.Build,
+				BuildNumber: meta.BuildNumber,
+				Channel:     channelName(meta.Channel, meta.Subdir),
+				Subdir:      meta.Subdir,
+				License:     meta.License,
+				URL:         meta.URL,
+				MD5:         meta.MD5,
+				SHA256:      meta.SHA256,
+				Depends:     meta.Depends,
+			},
+		},
+	}, nil, nil
+}
+
+// channelName returns the canonical name for the given channel (e.g. "conda-forge" or "pkgs/main"), which may be
+// recorded as a full URL that includes the platform subdirectory
+// (e.g. "https://conda.anaconda.org/conda-forge/linux-64").
+func channelName(channel, subdir string) string {
+	channel = strings.TrimSuffix(channel, "/")
+	if subdir != "" {
+		channel = strings.TrimSuffix(channel, "/"+subdir)
+	}
+	for _, prefix := range channelURLPrefixes {
+		if strings.HasPrefix(channel, prefix) {
+			return strings.TrimPrefix(channel, prefix)
+		}
+	}
+	return channel
+}
@@ -0,0 +1,128 @@
+package conda
+
+import (
+	"os"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondaMeta(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		expected    pkg.Package
+		expectedURL string
+	}{
+		{
+			fixture: "test-fixtures/conda-meta/six-1.16.0-pyhd3eb1b0_0.json",
+			expected: pkg.Package{
+				Name:         "six",
+				Version:      "1.16.0",
+				Licenses:     []string{"MIT"},
+				Type:         pkg.CondaPkg,
+				MetadataType: pkg.CondaMetadataType,
+				Metadata: pkg.CondaMetadata{
+					Name:        "six",
+					Version:     "1.16.0",
+					Build:       "pyhd3eb1b0_0",
+					BuildNumber: 0,
+					Channel:     "pkgs/main",
+					Subdir:      "noarch",
+					License:     "MIT",
+					URL:         "https://repo.anaconda.com/pkgs/main/noarch/six-1.16.0-pyhd3eb1b0_0.conda",
+					MD5:         "529ecd7d39bf53fbfcbf0edbd3dc7b9a",
+					SHA256:      "1b2ae3dd2cb6bca2adf9ab4a28dbb8bc80e6d4d2aa1ea8d7c2d7b82ddb5e7ab9",
+					Depends:     []string{"python"},
+				},
+			},
+			expectedURL: "pkg:conda/six@1.16.0?build=pyhd3eb1b0_0&channel=pkgs%2Fmain&subdir=noarch",
+		},
+		{
+			fixture: "test-fixtures/conda-meta/openssl-1.1.1l-h7f98852_0.json",
+			expected: pkg.Package{
+				Name:         "openssl",
+				Version:      "1.1.1l",
+				Licenses:     []string{"OpenSSL"},
+				Type:         pkg.CondaPkg,
+				MetadataType: pkg.CondaMetadataType,
+				Metadata: pkg.CondaMetadata{
+					Name:        "openssl",
+					Version:     "1.1.1l",
+					Build:       "h7f98852_0",
+					BuildNumber: 0,
+					Channel:     "conda-forge",
+					Subdir:      "linux-64",
+					License:     "OpenSSL",
+					URL:         "https://conda.anaconda.org/conda-forge/linux-64/openssl-1.1.1l-h7f98852_0.tar.bz2",
+					MD5:         "de7b38a1542dbe6f41653a8ae71adc53",
+					SHA256:      "7a7e3d16b2d1a8a5b5e4ae0f5a0db4e1a5b4e7b0c1f2e6a1d7e2c3b4a5f6e7d8",
+					Depends: []string{
+						"__glibc >=2.17,<3.0.a0",
+						"ca-certificates",
+						"libgcc-ng >=9.3.0",
+					},
+				},
+			},
+			expectedURL: "pkg:conda/openssl@1.1.1l?build=h7f98852_0&channel=conda-forge&subdir=linux-64",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			require.NoError(t, err)
+
+			actual, _, err := parseCondaMeta(fixture.Name(), fixture)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+
+			for _, d := range deep.Equal(test.expected, actual[0]) {
+				t.Errorf("diff: %+v", d)
+			}
+
+			// the build string distinguishes between builds of the same version (e.g. for different python versions)
+			assert.Equal(t, test.expectedURL, actual[0].Metadata.(pkg.CondaMetadata).PackageURL())
+		})
+	}
+}
+
+func Test_channelName(t *testing.T) {
+	tests := []struct {
+		channel  string
+		subdir   string
+		expected string
+	}{
+		{
+			channel:  "https://conda.anaconda.org/conda-forge/linux-64",
+			subdir:   "linux-64",
+			expected: "conda-forge",
+		},
+		{
+			channel:  "https://repo.anaconda.com/pkgs/main/noarch",
+			subdir:   "noarch",
+			expected: "pkgs/main",
+		},
+		{
+			channel:  "conda-forge",
+			subdir:   "linux-64",
+			expected: "conda-forge",
+		},
+		{
+			channel:  "https://conda.example.com/internal/linux-64/",
+			subdir:   "linux-64",
+			expected: "https://conda.example.com/internal",
+		},
+		{
+			channel:  "",
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.channel, func(t *testing.T) {
+			assert.Equal(t, test.expected, channelName(test.channel, test.subdir))
+		})
+	}
+}
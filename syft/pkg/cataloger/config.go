@@ -0,0 +1,47 @@
+package cataloger
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+	"github.com/anchore/syft/syft/source"
+)
+
+// Config represents the options for cataloging packages from a source.
+type Config struct {
+	Scope       source.Scope // the perspective of the source to catalog (e.g. squashed or all layers of an image)
+	Parallelism int          // the number of catalogers (and files within each cataloger) that may be processed concurrently (values less than 1 are treated as 1)
+	// SkipFileOwnership indicates that packages should not be related to the files that they own (which requires
+	// resolving every owned file within the source).
+	SkipFileOwnership bool
+	// SkipDeduplication indicates that the same package found multiple times (by several catalogers or at several paths)
+	// should be reported as separate packages instead of a single package with all locations merged (useful for debugging).
+	SkipDeduplication bool
+	// DetectLicenses indicates that packages without licenses within their metadata should have licenses concluded from
+	// the license files that they own (e.g. LICENSE or COPYING files).
+	DetectLicenses bool
+	// NormalizeVersions indicates that packages should record their version in a form that is comparable across
+	// ecosystems (e.g. "1:2.30-1" as "2.30" for deb packages, or "v1.2.3" as "1.2.3" for go modules), see
+	// pkg.Package.NormalizedVersion. The raw version is always kept.
+	NormalizeVersions bool
+	// Catalogers selects the catalogers to run by name, where names prefixed with "+" or "-" add to or remove from the
+	// default catalogers for the source, and any name without a prefix only runs the named catalogers (see
+	// SelectCatalogers). All default catalogers are run when empty.
+	Catalogers []string
+	// LayerCacheDir is the directory where the parse results for files within image layers are cached, so the same
+	// layers are not parsed again by later scans (e.g. of images sharing base layers). Caching is disabled when empty.
+	LayerCacheDir string
+	// FailOnError indicates that cataloging should fail when any file selected by a cataloger fails to parse (e.g. a
+	// corrupt lockfile), returning common.ParseErrors that describe every such file. Otherwise, these files are skipped
+	// with a warning and all other files are still cataloged.
+	FailOnError bool
+	// CPERules suppress generated CPEs that are known to be poor candidates (e.g. wildcard vendors for some package
+	// types), which would otherwise cause false positives when matching vulnerabilities.
+	CPERules cpe.Rules
+}
+
+// DefaultConfig returns a Config that catalogs the squashed perspective of the source, one cataloger at a time.
+func DefaultConfig() Config {
+	return Config{
+		Scope:       source.SquashedScope,
+		Parallelism: 1,
+	}
+}
@@ -0,0 +1,17 @@
+/*
+Package dart provides a concrete Cataloger implementation for Dart (and Flutter) pubspec.lock files.
+*/
+package dart
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// NewPubspecLockCataloger returns a new Dart pubspec.lock cataloger object.
+func NewPubspecLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/pubspec.lock": parsePubspecLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "dart-pubspec-lock-cataloger")
+}
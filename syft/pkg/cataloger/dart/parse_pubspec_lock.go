@@ -0,0 +1,113 @@
+package dart
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"gopkg.in/yaml.v2"
+)
+
+// integrity check
+var _ common.ParserFn = parsePubspecLock
+
+type pubspecLock struct {
+	Packages map[string]pubspecLockPackage `yaml:"packages"`
+}
+
+type pubspecLockPackage struct {
+	Dependency  string                 `yaml:"dependency"`
+	Description pubspecLockDescription `yaml:"description"`
+	Source      string                 `yaml:"source"`
+	Version     string                 `yaml:"version"`
+}
+
+// pubspecLockDescription describes where a package was resolved from, which depends on the source: hosted packages
+// have a name and repository URL, git packages have a repository URL, ref, and resolved ref (with the path of the
+// package within the repository), and path packages have a local path. SDK packages (e.g. flutter) are only described
+// by the name of the SDK.
+type pubspecLockDescription struct {
+	Name        string `yaml:"name"`
+	URL         string `yaml:"url"`
+	Path        string `yaml:"path"`
+	Ref         string `yaml:"ref"`
+	ResolvedRef string `yaml:"resolved-ref"`
+	SDK         string `yaml:"-"`
+}
+
+// UnmarshalYAML accepts either a description mapping or the plain SDK name used by SDK packages.
+func (d *pubspecLockDescription) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var sdk string
+	if err := unmarshal(&sdk); err == nil {
+		*d = pubspecLockDescription{SDK: sdk}
+		return nil
+	}
+
+	type description pubspecLockDescription
+	var fields description
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	*d = pubspecLockDescription(fields)
+	return nil
+}
+
+// parsePubspecLock is a parser function for pubspec.lock contents, returning all packages resolved for the Dart
+// project (sorted by name).
+func parsePubspecLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var lock pubspecLock
+	if err := yaml.NewDecoder(reader).Decode(&lock); err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to parse pubspec.lock file: %w", err)
+	}
+
+	names := make([]string, 0, len(lock.Packages))
+	for name := range lock.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pkgs []pkg.Package
+	for _, name := range names {
+		pkgs = append(pkgs, newPubspecLockPackage(name, lock.Packages[name]))
+	}
+
+	return pkgs, nil, nil
+}
+
+func newPubspecLockPackage(name string, p pubspecLockPackage) pkg.Package {
+	metadata := pkg.DartPubMetadata{
+		Name:       name,
+		Version:    p.Version,
+		Source:     p.Source,
+		Dependency: p.Dependency,
+	}
+
+	switch p.Source {
+	case "hosted":
+		metadata.HostedURL = p.Description.URL
+	case "git":
+		metadata.VcsURL = p.Description.URL
+		metadata.ResolvedRef = p.Description.ResolvedRef
+		if metadata.ResolvedRef == "" {
+			metadata.ResolvedRef = p.Description.Ref
+		}
+		// the path of the package within the repository ("." for the repository root)
+		if p.Description.Path != "." {
+			metadata.Path = p.Description.Path
+		}
+	case "path":
+		metadata.Path = p.Description.Path
+	}
+
+	return pkg.Package{
+		Name:         name,
+		Version:      p.Version,
+		Language:     pkg.Dart,
+		Type:         pkg.DartPubPkg,
+		MetadataType: pkg.DartPubMetadataType,
+		Metadata:     metadata,
+	}
+}
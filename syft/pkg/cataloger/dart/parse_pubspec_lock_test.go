@@ -0,0 +1,133 @@
+package dart
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePubspecLock(t *testing.T) {
+	expected := []pkg.Package{
+		{
+			Name:         "async",
+			Version:      "2.8.2",
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:       "async",
+				Version:    "2.8.2",
+				Source:     "hosted",
+				Dependency: "transitive",
+				HostedURL:  "https://pub.dartlang.org",
+			},
+		},
+		{
+			Name:         "flutter",
+			Version:      "0.0.0",
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:       "flutter",
+				Version:    "0.0.0",
+				Source:     "sdk",
+				Dependency: "direct main",
+			},
+		},
+		{
+			Name:         "flutter_lints",
+			Version:      "1.0.4",
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:       "flutter_lints",
+				Version:    "1.0.4",
+				Source:     "hosted",
+				Dependency: "direct dev",
+				HostedURL:  "https://pub.dartlang.org",
+			},
+		},
+		{
+			Name:         "flutter_markdown",
+			Version:      "0.6.9",
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:        "flutter_markdown",
+				Version:     "0.6.9",
+				Source:      "git",
+				Dependency:  "direct main",
+				VcsURL:      "https://github.com/flutter/packages.git",
+				ResolvedRef: "4a8ee1b0e8e4ba9d4e5ac3c4c2cd6ee0c4b2c0c3",
+				Path:        "packages/flutter_markdown",
+			},
+		},
+		{
+			Name:         "http",
+			Version:      "0.13.4",
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:       "http",
+				Version:    "0.13.4",
+				Source:     "hosted",
+				Dependency: "direct main",
+				HostedURL:  "https://pub.dartlang.org",
+			},
+		},
+		{
+			Name:         "shared_models",
+			Version:      "1.0.0",
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:       "shared_models",
+				Version:    "1.0.0",
+				Source:     "path",
+				Dependency: "direct overridden",
+				Path:       "../shared_models",
+			},
+		},
+	}
+
+	fixture, err := os.Open("test-fixtures/pubspec.lock")
+	require.NoError(t, err)
+
+	actual, _, err := parsePubspecLock(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	for _, d := range deep.Equal(expected, actual) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	var direct, transitive []string
+	for _, p := range actual {
+		if p.Metadata.(pkg.DartPubMetadata).IsDirect() {
+			direct = append(direct, p.Name)
+		} else {
+			transitive = append(transitive, p.Name)
+		}
+	}
+	assert.Equal(t, []string{"flutter", "flutter_lints", "flutter_markdown", "http", "shared_models"}, direct)
+	assert.Equal(t, []string{"async"}, transitive)
+}
+
+func TestParsePubspecLock_invalid(t *testing.T) {
+	_, _, err := parsePubspecLock("pubspec.lock", strings.NewReader("packages: [not, a, mapping]"))
+	assert.Error(t, err)
+
+	// an empty lock file (e.g. a project without dependencies) has no packages
+	actual, _, err := parsePubspecLock("pubspec.lock", strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
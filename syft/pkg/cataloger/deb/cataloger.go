@@ -15,6 +15,7 @@ import (
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -44,6 +45,7 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 	}
 
 	var allPackages []pkg.Package
+	var parseErrs common.ParseErrors
 	for _, dbLocation := range dbFileMatches {
 		dbContents, err := resolver.FileContentsByLocation(dbLocation)
 		if err != nil {
@@ -53,7 +55,9 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 		pkgs, err := parseDpkgStatus(dbContents)
 		internal.CloseAndLogError(dbContents, dbLocation.VirtualPath)
 		if err != nil {
-			return nil, nil, fmt.Errorf("unable to catalog dpkg package=%+v: %w", dbLocation.RealPath, err)
+			log.Warnf("cataloger '%s' failed to parse entries (location=%+v): %+v", c.Name(), dbLocation, err)
+			parseErrs = append(parseErrs, common.ParseError{Cataloger: c.Name(), Location: dbLocation, Err: err})
+			continue
 		}
 
 		for i := range pkgs {
@@ -72,7 +76,7 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 
 		allPackages = append(allPackages, pkgs...)
 	}
-	return allPackages, nil, nil
+	return allPackages, nil, parseErrs.OrNil()
 }
 
 func addLicenses(resolver source.FileResolver, dbLocation source.Location, p *pkg.Package) {
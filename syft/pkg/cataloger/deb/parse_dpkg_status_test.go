@@ -203,6 +203,39 @@ func TestMultiplePackages(t *testing.T) {
 	}
 }
 
+func TestSourceVersionPackage(t *testing.T) {
+	file, err := os.Open("test-fixtures/status/source-version")
+	if err != nil {
+		t.Fatal("Unable to read: ", err)
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			t.Fatal("closing file failed:", err)
+		}
+	}()
+
+	pkgs, err := parseDpkgStatus(file)
+	if err != nil {
+		t.Fatal("Unable to read file contents: ", err)
+	}
+
+	if len(pkgs) != 1 {
+		t.Fatalf("unexpected number of entries: %d", len(pkgs))
+	}
+
+	compareEntries(t, pkgs[0].Metadata.(pkg.DpkgMetadata), pkg.DpkgMetadata{
+		Package:       "libgcc1",
+		Source:        "gcc-8",
+		Version:       "1:8.3.0-6",
+		SourceVersion: "8.3.0-6",
+		Architecture:  "amd64",
+		InstalledSize: 116,
+		Maintainer:    "Debian GCC Maintainers <debian-gcc@lists.debian.org>",
+		Files:         []pkg.DpkgFileRecord{},
+	})
+}
+
 func TestSourceVersionExtract(t *testing.T) {
 
 	tests := []struct {
@@ -0,0 +1,152 @@
+package cataloger
+
+import (
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// packageKey is the identity of a package for the purposes of deduplication (irrespective of where or how the package
+// was found).
+type packageKey struct {
+	pkgType pkg.Type
+	name    string
+	version string
+	purl    string
+}
+
+func newPackageKey(p pkg.Package) packageKey {
+	return packageKey{
+		pkgType: p.Type,
+		name:    p.Name,
+		version: p.Version,
+		purl:    p.PURL,
+	}
+}
+
+// Deduplicate merges all packages with the same type, name, version, and PURL (e.g. the same package found by multiple
+// catalogers or at multiple paths) into the first package found, which takes on the locations, licenses, and CPEs of
+// every duplicate (all other fields, such as the metadata, are kept from the first package). The given relationships are
+// updated to refer to the merged packages (without duplicates).
+func Deduplicate(packages []pkg.Package, relationships []artifact.Relationship) ([]pkg.Package, []artifact.Relationship) {
+	var keys []packageKey
+	merged := make(map[packageKey]pkg.Package)
+	originalKeys := make(map[artifact.ID]packageKey)
+
+	for _, p := range packages {
+		key := newPackageKey(p)
+		originalKeys[p.ID()] = key
+
+		existing, exists := merged[key]
+		if !exists {
+			keys = append(keys, key)
+			merged[key] = p
+			continue
+		}
+		merged[key] = mergePackages(existing, p)
+	}
+
+	// note: the package ID is only stable once all duplicates have been merged
+	results := make([]pkg.Package, len(keys))
+	for i, key := range keys {
+		results[i] = merged[key]
+	}
+
+	identifiable := func(identifiable artifact.Identifiable) artifact.Identifiable {
+		if p, ok := identifiable.(pkg.Package); ok {
+			if key, exists := originalKeys[p.ID()]; exists {
+				return merged[key]
+			}
+		}
+		return identifiable
+	}
+
+	type relationshipKey struct {
+		from, to         artifact.ID
+		relationshipType artifact.RelationshipType
+	}
+	observed := make(map[relationshipKey]struct{})
+
+	var updated []artifact.Relationship
+	for _, r := range relationships {
+		r.From = identifiable(r.From)
+		r.To = identifiable(r.To)
+
+		key := relationshipKey{from: r.From.ID(), to: r.To.ID(), relationshipType: r.Type}
+		if _, exists := observed[key]; exists {
+			continue
+		}
+		observed[key] = struct{}{}
+		updated = append(updated, r)
+	}
+
+	return results, updated
+}
+
+// mergePackages adds the locations, licenses, and CPEs from the duplicate package that are not already on the given
+// package (all other fields are kept from the given package).
+func mergePackages(p, duplicate pkg.Package) pkg.Package {
+	p.Locations = mergeLocations(p.Locations, duplicate.Locations)
+	p.Licenses, p.LicensesConcluded = mergeLicenses(p, duplicate)
+
+	observedCPEs := internal.NewStringSet()
+	var cpes []pkg.CPE
+	for _, set := range [][]pkg.CPE{p.CPEs, duplicate.CPEs} {
+		for _, c := range set {
+			value := c.BindToFmtString()
+			if observedCPEs.Contains(value) {
+				continue
+			}
+			observedCPEs.Add(value)
+			cpes = append(cpes, c)
+		}
+	}
+	p.CPEs = cpes
+
+	return p
+}
+
+// mergeLicenses returns the licenses of both packages, where licenses declared within the package metadata take
+// precedence over licenses concluded from the package files (the two are never mixed).
+func mergeLicenses(p, duplicate pkg.Package) ([]string, bool) {
+	switch {
+	case len(duplicate.Licenses) == 0:
+		return p.Licenses, p.LicensesConcluded
+	case len(p.Licenses) == 0:
+		return duplicate.Licenses, duplicate.LicensesConcluded
+	case p.LicensesConcluded == duplicate.LicensesConcluded:
+		return mergeStrings(p.Licenses, duplicate.Licenses), p.LicensesConcluded
+	case p.LicensesConcluded:
+		return duplicate.Licenses, false
+	default:
+		return p.Licenses, false
+	}
+}
+
+// mergeLocations returns the locations of both packages, where each file is only listed once (by its canonical
+// location, see source.CanonicalLocations).
+func mergeLocations(locations, others []source.Location) []source.Location {
+	merged := make([]source.Location, 0, len(locations)+len(others))
+	merged = append(merged, locations...)
+	return source.CanonicalLocations(append(merged, others...))
+}
+
+func mergeStrings(values, others []string) []string {
+	if len(others) == 0 {
+		return values
+	}
+
+	observed := internal.NewStringSet()
+	var results []string
+	for _, set := range [][]string{values, others} {
+		for _, v := range set {
+			if observed.Contains(v) {
+				continue
+			}
+			observed.Add(v)
+			results = append(results, v)
+		}
+	}
+	return results
+}
@@ -0,0 +1,77 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseDotnetDeps
+
+// libraryTypePackage is the library type for NuGet packages (as opposed to "project" libraries, which are built from
+// the solution being described and are not distributed as packages).
+const libraryTypePackage = "package"
+
+type dotnetDeps struct {
+	Libraries map[string]dotnetDepsLibrary `json:"libraries"`
+}
+
+type dotnetDepsLibrary struct {
+	Type     string `json:"type"`
+	Path     string `json:"path"`
+	Sha512   string `json:"sha512"`
+	HashPath string `json:"hashPath"`
+}
+
+// parseDotnetDeps is a parser function for .NET *.deps.json contents, returning all NuGet packages discovered.
+func parseDotnetDeps(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var deps dotnetDeps
+	if err := json.NewDecoder(reader).Decode(&deps); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse deps.json file: %w", err)
+	}
+
+	// libraries are keyed by "name/version", sort for stable results
+	keys := make([]string, 0, len(deps.Libraries))
+	for key := range deps.Libraries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pkgs []pkg.Package
+	for _, key := range keys {
+		lib := deps.Libraries[key]
+		if lib.Type != libraryTypePackage {
+			continue
+		}
+
+		fields := strings.Split(key, "/")
+		if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+			continue
+		}
+		name, version := fields[0], fields[1]
+
+		pkgs = append(pkgs, pkg.Package{
+			Name:         name,
+			Version:      version,
+			Language:     pkg.Dotnet,
+			Type:         pkg.DotnetPkg,
+			MetadataType: pkg.DotnetDepsMetadataType,
+			Metadata: pkg.DotnetDepsMetadata{
+				Name:     name,
+				Version:  version,
+				Path:     lib.Path,
+				Sha512:   lib.Sha512,
+				HashPath: lib.HashPath,
+			},
+		})
+	}
+
+	return pkgs, nil, nil
+}
@@ -0,0 +1,103 @@
+package dotnet
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDotnetDeps(t *testing.T) {
+	// note: the "project" libraries (TestLibrary and TestCommon) are not expected
+	expected := []pkg.Package{
+		{
+			Name:         "AWSSDK.Core",
+			Version:      "3.7.10.6",
+			Language:     pkg.Dotnet,
+			Type:         pkg.DotnetPkg,
+			MetadataType: pkg.DotnetDepsMetadataType,
+			Metadata: pkg.DotnetDepsMetadata{
+				Name:     "AWSSDK.Core",
+				Version:  "3.7.10.6",
+				Path:     "awssdk.core/3.7.10.6",
+				Sha512:   "sha512-kHBB+QmosVaG6DpngXQ8OlLVVNMzltNITfsRr68Z90qO7dSqJ2EHNd8dtBU9kNvVsxvBGiVsCUwgj7gmBSQ1Q==",
+				HashPath: "awssdk.core.3.7.10.6.nupkg.sha512",
+			},
+		},
+		{
+			Name:         "Microsoft.Extensions.DependencyInjection.Abstractions",
+			Version:      "6.0.0",
+			Language:     pkg.Dotnet,
+			Type:         pkg.DotnetPkg,
+			MetadataType: pkg.DotnetDepsMetadataType,
+			Metadata: pkg.DotnetDepsMetadata{
+				Name:     "Microsoft.Extensions.DependencyInjection.Abstractions",
+				Version:  "6.0.0",
+				Path:     "microsoft.extensions.dependencyinjection.abstractions/6.0.0",
+				Sha512:   "sha512-xlzi2IYREJH3/m6+lUrQlujzX8wDitm4QGnUu6kUXTQAWPuZY8i+ticFJbzfqaetLA6KR/rO6Ew/HuYD+bxifg==",
+				HashPath: "microsoft.extensions.dependencyinjection.abstractions.6.0.0.nupkg.sha512",
+			},
+		},
+		{
+			Name:         "Microsoft.Extensions.DependencyInjection",
+			Version:      "6.0.0",
+			Language:     pkg.Dotnet,
+			Type:         pkg.DotnetPkg,
+			MetadataType: pkg.DotnetDepsMetadataType,
+			Metadata: pkg.DotnetDepsMetadata{
+				Name:     "Microsoft.Extensions.DependencyInjection",
+				Version:  "6.0.0",
+				Path:     "microsoft.extensions.dependencyinjection/6.0.0",
+				Sha512:   "sha512-k6PWQMuoBDGGHOQTtyois2u4AwyVcIwL2LaSLlTZQm2CYcJ1pxbt6jfAnpWmzENA/wfrYRI/X9DTLoUkE4AsLw==",
+				HashPath: "microsoft.extensions.dependencyinjection.6.0.0.nupkg.sha512",
+			},
+		},
+		{
+			Name:         "Serilog",
+			Version:      "2.10.0",
+			Language:     pkg.Dotnet,
+			Type:         pkg.DotnetPkg,
+			MetadataType: pkg.DotnetDepsMetadataType,
+			Metadata: pkg.DotnetDepsMetadata{
+				Name:     "Serilog",
+				Version:  "2.10.0",
+				Path:     "serilog/2.10.0",
+				Sha512:   "sha512-+QX0hmf37a0/OZLxM3wL7V6/ADvC1XihXN4Kq/p6d8lCPfgkRdiuhbWlMaFjR9Av0dy5F0+MBeDmDdRZN/YwQA==",
+				HashPath: "serilog.2.10.0.nupkg.sha512",
+			},
+		},
+	}
+
+	fixture, err := os.Open("test-fixtures/TestLibrary.deps.json")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	// TODO: no relationships are under test yet
+	actual, _, err := parseDotnetDeps(fixture.Name(), fixture)
+	if err != nil {
+		t.Fatalf("failed to parse deps.json: %+v", err)
+	}
+
+	differences := deep.Equal(expected, actual)
+	if differences != nil {
+		t.Errorf("returned package list differed from expectation: %+v", differences)
+	}
+}
+
+func TestParseDotnetDeps_invalid(t *testing.T) {
+	_, _, err := parseDotnetDeps("bad.deps.json", strings.NewReader("{not json"))
+	assert.Error(t, err)
+}
+
+func TestDotnetDepsMetadata_pURL(t *testing.T) {
+	metadata := pkg.DotnetDepsMetadata{
+		Name:    "Microsoft.Extensions.DependencyInjection",
+		Version: "6.0.0",
+	}
+
+	assert.Equal(t, "pkg:nuget/Microsoft.Extensions.DependencyInjection@6.0.0", metadata.PackageURL())
+}
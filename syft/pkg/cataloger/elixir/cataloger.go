@@ -0,0 +1,17 @@
+/*
+Package elixir provides a concrete Cataloger implementation for Elixir mix.lock files.
+*/
+package elixir
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// NewMixLockCataloger returns a new Elixir mix.lock cataloger object.
+func NewMixLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/mix.lock": parseMixLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "elixir-mix-lock-cataloger")
+}
@@ -0,0 +1,87 @@
+package elixir
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseMixLock
+
+var (
+	// matches a hex sourced dependency, e.g.
+	//   "plug": {:hex, :plug, "1.12.1", "<inner hash>", [:mix], [<dependencies>], "hexpm", "<outer hash>"},
+	// where the package name (which may differ from the application name used as the map key) is the second element.
+	hexEntryPattern = regexp.MustCompile(`"[^"]+":\s*\{:hex,\s*:"?([^",\s]+)"?,\s*"([^"]+)",\s*"([^"]*)"`)
+
+	// matches the repository and outer hash that end a hex sourced dependency, which are missing from lock files
+	// written by older versions of mix (the outer hash was added in Elixir 1.10, the repository in Elixir 1.6).
+	hexEntrySuffixPattern = regexp.MustCompile(`\],\s*"([^"]*)"(?:,\s*"([^"]*)")?\}[},\s]*$`)
+
+	// matches a git sourced dependency, e.g.
+	//   "my_dep": {:git, "https://github.com/org/my_dep.git", "<locked ref>", [branch: "main"]},
+	gitEntryPattern = regexp.MustCompile(`"([^"]+)":\s*\{:git,\s*"([^"]+)",\s*"([^"]+)",`)
+)
+
+// parseMixLock is a parser function for mix.lock contents, returning all dependencies locked to a hex package version
+// or a git ref. The lock file is an Elixir map literal, which mix always writes with a single dependency per line.
+func parseMixLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var pkgs []pkg.Package
+
+	scanner := bufio.NewScanner(reader)
+	// entries with many dependencies can exceed the default max token size
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := hexEntryPattern.FindStringSubmatch(line); match != nil {
+			metadata := pkg.MixLockMetadata{
+				Name:    match[1],
+				Version: match[2],
+				PkgHash: match[3],
+			}
+			if suffix := hexEntrySuffixPattern.FindStringSubmatch(line); suffix != nil {
+				metadata.Repo = suffix[1]
+				metadata.PkgHashExt = suffix[2]
+			}
+			pkgs = append(pkgs, newMixLockPackage(metadata))
+			continue
+		}
+
+		if match := gitEntryPattern.FindStringSubmatch(line); match != nil {
+			pkgs = append(pkgs, newMixLockPackage(pkg.MixLockMetadata{
+				Name: match[1],
+				Git:  match[2],
+				Ref:  match[3],
+			}))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return pkgs, nil, nil
+}
+
+func newMixLockPackage(metadata pkg.MixLockMetadata) pkg.Package {
+	// git sourced dependencies have no version, in which case the locked ref is the most specific version available
+	version := metadata.Version
+	if version == "" {
+		version = metadata.Ref
+	}
+
+	return pkg.Package{
+		Name:         metadata.Name,
+		Version:      version,
+		Language:     pkg.Elixir,
+		Type:         pkg.HexPkg,
+		MetadataType: pkg.MixLockMetadataType,
+		Metadata:     metadata,
+	}
+}
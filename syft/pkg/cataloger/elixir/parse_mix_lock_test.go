@@ -0,0 +1,203 @@
+package elixir
+
+import (
+	"os"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMixLock(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			fixture: "test-fixtures/mix.lock",
+			expected: []pkg.Package{
+				{
+					Name:         "castore",
+					Version:      "0.1.10",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "castore",
+						Version:    "0.1.10",
+						PkgHash:    "b01a007416a0ae4188e70b3b306236137b6d2a0e5f6e7ae5e2a0c6c7ff2d5e5f",
+						PkgHashExt: "a48314e0cb45682db2ea27b8ebfa11bd6fa0a6e21a65e5772ad83ca136ff2665",
+						Repo:       "hexpm",
+					},
+				},
+				{
+					Name:         "connection",
+					Version:      "1.1.0",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "connection",
+						Version:    "1.1.0",
+						PkgHash:    "ff2a49c4b75b6fb3e674bfc5536451607270aac754ffd1bdfe175abe4a6d7a68",
+						PkgHashExt: "722c1eb0a418fbe91ba7bd59a47e28008a189d47e37e0e7bb85585a016b2869c",
+						Repo:       "hexpm",
+					},
+				},
+				{
+					Name:         "cowboy",
+					Version:      "2.9.0",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "cowboy",
+						Version:    "2.9.0",
+						PkgHash:    "865dd8b6607e14cf03282e10e934023a1bd8be6f6bacf921a7e2a96d800cd452",
+						PkgHashExt: "2c729f934b4e1aa149aff882f57c6372c15399a20d54f65c8d67bef583021bde",
+						Repo:       "hexpm",
+					},
+				},
+				{
+					Name:         "db_connection",
+					Version:      "2.4.1",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "db_connection",
+						Version:    "2.4.1",
+						PkgHash:    "6411f6e23f1a8b68a82fa3a36366d4881f21f47fc79a9efb8c615e62050219da",
+						PkgHashExt: "ea36d226ec5999781a9a8ad64e5d8c4454ecedc7a4d643e4832bf08efca01f00",
+						Repo:       "hexpm",
+					},
+				},
+				{
+					Name:         "ecto_network",
+					Version:      "7bd4f5ea7b2d5b2a0c1ae4e6c4d5c59d3b0d4f0a",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name: "ecto_network",
+						Git:  "https://github.com/vic/ecto_network.git",
+						Ref:  "7bd4f5ea7b2d5b2a0c1ae4e6c4d5c59d3b0d4f0a",
+					},
+				},
+				{
+					Name:         "phoenix_live_dashboard",
+					Version:      "0.6.2",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "phoenix_live_dashboard",
+						Version:    "0.6.2",
+						PkgHash:    "0769470265eb13af01b5001b29cb935f4710d6adaa1ffc18417a570a337a2f0f",
+						PkgHashExt: "c5bc9c1b6a9b1f2b1f6e2d4d2a8e7d8e5c2c7f7e9b9d2b1d2b9f2c5d2a6c1b8e",
+						Repo:       "hexpm",
+					},
+				},
+				{
+					Name:         "private_lib",
+					Version:      "1.2.0",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "private_lib",
+						Version:    "1.2.0",
+						PkgHash:    "9c4bd8b9d0e18ab9c7a1b5bd8a6d8c1e1d7f2a3e4b5c6d7e8f9a0b1c2d3e4f5a",
+						PkgHashExt: "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b",
+						Repo:       "acme",
+					},
+				},
+				{
+					Name:         "telemetry",
+					Version:      "1.0.0",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:       "telemetry",
+						Version:    "1.0.0",
+						PkgHash:    "0f453a102cdf13d506b7c0ab158324c337c41f1cc7548f0bc0e130bbf0ae9452",
+						PkgHashExt: "73bc09fa59b4a0284efb4624335583c528e07ec9ae76aca96ea0673850aec57a",
+						Repo:       "hexpm",
+					},
+				},
+				{
+					Name:         "tz_extra",
+					Version:      "0d1c1b8b7f8a6c4a5b3d2e1f0a9b8c7d6e5f4a3b",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name: "tz_extra",
+						Git:  "git@github.com:acme/tz_extra.git",
+						Ref:  "0d1c1b8b7f8a6c4a5b3d2e1f0a9b8c7d6e5f4a3b",
+					},
+				},
+			},
+		},
+		{
+			// written by older versions of mix, without the outer hash (or repository)
+			fixture: "test-fixtures/mix-legacy.lock",
+			expected: []pkg.Package{
+				{
+					Name:         "cowlib",
+					Version:      "2.7.0",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:    "cowlib",
+						Version: "2.7.0",
+						PkgHash: "3ef16e77562f9855a2605900cedb15c1462d76fb1be6a32fc3ae91973ee543d2",
+						Repo:    "hexpm",
+					},
+				},
+				{
+					Name:         "jason",
+					Version:      "1.1.2",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:    "jason",
+						Version: "1.1.2",
+						PkgHash: "b03dedea67a99223a2eaf9f1264ce37154564de899fd3d8b9a21b1a6fd64afe7",
+						Repo:    "hexpm",
+					},
+				},
+				{
+					Name:         "poison",
+					Version:      "2.2.0",
+					Language:     pkg.Elixir,
+					Type:         pkg.HexPkg,
+					MetadataType: pkg.MixLockMetadataType,
+					Metadata: pkg.MixLockMetadata{
+						Name:    "poison",
+						Version: "2.2.0",
+						PkgHash: "4763b69a8a77bd77d26f477d196428b741261a761257ff1cf92753a0d4d24a63",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			require.NoError(t, err)
+
+			actual, _, err := parseMixLock(fixture.Name(), fixture)
+			require.NoError(t, err)
+
+			for _, d := range deep.Equal(test.expected, actual) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
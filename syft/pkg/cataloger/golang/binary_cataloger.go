@@ -11,6 +11,7 @@ import (
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -37,6 +38,7 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 		return pkgs, nil, fmt.Errorf("failed to find bin by mime types: %w", err)
 	}
 
+	var parseErrs common.ParseErrors
 	for _, location := range fileMatches {
 		r, err := resolver.FileContentsByLocation(location)
 		if err != nil {
@@ -44,13 +46,15 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 		}
 
 		goPkgs, err := parseGoBin(location, r, openExe)
+		internal.CloseAndLogError(r, location.RealPath)
 		if err != nil {
 			log.Warnf("could not parse possible go binary at %q: %+v", location.RealPath, err)
+			parseErrs = append(parseErrs, common.ParseError{Cataloger: c.Name(), Location: location, Err: err})
+			continue
 		}
 
-		internal.CloseAndLogError(r, location.RealPath)
 		pkgs = append(pkgs, goPkgs...)
 	}
 
-	return pkgs, nil, nil
+	return pkgs, nil, parseErrs.OrNil()
 }
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/anchore/syft/syft/pkg"
@@ -11,8 +12,14 @@ import (
 )
 
 const (
-	packageIdentifier = "dep"
-	replaceIdentifier = "=>"
+	mainModuleIdentifier   = "mod"
+	packageIdentifier      = "dep"
+	replaceIdentifier      = "=>"
+	buildSettingIdentifier = "build"
+
+	// develVersion is reported by the go toolchain for the main module when it is built from a local checkout (rather
+	// than a tagged release), which does not describe any version of the module.
+	develVersion = "(devel)"
 )
 
 type exeOpener func(file io.ReadCloser) ([]exe, error)
@@ -44,33 +51,100 @@ func buildGoPkgInfo(location source.Location, mod, goVersion, arch string) []pkg
 	pkgsSlice := make([]pkg.Package, 0)
 	scanner := bufio.NewScanner(strings.NewReader(mod))
 
+	var main *pkg.Package
+	buildSettings := make(map[string]string)
+
 	// filter mod dependencies: [dep, name, version, sha]
 	for scanner.Scan() {
-		fields := strings.Fields(scanner.Text())
-
-		// must have dep, name, version, sha
-		if len(fields) < 4 {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
 			continue
 		}
 
-		if fields[0] == packageIdentifier || fields[0] == replaceIdentifier {
-			pkgsSlice = append(pkgsSlice, pkg.Package{
-				Name:     fields[1],
-				Version:  fields[2],
-				Language: pkg.Go,
-				Type:     pkg.GoModulePkg,
-				Locations: []source.Location{
-					location,
-				},
-				MetadataType: pkg.GolangBinMetadataType,
-				Metadata: pkg.GolangBinMetadata{
-					GoCompiledVersion: goVersion,
-					H1Digest:          fields[3],
-					Architecture:      arch,
-				},
-			})
+		switch fields[0] {
+		case mainModuleIdentifier:
+			// the main module may not have a sum: [mod, name, version, (sha)]
+			if len(fields) < 3 {
+				continue
+			}
+			version := fields[2]
+			if version == develVersion {
+				// leave the version unknown (thus no CPEs are generated for a version that was never released)
+				version = ""
+			}
+			p := newGoBinPackage(location, fields[1], version, goVersion, arch, "")
+			if len(fields) > 3 {
+				metadata := p.Metadata.(pkg.GolangBinMetadata)
+				metadata.H1Digest = fields[3]
+				p.Metadata = metadata
+			}
+			main = &p
+		case buildSettingIdentifier:
+			key, value := parseBuildSetting(strings.TrimSpace(strings.TrimPrefix(line, buildSettingIdentifier)))
+			if key != "" {
+				buildSettings[key] = value
+			}
+		case packageIdentifier, replaceIdentifier:
+			// must have dep, name, version, sha
+			if len(fields) < 4 {
+				continue
+			}
+			pkgsSlice = append(pkgsSlice, newGoBinPackage(location, fields[1], fields[2], goVersion, arch, fields[3]))
 		}
 	}
 
-	return pkgsSlice
+	if main == nil {
+		return pkgsSlice
+	}
+
+	// the main module (the application itself) is reported first, along with the settings it was built with
+	metadata := main.Metadata.(pkg.GolangBinMetadata)
+	if len(buildSettings) > 0 {
+		metadata.BuildSettings = buildSettings
+	}
+	main.Metadata = metadata
+
+	for i := range pkgsSlice {
+		metadata := pkgsSlice[i].Metadata.(pkg.GolangBinMetadata)
+		metadata.MainModule = main.Name
+		pkgsSlice[i].Metadata = metadata
+	}
+
+	return append([]pkg.Package{*main}, pkgsSlice...)
+}
+
+func newGoBinPackage(location source.Location, name, version, goVersion, arch, h1Digest string) pkg.Package {
+	return pkg.Package{
+		Name:     name,
+		Version:  version,
+		Language: pkg.Go,
+		Type:     pkg.GoModulePkg,
+		Locations: []source.Location{
+			location,
+		},
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			GoCompiledVersion: goVersion,
+			H1Digest:          h1Digest,
+			Architecture:      arch,
+		},
+	}
+}
+
+// parseBuildSetting splits a build setting of the form "key=value" (e.g. "vcs.revision=abc123" or
+// "-ldflags=\"-s -w\""), where values containing spaces or quotes are quoted.
+func parseBuildSetting(setting string) (string, string) {
+	idx := strings.Index(setting, "=")
+	if idx <= 0 {
+		return "", ""
+	}
+
+	key, value := strings.TrimSpace(setting[:idx]), strings.TrimSpace(setting[idx+1:])
+	if strings.HasPrefix(value, `"`) {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+	}
+	return key, value
 }
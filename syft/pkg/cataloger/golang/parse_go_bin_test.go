@@ -81,6 +81,25 @@ func TestBuildGoPkgInfo(t *testing.T) {
 				  dep     golang.org/x/term       v0.0.0-20210927222741-03fcf44c2211
 				  =>      golang.org/x/term       v0.0.0-20210916214954-140adaaadfaf      h1:Ihq/mm/suC88gF8WFcVwk+OV6Tq+wyA1O0E5UEvDglI=`,
 			expected: []pkg.Package{
+				{
+					Name:     "github.com/anchore/test",
+					Version:  "",
+					Language: pkg.Go,
+					Type:     pkg.GoModulePkg,
+					Locations: []source.Location{
+						{
+							Coordinates: source.Coordinates{
+								RealPath:     "/a-path",
+								FileSystemID: "layer-id",
+							},
+						},
+					},
+					MetadataType: pkg.GolangBinMetadataType,
+					Metadata: pkg.GolangBinMetadata{
+						GoCompiledVersion: goCompiledVersion,
+						Architecture:      archDetails,
+					},
+				},
 				{
 					Name:     "golang.org/x/net",
 					Version:  "v0.0.0-20211006190231-62292e806868",
@@ -98,6 +117,7 @@ func TestBuildGoPkgInfo(t *testing.T) {
 					Metadata: pkg.GolangBinMetadata{
 						GoCompiledVersion: goCompiledVersion,
 						Architecture:      archDetails,
+						MainModule:        "github.com/anchore/test",
 						H1Digest:          "h1:KlOXYy8wQWTUJYFgkUI40Lzr06ofg5IRXUK5C7qZt1k=",
 					},
 				},
@@ -118,6 +138,7 @@ func TestBuildGoPkgInfo(t *testing.T) {
 					Metadata: pkg.GolangBinMetadata{
 						GoCompiledVersion: goCompiledVersion,
 						Architecture:      archDetails,
+						MainModule:        "github.com/anchore/test",
 						H1Digest:          "h1:PjhxBct4MZii8FFR8+oeS7QOvxKOTZXgk63EU2XpfJE=",
 					},
 				},
@@ -138,11 +159,110 @@ func TestBuildGoPkgInfo(t *testing.T) {
 					Metadata: pkg.GolangBinMetadata{
 						GoCompiledVersion: goCompiledVersion,
 						Architecture:      archDetails,
+						MainModule:        "github.com/anchore/test",
 						H1Digest:          "h1:Ihq/mm/suC88gF8WFcVwk+OV6Tq+wyA1O0E5UEvDglI=",
 					},
 				},
 			},
 		},
+		{
+			name: "buildGoPkgInfo does not report the devel version of a main module built from a local checkout",
+			mod: `path	github.com/anchore/test/cmd/test
+mod	github.com/anchore/test	(devel)
+build	vcs.revision=41bc6bb410352845f22766e27dd48ba93aa825a4`,
+			expected: []pkg.Package{
+				{
+					Name:     "github.com/anchore/test",
+					Language: pkg.Go,
+					Type:     pkg.GoModulePkg,
+					Locations: []source.Location{
+						{
+							Coordinates: source.Coordinates{
+								RealPath:     "/a-path",
+								FileSystemID: "layer-id",
+							},
+						},
+					},
+					MetadataType: pkg.GolangBinMetadataType,
+					Metadata: pkg.GolangBinMetadata{
+						GoCompiledVersion: goCompiledVersion,
+						Architecture:      archDetails,
+						BuildSettings: map[string]string{
+							"vcs.revision": "41bc6bb410352845f22766e27dd48ba93aa825a4",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "buildGoPkgInfo captures the main module and its build settings",
+			mod: `path	github.com/anchore/test/cmd/test
+mod	github.com/anchore/test	v1.2.3	h1:Q7jIcI0+B1k6r5mOYLM4Gs9EO1lnoBn9FzbgG2XaNFE=
+dep	golang.org/x/net	v0.0.0-20211006190231-62292e806868	h1:KlOXYy8wQWTUJYFgkUI40Lzr06ofg5IRXUK5C7qZt1k=
+build	-compiler=gc
+build	-ldflags="-s -w -X main.version=1.2.3"
+build	-trimpath=true
+build	CGO_ENABLED=0
+build	GOOS=linux
+build	vcs=git
+build	vcs.revision=41bc6bb410352845f22766e27dd48ba93aa825a4
+build	vcs.time=2022-03-24T19:49:30Z
+build	vcs.modified=false`,
+			expected: []pkg.Package{
+				{
+					Name:     "github.com/anchore/test",
+					Version:  "v1.2.3",
+					Language: pkg.Go,
+					Type:     pkg.GoModulePkg,
+					Locations: []source.Location{
+						{
+							Coordinates: source.Coordinates{
+								RealPath:     "/a-path",
+								FileSystemID: "layer-id",
+							},
+						},
+					},
+					MetadataType: pkg.GolangBinMetadataType,
+					Metadata: pkg.GolangBinMetadata{
+						GoCompiledVersion: goCompiledVersion,
+						Architecture:      archDetails,
+						H1Digest:          "h1:Q7jIcI0+B1k6r5mOYLM4Gs9EO1lnoBn9FzbgG2XaNFE=",
+						BuildSettings: map[string]string{
+							"-compiler":    "gc",
+							"-ldflags":     "-s -w -X main.version=1.2.3",
+							"-trimpath":    "true",
+							"CGO_ENABLED":  "0",
+							"GOOS":         "linux",
+							"vcs":          "git",
+							"vcs.revision": "41bc6bb410352845f22766e27dd48ba93aa825a4",
+							"vcs.time":     "2022-03-24T19:49:30Z",
+							"vcs.modified": "false",
+						},
+					},
+				},
+				{
+					Name:     "golang.org/x/net",
+					Version:  "v0.0.0-20211006190231-62292e806868",
+					Language: pkg.Go,
+					Type:     pkg.GoModulePkg,
+					Locations: []source.Location{
+						{
+							Coordinates: source.Coordinates{
+								RealPath:     "/a-path",
+								FileSystemID: "layer-id",
+							},
+						},
+					},
+					MetadataType: pkg.GolangBinMetadataType,
+					Metadata: pkg.GolangBinMetadata{
+						GoCompiledVersion: goCompiledVersion,
+						Architecture:      archDetails,
+						H1Digest:          "h1:KlOXYy8wQWTUJYFgkUI40Lzr06ofg5IRXUK5C7qZt1k=",
+						MainModule:        "github.com/anchore/test",
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
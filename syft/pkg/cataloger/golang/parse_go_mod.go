@@ -26,22 +26,31 @@ func parseGoMod(path string, reader io.Reader) ([]pkg.Package, []artifact.Relati
 	}
 
 	for _, m := range file.Require {
-		packages[m.Mod.Path] = pkg.Package{
-			Name:     m.Mod.Path,
-			Version:  m.Mod.Version,
-			Language: pkg.Go,
-			Type:     pkg.GoModulePkg,
-		}
+		packages[m.Mod.Path] = newGoModPackage(m.Mod.Path, m.Mod.Version, pkg.GolangModMetadata{
+			Indirect: m.Indirect,
+		})
 	}
 
 	// remove any old packages and replace with new ones...
 	for _, m := range file.Replace {
-		packages[m.New.Path] = pkg.Package{
-			Name:     m.New.Path,
-			Version:  m.New.Version,
-			Language: pkg.Go,
-			Type:     pkg.GoModulePkg,
+		old, exists := packages[m.Old.Path]
+		if !exists {
+			// replace directives only apply to modules that are required
+			continue
+		}
+
+		if m.Old.Version != "" && m.Old.Version != old.Version {
+			// only a specific version of the module is replaced, which is not the version required
+			continue
+		}
+
+		metadata, _ := old.Metadata.(pkg.GolangModMetadata)
+		if m.Old.Path != m.New.Path {
+			metadata.Replaces = m.Old.Path
 		}
+
+		delete(packages, m.Old.Path)
+		packages[m.New.Path] = newGoModPackage(m.New.Path, m.New.Version, metadata)
 	}
 
 	// remove any packages from the exclude fields
@@ -62,3 +71,14 @@ func parseGoMod(path string, reader io.Reader) ([]pkg.Package, []artifact.Relati
 
 	return pkgsSlice, nil, nil
 }
+
+func newGoModPackage(name, version string, metadata pkg.GolangModMetadata) pkg.Package {
+	return pkg.Package{
+		Name:         name,
+		Version:      version,
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		MetadataType: pkg.GolangModMetadataType,
+		Metadata:     metadata,
+	}
+}
@@ -18,10 +18,12 @@ func TestParseGoMod(t *testing.T) {
 			fixture: "test-fixtures/one-package",
 			expected: map[string]pkg.Package{
 				"github.com/bmatcuk/doublestar": {
-					Name:     "github.com/bmatcuk/doublestar",
-					Version:  "v1.3.1",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/bmatcuk/doublestar",
+					Version:      "v1.3.1",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 			},
 		},
@@ -30,34 +32,81 @@ func TestParseGoMod(t *testing.T) {
 			fixture: "test-fixtures/many-packages",
 			expected: map[string]pkg.Package{
 				"github.com/anchore/go-testutils": {
-					Name:     "github.com/anchore/go-testutils",
-					Version:  "v0.0.0-20200624184116-66aa578126db",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/anchore/go-testutils",
+					Version:      "v0.0.0-20200624184116-66aa578126db",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 				"github.com/anchore/go-version": {
-					Name:     "github.com/anchore/go-version",
-					Version:  "v1.2.2-0.20200701162849-18adb9c92b9b",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/anchore/go-version",
+					Version:      "v1.2.2-0.20200701162849-18adb9c92b9b",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 				"github.com/anchore/stereoscope": {
-					Name:     "github.com/anchore/stereoscope",
-					Version:  "v0.0.0-20200706164556-7cf39d7f4639",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/anchore/stereoscope",
+					Version:      "v0.0.0-20200706164556-7cf39d7f4639",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 				"github.com/bmatcuk/doublestar": {
-					Name:     "github.com/bmatcuk/doublestar",
-					Version:  "v8.8.8",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/bmatcuk/doublestar",
+					Version:      "v8.8.8",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{Indirect: true},
 				},
 				"github.com/go-test/deep": {
-					Name:     "github.com/go-test/deep",
-					Version:  "v1.0.6",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/go-test/deep",
+					Version:      "v1.0.6",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/replaced-packages",
+			expected: map[string]pkg.Package{
+				"github.com/anchore/go-version": {
+					Name:         "github.com/anchore/go-version",
+					Version:      "v1.2.2-0.20200701162849-18adb9c92b9b",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
+				},
+				"github.com/bmatcuk/doublestar": {
+					Name:         "github.com/bmatcuk/doublestar",
+					Version:      "v1.3.4",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{Indirect: true},
+				},
+				"github.com/go-test/deep": {
+					Name:         "github.com/go-test/deep",
+					Version:      "v1.0.6",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
+				},
+				"github.com/golang/crypto": {
+					Name:         "github.com/golang/crypto",
+					Version:      "v0.0.0-20201216223049-8b5274cf687f",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{Replaces: "golang.org/x/crypto"},
 				},
 			},
 		},
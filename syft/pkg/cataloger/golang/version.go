@@ -163,9 +163,18 @@ func scanFile(file string, info fs.FileInfo, mustPrint bool) {
 // The build info blob left by the linker is identified by
 // a 16-byte header, consisting of buildInfoMagic (14 bytes),
 // the binary's pointer size (1 byte),
-// and whether the binary is big endian (1 byte).
+// and whether the binary is big endian (1 byte), which (since Go 1.18)
+// additionally flags if the strings are stored inline.
 var buildInfoMagic = []byte("\xff Go buildinf:")
 
+const (
+	buildInfoAlign      = 16
+	buildInfoHeaderSize = 32
+
+	flagsEndianBig  = 0x1
+	flagsVersionInl = 0x2
+)
+
 // findVers finds and returns the Go version and module version information
 // in the executable x.
 func findVers(x exe) (vers, mod string) {
@@ -175,32 +184,44 @@ func findVers(x exe) (vers, mod string) {
 	if err != nil {
 		return
 	}
-	for ; !bytes.HasPrefix(data, buildInfoMagic); data = data[32:] {
-		if len(data) < 32 {
+	for ; !bytes.HasPrefix(data, buildInfoMagic); data = data[buildInfoAlign:] {
+		if len(data) < buildInfoHeaderSize {
 			return
 		}
 	}
+	if len(data) < buildInfoHeaderSize {
+		return
+	}
 
 	// Decode the blob.
 	ptrSize := int(data[14])
-	bigEndian := data[15] != 0
-	var bo binary.ByteOrder
-	if bigEndian {
-		bo = binary.BigEndian
-	} else {
-		bo = binary.LittleEndian
-	}
-	var readPtr func([]byte) uint64
-	if ptrSize == 4 {
-		readPtr = func(b []byte) uint64 { return uint64(bo.Uint32(b)) }
+	flags := data[15]
+	if flags&flagsVersionInl != 0 {
+		// Since Go 1.18, the strings are stored inline after the header (as varint length-prefixed strings).
+		vers, data = decodeString(data[buildInfoHeaderSize:])
+		if vers == "" {
+			return
+		}
+		mod, _ = decodeString(data)
 	} else {
-		readPtr = bo.Uint64
-	}
-	vers = readString(x, ptrSize, readPtr, readPtr(data[16:]))
-	if vers == "" {
-		return
+		var bo binary.ByteOrder
+		if flags&flagsEndianBig != 0 {
+			bo = binary.BigEndian
+		} else {
+			bo = binary.LittleEndian
+		}
+		var readPtr func([]byte) uint64
+		if ptrSize == 4 {
+			readPtr = func(b []byte) uint64 { return uint64(bo.Uint32(b)) }
+		} else {
+			readPtr = bo.Uint64
+		}
+		vers = readString(x, ptrSize, readPtr, readPtr(data[16:]))
+		if vers == "" {
+			return
+		}
+		mod = readString(x, ptrSize, readPtr, readPtr(data[16+ptrSize:]))
 	}
-	mod = readString(x, ptrSize, readPtr, readPtr(data[16+ptrSize:]))
 	if len(mod) >= 33 && mod[len(mod)-17] == '\n' {
 		// Strip module framing.
 		mod = mod[16 : len(mod)-16]
@@ -210,6 +231,15 @@ func findVers(x exe) (vers, mod string) {
 	return vers, mod
 }
 
+// decodeString returns the varint length-prefixed string at the start of data, and the remaining data.
+func decodeString(data []byte) (s string, rest []byte) {
+	u, n := binary.Uvarint(data)
+	if n <= 0 || u >= uint64(len(data)-n) {
+		return "", nil
+	}
+	return string(data[n : uint64(n)+u]), data[uint64(n)+u:]
+}
+
 // readString returns the string at address addr in the executable x.
 func readString(x exe, ptrSize int, readPtr func([]byte) uint64, addr uint64) string {
 	hdr, err := x.ReadData(addr, uint64(2*ptrSize))
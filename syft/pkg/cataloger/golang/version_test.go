@@ -0,0 +1,72 @@
+package golang
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inlineBuildInfoExe is an executable with only a Go 1.18+ build info blob (where the strings are stored inline)
+// within the data segment.
+type inlineBuildInfoExe struct {
+	data []byte
+}
+
+func newInlineBuildInfoExe(vers, mod string) *inlineBuildInfoExe {
+	header := make([]byte, buildInfoHeaderSize)
+	copy(header, buildInfoMagic)
+	// pointer size
+	header[14] = 8
+	// little endian, with the strings stored inline
+	header[15] = flagsVersionInl
+
+	// the blob is not necessarily at the start of the segment
+	data := make([]byte, buildInfoAlign)
+	data = append(data, header...)
+	for _, s := range []string{vers, mod} {
+		data = append(data, encodeString(s)...)
+	}
+	// the remainder of the segment
+	data = append(data, make([]byte, buildInfoAlign)...)
+	return &inlineBuildInfoExe{data: data}
+}
+
+func encodeString(s string) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(len(s)))
+	return append(buf[:n], s...)
+}
+
+func (e *inlineBuildInfoExe) Close() error { return nil }
+
+func (e *inlineBuildInfoExe) ReadData(addr, size uint64) ([]byte, error) {
+	if addr >= uint64(len(e.data)) {
+		return nil, nil
+	}
+	end := addr + size
+	if end > uint64(len(e.data)) {
+		end = uint64(len(e.data))
+	}
+	return e.data[addr:end], nil
+}
+
+func (e *inlineBuildInfoExe) ArchName() string { return "amd64" }
+
+func (e *inlineBuildInfoExe) DataStart() uint64 { return 0 }
+
+func Test_findVers_inlineBuildInfo(t *testing.T) {
+	mod := strings.Join([]string{
+		"path\tgithub.com/anchore/test/cmd/test",
+		"mod\tgithub.com/anchore/test\t(devel)\t",
+		"build\tvcs.revision=41bc6bb410352845f22766e27dd48ba93aa825a4",
+		"",
+	}, "\n")
+	// the module info is framed by 16 bytes on either side (where the byte before the trailing frame is a newline)
+	framed := strings.Repeat("x", 16) + mod + strings.Repeat("x", 16)
+
+	vers, actualMod := findVers(newInlineBuildInfoExe("go1.18", framed))
+	assert.Equal(t, "go1.18", vers)
+	assert.Equal(t, mod, actualMod)
+}
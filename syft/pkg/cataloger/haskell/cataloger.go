@@ -0,0 +1,18 @@
+/*
+Package haskell provides a concrete Cataloger implementation for Haskell stack.yaml.lock and cabal.project.freeze files.
+*/
+package haskell
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// NewHaskellCataloger returns a new Haskell stack.yaml.lock and cabal.project.freeze cataloger object.
+func NewHaskellCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/stack.yaml.lock":      parseStackLock,
+		"**/cabal.project.freeze": parseCabalFreeze,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "haskell-cataloger")
+}
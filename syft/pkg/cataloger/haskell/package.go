@@ -0,0 +1,39 @@
+package haskell
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func newHaskellPackage(metadata pkg.HaskellMetadata) pkg.Package {
+	// git sourced packages may have no version, in which case the pinned commit is the most specific version available
+	version := metadata.Version
+	if version == "" {
+		version = metadata.Commit
+	}
+
+	return pkg.Package{
+		Name:         metadata.Name,
+		Version:      version,
+		Language:     pkg.Haskell,
+		Type:         pkg.HackagePkg,
+		MetadataType: pkg.HaskellMetadataType,
+		Metadata:     metadata,
+	}
+}
+
+// splitNameAndVersion separates a hackage package identifier (e.g. "configurator-pg-0.2.6") into the package name and
+// version. Package names may contain "-", however, versions are only made up of digits and ".".
+func splitNameAndVersion(value string) (string, string) {
+	idx := strings.LastIndex(value, "-")
+	if idx <= 0 {
+		return value, ""
+	}
+
+	version := value[idx+1:]
+	if strings.Trim(version, "0123456789.") != "" {
+		return value, ""
+	}
+	return value[:idx], version
+}
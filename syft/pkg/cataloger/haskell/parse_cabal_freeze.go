@@ -0,0 +1,79 @@
+package haskell
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseCabalFreeze
+
+const constraintsField = "constraints:"
+
+// parseCabalFreeze is a parser function for cabal.project.freeze contents, returning all packages pinned to an exact
+// version within the "constraints" field (e.g. "any.aeson ==1.5.6.0"). Flag constraints (e.g. "aeson -cffi") and
+// constraints on installed packages (e.g. "base installed") do not describe a version, so are ignored.
+func parseCabalFreeze(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var pkgs []pkg.Package
+	inConstraints := false
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, constraintsField):
+			inConstraints = true
+			line = strings.TrimPrefix(line, constraintsField)
+		case len(line) > 0 && line[0] != ' ' && line[0] != '\t':
+			// the start of another field (constraints continue on indented lines)
+			inConstraints = false
+		}
+
+		if !inConstraints {
+			continue
+		}
+
+		for _, constraint := range strings.Split(line, ",") {
+			if p, ok := parseCabalConstraint(constraint); ok {
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return pkgs, nil, nil
+}
+
+func parseCabalConstraint(constraint string) (pkg.Package, bool) {
+	fields := strings.Fields(constraint)
+	if len(fields) < 2 {
+		return pkg.Package{}, false
+	}
+
+	// note: the version may be separated from the operator (e.g. "== 1.5.6.0")
+	requirement := strings.Join(fields[1:], "")
+	if !strings.HasPrefix(requirement, "==") {
+		return pkg.Package{}, false
+	}
+
+	// the "any." qualifier applies the constraint to all instances of the package (e.g. setup dependencies)
+	name := strings.TrimPrefix(fields[0], "any.")
+	version := strings.TrimPrefix(requirement, "==")
+	if name == "" || version == "" {
+		return pkg.Package{}, false
+	}
+
+	return newHaskellPackage(pkg.HaskellMetadata{
+		Name:    name,
+		Version: version,
+	}), true
+}
@@ -0,0 +1,52 @@
+package haskell
+
+import (
+	"os"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCabalFreeze(t *testing.T) {
+	versions := []struct {
+		name    string
+		version string
+	}{
+		{name: "Cabal", version: "3.2.1.0"},
+		{name: "aeson", version: "1.5.6.0"},
+		{name: "base", version: "4.14.3.0"},
+		{name: "bytestring", version: "0.10.12.0"},
+		{name: "ghc-prim", version: "0.6.1"},
+		{name: "hashable", version: "1.3.5.0"},
+		// constraints without the "any." qualifier
+		{name: "text", version: "1.2.4.1"},
+		{name: "unordered-containers", version: "0.2.16.0"},
+	}
+
+	var expected []pkg.Package
+	for _, v := range versions {
+		expected = append(expected, pkg.Package{
+			Name:         v.name,
+			Version:      v.version,
+			Language:     pkg.Haskell,
+			Type:         pkg.HackagePkg,
+			MetadataType: pkg.HaskellMetadataType,
+			Metadata: pkg.HaskellMetadata{
+				Name:    v.name,
+				Version: v.version,
+			},
+		})
+	}
+
+	fixture, err := os.Open("test-fixtures/cabal/cabal.project.freeze")
+	require.NoError(t, err)
+
+	actual, _, err := parseCabalFreeze(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	for _, d := range deep.Equal(expected, actual) {
+		t.Errorf("diff: %+v", d)
+	}
+}
@@ -0,0 +1,127 @@
+package haskell
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"gopkg.in/yaml.v2"
+)
+
+// integrity check
+var _ common.ParserFn = parseStackLock
+
+type stackLock struct {
+	Packages  []stackPackage  `yaml:"packages"`
+	Snapshots []stackSnapshot `yaml:"snapshots"`
+}
+
+type stackPackage struct {
+	Completed completedStackPackage `yaml:"completed"`
+}
+
+// completedStackPackage is the resolved location of an extra-dep, which is either a hackage identifier (e.g.
+// "HTTP-4000.3.16@sha256:<hash>,<size>") or a git repository and commit (with the name and version, when known).
+type completedStackPackage struct {
+	Hackage string `yaml:"hackage"`
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Git     string `yaml:"git"`
+	Commit  string `yaml:"commit"`
+	Subdir  string `yaml:"subdir"`
+}
+
+type stackSnapshot struct {
+	Completed struct {
+		URL string `yaml:"url"`
+	} `yaml:"completed"`
+}
+
+// parseStackLock is a parser function for stack.yaml.lock contents, returning all extra-deps pinned (packages from the
+// resolver snapshot are not listed within the lock file).
+func parseStackLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var lock stackLock
+	if err := yaml.NewDecoder(reader).Decode(&lock); err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to parse stack.yaml.lock file: %w", err)
+	}
+
+	var snapshotURL string
+	if len(lock.Snapshots) > 0 {
+		snapshotURL = lock.Snapshots[0].Completed.URL
+	}
+
+	var pkgs []pkg.Package
+	for _, p := range lock.Packages {
+		metadata, ok := p.Completed.metadata()
+		if !ok {
+			continue
+		}
+		metadata.SnapshotURL = snapshotURL
+		pkgs = append(pkgs, newHaskellPackage(metadata))
+	}
+
+	return pkgs, nil, nil
+}
+
+func (c completedStackPackage) metadata() (pkg.HaskellMetadata, bool) {
+	if c.Hackage != "" {
+		identifier, hash := splitHackageIdentifier(c.Hackage)
+		name, version := splitNameAndVersion(identifier)
+		return pkg.HaskellMetadata{
+			Name:    name,
+			Version: version,
+			PkgHash: hash,
+		}, true
+	}
+
+	if c.Git == "" {
+		// archive sources without a name cannot be identified
+		if c.Name == "" {
+			return pkg.HaskellMetadata{}, false
+		}
+		return pkg.HaskellMetadata{
+			Name:    c.Name,
+			Version: c.Version,
+		}, true
+	}
+
+	// older lock files only record the repository (and subdirectory) for git sources, not the package name or version
+	name := c.Name
+	if name == "" {
+		name = c.Subdir
+	}
+	if name == "" {
+		name = gitRepositoryName(c.Git)
+	}
+
+	return pkg.HaskellMetadata{
+		Name:    name,
+		Version: c.Version,
+		Git:     c.Git,
+		Commit:  c.Commit,
+	}, true
+}
+
+// splitHackageIdentifier separates the package identifier from the cabal file hash or revision (e.g.
+// "HTTP-4000.3.16@sha256:<hash>,<size>" or "HTTP-4000.3.16@rev:0"), returning the identifier and the hash (if any).
+func splitHackageIdentifier(value string) (string, string) {
+	fields := strings.SplitN(value, "@", 2)
+	if len(fields) < 2 || !strings.HasPrefix(fields[1], "sha256:") {
+		return fields[0], ""
+	}
+	return fields[0], strings.SplitN(strings.TrimPrefix(fields[1], "sha256:"), ",", 2)[0]
+}
+
+// gitRepositoryName returns the repository name from a git URL (e.g. "https://github.com/org/repo.git" or
+// "git@github.com:org/repo.git").
+func gitRepositoryName(location string) string {
+	location = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(location), "/"), ".git")
+	if idx := strings.LastIndex(location, ":"); idx > strings.LastIndex(location, "/") {
+		location = location[idx+1:]
+	}
+	return path.Base(location)
+}
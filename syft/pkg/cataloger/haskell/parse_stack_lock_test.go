@@ -0,0 +1,137 @@
+package haskell
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStackLock(t *testing.T) {
+	snapshotURL := "https://raw.githubusercontent.com/commercialhaskell/stackage-snapshots/master/lts/18/28.yaml"
+	expected := []pkg.Package{
+		{
+			Name:         "HTTP",
+			Version:      "4000.3.16",
+			Language:     pkg.Haskell,
+			Type:         pkg.HackagePkg,
+			MetadataType: pkg.HaskellMetadataType,
+			Metadata: pkg.HaskellMetadata{
+				Name:        "HTTP",
+				Version:     "4000.3.16",
+				PkgHash:     "6042643c15a0b43e522a6693f1e322f05000d519543a84149cb80aeffee34f71",
+				SnapshotURL: snapshotURL,
+			},
+		},
+		{
+			Name:         "configurator-pg",
+			Version:      "0.2.6",
+			Language:     pkg.Haskell,
+			Type:         pkg.HackagePkg,
+			MetadataType: pkg.HaskellMetadataType,
+			Metadata: pkg.HaskellMetadata{
+				Name:        "configurator-pg",
+				Version:     "0.2.6",
+				PkgHash:     "cd9b06a458428e493a4d6def725af7ab1ab0fef678fbd871f9586fc7f9aa70be",
+				SnapshotURL: snapshotURL,
+			},
+		},
+		{
+			// pinned by cabal file revision instead of hash
+			Name:         "hasql-dynamic-statements",
+			Version:      "0.3.1.1",
+			Language:     pkg.Haskell,
+			Type:         pkg.HackagePkg,
+			MetadataType: pkg.HaskellMetadataType,
+			Metadata: pkg.HaskellMetadata{
+				Name:        "hasql-dynamic-statements",
+				Version:     "0.3.1.1",
+				SnapshotURL: snapshotURL,
+			},
+		},
+		{
+			Name:         "servant-options",
+			Version:      "0.1.0.0",
+			Language:     pkg.Haskell,
+			Type:         pkg.HackagePkg,
+			MetadataType: pkg.HaskellMetadataType,
+			Metadata: pkg.HaskellMetadata{
+				Name:        "servant-options",
+				Version:     "0.1.0.0",
+				SnapshotURL: snapshotURL,
+				Git:         "https://github.com/sordina/servant-options.git",
+				Commit:      "1cc7a3d3a7a5f9e5ab4c0aa6b7b1de36e9a1a3e0",
+			},
+		},
+		{
+			// git sourced without a name or version
+			Name:         "jwt",
+			Version:      "4b1b4c7a0c9a2e8f3d6b5a4c3e2d1f0a9b8c7d6e",
+			Language:     pkg.Haskell,
+			Type:         pkg.HackagePkg,
+			MetadataType: pkg.HaskellMetadataType,
+			Metadata: pkg.HaskellMetadata{
+				Name:        "jwt",
+				SnapshotURL: snapshotURL,
+				Git:         "git@github.com:some-org/haskell-jwt.git",
+				Commit:      "4b1b4c7a0c9a2e8f3d6b5a4c3e2d1f0a9b8c7d6e",
+			},
+		},
+	}
+
+	fixture, err := os.Open("test-fixtures/stack/stack.yaml.lock")
+	require.NoError(t, err)
+
+	actual, _, err := parseStackLock(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	for _, d := range deep.Equal(expected, actual) {
+		t.Errorf("diff: %+v", d)
+	}
+}
+
+func TestParseStackLock_invalid(t *testing.T) {
+	_, _, err := parseStackLock("stack.yaml.lock", strings.NewReader("packages: {not: [a list"))
+	assert.Error(t, err)
+}
+
+func Test_splitNameAndVersion(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedName    string
+		expectedVersion string
+	}{
+		{input: "HTTP-4000.3.16", expectedName: "HTTP", expectedVersion: "4000.3.16"},
+		{input: "configurator-pg-0.2.6", expectedName: "configurator-pg", expectedVersion: "0.2.6"},
+		{input: "no-version", expectedName: "no-version"},
+		{input: "base", expectedName: "base"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			name, version := splitNameAndVersion(test.input)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func Test_gitRepositoryName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "https://github.com/sordina/servant-options.git", expected: "servant-options"},
+		{input: "https://github.com/sordina/servant-options/", expected: "servant-options"},
+		{input: "git@github.com:some-org/haskell-jwt.git", expected: "haskell-jwt"},
+		{input: "git@example.com:repo.git", expected: "repo"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, gitRepositoryName(test.input))
+		})
+	}
+}
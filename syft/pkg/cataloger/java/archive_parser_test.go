@@ -12,10 +12,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/internal"
 
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
 	"github.com/go-test/deep"
 	"github.com/gookit/color"
 )
@@ -940,3 +942,27 @@ func Test_newPackageFromMavenData(t *testing.T) {
 		})
 	}
 }
+
+func TestParseJar_CPEsFromManifestVendor(t *testing.T) {
+	// note: this fixture is a minimal checked-in jar (no build is needed) with a manifest that has
+	// "Implementation-Vendor: Acme Corporation" and pom properties for com.example:acme-widget
+	fixture, err := os.Open("test-fixtures/jar-with-vendor/acme-widget-1.2.3.jar")
+	require.NoError(t, err)
+	t.Cleanup(func() { fixture.Close() })
+
+	actual, _, err := parseJavaArchive(fixture.Name(), fixture)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+
+	p := actual[0]
+	assert.Equal(t, "acme-widget", p.Name)
+	assert.Equal(t, "1.2.3", p.Version)
+
+	var cpes []string
+	for _, c := range cpe.Generate(p) {
+		cpes = append(cpes, c.BindToFmtString())
+	}
+
+	assert.Contains(t, cpes, "cpe:2.3:a:acme_corporation:acme-widget:1.2.3:*:*:*:*:*:*:*")
+	assert.Contains(t, cpes, "cpe:2.3:a:example:acme-widget:1.2.3:*:*:*:*:*:*:*")
+}
@@ -0,0 +1,104 @@
+package javascript
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
+)
+
+const packageJSONGlob = "**/package.json"
+
+// PackageCataloger catalogs installed npm packages from package.json files. Every node_modules directory is
+// considered (no matter how deeply nested within other packages), so project, global (e.g. /usr/local/lib/node_modules),
+// and nvm (e.g. ~/.nvm/versions/node/<version>/lib/node_modules) installations are all found.
+type PackageCataloger struct{}
+
+// NewJavascriptPackageCataloger returns a new JavaScript cataloger object based on detection of npm based packages.
+func NewJavascriptPackageCataloger() *PackageCataloger {
+	return &PackageCataloger{}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *PackageCataloger) Name() string {
+	return "javascript-package-cataloger"
+}
+
+// Catalog is given an object to resolve file references and content, this function returns any discovered Packages
+// after analyzing package.json files at package install paths.
+func (c *PackageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob(packageJSONGlob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files by glob: %s", packageJSONGlob)
+	}
+
+	var pkgs []pkg.Package
+	var parseErrs common.ParseErrors
+	// the same install path may be found more than once (e.g. through a symlink, such as the "current" nvm version)
+	observedInstallPaths := internal.NewStringSet()
+	for _, location := range locations {
+		if !isPackageInstallPath(location.RealPath) {
+			continue
+		}
+
+		installPath := path.Dir(location.RealPath)
+		if observedInstallPaths.Contains(installPath) {
+			continue
+		}
+		observedInstallPaths.Add(installPath)
+
+		discovered, err := c.catalogPackageJSON(resolver, location)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries (location=%+v): %+v", c.Name(), location, err)
+			parseErrs = append(parseErrs, common.ParseError{Cataloger: c.Name(), Location: location, Err: err})
+			continue
+		}
+		pkgs = append(pkgs, discovered...)
+	}
+
+	return pkgs, nil, parseErrs.OrNil()
+}
+
+func (c *PackageCataloger) catalogPackageJSON(resolver source.FileResolver, location source.Location) ([]pkg.Package, error) {
+	contentReader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch contents for location=%v : %w", location, err)
+	}
+	defer internal.CloseAndLogError(contentReader, location.VirtualPath)
+
+	discovered, _, err := parsePackageJSON(location.RealPath, contentReader)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range discovered {
+		discovered[i].FoundBy = c.Name()
+		discovered[i].Locations = append(discovered[i].Locations, location)
+	}
+	return discovered, nil
+}
+
+// isPackageInstallPath indicates if the given package.json path describes an installed package, which is either
+// directly within a node_modules directory (e.g. "node_modules/lodash/package.json" or
+// "node_modules/@babel/core/package.json") or not within any node_modules directory at all (e.g. the package.json of a
+// project). Any other package.json files within a package (e.g. test fixtures shipped with a package) are not
+// considered.
+func isPackageInstallPath(p string) bool {
+	dir := path.Dir(p)
+	if !pathContainsNodeModulesDirectory("/" + dir + "/") {
+		return true
+	}
+
+	parent := path.Dir(dir)
+	if strings.HasPrefix(path.Base(parent), "@") {
+		// scoped packages are installed one level deeper (e.g. "node_modules/@babel/core")
+		parent = path.Dir(parent)
+	}
+	return path.Base(parent) == "node_modules"
+}
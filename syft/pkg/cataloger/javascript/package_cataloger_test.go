@@ -0,0 +1,71 @@
+package javascript
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageCataloger_NodeModules(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/node-modules")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, _, err := NewJavascriptPackageCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		// project
+		"example-app": "test-fixtures/node-modules/app/package.json",
+		// project dependencies (including nested transitive dependencies)
+		"lodash":  "test-fixtures/node-modules/app/node_modules/lodash/package.json",
+		"express": "test-fixtures/node-modules/app/node_modules/express/package.json",
+		"debug":   "test-fixtures/node-modules/app/node_modules/express/node_modules/debug/package.json",
+		"ms":      "test-fixtures/node-modules/app/node_modules/express/node_modules/debug/node_modules/ms/package.json",
+		// global installation (including scoped packages)
+		"npm":              "test-fixtures/node-modules/usr/local/lib/node_modules/npm/package.json",
+		"@npmcli/arborist": "test-fixtures/node-modules/usr/local/lib/node_modules/npm/node_modules/@npmcli/arborist/package.json",
+		"semver":           "test-fixtures/node-modules/usr/local/lib/node_modules/npm/node_modules/@npmcli/arborist/node_modules/semver/package.json",
+		// nvm installation (also reachable through the "current" symlink, but only reported once)
+		"yarn": "test-fixtures/node-modules/root/.nvm/versions/node/v16.13.0/lib/node_modules/yarn/package.json",
+	}
+
+	actual := make(map[string]string)
+	var names []string
+	for _, p := range pkgs {
+		names = append(names, p.Name)
+		require.Len(t, p.Locations, 1, "package=%q", p.Name)
+		assert.Equal(t, "javascript-package-cataloger", p.FoundBy)
+		actual[p.Name] = p.Locations[0].RealPath
+	}
+	sort.Strings(names)
+
+	assert.Len(t, pkgs, len(expected), "packages=%v", names)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_isPackageInstallPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "test-fixtures/node-modules/app/package.json", expected: true},
+		{path: "package.json", expected: true},
+		{path: "/node_modules/lodash/package.json", expected: true},
+		{path: "node_modules/lodash/package.json", expected: true},
+		{path: "/node_modules/@babel/core/package.json", expected: true},
+		{path: "/node_modules/a/node_modules/b/package.json", expected: true},
+		{path: "/node_modules/yarn/test/fixtures/package.json", expected: false},
+		{path: "/node_modules/@babel/core/lib/package.json", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, isPackageInstallPath(test.path))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
@@ -25,10 +26,15 @@ type Dependency struct {
 	Version   string `json:"version"`
 	Resolved  string `json:"resolved"`
 	Integrity string `json:"integrity"`
+	Dev       bool   `json:"dev"`
 	Requires  map[string]string
+	// Dependencies are the packages installed within the node_modules directory of this package (which take precedence
+	// over the top-level packages when resolving the requirements of this package)
+	Dependencies map[string]Dependency
 }
 
-// parsePackageLock parses a package-lock.json and returns the discovered JavaScript packages.
+// parsePackageLock parses a package-lock.json and returns the discovered JavaScript packages, along with the
+// dependency relationships between them.
 func parsePackageLock(path string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
 	// in the case we find package-lock.json files in the node_modules directories, skip those
 	// as the whole purpose of the lock file is for the specific dependencies of the root project
@@ -37,6 +43,7 @@ func parsePackageLock(path string, reader io.Reader) ([]pkg.Package, []artifact.
 	}
 
 	var packages []pkg.Package
+	var relationships []artifact.Relationship
 	dec := json.NewDecoder(reader)
 
 	for {
@@ -46,15 +53,65 @@ func parsePackageLock(path string, reader io.Reader) ([]pkg.Package, []artifact.
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse package-lock.json file: %w", err)
 		}
+		lockPackages := make(map[string]pkg.Package)
 		for name, pkgMeta := range lock.Dependencies {
-			packages = append(packages, pkg.Package{
-				Name:     name,
-				Version:  pkgMeta.Version,
-				Language: pkg.JavaScript,
-				Type:     pkg.NpmPkg,
-			})
+			p := pkg.Package{
+				Name:         name,
+				Version:      pkgMeta.Version,
+				Language:     pkg.JavaScript,
+				Type:         pkg.NpmPkg,
+				MetadataType: pkg.NpmPackageLockJSONMetadataType,
+				Metadata: pkg.NpmPackageLockJSONMetadata{
+					Resolved:  pkgMeta.Resolved,
+					Integrity: pkgMeta.Integrity,
+					Dev:       pkgMeta.Dev,
+				},
+			}
+			lockPackages[name] = p
+			packages = append(packages, p)
 		}
+
+		relationships = append(relationships, packageLockRelationships(lock, lockPackages)...)
+	}
+
+	return packages, relationships, nil
+}
+
+// packageLockRelationships creates a "dependency-of" relationship from each required package to the (top-level) package
+// that requires it. Requirements that are satisfied by a package nested within the node_modules directory of the
+// requiring package are not resolved to the top-level package (which may be a different version).
+func packageLockRelationships(lock PackageLock, packages map[string]pkg.Package) []artifact.Relationship {
+	// note: map iteration order is random, so sort by name to keep the results stable across runs
+	names := make([]string, 0, len(lock.Dependencies))
+	for name := range lock.Dependencies {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	return packages, nil, nil
+	var relationships []artifact.Relationship
+	for _, name := range names {
+		dependency := lock.Dependencies[name]
+
+		required := make([]string, 0, len(dependency.Requires))
+		for requiredName := range dependency.Requires {
+			required = append(required, requiredName)
+		}
+		sort.Strings(required)
+
+		for _, requiredName := range required {
+			if _, nested := dependency.Dependencies[requiredName]; nested {
+				continue
+			}
+			requiredPkg, exists := packages[requiredName]
+			if !exists {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: requiredPkg,
+				To:   packages[name],
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
 }
@@ -1,10 +1,14 @@
 package javascript
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func assertPkgsEqual(t *testing.T, actual []pkg.Package, expected map[string]pkg.Package) {
@@ -109,7 +113,6 @@ func TestParsePackageLock(t *testing.T) {
 		t.Fatalf("failed to open fixture: %+v", err)
 	}
 
-	// TODO: no relationships are under test yet
 	actual, _, err := parsePackageLock(fixture.Name(), fixture)
 	if err != nil {
 		t.Fatalf("failed to parse package-lock.json: %+v", err)
@@ -118,3 +121,83 @@ func TestParsePackageLock(t *testing.T) {
 	assertPkgsEqual(t, actual, expected)
 
 }
+
+func TestParsePackageLock_devDependencies(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/pkg-lock-dev/package-lock.json")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, _, err := parsePackageLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Fatalf("failed to parse package-lock.json: %+v", err)
+	}
+
+	expected := map[string]bool{
+		"has-flag":       true,
+		"lodash":         false,
+		"supports-color": true,
+	}
+
+	assert.Len(t, actual, len(expected))
+	for _, p := range actual {
+		assert.Equal(t, pkg.NpmPackageLockJSONMetadataType, p.MetadataType)
+		assert.Equal(t, expected[p.Name], pkg.IsDevDependency(p), "package=%q", p.Name)
+	}
+}
+
+func TestParsePackageLock_relationships(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []string
+	}{
+		{
+			fixture: "test-fixtures/pkg-lock/package-lock.json",
+			expected: []string{
+				"ansi-regex -> strip-ansi",
+				"get-stdin -> cowsay",
+				"is-fullwidth-code-point -> string-width",
+				"minimist -> optimist",
+				"optimist -> cowsay",
+				"string-width -> cowsay",
+				"strip-ansi -> string-width",
+				"strip-eof -> cowsay",
+				"wordwrap -> optimist",
+			},
+		},
+		{
+			// es-abstract and is-regex depend on each other, and es-abstract requires a nested version of object-inspect
+			// (not the top-level package)
+			fixture: "test-fixtures/pkg-lock-cycle/package-lock.json",
+			expected: []string{
+				"es-abstract -> is-regex",
+				"is-regex -> es-abstract",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			require.NoError(t, err)
+
+			packages, relationships, err := parsePackageLock(fixture.Name(), fixture)
+			require.NoError(t, err)
+
+			ids := make(map[artifact.ID]struct{})
+			for _, p := range packages {
+				ids[p.ID()] = struct{}{}
+			}
+
+			var actual []string
+			for _, r := range relationships {
+				assert.Equal(t, artifact.DependencyOfRelationship, r.Type)
+				// every relationship must refer to the packages returned by the parser
+				assert.Contains(t, ids, r.From.ID())
+				assert.Contains(t, ids, r.To.ID())
+				actual = append(actual, fmt.Sprintf("%s -> %s", r.From.(pkg.Package).Name, r.To.(pkg.Package).Name))
+			}
+			assert.ElementsMatch(t, test.expected, actual)
+		})
+	}
+}
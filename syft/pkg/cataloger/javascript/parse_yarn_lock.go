@@ -16,18 +16,17 @@ import (
 var _ common.ParserFn = parseYarnLock
 
 var (
-	// composedNameExp matches the "composed" variant of yarn.lock entry names,
-	// where the name appears in quotes and is prefixed with @<some-namespace>.
-	// For example: "@babel/code-frame@^7.0.0"
-	composedNameExp = regexp.MustCompile(`^"(@[^@]+)`)
-
-	// simpleNameExp matches the "simple" variant of yarn.lock entry names, for packages with no namespace prefix.
-	// For example: aws-sdk@2.706.0
-	simpleNameExp = regexp.MustCompile(`^(\w[\w-_.]*)@`)
+	// entryNameExp matches the first package descriptor of a yarn.lock entry (which is never indented) and captures the
+	// package name, which may be prefixed with @<some-namespace>. Descriptors are quoted when the name is namespaced or
+	// when the version constraint contains special characters. For example:
+	//   aws-sdk@2.706.0:
+	//   "@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+	//   "string-width@^1.0.1 || ^2.0.0", string-width@^2.1.1:
+	entryNameExp = regexp.MustCompile(`^"?((?:@[^@"/\s]+/)?[^@"/\s]+)@`)
 
 	// versionExp matches the "version" line of a yarn.lock entry and captures the version value.
 	// For example: version "4.10.1" (...and the value "4.10.1" is captured)
-	versionExp = regexp.MustCompile(`^\W+version\W+"([\w-_.]+)"`)
+	versionExp = regexp.MustCompile(`^\W+version\W+"([\w-_.+]+)"`)
 )
 
 const (
@@ -50,32 +49,27 @@ func parseYarnLock(path string, reader io.Reader) ([]pkg.Package, []artifact.Rel
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		if currentPackage == noPackage {
-			// Scan until we find the next package
-
-			packageName := findPackageName(line)
-			if packageName == noPackage {
-				continue
-			}
-
-			if parsedPackages.Contains(packageName) {
-				// We don't parse repeated package declarations.
-				continue
-			}
-
+		if packageName := findPackageName(line); packageName != noPackage {
+			// a new entry has started, where all of the package descriptors resolve to a single version
 			currentPackage = packageName
-			parsedPackages.Add(currentPackage)
+			continue
+		}
 
+		if currentPackage == noPackage {
+			// Scan until we find the next package
 			continue
 		}
 
 		// We've found the package entry, now we just need the version
 
 		if version := findPackageVersion(line); version != noVersion {
-			packages = append(packages, newYarnLockPackage(currentPackage, version))
+			// the same package may be resolved to multiple versions (from different entries), however, we don't parse
+			// repeated declarations of the same resolution.
+			if id := currentPackage + "@" + version; !parsedPackages.Contains(id) {
+				parsedPackages.Add(id)
+				packages = append(packages, newYarnLockPackage(currentPackage, version))
+			}
 			currentPackage = noPackage
-
-			continue
 		}
 	}
 
@@ -87,11 +81,7 @@ func parseYarnLock(path string, reader io.Reader) ([]pkg.Package, []artifact.Rel
 }
 
 func findPackageName(line string) string {
-	if matches := composedNameExp.FindStringSubmatch(line); len(matches) >= 2 {
-		return matches[1]
-	}
-
-	if matches := simpleNameExp.FindStringSubmatch(line); len(matches) >= 2 {
+	if matches := entryNameExp.FindStringSubmatch(line); len(matches) >= 2 {
 		return matches[1]
 	}
 
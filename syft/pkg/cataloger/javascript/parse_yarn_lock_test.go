@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
 )
 
 func TestParseYarnLock(t *testing.T) {
@@ -78,3 +79,57 @@ func TestParseYarnLock(t *testing.T) {
 
 	assertPkgsEqual(t, actual, expected)
 }
+
+func TestParseYarnLock_MultipleVersions(t *testing.T) {
+	expected := []pkg.Package{
+		{
+			Name:     "@babel/code-frame",
+			Version:  "7.10.4",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+		},
+		{
+			Name:     "@babel/code-frame",
+			Version:  "6.26.0",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+		},
+		{
+			Name:     "lodash",
+			Version:  "3.10.1",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+		},
+		{
+			Name:     "lodash",
+			Version:  "4.17.19",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+		},
+		{
+			Name:     "string-width",
+			Version:  "2.1.1",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+		},
+		{
+			Name:     "semver",
+			Version:  "7.3.5+build.1",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+		},
+	}
+	fixture, err := os.Open("test-fixtures/yarn-multiple-versions/yarn.lock")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, _, err := parseYarnLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Fatalf("failed to parse yarn.lock: %+v", err)
+	}
+
+	for _, d := range deep.Equal(expected, actual) {
+		t.Errorf("diff: %+v", d)
+	}
+}
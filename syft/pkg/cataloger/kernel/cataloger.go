@@ -0,0 +1,109 @@
+/*
+Package kernel provides a concrete Cataloger implementation for the Linux kernel installed within a host or VM image,
+which is identified from the kernel modules directory (e.g. /lib/modules/5.10.0-8-amd64) since the kernel is often not
+described by any package manager metadata found within the image.
+*/
+package kernel
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	catalogerName = "linux-kernel-cataloger"
+	// packageName is the name of every kernel package (the kernel release is the version)
+	packageName = "linux-kernel"
+	// modulesGlob matches the files within the modules directory of each kernel release (including /usr/lib/modules)
+	modulesGlob = "**/lib/modules/*/modules.*"
+)
+
+// modulesFiles are the files within a kernel modules directory that evidence an installed kernel. The builtin and
+// order lists are installed with the kernel (so are owned by the OS package that installed the kernel, if any), while
+// the dependency list is generated by depmod after the kernel is installed.
+var modulesFiles = map[string]bool{
+	"modules.builtin": true,
+	"modules.order":   true,
+	"modules.dep":     true,
+}
+
+// releasePattern matches a kernel release, capturing the upstream kernel version (e.g. "5.10.0" of "5.10.0-8-amd64").
+var releasePattern = regexp.MustCompile(`^(?P<version>[0-9]+\.[0-9]+(\.[0-9]+)?)`)
+
+// Cataloger identifies installed Linux kernels from the names of the kernel modules directories. The files within each
+// modules directory are reported as the package locations, so that the OS package that installed the kernel (e.g. the
+// linux-image-* deb or kernel-core rpm) is related to the kernel package by file ownership. The release is only the
+// version of a kernel that was not installed by an OS package: otherwise the version of the OS package is reported
+// instead (without CPEs), since the release does not identify the distribution's kernel build.
+type Cataloger struct{}
+
+// NewCataloger returns a new cataloger object for installed Linux kernels.
+func NewCataloger() *Cataloger {
+	return &Cataloger{}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *Cataloger) Name() string {
+	return catalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns a package for each kernel
+// release that has a modules directory.
+func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob(modulesGlob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find kernel modules directories: %w", err)
+	}
+
+	// the files are grouped by modules directory, since each directory is a single kernel release
+	locationsByDir := make(map[string][]source.Location)
+	for _, location := range locations {
+		if !modulesFiles[path.Base(location.RealPath)] {
+			continue
+		}
+		dir := path.Dir(location.RealPath)
+		locationsByDir[dir] = append(locationsByDir[dir], location)
+	}
+
+	dirs := make([]string, 0, len(locationsByDir))
+	for dir := range locationsByDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var pkgs []pkg.Package
+	for _, dir := range dirs {
+		release := path.Base(dir)
+		match := releasePattern.FindStringSubmatch(release)
+		if match == nil {
+			// not a kernel release (e.g. a directory of modules that are not specific to a release)
+			continue
+		}
+
+		dirLocations := locationsByDir[dir]
+		sort.Slice(dirLocations, func(i, j int) bool {
+			return dirLocations[i].RealPath < dirLocations[j].RealPath
+		})
+
+		pkgs = append(pkgs, pkg.Package{
+			Name:         packageName,
+			Version:      release,
+			FoundBy:      c.Name(),
+			Locations:    dirLocations,
+			Type:         pkg.LinuxKernelPkg,
+			MetadataType: pkg.LinuxKernelMetadataType,
+			Metadata: pkg.LinuxKernelMetadata{
+				Release: release,
+				Version: match[releasePattern.SubexpIndex("version")],
+			},
+		})
+	}
+
+	return pkgs, nil, nil
+}
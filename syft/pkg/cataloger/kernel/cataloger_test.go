@@ -0,0 +1,102 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func locationPaths(locations []source.Location) (paths []string) {
+	for _, l := range locations {
+		paths = append(paths, l.RealPath)
+	}
+	return paths
+}
+
+func TestCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/modules")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+
+	expected := []struct {
+		version   string
+		metadata  pkg.LinuxKernelMetadata
+		locations []string
+	}{
+		{
+			version:  "5.10.0-8-amd64",
+			metadata: pkg.LinuxKernelMetadata{Release: "5.10.0-8-amd64", Version: "5.10.0"},
+			locations: []string{
+				"test-fixtures/modules/lib/modules/5.10.0-8-amd64/modules.builtin",
+				"test-fixtures/modules/lib/modules/5.10.0-8-amd64/modules.dep",
+				"test-fixtures/modules/lib/modules/5.10.0-8-amd64/modules.order",
+			},
+		},
+		{
+			version:  "5.14.0-70.13.1.el9_0.x86_64",
+			metadata: pkg.LinuxKernelMetadata{Release: "5.14.0-70.13.1.el9_0.x86_64", Version: "5.14.0"},
+			locations: []string{
+				"test-fixtures/modules/usr/lib/modules/5.14.0-70.13.1.el9_0.x86_64/modules.builtin",
+				"test-fixtures/modules/usr/lib/modules/5.14.0-70.13.1.el9_0.x86_64/modules.dep",
+			},
+		},
+	}
+
+	// note: the extramodules directory is not a kernel release
+	require.Len(t, pkgs, len(expected))
+	for i, p := range pkgs {
+		assert.Equal(t, "linux-kernel", p.Name)
+		assert.Equal(t, expected[i].version, p.Version)
+		assert.Equal(t, pkg.LinuxKernelPkg, p.Type)
+		assert.Equal(t, catalogerName, p.FoundBy)
+		assert.Equal(t, pkg.LinuxKernelMetadataType, p.MetadataType)
+		assert.Equal(t, expected[i].metadata, p.Metadata)
+		assert.Equal(t, expected[i].locations, locationPaths(p.Locations))
+	}
+}
+
+func TestCataloger_owningPackage(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"/boot/vmlinuz-5.10.0-8-amd64",
+		"/lib/modules/5.10.0-8-amd64/modules.builtin",
+		"/lib/modules/5.10.0-8-amd64/modules.dep",
+		"/lib/modules/5.10.0-8-amd64/modules.order",
+	)
+
+	pkgs, _, err := NewCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	kernel := pkgs[0]
+
+	// the deb package that installed the kernel owns the module lists installed with the kernel
+	image := pkg.Package{
+		Name:         "linux-image-5.10.0-8-amd64",
+		Version:      "5.10.46-4",
+		Type:         pkg.DebPkg,
+		MetadataType: pkg.DpkgMetadataType,
+		Metadata: pkg.DpkgMetadata{
+			Package: "linux-image-5.10.0-8-amd64",
+			Files: []pkg.DpkgFileRecord{
+				{Path: "/boot/vmlinuz-5.10.0-8-amd64"},
+				{Path: "/lib/modules/5.10.0-8-amd64/modules.builtin"},
+				{Path: "/lib/modules/5.10.0-8-amd64/modules.order"},
+			},
+		},
+	}
+
+	relationships := pkg.NewRelationships(pkg.NewCatalog(append(pkgs, image)...))
+	require.Len(t, relationships, 1)
+	assert.Equal(t, artifact.OwnershipByFileOverlapRelationship, relationships[0].Type)
+	assert.Equal(t, image.Name, relationships[0].From.(pkg.Package).Name)
+	assert.Equal(t, kernel.ID(), relationships[0].To.ID())
+}
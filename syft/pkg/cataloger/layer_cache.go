@@ -0,0 +1,248 @@
+package cataloger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/syftjson"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ common.ParseCache = (*layerCache)(nil)
+
+// layerCacheVersion invalidates all existing cache entries when the layout of the entries changes.
+const layerCacheVersion = "2"
+
+var (
+	// layerDigestPattern matches image layer digests (e.g. "sha256:<hex>"), which are used as cache directory names.
+	layerDigestPattern = regexp.MustCompile(`^([a-z0-9]+):([a-f0-9]+)$`)
+	// buildDirPattern matches the names of the directories that hold the entries written by a single syft build.
+	buildDirPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+	// legacyLayerDirs are the directories that held the entries of every syft build before entries were grouped by
+	// build (i.e. layer cache version 1).
+	legacyLayerDirs = internal.NewStringSetFromSlice([]string{"sha256"})
+)
+
+// layerCache is a content-addressed cache of parse results on disk. Since an image layer digest identifies the
+// contents of every file within the layer, the parse results for a file are keyed by the digest of the layer that
+// contains the file (along with the file path and cataloger). Entries are grouped by the syft build that wrote them
+// (e.g. "<dir>/<build>/sha256/<hex>/<key>.json"), since entries are never reused by another build, and the entries of
+// all other builds are removed whenever the cache is opened (so the cache does not grow with every syft upgrade).
+// Files from other sources (e.g. directories) are never cached. Entries are written atomically, so the cache is safe
+// to share between concurrent runs.
+type layerCache struct {
+	dir    string
+	hits   int64
+	misses int64
+}
+
+// cacheableCataloger is implemented by catalogers that are able to reuse the parse results for each file.
+type cacheableCataloger interface {
+	CatalogWithCache(resolver source.FileResolver, cache common.ParseCache) ([]pkg.Package, []artifact.Relationship, error)
+}
+
+func newLayerCache(dir string) (*layerCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create layer cache dir=%q: %w", dir, err)
+	}
+	c := &layerCache{dir: dir}
+	c.prune()
+	return c, nil
+}
+
+// prune removes the entries written by all other syft builds (and by earlier cache layouts), which are never read
+// again. Only directories that look like cache entries are removed, since the cache dir is configurable. Failures are
+// only logged, since the cache is an optimization.
+func (c *layerCache) prune() {
+	current := buildCacheKey()
+	if current == "" {
+		return
+	}
+
+	entries, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		log.Debugf("unable to read layer cache dir=%q: %+v", c.dir, err)
+		return
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == current {
+			continue
+		}
+		if !buildDirPattern.MatchString(name) && !legacyLayerDirs.Contains(name) {
+			continue
+		}
+
+		path := filepath.Join(c.dir, name)
+		if err := os.RemoveAll(path); err != nil {
+			log.Debugf("unable to prune layer cache entries=%q: %+v", path, err)
+			continue
+		}
+		log.Debugf("pruned layer cache entries of another syft build=%q", path)
+	}
+}
+
+// Load returns the cached parse results for the given file, if the file is within an image layer that has been parsed
+// before. Any unreadable entry is treated as a cache miss.
+func (c *layerCache) Load(cataloger string, location source.Location) ([]pkg.Package, []artifact.Relationship, bool) {
+	path, ok := c.entryPath(cataloger, location)
+	if !ok {
+		return nil, nil, false
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debugf("unable to read layer cache entry=%q: %+v", path, err)
+		}
+		atomic.AddInt64(&c.misses, 1)
+		return nil, nil, false
+	}
+
+	entry, err := syftjson.Format().Decode(bytes.NewReader(contents))
+	if err != nil {
+		log.Debugf("unable to decode layer cache entry=%q: %+v", path, err)
+		atomic.AddInt64(&c.misses, 1)
+		return nil, nil, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return entry.Artifacts.PackageCatalog.Sorted(), entry.Relationships, true
+}
+
+// Store records the parse results for the given file (if the file is within an image layer). Failures are only logged,
+// since the cache is an optimization.
+func (c *layerCache) Store(cataloger string, location source.Location, packages []pkg.Package, relationships []artifact.Relationship) {
+	path, ok := c.entryPath(cataloger, location)
+	if !ok {
+		return
+	}
+
+	// the entries are SBOMs with the packages from a single file (within an image layer)
+	var buf bytes.Buffer
+	err := syftjson.Format().Encode(&buf, sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(packages...),
+		},
+		Relationships: relationships,
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+		},
+	})
+	if err != nil {
+		log.Debugf("unable to encode layer cache entry for location=%+v: %+v", location, err)
+		return
+	}
+
+	if err := writeFileAtomically(path, buf.Bytes()); err != nil {
+		log.Debugf("unable to write layer cache entry=%q: %+v", path, err)
+	}
+}
+
+// entryPath returns the path of the cache entry for the given file, which is within a directory for the syft build and
+// the image layer (e.g. "<dir>/<build>/sha256/<hex>/<key>.json"). Returns false for files that are not within an
+// image layer.
+func (c *layerCache) entryPath(cataloger string, location source.Location) (string, bool) {
+	match := layerDigestPattern.FindStringSubmatch(location.FileSystemID)
+	build := buildCacheKey()
+	if match == nil || build == "" {
+		return "", false
+	}
+
+	key := sha256.Sum256([]byte(strings.Join([]string{
+		cataloger,
+		location.RealPath,
+	}, "\x00")))
+
+	return filepath.Join(c.dir, build, match[1], match[2], fmt.Sprintf("%x.json", key)), true
+}
+
+// buildCacheKey returns the name of the directory that holds the entries written by the running syft build (see
+// buildIdentity), which is empty when the build cannot be identified.
+func buildCacheKey() string {
+	if buildIdentity() == "" {
+		return ""
+	}
+	key := sha256.Sum256([]byte(strings.Join([]string{
+		layerCacheVersion,
+		buildIdentity(),
+		internal.JSONSchemaVersion,
+	}, "\x00")))
+	return fmt.Sprintf("%x", key)
+}
+
+func (c *layerCache) logStatistics() {
+	log.Debugf("layer cache hits=%d misses=%d (dir=%q)", atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses), c.dir)
+}
+
+// writeFileAtomically writes the file to a temp file within the same directory before renaming it into place, so
+// concurrent readers never observe a partially written file.
+func writeFileAtomically(path string, contents []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(contents)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+var (
+	buildIdentityOnce  sync.Once
+	buildIdentityValue string
+)
+
+// buildIdentity describes the syft build, so parse results are never reused by a build that may parse files
+// differently. Release builds are identified by version, otherwise (e.g. development builds or programs using syft
+// as a library) the running executable is identified by its path, size, and modification time.
+func buildIdentity() string {
+	buildIdentityOnce.Do(func() {
+		info := version.FromBuild()
+		if info.IsProductionBuild() {
+			buildIdentityValue = info.Version + "@" + info.GitCommit
+			return
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			log.Debugf("unable to identify executable for the layer cache: %+v", err)
+			return
+		}
+		stat, err := os.Stat(executable)
+		if err != nil {
+			log.Debugf("unable to identify executable for the layer cache: %+v", err)
+			return
+		}
+		buildIdentityValue = fmt.Sprintf("%s@%d@%d", executable, stat.Size(), stat.ModTime().UnixNano())
+	})
+	return buildIdentityValue
+}
@@ -0,0 +1,157 @@
+package cataloger
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCountingCataloger returns a cataloger for "<name> <version>" lines within lock files, where the first package
+// of each file depends on the others. The number of parsed files is counted.
+func newCountingCataloger(parsed *int64) *common.GenericCataloger {
+	parser := func(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+		atomic.AddInt64(parsed, 1)
+
+		var packages []pkg.Package
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			packages = append(packages, pkg.Package{
+				Name:         fields[0],
+				Version:      fields[1],
+				Type:         pkg.NpmPkg,
+				Language:     pkg.JavaScript,
+				MetadataType: pkg.NpmPackageLockJSONMetadataType,
+				Metadata: pkg.NpmPackageLockJSONMetadata{
+					Resolved: "https://registry.npmjs.org/" + fields[0],
+				},
+			})
+		}
+
+		var relationships []artifact.Relationship
+		for _, p := range packages[1:] {
+			relationships = append(relationships, artifact.Relationship{
+				From: p,
+				To:   packages[0],
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+		return packages, relationships, scanner.Err()
+	}
+
+	return common.NewGenericCataloger(nil, map[string]common.ParserFn{"**/*.lock": parser}, "counting-cataloger")
+}
+
+// newLayeredResolver returns a resolver with the given fixture files, each within the image layer with the given digest.
+func newLayeredResolver(layers map[string]string) source.FileResolver {
+	metadata := make(map[source.Location]source.FileMetadata)
+	for path, digest := range layers {
+		metadata[source.NewLocationFromCoordinates(source.Coordinates{RealPath: path, FileSystemID: digest})] = source.FileMetadata{}
+	}
+	return source.NewMockResolverForPathsWithMetadata(metadata)
+}
+
+func TestCatalog_layerCache(t *testing.T) {
+	const (
+		baseLayer     = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		appLayer      = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+		otherAppLayer = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+	)
+	base := "test-fixtures/layer-cache/base.lock"
+	app := "test-fixtures/layer-cache/app.lock"
+
+	cfg := DefaultConfig()
+	cfg.LayerCacheDir = t.TempDir()
+
+	var parsed int64
+	catalog := func(resolver source.FileResolver, cfg Config) (*pkg.Catalog, []artifact.Relationship) {
+		c, relationships, err := Catalog(resolver, nil, cfg, newCountingCataloger(&parsed))
+		require.NoError(t, err)
+		return c, relationships
+	}
+
+	// the first image parses all files
+	catalog(newLayeredResolver(map[string]string{base: baseLayer, app: appLayer}), cfg)
+	assert.EqualValues(t, 2, parsed)
+
+	// the second image shares the base layer, so only the files from the other layer are parsed
+	parsed = 0
+	otherImage := newLayeredResolver(map[string]string{base: baseLayer, app: otherAppLayer})
+	cached, cachedRelationships := catalog(otherImage, cfg)
+	assert.EqualValues(t, 1, parsed)
+
+	// the results are the same as without the cache
+	parsed = 0
+	uncached, uncachedRelationships := catalog(otherImage, DefaultConfig())
+	assert.EqualValues(t, 2, parsed)
+
+	assert.Equal(t, uncached.PackageCount(), cached.PackageCount())
+	for _, p := range uncached.Sorted() {
+		assert.NotNil(t, cached.Package(p.ID()), "missing package=%s", p)
+	}
+	assert.ElementsMatch(t, relationshipIDs(uncachedRelationships), relationshipIDs(cachedRelationships))
+	assert.Len(t, cachedRelationships, 1)
+}
+
+func TestCatalog_layerCacheSkipsDirectories(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LayerCacheDir = t.TempDir()
+
+	var parsed int64
+	for i := 0; i < 2; i++ {
+		// files outside of image layers are never cached
+		_, _, err := Catalog(source.NewMockResolverForPaths("test-fixtures/layer-cache/base.lock"), nil, cfg, newCountingCataloger(&parsed))
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 2, parsed)
+}
+
+func TestLayerCache_prune(t *testing.T) {
+	dir := t.TempDir()
+	otherBuild := filepath.Join(dir, strings.Repeat("a", 64))
+	legacy := filepath.Join(dir, "sha256", strings.Repeat("1", 64))
+	unrelated := filepath.Join(dir, "notes")
+	for _, d := range []string{otherBuild, legacy, unrelated} {
+		require.NoError(t, os.MkdirAll(d, 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(d, "entry.json"), []byte("{}"), 0644))
+	}
+
+	cache, err := newLayerCache(dir)
+	require.NoError(t, err)
+	location := source.NewLocationFromCoordinates(source.Coordinates{
+		RealPath:     "/base.lock",
+		FileSystemID: "sha256:" + strings.Repeat("1", 64),
+	})
+	entry, ok := cache.entryPath("counting-cataloger", location)
+	require.True(t, ok)
+	require.NoError(t, os.MkdirAll(filepath.Dir(entry), 0755))
+	require.NoError(t, ioutil.WriteFile(entry, []byte("{}"), 0644))
+
+	// reopening the cache keeps the entries of the current build (and unrelated files), but not of any other build
+	_, err = newLayerCache(dir)
+	require.NoError(t, err)
+
+	assert.FileExists(t, entry)
+	assert.DirExists(t, unrelated)
+	assert.NoDirExists(t, otherBuild)
+	assert.NoDirExists(t, filepath.Join(dir, "sha256"))
+}
+
+func relationshipIDs(relationships []artifact.Relationship) (results []string) {
+	for _, r := range relationships {
+		results = append(results, string(r.From.ID())+" "+string(r.Type)+" "+string(r.To.ID()))
+	}
+	return results
+}
@@ -0,0 +1,81 @@
+package cataloger
+
+import (
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// matches the names of files that contain the license of a package (e.g. "LICENSE", "LICENSE.txt", "LICENSE-MIT",
+// "COPYING", or "COPYING.LESSER")
+var licenseFilePattern = regexp.MustCompile(`(?i)^(licen[cs]e|copying)([.\-_].*)?$`)
+
+// maxLicenseFileSize is the largest license file that will be read in order to conclude a license (license texts are
+// at most tens of kilobytes, anything larger is unlikely to be a license file).
+const maxLicenseFileSize = 1024 * 1024
+
+// concludeLicenses populates the licenses for a package without any licenses within its metadata by classifying the
+// license files that the package owns. Such licenses are concluded (not declared by the package authors).
+func concludeLicenses(p pkg.Package, resolver source.FileResolver) pkg.Package {
+	if len(p.Licenses) > 0 {
+		return p
+	}
+
+	fileOwner, ok := p.Metadata.(pkg.FileOwner)
+	if !ok {
+		return p
+	}
+
+	observed := internal.NewStringSet()
+	var licenses []string
+	for _, ownedPath := range fileOwner.OwnedFiles() {
+		if !licenseFilePattern.MatchString(path.Base(ownedPath)) {
+			continue
+		}
+
+		locations, err := resolver.FilesByPath(ownedPath)
+		if err != nil {
+			log.Debugf("unable to find license file=%q for package=%s: %+v", ownedPath, p, err)
+			continue
+		}
+
+		for _, location := range locations {
+			license, ok := classifyLicenseFile(location, resolver)
+			if !ok || observed.Contains(license) {
+				continue
+			}
+			observed.Add(license)
+			licenses = append(licenses, license)
+		}
+	}
+
+	if len(licenses) > 0 {
+		p.Licenses = licenses
+		p.LicensesConcluded = true
+	}
+	return p
+}
+
+func classifyLicenseFile(location source.Location, resolver source.FileContentResolver) (string, bool) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Debugf("unable to read license file=%q: %+v", location.RealPath, err)
+		return "", false
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	contents, err := ioutil.ReadAll(io.LimitReader(reader, maxLicenseFileSize))
+	if err != nil {
+		log.Debugf("unable to read license file=%q: %+v", location.RealPath, err)
+		return "", false
+	}
+
+	return spdxlicense.Classify(string(contents))
+}
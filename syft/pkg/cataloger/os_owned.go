@@ -0,0 +1,119 @@
+package cataloger
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// osPackageTypes are the types of packages installed by an OS package manager, which own the files they installed.
+var osPackageTypes = map[pkg.Type]bool{
+	pkg.ApkPkg: true,
+	pkg.DebPkg: true,
+	pkg.RpmPkg: true,
+}
+
+// osPackageOwners returns the OS package (e.g. a deb, rpm, or apk) that owns each file, by path. When several packages
+// claim the same file, the first package found is kept.
+func osPackageOwners(packages []pkg.Package) map[string]pkg.Package {
+	owners := make(map[string]pkg.Package)
+	for _, p := range packages {
+		if !osPackageTypes[p.Type] {
+			continue
+		}
+		fileOwner, ok := p.Metadata.(pkg.FileOwner)
+		if !ok {
+			continue
+		}
+		for _, path := range fileOwner.OwnedFiles() {
+			if _, exists := owners[path]; !exists {
+				owners[path] = p
+			}
+		}
+	}
+	return owners
+}
+
+// owningOSPackage returns the OS package that owns any of the given locations (if any).
+func owningOSPackage(owners map[string]pkg.Package, locations []source.Location) (pkg.Package, bool) {
+	for _, l := range locations {
+		for _, path := range []string{l.RealPath, l.VirtualPath} {
+			if path == "" {
+				continue
+			}
+			if owner, exists := owners[path]; exists {
+				return owner, true
+			}
+		}
+	}
+	return pkg.Package{}, false
+}
+
+// reconcileOSOwnedPackages updates the packages found within files that were installed by an OS package, since the OS
+// package is the authoritative description of such files:
+//   - a kernel installed by an OS package takes on the version of the OS package (the modules directory name is the
+//     kernel release, which is not necessarily an upstream version), and no CPEs are reported for it (the OS package
+//     describes the vulnerabilities of the distribution's kernel build)
+//   - binaries identified by classification (e.g. a libc.so.6 installed by the libc6 deb) are not reported at all, since
+//     the OS package already describes them (and the classified version is only the upstream version)
+//
+// The given relationships are updated to refer to the updated packages (relationships of packages that are not reported
+// are dropped).
+func reconcileOSOwnedPackages(packages []pkg.Package, relationships []artifact.Relationship) ([]pkg.Package, []artifact.Relationship) {
+	owners := osPackageOwners(packages)
+	if len(owners) == 0 {
+		return packages, relationships
+	}
+
+	updated := make(map[artifact.ID]pkg.Package)
+	removed := make(map[artifact.ID]struct{})
+	results := make([]pkg.Package, 0, len(packages))
+	for _, p := range packages {
+		owner, owned := owningOSPackage(owners, p.Locations)
+		switch {
+		case !owned:
+		case p.Type == pkg.BinaryPkg:
+			removed[p.ID()] = struct{}{}
+			continue
+		case p.Type == pkg.LinuxKernelPkg:
+			originalID := p.ID()
+			p.Version = owner.Version
+			p.CPEs = nil
+			if p.NormalizedVersion != "" {
+				p.NormalizedVersion = normalizeVersion(p)
+			}
+			updated[originalID] = p
+		}
+		results = append(results, p)
+	}
+
+	if len(updated) == 0 && len(removed) == 0 {
+		return results, relationships
+	}
+
+	isRemoved := func(identifiable artifact.Identifiable) bool {
+		_, exists := removed[identifiable.ID()]
+		return exists
+	}
+
+	identifiable := func(identifiable artifact.Identifiable) artifact.Identifiable {
+		if p, ok := identifiable.(pkg.Package); ok {
+			if u, exists := updated[p.ID()]; exists {
+				return u
+			}
+		}
+		return identifiable
+	}
+
+	var reconciled []artifact.Relationship
+	for _, r := range relationships {
+		if isRemoved(r.From) || isRemoved(r.To) {
+			continue
+		}
+		r.From = identifiable(r.From)
+		r.To = identifiable(r.To)
+		reconciled = append(reconciled, r)
+	}
+
+	return results, reconciled
+}
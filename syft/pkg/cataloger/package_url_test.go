@@ -30,6 +30,18 @@ func TestPackageURL(t *testing.T) {
 			},
 			expected: "pkg:pypi/name@v0.1.0",
 		},
+		{
+			// e.g. a requirements.txt entry without a pinned version
+			pkg: pkg.Package{
+				Name:         "name",
+				Type:         pkg.PythonPkg,
+				MetadataType: pkg.PythonRequirementsMetadataType,
+				Metadata: pkg.PythonRequirementsMetadata{
+					VersionConstraint: ">=1.0",
+				},
+			},
+			expected: "pkg:pypi/name",
+		},
 		{
 			pkg: pkg.Package{
 				Name:    "name",
@@ -62,6 +74,23 @@ func TestPackageURL(t *testing.T) {
 			},
 			expected: "pkg:deb/ubuntu/name@v0.1.0?arch=amd64",
 		},
+		{
+			distro: &distro.Distro{
+				Type:       distro.Debian,
+				RawVersion: "11",
+			},
+			pkg: pkg.Package{
+				Name:    "libgcrypt20",
+				Version: "1:1.8.7-6",
+				Type:    pkg.DebPkg,
+				Metadata: pkg.DpkgMetadata{
+					Package:      "libgcrypt20",
+					Version:      "1:1.8.7-6",
+					Architecture: "amd64",
+				},
+			},
+			expected: "pkg:deb/debian/libgcrypt20@1.8.7-6?arch=amd64&distro=debian-11&epoch=1",
+		},
 		{
 			distro: &distro.Distro{
 				Type: distro.CentOS,
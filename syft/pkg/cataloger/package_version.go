@@ -0,0 +1,40 @@
+package cataloger
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+var (
+	// epochPattern matches the epoch that prefixes deb and rpm versions (e.g. the "1:" of "1:2.30-1").
+	epochPattern = regexp.MustCompile(`^[0-9]+:`)
+	// apkReleasePattern matches the package release that suffixes apk versions (e.g. the "-r2" of "1.1.24-r2").
+	apkReleasePattern = regexp.MustCompile(`-r[0-9]+$`)
+	// versionPrefixPattern matches a "v" prefixed to a version number (e.g. the "v" of "v1.2.3").
+	versionPrefixPattern = regexp.MustCompile(`^[vV]([0-9])`)
+)
+
+// normalizeVersion returns the version of the given package in a form that is comparable across ecosystems, which is
+// the upstream version without any ecosystem-specific decoration: the epoch and release of distro packages (e.g.
+// "1:2.30-1" is "2.30") and a "v" prefix (e.g. "v1.2.3" is "1.2.3"). The raw version of the package is left as-is.
+func normalizeVersion(p pkg.Package) string {
+	version := strings.TrimSpace(p.Version)
+
+	switch p.Type {
+	case pkg.DebPkg, pkg.RpmPkg:
+		version = epochPattern.ReplaceAllString(version, "")
+		// the upstream version may contain hyphens (deb), so only the last hyphen separates the release
+		if idx := strings.LastIndex(version, "-"); idx > 0 {
+			version = version[:idx]
+		}
+	case pkg.ApkPkg, pkg.ApkbuildPkg:
+		version = apkReleasePattern.ReplaceAllString(version, "")
+	case pkg.GoModulePkg:
+		// major versions beyond v1 without a go.mod file are marked as incompatible (e.g. "v2.0.0+incompatible")
+		version = strings.TrimSuffix(version, "+incompatible")
+	}
+
+	return versionPrefixPattern.ReplaceAllString(version, "$1")
+}
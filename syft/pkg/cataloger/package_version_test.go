@@ -0,0 +1,96 @@
+package cataloger
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      pkg.Package
+		expected string
+	}{
+		{
+			name:     "go module with v prefix",
+			pkg:      pkg.Package{Version: "v1.3.0", Type: pkg.GoModulePkg},
+			expected: "1.3.0",
+		},
+		{
+			name:     "go module pseudo-version",
+			pkg:      pkg.Package{Version: "v0.0.0-20210817142637-7d9622a276b7", Type: pkg.GoModulePkg},
+			expected: "0.0.0-20210817142637-7d9622a276b7",
+		},
+		{
+			name:     "go module incompatible major version",
+			pkg:      pkg.Package{Version: "v2.0.0+incompatible", Type: pkg.GoModulePkg},
+			expected: "2.0.0",
+		},
+		{
+			name:     "go module devel version",
+			pkg:      pkg.Package{Version: "(devel)", Type: pkg.GoModulePkg},
+			expected: "(devel)",
+		},
+		{
+			name:     "deb with epoch and release",
+			pkg:      pkg.Package{Version: "1:2.30-1", Type: pkg.DebPkg},
+			expected: "2.30",
+		},
+		{
+			name:     "deb with hyphens in the upstream version",
+			pkg:      pkg.Package{Version: "2:8.2.2434-3+deb11u1", Type: pkg.DebPkg},
+			expected: "8.2.2434",
+		},
+		{
+			name:     "deb without epoch or release",
+			pkg:      pkg.Package{Version: "20210119", Type: pkg.DebPkg},
+			expected: "20210119",
+		},
+		{
+			name:     "deb with tilde",
+			pkg:      pkg.Package{Version: "1.2.3~rc1-2", Type: pkg.DebPkg},
+			expected: "1.2.3~rc1",
+		},
+		{
+			name:     "rpm with epoch and release",
+			pkg:      pkg.Package{Version: "1:1.1.1k-4.el8", Type: pkg.RpmPkg},
+			expected: "1.1.1k",
+		},
+		{
+			name:     "apk with release",
+			pkg:      pkg.Package{Version: "1.1.24-r2", Type: pkg.ApkPkg},
+			expected: "1.1.24",
+		},
+		{
+			name:     "npm with v prefix",
+			pkg:      pkg.Package{Version: "v2.1.0", Type: pkg.NpmPkg},
+			expected: "2.1.0",
+		},
+		{
+			name:     "v that is not a prefix",
+			pkg:      pkg.Package{Version: "very-old", Type: pkg.NpmPkg},
+			expected: "very-old",
+		},
+		{
+			name:     "release only removed from distro packages",
+			pkg:      pkg.Package{Version: "1.0.0-beta.1", Type: pkg.NpmPkg},
+			expected: "1.0.0-beta.1",
+		},
+		{
+			name:     "epoch only removed from distro packages",
+			pkg:      pkg.Package{Version: "1:2.0", Type: pkg.PythonPkg},
+			expected: "1:2.0",
+		},
+		{
+			name: "no version",
+			pkg:  pkg.Package{Type: pkg.PythonPkg},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizeVersion(test.pkg))
+		})
+	}
+}
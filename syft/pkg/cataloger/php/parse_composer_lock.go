@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/anchore/syft/syft/artifact"
 
@@ -17,16 +18,23 @@ type ComposerLock struct {
 }
 
 type Dependency struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	License  []string `json:"license"`
+	Homepage string   `json:"homepage"`
+	// Require are the packages (and platform requirements, e.g. "php" or "ext-json") required by this package, keyed
+	// by name with the version constraint as the value
+	Require map[string]string `json:"require"`
 }
 
 // integrity check
 var _ common.ParserFn = parseComposerLock
 
-// parseComposerLock is a parser function for Composer.lock contents, returning "Default" php packages discovered.
+// parseComposerLock is a parser function for Composer.lock contents, returning all php packages discovered (including dev
+// packages) along with the dependency relationships between them.
 func parseComposerLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
 	packages := make([]pkg.Package, 0)
+	var relationships []artifact.Relationship
 	dec := json.NewDecoder(reader)
 
 	for {
@@ -36,17 +44,65 @@ func parseComposerLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Re
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse composer.lock file: %w", err)
 		}
+		lockPackages := make(map[string]pkg.Package)
 		for _, pkgMeta := range lock.Packages {
-			version := pkgMeta.Version
-			name := pkgMeta.Name
-			packages = append(packages, pkg.Package{
-				Name:     name,
-				Version:  version,
-				Language: pkg.PHP,
-				Type:     pkg.PhpComposerPkg,
-			})
+			p := newComposerLockPackage(pkgMeta, false)
+			lockPackages[pkgMeta.Name] = p
+			packages = append(packages, p)
 		}
+		for _, pkgMeta := range lock.PackageDev {
+			p := newComposerLockPackage(pkgMeta, true)
+			lockPackages[pkgMeta.Name] = p
+			packages = append(packages, p)
+		}
+
+		for _, pkgMeta := range append(lock.Packages, lock.PackageDev...) {
+			relationships = append(relationships, composerLockRelationships(pkgMeta, lockPackages)...)
+		}
+	}
+
+	return packages, relationships, nil
+}
+
+// composerLockRelationships creates a "dependency-of" relationship from each package required by the given package to
+// the given package (platform requirements, such as "php" or "ext-json", are not packages within the lock file).
+func composerLockRelationships(dep Dependency, packages map[string]pkg.Package) []artifact.Relationship {
+	// note: map iteration order is random, so sort by name to keep the results stable across runs
+	required := make([]string, 0, len(dep.Require))
+	for name := range dep.Require {
+		required = append(required, name)
+	}
+	sort.Strings(required)
+
+	var relationships []artifact.Relationship
+	for _, name := range required {
+		requiredPkg, exists := packages[name]
+		if !exists {
+			continue
+		}
+		relationships = append(relationships, artifact.Relationship{
+			From: requiredPkg,
+			To:   packages[dep.Name],
+			Type: artifact.DependencyOfRelationship,
+		})
 	}
+	return relationships
+}
 
-	return packages, nil, nil
+func newComposerLockPackage(dep Dependency, dev bool) pkg.Package {
+	return pkg.Package{
+		Name:         dep.Name,
+		Version:      dep.Version,
+		Licenses:     dep.License,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerMetadataType,
+		Metadata: pkg.PhpComposerMetadata{
+			Name:     dep.Name,
+			Version:  dep.Version,
+			Licenses: dep.License,
+			Homepage: dep.Homepage,
+			Dev:      dev,
+		},
+	}
 }
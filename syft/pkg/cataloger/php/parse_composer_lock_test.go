@@ -4,23 +4,70 @@ import (
 	"os"
 	"testing"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseComposerFileLock(t *testing.T) {
 	expected := []pkg.Package{
 		{
-			Name:     "adoy/fastcgi-client",
-			Version:  "1.0.2",
-			Language: pkg.PHP,
-			Type:     pkg.PhpComposerPkg,
+			Name:         "adoy/fastcgi-client",
+			Version:      "1.0.2",
+			Licenses:     []string{"MIT"},
+			Language:     pkg.PHP,
+			Type:         pkg.PhpComposerPkg,
+			MetadataType: pkg.PhpComposerMetadataType,
+			Metadata: pkg.PhpComposerMetadata{
+				Name:     "adoy/fastcgi-client",
+				Version:  "1.0.2",
+				Licenses: []string{"MIT"},
+			},
 		},
 		{
-			Name:     "alcaeus/mongo-php-adapter",
-			Version:  "1.1.11",
-			Language: pkg.PHP,
-			Type:     pkg.PhpComposerPkg,
+			Name:         "alcaeus/mongo-php-adapter",
+			Version:      "1.1.11",
+			Licenses:     []string{"MIT"},
+			Language:     pkg.PHP,
+			Type:         pkg.PhpComposerPkg,
+			MetadataType: pkg.PhpComposerMetadataType,
+			Metadata: pkg.PhpComposerMetadata{
+				Name:     "alcaeus/mongo-php-adapter",
+				Version:  "1.1.11",
+				Licenses: []string{"MIT"},
+			},
+		},
+		{
+			Name:         "behat/gherkin",
+			Version:      "v4.6.2",
+			Licenses:     []string{"MIT"},
+			Language:     pkg.PHP,
+			Type:         pkg.PhpComposerPkg,
+			MetadataType: pkg.PhpComposerMetadataType,
+			Metadata: pkg.PhpComposerMetadata{
+				Name:     "behat/gherkin",
+				Version:  "v4.6.2",
+				Licenses: []string{"MIT"},
+				Homepage: "http://behat.org/",
+				Dev:      true,
+			},
+		},
+		{
+			Name:         "codeception/codeception",
+			Version:      "4.1.6",
+			Licenses:     []string{"MIT"},
+			Language:     pkg.PHP,
+			Type:         pkg.PhpComposerPkg,
+			MetadataType: pkg.PhpComposerMetadataType,
+			Metadata: pkg.PhpComposerMetadata{
+				Name:     "codeception/codeception",
+				Version:  "4.1.6",
+				Licenses: []string{"MIT"},
+				Homepage: "http://codeception.com/",
+				Dev:      true,
+			},
 		},
 	}
 	fixture, err := os.Open("test-fixtures/composer.lock")
@@ -39,3 +86,25 @@ func TestParseComposerFileLock(t *testing.T) {
 	}
 
 }
+
+func TestParseComposerFileLock_relationships(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/composer.lock")
+	require.NoError(t, err)
+
+	packages, relationships, err := parseComposerLock(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range packages {
+		byName[p.Name] = p
+	}
+
+	// platform requirements (e.g. "php" and "ext-json") and packages not within the lock file are not related
+	assert.Equal(t, []artifact.Relationship{
+		{
+			From: byName["behat/gherkin"],
+			To:   byName["codeception/codeception"],
+			Type: artifact.DependencyOfRelationship,
+		},
+	}, relationships)
+}
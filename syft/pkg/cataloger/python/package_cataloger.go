@@ -6,9 +6,11 @@ import (
 	"path/filepath"
 
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
 
 	"github.com/anchore/syft/syft/source"
 )
@@ -44,16 +46,19 @@ func (c *PackageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 	}
 
 	var pkgs []pkg.Package
+	var parseErrs common.ParseErrors
 	for _, location := range fileMatches {
 		p, err := c.catalogEggOrWheel(resolver, location)
 		if err != nil {
-			return nil, nil, fmt.Errorf("unable to catalog python package=%+v: %w", location.RealPath, err)
+			log.Warnf("cataloger '%s' failed to parse entries (location=%+v): %+v", c.Name(), location, err)
+			parseErrs = append(parseErrs, common.ParseError{Cataloger: c.Name(), Location: location, Err: err})
+			continue
 		}
 		if p != nil {
 			pkgs = append(pkgs, *p)
 		}
 	}
-	return pkgs, nil, nil
+	return pkgs, nil, parseErrs.OrNil()
 }
 
 // catalogEggOrWheel takes the primary metadata file reference and returns the python package it represents.
@@ -3,9 +3,12 @@ package python
 import (
 	"testing"
 
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/source"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPythonPackageWheelCataloger(t *testing.T) {
@@ -36,6 +39,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 					Platform:             "UNKNOWN",
 					Author:               "Kenneth Reitz",
 					AuthorEmail:          "me@kennethreitz.org",
+					Homepage:             "http://python-requests.org",
 					SitePackagesRootPath: "test-fixtures",
 					Files: []pkg.PythonFileRecord{
 						{Path: "requests-2.22.0.dist-info/INSTALLER", Digest: &pkg.PythonFileDigest{"sha256", "zuuue4knoyJ-UwPPXg8fezS7VCrXJQrAP7zeNuwvFQg"}, Size: "4"},
@@ -71,6 +75,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 					Platform:             "any",
 					Author:               "Georg Brandl",
 					AuthorEmail:          "georg@python.org",
+					Homepage:             "https://pygments.org/",
 					SitePackagesRootPath: "test-fixtures",
 					Files: []pkg.PythonFileRecord{
 						{Path: "../../../bin/pygmentize", Digest: &pkg.PythonFileDigest{"sha256", "dDhv_U2jiCpmFQwIRHpFRLAHUO4R1jIJPEvT_QYTFp8"}, Size: "220"},
@@ -105,6 +110,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 					Platform:             "any",
 					Author:               "Georg Brandl",
 					AuthorEmail:          "georg@python.org",
+					Homepage:             "https://pygments.org/",
 					SitePackagesRootPath: "test-fixtures",
 				},
 			},
@@ -127,6 +133,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 					Platform:             "UNKNOWN",
 					Author:               "Kenneth Reitz",
 					AuthorEmail:          "me@kennethreitz.org",
+					Homepage:             "http://python-requests.org",
 					SitePackagesRootPath: "test-fixtures",
 				},
 			},
@@ -184,3 +191,45 @@ func TestIgnorePackage(t *testing.T) {
 		})
 	}
 }
+
+func TestPackageCataloger_RecordPathsAndDigests(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/site-packages/six-1.16.0.dist-info/METADATA",
+		"test-fixtures/site-packages/six-1.16.0.dist-info/RECORD",
+		"test-fixtures/site-packages/six-1.16.0.dist-info/top_level.txt",
+		"test-fixtures/site-packages/six.py",
+	)
+
+	actual, _, err := NewPythonPackageCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+
+	metadata, ok := actual[0].Metadata.(pkg.PythonPackageMetadata)
+	require.True(t, ok)
+
+	// RECORD paths are relative to the site-packages root (the parent of the dist-info directory)
+	assert.Equal(t, []string{
+		"test-fixtures/site-packages/__pycache__/six.cpython-38.pyc",
+		"test-fixtures/site-packages/six-1.16.0.dist-info/METADATA",
+		"test-fixtures/site-packages/six-1.16.0.dist-info/RECORD",
+		"test-fixtures/site-packages/six-1.16.0.dist-info/top_level.txt",
+		"test-fixtures/site-packages/six.py",
+	}, metadata.OwnedFiles())
+
+	locations, err := resolver.FilesByPath("test-fixtures/site-packages/six.py")
+	require.NoError(t, err)
+	assert.Len(t, locations, 1, "owned file should be resolvable")
+
+	// RECORD digests are converted from URL-safe base64 to hex
+	assert.Equal(t, map[string][]file.Digest{
+		"test-fixtures/site-packages/six-1.16.0.dist-info/METADATA": {
+			{Algorithm: "sha256", Value: "a16bc2ee88f0a89e441599a8f503b1f50abb867239fd0949cc9d2242438ea114"},
+		},
+		"test-fixtures/site-packages/six-1.16.0.dist-info/top_level.txt": {
+			{Algorithm: "sha256", Value: "fe2547fe2604b445e70fc9d819062960552f9145bdb043b51986e478a4806a2b"},
+		},
+		"test-fixtures/site-packages/six.py": {
+			{Algorithm: "sha256", Value: "abc415b730bc5c6bd0ccc816a131dc3b33df674a103521b939e724fa863be94e"},
+		},
+	}, metadata.RecordedFileDigests())
+}
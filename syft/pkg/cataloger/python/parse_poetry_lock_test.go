@@ -11,32 +11,52 @@ import (
 func TestParsePoetryLock(t *testing.T) {
 	expected := []pkg.Package{
 		{
-			Name:     "added-value",
-			Version:  "0.14.2",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
-			Licenses: nil,
+			Name:         "added-value",
+			Version:      "0.14.2",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			Licenses:     nil,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata: pkg.PythonPoetryLockMetadata{
+				Category: "dev",
+				Dev:      true,
+			},
 		},
 		{
-			Name:     "alabaster",
-			Version:  "0.7.12",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
-			Licenses: nil,
+			Name:         "alabaster",
+			Version:      "0.7.12",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			Licenses:     nil,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata: pkg.PythonPoetryLockMetadata{
+				Category: "dev",
+				Dev:      true,
+			},
 		},
 		{
-			Name:     "appnope",
-			Version:  "0.1.0",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
-			Licenses: nil,
+			Name:         "appnope",
+			Version:      "0.1.0",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			Licenses:     nil,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata: pkg.PythonPoetryLockMetadata{
+				Category: "dev",
+				Dev:      true,
+			},
 		},
 		{
-			Name:     "asciitree",
-			Version:  "0.3.3",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
-			Licenses: nil,
+			Name:         "asciitree",
+			Version:      "0.3.3",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			Licenses:     nil,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata: pkg.PythonPoetryLockMetadata{
+				Category: "dev",
+				Dev:      true,
+			},
 		},
 	}
 
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/anchore/syft/syft/artifact"
@@ -14,54 +15,158 @@ import (
 // integrity check
 var _ common.ParserFn = parseRequirementsTxt
 
-// parseRequirementsTxt takes a Python requirements.txt file, returning all Python packages that are locked to a
-// specific version.
+// requirementOptionsPattern matches the start of the options that may follow a requirement (e.g. --hash).
+var requirementOptionsPattern = regexp.MustCompile(`\s--`)
+
+// requirementNamePattern matches valid python project names (see PEP 508).
+var requirementNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+// parseRequirementsTxt takes a Python requirements.txt file, returning all Python packages that are declared. Only
+// packages that are pinned to a specific version (with "==") have a version (and thus CPEs), otherwise the declared
+// version constraint is only recorded in the package metadata.
 func parseRequirementsTxt(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
 	packages := make([]pkg.Package, 0)
 
 	scanner := bufio.NewScanner(reader)
+	var continued string
 	for scanner.Scan() {
-		line := scanner.Text()
-
-		line = strings.TrimRight(line, "\n")
+		line := continued + scanner.Text()
+		continued = ""
 
-		switch {
-		case strings.HasPrefix(line, "#"):
-			// commented out line, skip
-			continue
-		case strings.HasPrefix(line, "-e"):
-			// editable packages aren't parsed (yet)
-			continue
-		case len(strings.Split(line, "==")) < 2:
-			// a package without a version, or a range (unpinned) which
-			// does not tell us exactly what will be installed
-			// XXX only needed if we want to log this, otherwise the next case catches it
-			continue
-		case len(strings.Split(line, "==")) == 2:
-			// remove comments if present
-			uncommented := removeTrailingComment(line)
-			// parse a new requirement
-			parts := strings.Split(uncommented, "==")
-			name := strings.TrimSpace(parts[0])
-			version := strings.TrimSpace(parts[1])
-			packages = append(packages, pkg.Package{
-				Name:     name,
-				Version:  version,
-				Language: pkg.Python,
-				Type:     pkg.PythonPkg,
-			})
-		default:
+		// a trailing backslash continues the line (e.g. when each --hash option is on a separate line)
+		if strings.HasSuffix(line, `\`) {
+			continued = strings.TrimSuffix(line, `\`)
 			continue
 		}
+
+		if p, ok := parseRequirementLine(line); ok {
+			packages = append(packages, p)
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, nil, fmt.Errorf("failed to parse python requirements file: %w", err)
 	}
 
+	if p, ok := parseRequirementLine(continued); ok {
+		packages = append(packages, p)
+	}
+
 	return packages, nil, nil
 }
 
+// parseRequirementLine parses a single requirement, such as `requests[security] >= 2.8.1 ; python_version < "3.8"`
+// optionally followed by --hash options, returning false for lines that do not declare a package by name.
+func parseRequirementLine(line string) (pkg.Package, bool) {
+	line = strings.TrimSpace(removeTrailingComment(line))
+
+	switch {
+	case line == "":
+		return pkg.Package{}, false
+	case strings.HasPrefix(line, "-"):
+		// options (e.g. -r other-requirements.txt and --index-url) and editable packages (-e) aren't parsed
+		return pkg.Package{}, false
+	}
+
+	requirement, hashes := splitRequirementOptions(line)
+
+	var markers string
+	if idx := strings.Index(requirement, ";"); idx >= 0 {
+		requirement, markers = requirement[:idx], strings.TrimSpace(requirement[idx+1:])
+	}
+
+	if strings.Contains(requirement, "@") || strings.Contains(requirement, "://") {
+		// direct references to an archive or VCS URL (e.g. "pip @ https://...") do not declare a version
+		return pkg.Package{}, false
+	}
+
+	name := requirement
+	if idx := strings.IndexAny(requirement, "[(<>=!~ \t"); idx >= 0 {
+		name = requirement[:idx]
+	}
+	if !requirementNamePattern.MatchString(name) {
+		return pkg.Package{}, false
+	}
+	remainder := strings.TrimSpace(requirement[len(name):])
+
+	var extras []string
+	if strings.HasPrefix(remainder, "[") {
+		end := strings.Index(remainder, "]")
+		if end < 0 {
+			return pkg.Package{}, false
+		}
+		for _, extra := range strings.Split(remainder[1:end], ",") {
+			if extra = strings.TrimSpace(extra); extra != "" {
+				extras = append(extras, extra)
+			}
+		}
+		remainder = strings.TrimSpace(remainder[end+1:])
+	}
+
+	// the version specifiers may be within parentheses, e.g. "name (>=1.0)"
+	constraint := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(remainder, "("), ")"))
+
+	return pkg.Package{
+		Name:         name,
+		Version:      pinnedVersion(constraint),
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonRequirementsMetadataType,
+		Metadata: pkg.PythonRequirementsMetadata{
+			Extras:            extras,
+			VersionConstraint: constraint,
+			Markers:           markers,
+			Hashes:            hashes,
+		},
+	}, true
+}
+
+// splitRequirementOptions separates the options that follow a requirement from the requirement itself, returning the
+// requirement and the values of all --hash options (other options, such as --global-option, are ignored).
+func splitRequirementOptions(line string) (string, []string) {
+	loc := requirementOptionsPattern.FindStringIndex(line)
+	if loc == nil {
+		return line, nil
+	}
+
+	var hashes []string
+	options := strings.Fields(line[loc[0]:])
+	for i := 0; i < len(options); i++ {
+		switch {
+		case strings.HasPrefix(options[i], "--hash="):
+			hashes = append(hashes, strings.TrimPrefix(options[i], "--hash="))
+		case options[i] == "--hash" && i+1 < len(options):
+			hashes = append(hashes, options[i+1])
+			i++
+		}
+	}
+	return line[:loc[0]], hashes
+}
+
+// pinnedVersion returns the version that the given version constraint pins the package to (with "==" or "==="),
+// which is empty for any other constraint (e.g. ">=1.0", "==1.*", or "==1.0,!=1.0.1").
+func pinnedVersion(constraint string) string {
+	if strings.Contains(constraint, ",") {
+		return ""
+	}
+
+	var version string
+	switch {
+	case strings.HasPrefix(constraint, "==="):
+		version = strings.TrimPrefix(constraint, "===")
+	case strings.HasPrefix(constraint, "=="):
+		version = strings.TrimPrefix(constraint, "==")
+	default:
+		return ""
+	}
+
+	version = strings.TrimSpace(version)
+	if strings.Contains(version, "*") {
+		return ""
+	}
+	return version
+}
+
 // removeTrailingComment takes a requirements.txt line and strips off comment strings.
 func removeTrailingComment(line string) string {
 	parts := strings.Split(line, "#")
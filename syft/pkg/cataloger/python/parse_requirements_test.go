@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/pkg"
 )
@@ -33,16 +35,34 @@ func assertPackagesEqual(t *testing.T, actual []pkg.Package, expected map[string
 func TestParseRequirementsTxt(t *testing.T) {
 	expected := map[string]pkg.Package{
 		"foo": {
-			Name:     "foo",
-			Version:  "1.0.0",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
+			Name:         "foo",
+			Version:      "1.0.0",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				VersionConstraint: "== 1.0.0",
+			},
 		},
 		"flask": {
-			Name:     "flask",
-			Version:  "4.0.0",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
+			Name:         "flask",
+			Version:      "4.0.0",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				VersionConstraint: "== 4.0.0",
+			},
+		},
+		// the version is unknown, since the package is not pinned to a specific version
+		"sqlalchemy": {
+			Name:         "sqlalchemy",
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				VersionConstraint: ">= 1.0.0",
+			},
 		},
 	}
 	fixture, err := os.Open("test-fixtures/requires/requirements.txt")
@@ -59,3 +79,81 @@ func TestParseRequirementsTxt(t *testing.T) {
 	assertPackagesEqual(t, actual, expected)
 
 }
+
+func TestParseRequirementsTxt_constraintsAndHashes(t *testing.T) {
+	newPackage := func(name, version string, metadata pkg.PythonRequirementsMetadata) pkg.Package {
+		return pkg.Package{
+			Name:         name,
+			Version:      version,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata:     metadata,
+		}
+	}
+
+	expected := map[string]pkg.Package{
+		// hashes given on continuation lines
+		"certifi": newPackage("certifi", "2021.10.8", pkg.PythonRequirementsMetadata{
+			VersionConstraint: "==2021.10.8",
+			Hashes: []string{
+				"sha256:78884e7c1d4b00ce3cea67b44566851c4343c120abd683433ce934a68ea58872",
+				"sha256:d62a0163eb4c2344ac042ab2bdf75399a71a2d8c7d47eac2e2ee91b9d6339569",
+			},
+		}),
+		"requests": newPackage("requests", "2.26.0", pkg.PythonRequirementsMetadata{
+			Extras:            []string{"security", "socks"},
+			VersionConstraint: "==2.26.0",
+			Hashes:            []string{"sha256:6c1246513ecd5ecd4528a0906f910e8f0f9c6b8ec72030dc9fd154dc1a6efd24"},
+		}),
+		"importlib-metadata": newPackage("importlib-metadata", "", pkg.PythonRequirementsMetadata{
+			VersionConstraint: ">=4.6",
+			Markers:           `python_version < "3.10"`,
+		}),
+		"urllib3": newPackage("urllib3", "", pkg.PythonRequirementsMetadata{
+			VersionConstraint: ">=1.21.1,<1.27",
+		}),
+		"six": newPackage("six", "1.16.0", pkg.PythonRequirementsMetadata{
+			VersionConstraint: "===1.16.0",
+		}),
+		"Django": newPackage("Django", "", pkg.PythonRequirementsMetadata{
+			VersionConstraint: ">=3.2",
+		}),
+		// a wildcard does not pin a specific version
+		"idna": newPackage("idna", "", pkg.PythonRequirementsMetadata{
+			VersionConstraint: "==3.*",
+		}),
+		"gunicorn": newPackage("gunicorn", "", pkg.PythonRequirementsMetadata{}),
+		// note: the direct reference to pip is not reported
+	}
+
+	fixture, err := os.Open("test-fixtures/requires/requirements-locked.txt")
+	require.NoError(t, err)
+
+	actual, _, err := parseRequirementsTxt(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	assertPackagesEqual(t, actual, expected)
+}
+
+func Test_pinnedVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{constraint: "==1.0.0", expected: "1.0.0"},
+		{constraint: "== 1.0.0", expected: "1.0.0"},
+		{constraint: "===1.0.0-custom", expected: "1.0.0-custom"},
+		{constraint: "==1.0.*", expected: ""},
+		{constraint: ">=1.0.0", expected: ""},
+		{constraint: "~=1.0", expected: ""},
+		{constraint: "!=1.0.0", expected: ""},
+		{constraint: "==1.0.0,!=1.0.1", expected: ""},
+		{constraint: "", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.constraint, func(t *testing.T) {
+			assert.Equal(t, test.expected, pinnedVersion(test.constraint))
+		})
+	}
+}
@@ -22,6 +22,7 @@ func TestParseWheelEggMetadata(t *testing.T) {
 				Platform:             "UNKNOWN",
 				Author:               "Kenneth Reitz",
 				AuthorEmail:          "me@kennethreitz.org",
+				Homepage:             "http://python-requests.org",
 				SitePackagesRootPath: "test-fixtures",
 			},
 		},
@@ -34,6 +35,7 @@ func TestParseWheelEggMetadata(t *testing.T) {
 				Platform:             "any",
 				Author:               "Georg Brandl",
 				AuthorEmail:          "georg@python.org",
+				Homepage:             "https://pygments.org/",
 				SitePackagesRootPath: "test-fixtures",
 			},
 		},
@@ -13,9 +13,15 @@ type PoetryMetadataPackage struct {
 // Pkg returns the standard `pkg.Package` representation of the package referenced within the poetry.lock metadata.
 func (p PoetryMetadataPackage) Pkg() pkg.Package {
 	return pkg.Package{
-		Name:     p.Name,
-		Version:  p.Version,
-		Language: pkg.Python,
-		Type:     pkg.PythonPkg,
+		Name:         p.Name,
+		Version:      p.Version,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata: pkg.PythonPoetryLockMetadata{
+			Category: p.Category,
+			Optional: p.Optional,
+			Dev:      p.Category == "dev",
+		},
 	}
 }
@@ -7,9 +7,11 @@ import (
 	"fmt"
 
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -35,6 +37,7 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 	}
 
 	var pkgs []pkg.Package
+	var parseErrs common.ParseErrors
 	for _, location := range fileMatches {
 		dbContentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
@@ -44,10 +47,12 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 		discoveredPkgs, err := parseRpmDB(resolver, location, dbContentReader)
 		internal.CloseAndLogError(dbContentReader, location.VirtualPath)
 		if err != nil {
-			return nil, nil, fmt.Errorf("unable to catalog rpmdb package=%+v: %w", location.RealPath, err)
+			log.Warnf("cataloger '%s' failed to parse entries (location=%+v): %+v", c.Name(), location, err)
+			parseErrs = append(parseErrs, common.ParseError{Cataloger: c.Name(), Location: location, Err: err})
+			continue
 		}
 
 		pkgs = append(pkgs, discoveredPkgs...)
 	}
-	return pkgs, nil, nil
+	return pkgs, nil, parseErrs.OrNil()
 }
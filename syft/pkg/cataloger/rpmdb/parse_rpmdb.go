@@ -91,6 +91,7 @@ func toELVersion(metadata pkg.RpmdbMetadata) string {
 
 func extractRpmdbFileRecords(resolver source.FilePathResolver, entry *rpmdb.PackageInfo) []pkg.RpmdbFileRecord {
 	var records = make([]pkg.RpmdbFileRecord, 0)
+	algorithm := digestAlgorithm(entry)
 
 	for _, record := range entry.Files {
 		// only persist RPMDB file records which exist in the image/directory, otherwise ignore them
@@ -101,7 +102,7 @@ func extractRpmdbFileRecords(resolver source.FilePathResolver, entry *rpmdb.Pack
 				Size: int(record.Size),
 				Digest: file.Digest{
 					Value:     record.Digest,
-					Algorithm: entry.DigestAlgorithm.String(),
+					Algorithm: algorithm,
 				},
 				UserName:  record.Username,
 				GroupName: record.Groupname,
@@ -111,3 +112,13 @@ func extractRpmdbFileRecords(resolver source.FilePathResolver, entry *rpmdb.Pack
 	}
 	return records
 }
+
+// digestAlgorithm returns the name of the algorithm used for all file digests of the given RPM DB entry. Packages built
+// by older versions of RPM (e.g. for RHEL/CentOS 5) do not have a file digest algorithm tag (RPMTAG_FILEDIGESTALGO), in
+// which case RPM assumes the file digests are MD5.
+func digestAlgorithm(entry *rpmdb.PackageInfo) string {
+	if entry.DigestAlgorithm == 0 {
+		return rpmdb.DigestAlgorithm(rpmdb.PGPHASHALGO_MD5).String()
+	}
+	return entry.DigestAlgorithm.String()
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/anchore/syft/syft/source"
 
+	rpmdb "github.com/anchore/go-rpmdb/pkg"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
 )
@@ -212,3 +213,52 @@ func TestToElVersion(t *testing.T) {
 func intRef(i int) *int {
 	return &i
 }
+
+func TestExtractRpmdbFileRecords_digestAlgorithm(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm rpmdb.DigestAlgorithm
+		expected  string
+	}{
+		{
+			name:      "sha256",
+			algorithm: rpmdb.PGPHASHALGO_SHA256,
+			expected:  "sha256",
+		},
+		{
+			// packages built by older versions of RPM do not specify the digest algorithm
+			name:     "missing algorithm defaults to md5",
+			expected: "md5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry := &rpmdb.PackageInfo{
+				DigestAlgorithm: test.algorithm,
+				Files: []rpmdb.FileInfo{
+					{
+						Path:   "/usr/bin/app",
+						Mode:   33261,
+						Size:   42,
+						Digest: "d41d8cd98f00b204e9800998ecf8427e",
+					},
+				},
+			}
+
+			records := extractRpmdbFileRecords(newTestFileResolver(false), entry)
+
+			assert.Equal(t, []pkg.RpmdbFileRecord{
+				{
+					Path: "/usr/bin/app",
+					Mode: 33261,
+					Size: 42,
+					Digest: file.Digest{
+						Algorithm: test.expected,
+						Value:     "d41d8cd98f00b204e9800998ecf8427e",
+					},
+				},
+			}, records)
+		})
+	}
+}
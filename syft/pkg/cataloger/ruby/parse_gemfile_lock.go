@@ -14,15 +14,22 @@ import (
 // integrity check
 var _ common.ParserFn = parseGemFileLockEntries
 
-var sectionsOfInterest = internal.NewStringSetFromSlice([]string{"GEM"})
+const (
+	gemSection          = "GEM"
+	dependenciesSection = "DEPENDENCIES"
+	bundledWithSection  = "BUNDLED WITH"
+)
 
-// parseGemFileLockEntries is a parser function for Gemfile.lock contents, returning all Gems discovered.
+// parseGemFileLockEntries is a parser function for Gemfile.lock contents, returning all Gems discovered. Gems are
+// discovered within the GEM "specs" section, where gems listed within the DEPENDENCIES section are marked as direct
+// (top-level) dependencies and all others are transitive.
 func parseGemFileLockEntries(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
-	pkgs := make([]pkg.Package, 0)
-	scanner := bufio.NewScanner(reader)
-
-	var currentSection string
+	var entries []*pkg.GemfileLockMetadata
+	var currentEntry *pkg.GemfileLockMetadata
+	var currentSection, remote, bundlerVersion string
+	direct := internal.NewStringSet()
 
+	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		sanitizedLine := strings.TrimSpace(line)
@@ -30,28 +37,61 @@ func parseGemFileLockEntries(_ string, reader io.Reader) ([]pkg.Package, []artif
 		if len(line) > 1 && line[0] != ' ' {
 			// start of section
 			currentSection = sanitizedLine
-			continue
-		} else if !sectionsOfInterest.Contains(currentSection) {
-			// skip this line, we're in the wrong section
+			currentEntry = nil
 			continue
 		}
 
-		if isDependencyLine(line) {
-			candidate := strings.Fields(sanitizedLine)
-			if len(candidate) != 2 {
-				continue
+		switch currentSection {
+		case gemSection:
+			switch {
+			case strings.HasPrefix(sanitizedLine, "remote:"):
+				remote = strings.TrimSpace(strings.TrimPrefix(sanitizedLine, "remote:"))
+			case isDependencyLine(line):
+				currentEntry = nil
+				candidate := strings.Fields(sanitizedLine)
+				if len(candidate) != 2 {
+					continue
+				}
+				version, platform := splitGemVersion(strings.Trim(candidate[1], "()"))
+				currentEntry = &pkg.GemfileLockMetadata{
+					Name:     candidate[0],
+					Version:  version,
+					Platform: platform,
+					Remote:   remote,
+				}
+				entries = append(entries, currentEntry)
+			case currentEntry != nil && sanitizedLine != "":
+				// a dependency of the current gem (indented below the gem spec)
+				currentEntry.Dependencies = appendUnique(currentEntry.Dependencies, gemName(sanitizedLine))
+			}
+		case dependenciesSection:
+			if sanitizedLine != "" {
+				direct.Add(gemName(sanitizedLine))
+			}
+		case bundledWithSection:
+			if sanitizedLine != "" {
+				bundlerVersion = sanitizedLine
 			}
-			pkgs = append(pkgs, pkg.Package{
-				Name:     candidate[0],
-				Version:  strings.Trim(candidate[1], "()"),
-				Language: pkg.Ruby,
-				Type:     pkg.GemPkg,
-			})
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, nil, err
 	}
+
+	pkgs := make([]pkg.Package, 0, len(entries))
+	for _, entry := range entries {
+		entry.Direct = direct.Contains(entry.Name)
+		entry.BundlerVersion = bundlerVersion
+
+		pkgs = append(pkgs, pkg.Package{
+			Name:         entry.Name,
+			Version:      entry.Version,
+			Language:     pkg.Ruby,
+			Type:         pkg.GemPkg,
+			MetadataType: pkg.GemfileLockMetadataType,
+			Metadata:     *entry,
+		})
+	}
 	return pkgs, nil, nil
 }
 
@@ -61,3 +101,31 @@ func isDependencyLine(line string) bool {
 	}
 	return strings.Count(line[:5], " ") == 4
 }
+
+// gemName returns the gem name from a dependency entry (e.g. "rails (= 4.1.1)" or "my-gem!", where "!" indicates a
+// gem from a non-rubygems source).
+func gemName(entry string) string {
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(fields[0], "!")
+}
+
+// splitGemVersion separates the platform from a resolved gem version (e.g. "1.13.1-x86_64-linux"). Gem versions never
+// contain "-" (prereleases are denoted with letters, e.g. "1.0.0.pre"), so anything after the first "-" is the platform.
+func splitGemVersion(value string) (string, string) {
+	if idx := strings.Index(value, "-"); idx > 0 {
+		return value[:idx], value[idx+1:]
+	}
+	return value, ""
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseGemfileLockEntries(t *testing.T) {
@@ -100,3 +102,45 @@ func TestParseGemfileLockEntries(t *testing.T) {
 		}
 	}
 }
+
+func TestParseGemfileLockEntries_directDependencies(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/gemfile-lock-direct/Gemfile.lock")
+	require.NoError(t, err)
+
+	actual, _, err := parseGemFileLockEntries(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	metadata := func(name, version, platform string, direct bool, dependencies ...string) pkg.GemfileLockMetadata {
+		return pkg.GemfileLockMetadata{
+			Name:           name,
+			Version:        version,
+			Platform:       platform,
+			Remote:         "https://rubygems.org/",
+			Dependencies:   dependencies,
+			Direct:         direct,
+			BundlerVersion: "2.3.5",
+		}
+	}
+
+	// note: gems from GIT and PATH sources are not cataloged
+	expected := []pkg.GemfileLockMetadata{
+		metadata("loofah", "2.13.0", "", false, "crass", "nokogiri"),
+		metadata("crass", "1.0.6", "", false),
+		metadata("mini_portile2", "2.7.1", "", false),
+		metadata("nokogiri", "1.13.1", "", true, "mini_portile2", "racc"),
+		metadata("nokogiri", "1.13.1", "x86_64-linux", true, "racc"),
+		metadata("racc", "1.6.0", "", false),
+		metadata("rake", "13.0.6", "", true),
+	}
+
+	require.Len(t, actual, len(expected))
+	for i, p := range actual {
+		assert.Equal(t, expected[i].Name, p.Name)
+		assert.Equal(t, expected[i].Version, p.Version)
+		assert.Equal(t, pkg.GemfileLockMetadataType, p.MetadataType)
+		assert.Equal(t, expected[i], p.Metadata)
+	}
+
+	assert.Equal(t, "pkg:gem/nokogiri@1.13.1", expected[3].PackageURL())
+	assert.Equal(t, "pkg:gem/nokogiri@1.13.1?platform=x86_64-linux", expected[4].PackageURL())
+}
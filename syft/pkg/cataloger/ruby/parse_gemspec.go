@@ -45,22 +45,38 @@ var patterns = map[string]*regexp.Regexp{
 
 	// match example:	    licenses = ["MIT".freeze]   ----> "MIT".freeze
 	"licenses": regexp.MustCompile(`.*\.licenses\s*=\s*\[(?P<licenses>.*)\] *`),
+
+	// match example:	    license = "MIT".freeze   ----> MIT
+	"license": regexp.MustCompile(`.*\.license\s*=\s*["']{1}(?P<license>.*)["']{1} *`),
 }
 
 var postProcessors = map[string]postProcessor{
 	"files":    processList,
 	"authors":  processList,
 	"licenses": processList,
+	"license":  processSingleton,
+}
+
+// fieldAliases are the fields that set the same metadata as another field, where (as with rubygems) the last
+// assignment of either field wins. For instance, the older license field is the same as a list with a single license.
+var fieldAliases = map[string]string{
+	"license": "licenses",
 }
 
 func processList(s string) []string {
 	var results []string
 	for _, item := range strings.Split(s, ",") {
-		results = append(results, strings.Trim(item, "\" "))
+		if item = strings.Trim(item, "\"' "); item != "" {
+			results = append(results, item)
+		}
 	}
 	return results
 }
 
+func processSingleton(s string) []string {
+	return []string{s}
+}
+
 func parseGemSpecEntries(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
 	var fields = make(map[string]interface{})
@@ -80,10 +96,14 @@ func parseGemSpecEntries(_ string, reader io.Reader) ([]pkg.Package, []artifact.
 		for field, pattern := range patterns {
 			matchMap := internal.MatchNamedCaptureGroups(pattern, sanitizedLine)
 			if value := matchMap[field]; value != "" {
+				name := field
+				if alias, ok := fieldAliases[field]; ok {
+					name = alias
+				}
 				if postProcessor := postProcessors[field]; postProcessor != nil {
-					fields[field] = postProcessor(value)
+					fields[name] = postProcessor(value)
 				} else {
-					fields[field] = value
+					fields[name] = value
 				}
 				// TODO: know that a line could actually match on multiple patterns, this is unlikely though
 				break
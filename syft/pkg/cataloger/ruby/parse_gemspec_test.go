@@ -2,10 +2,13 @@ package ruby
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseGemspec(t *testing.T) {
@@ -48,3 +51,68 @@ func TestParseGemspec(t *testing.T) {
 		t.Errorf("diff: %+v", d)
 	}
 }
+
+func TestParseGemspec_licenses(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected []string
+	}{
+		{
+			name:     "single license",
+			contents: `s.licenses = ["MIT".freeze]`,
+			expected: []string{"MIT"},
+		},
+		{
+			name:     "multiple licenses",
+			contents: `s.licenses = ["Ruby".freeze, "BSD-2-Clause".freeze]`,
+			expected: []string{"Ruby", "BSD-2-Clause"},
+		},
+		{
+			name:     "single quoted licenses",
+			contents: `spec.licenses = ['MIT', 'Apache-2.0']`,
+			expected: []string{"MIT", "Apache-2.0"},
+		},
+		{
+			name:     "singular license field",
+			contents: `s.license = "MIT".freeze`,
+			expected: []string{"MIT"},
+		},
+		{
+			name:     "last license assignment wins",
+			contents: "s.license = \"MIT\"\ns.licenses = [\"Ruby\", \"GPL-2.0\"]",
+			expected: []string{"Ruby", "GPL-2.0"},
+		},
+		{
+			name:     "no licenses",
+			contents: `s.licenses = []`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contents := "s.name = \"some-gem\"\ns.version = \"1.0.0\"\n" + test.contents + "\n"
+
+			actual, _, err := parseGemSpecEntries("some-gem.gemspec", strings.NewReader(contents))
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+
+			assert.Equal(t, test.expected, actual[0].Licenses)
+			assert.Equal(t, test.expected, actual[0].Metadata.(pkg.GemMetadata).Licenses)
+		})
+	}
+}
+
+func TestParseGemspec_multipleLicenses(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/racc.gemspec")
+	require.NoError(t, err)
+
+	actual, _, err := parseGemSpecEntries(fixture.Name(), fixture)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+
+	assert.Equal(t, "racc", actual[0].Name)
+	assert.Equal(t, "1.6.0", actual[0].Version)
+	assert.Equal(t, []string{"Ruby", "BSD-2-Clause"}, actual[0].Licenses)
+	assert.Equal(t, []string{"Minero Aoki", "Aaron Patterson"}, actual[0].Metadata.(pkg.GemMetadata).Authors)
+}
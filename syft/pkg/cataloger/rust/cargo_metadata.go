@@ -1,9 +1,16 @@
 package rust
 
-import "github.com/anchore/syft/syft/pkg"
+import (
+	"fmt"
+
+	"github.com/anchore/syft/syft/pkg"
+)
 
 type CargoMetadata struct {
 	Packages []pkg.CargoPackageMetadata `toml:"package"`
+	// Metadata is only found in v1 lockfiles, where package checksums are listed in a separate table with keys of the
+	// form "checksum <name> <version> (<source>)" (later lockfile versions store the checksum on each package entry).
+	Metadata map[string]string `toml:"metadata"`
 }
 
 // Pkgs returns all of the packages referenced within the Cargo.lock metadata.
@@ -14,6 +21,9 @@ func (m CargoMetadata) Pkgs() []pkg.Package {
 		if p.Dependencies == nil {
 			p.Dependencies = make([]string, 0)
 		}
+		if p.Checksum == "" {
+			p.Checksum = m.Metadata[fmt.Sprintf("checksum %s %s (%s)", p.Name, p.Version, p.Source)]
+		}
 		pkgs = append(pkgs, p.Pkg())
 	}
 
@@ -3,7 +3,9 @@ package rust
 import (
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/common"
@@ -13,7 +15,8 @@ import (
 // integrity check
 var _ common.ParserFn = parseCargoLock
 
-// parseCargoLock is a parser function for Cargo.lock contents, returning all rust cargo crates discovered.
+// parseCargoLock is a parser function for Cargo.lock contents, returning all rust cargo crates discovered along with the
+// dependency relationships between them.
 func parseCargoLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
 	tree, err := toml.LoadReader(reader)
 	if err != nil {
@@ -26,5 +29,68 @@ func parseCargoLock(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relat
 		return nil, nil, fmt.Errorf("unable to parse Cargo.lock: %v", err)
 	}
 
-	return metadata.Pkgs(), nil, nil
+	pkgs := metadata.Pkgs()
+	return pkgs, cargoLockRelationships(pkgs), nil
+}
+
+// cargoLockRelationships creates a "dependency-of" relationship from each dependency of a crate to the crate. Within a
+// Cargo.lock each dependency is listed as "name", "name version", or "name version (source)", where the version (and
+// source) are only given when needed to disambiguate multiple crates with the same name.
+func cargoLockRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	byName := make(map[string][]pkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(pkg.CargoPackageMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range metadata.Dependencies {
+			dependencyPkg, exists := resolveCargoDependency(dependency, byName)
+			if !exists {
+				log.Debugf("unable to resolve Cargo.lock dependency=%q of crate=%s", dependency, p)
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: dependencyPkg,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
+
+func resolveCargoDependency(dependency string, byName map[string][]pkg.Package) (pkg.Package, bool) {
+	fields := strings.Fields(dependency)
+	if len(fields) == 0 {
+		return pkg.Package{}, false
+	}
+
+	candidates := byName[fields[0]]
+	if len(fields) == 1 {
+		if len(candidates) != 1 {
+			return pkg.Package{}, false
+		}
+		return candidates[0], true
+	}
+
+	source := ""
+	if len(fields) > 2 {
+		source = strings.Trim(strings.Join(fields[2:], " "), "()")
+	}
+	for _, candidate := range candidates {
+		metadata, ok := candidate.Metadata.(pkg.CargoPackageMetadata)
+		if !ok || candidate.Version != fields[1] {
+			continue
+		}
+		if source != "" && metadata.Source != source {
+			continue
+		}
+		return candidate, true
+	}
+	return pkg.Package{}, false
 }
@@ -1,11 +1,15 @@
 package rust
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseCargoLock(t *testing.T) {
@@ -188,3 +192,166 @@ func TestParseCargoLock(t *testing.T) {
 		t.Errorf("returned package list differed from expectation: %+v", differences)
 	}
 }
+
+func TestParseCargoLock_v1(t *testing.T) {
+	expected := []pkg.Package{
+		{
+			Name:         "memchr",
+			Version:      "2.3.3",
+			Language:     pkg.Rust,
+			Type:         pkg.RustPkg,
+			MetadataType: pkg.RustCargoPackageMetadataType,
+			Metadata: pkg.CargoPackageMetadata{
+				Name:         "memchr",
+				Version:      "2.3.3",
+				Source:       "registry+https://github.com/rust-lang/crates.io-index",
+				Checksum:     "3728d817d99e5ac407411fa471ff9800a778d88a24685968b36824eaf4bee400",
+				Dependencies: []string{},
+			},
+		},
+		{
+			Name:         "nom",
+			Version:      "4.2.3",
+			Language:     pkg.Rust,
+			Type:         pkg.RustPkg,
+			MetadataType: pkg.RustCargoPackageMetadataType,
+			Metadata: pkg.CargoPackageMetadata{
+				Name:     "nom",
+				Version:  "4.2.3",
+				Source:   "registry+https://github.com/rust-lang/crates.io-index",
+				Checksum: "2ad2a91a8e869eeb30b9cb3119ae87773a8f4ae617f41b1eb9c154b2905f7bd6",
+				Dependencies: []string{
+					"memchr 2.3.3 (registry+https://github.com/rust-lang/crates.io-index)",
+					"version_check 0.1.5 (registry+https://github.com/rust-lang/crates.io-index)",
+				},
+			},
+		},
+		{
+			Name:         "some-local-crate",
+			Version:      "0.1.0",
+			Language:     pkg.Rust,
+			Type:         pkg.RustPkg,
+			MetadataType: pkg.RustCargoPackageMetadataType,
+			Metadata: pkg.CargoPackageMetadata{
+				Name:    "some-local-crate",
+				Version: "0.1.0",
+				Dependencies: []string{
+					"nom 4.2.3 (registry+https://github.com/rust-lang/crates.io-index)",
+				},
+			},
+		},
+		{
+			Name:         "version_check",
+			Version:      "0.1.5",
+			Language:     pkg.Rust,
+			Type:         pkg.RustPkg,
+			MetadataType: pkg.RustCargoPackageMetadataType,
+			Metadata: pkg.CargoPackageMetadata{
+				Name:         "version_check",
+				Version:      "0.1.5",
+				Source:       "registry+https://github.com/rust-lang/crates.io-index",
+				Checksum:     "914b1a6776c4c929a602fafd8bc742e06365d4bcbe48c30f9cca5824f70dc9dd",
+				Dependencies: []string{},
+			},
+		},
+	}
+
+	fixture, err := os.Open("test-fixtures/v1/Cargo.lock")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, _, err := parseCargoLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Error(err)
+	}
+
+	differences := deep.Equal(expected, actual)
+	if differences != nil {
+		t.Errorf("returned package list differed from expectation: %+v", differences)
+	}
+}
+
+func TestParseCargoLock_relationships(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []string
+	}{
+		{
+			fixture: "test-fixtures/Cargo.lock",
+			expected: []string{
+				"winapi -> ansi_term",
+				"memchr -> nom",
+				"version_check -> nom",
+				"matches -> unicode-bidi",
+				"winapi-i686-pc-windows-gnu -> winapi",
+				"winapi-x86_64-pc-windows-gnu -> winapi",
+			},
+		},
+		{
+			// v1 lockfiles always qualify dependencies with the version and source
+			fixture: "test-fixtures/v1/Cargo.lock",
+			expected: []string{
+				"memchr -> nom",
+				"version_check -> nom",
+				"nom -> some-local-crate",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			require.NoError(t, err)
+
+			_, relationships, err := parseCargoLock(fixture.Name(), fixture)
+			require.NoError(t, err)
+
+			var actual []string
+			for _, r := range relationships {
+				assert.Equal(t, artifact.DependencyOfRelationship, r.Type)
+				actual = append(actual, fmt.Sprintf("%s -> %s", r.From.(pkg.Package).Name, r.To.(pkg.Package).Name))
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_resolveCargoDependency(t *testing.T) {
+	crate := func(name, version, source string) pkg.Package {
+		return pkg.CargoPackageMetadata{Name: name, Version: version, Source: source}.Pkg()
+	}
+	registry := "registry+https://github.com/rust-lang/crates.io-index"
+	git := "git+https://github.com/rust-lang/cfg-if#bf1a6f7a0d3a4a6b8a6d6c5e38d7d2d8c9ffc0c1"
+
+	byName := map[string][]pkg.Package{
+		"libc":   {crate("libc", "0.2.112", registry)},
+		"cfg-if": {crate("cfg-if", "0.1.10", registry), crate("cfg-if", "1.0.0", registry), crate("cfg-if", "1.0.0", git)},
+	}
+
+	tests := []struct {
+		dependency string
+		expected   *pkg.Package
+	}{
+		{dependency: "libc", expected: &byName["libc"][0]},
+		{dependency: "cfg-if 0.1.10", expected: &byName["cfg-if"][0]},
+		{dependency: "cfg-if 1.0.0 (" + git + ")", expected: &byName["cfg-if"][2]},
+		// the name alone is ambiguous when there are multiple versions
+		{dependency: "cfg-if"},
+		{dependency: "cfg-if 2.0.0"},
+		{dependency: "missing"},
+		{dependency: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			actual, exists := resolveCargoDependency(test.dependency, byName)
+			if test.expected == nil {
+				assert.False(t, exists)
+				return
+			}
+			assert.True(t, exists)
+			assert.Equal(t, *test.expected, actual)
+		})
+	}
+}
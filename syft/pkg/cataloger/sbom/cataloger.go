@@ -0,0 +1,36 @@
+/*
+Package sbom provides a concrete Cataloger implementation for the SBOM documents that are embedded within a source
+(e.g. the SPDX documents that vendors ship alongside packaged applications).
+*/
+package sbom
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+const catalogerName = "sbom-cataloger"
+
+// Cataloger imports the packages declared by embedded SBOM documents, keeping the declared CPEs and PURL of each
+// package (rather than generating them).
+type Cataloger struct {
+	*common.GenericCataloger
+}
+
+// NewSBOMCataloger returns a new cataloger object for the packages declared by embedded SBOM documents (SPDX JSON,
+// SPDX tag-value, or syft JSON). The packages are found by this cataloger, at the location of the SBOM document.
+func NewSBOMCataloger() *Cataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/*.spdx.json": parseSBOM,
+		"**/*.spdx":      parseSBOM,
+		"**/*.syft.json": parseSBOM,
+	}
+
+	return &Cataloger{
+		GenericCataloger: common.NewGenericCataloger(nil, globParsers, catalogerName),
+	}
+}
+
+// ImportsPackages indicates that the packages found are imported as declared by another document.
+func (c *Cataloger) ImportsPackages() bool {
+	return true
+}
@@ -0,0 +1,190 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/spdx/tools-golang/tvloader"
+
+	"github.com/anchore/syft/internal/formats/spdx22json/model"
+	"github.com/anchore/syft/internal/formats/syftjson"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseSBOM
+
+// spdxPackage is the subset of an SPDX package (from either SPDX format) that describes a syft package.
+type spdxPackage struct {
+	name             string
+	version          string
+	licenseDeclared  string
+	licenseConcluded string
+	purl             string
+	cpes             []string
+}
+
+// parseSBOM parses the packages declared by an embedded SBOM document, which is either an SPDX 2.2 document (JSON or
+// tag-value, regardless of the file extension, since e.g. Bitnami ships SPDX JSON as ".spdx" files) or a syft JSON
+// document. Packages from syft JSON documents keep all details (except for their locations), while packages from
+// SPDX documents are typed by their PURL (if any).
+func parseSBOM(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(contents), []byte("{")) {
+		return parseSPDXTagValue(contents)
+	}
+
+	if syftjson.Format().Validate(bytes.NewReader(contents)) == nil {
+		return parseSyftJSON(contents)
+	}
+	return parseSPDXJSON(contents)
+}
+
+func parseSyftJSON(contents []byte) ([]pkg.Package, []artifact.Relationship, error) {
+	s, err := syftjson.Format().Decode(bytes.NewReader(contents))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode syft JSON SBOM: %w", err)
+	}
+
+	var packages []pkg.Package
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		// the locations of the packages are within the source that the SBOM describes (not within this source)
+		p.Locations = nil
+		packages = append(packages, p)
+	}
+	return packages, nil, nil
+}
+
+func parseSPDXJSON(contents []byte) ([]pkg.Package, []artifact.Relationship, error) {
+	var doc model.Document
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode SPDX JSON SBOM: %w", err)
+	}
+	if !strings.HasPrefix(doc.SPDXVersion, "SPDX-") {
+		return nil, nil, fmt.Errorf("unsupported SBOM: not a syft JSON or SPDX document")
+	}
+
+	var packages []spdxPackage
+	for _, p := range doc.Packages {
+		sp := spdxPackage{
+			name:             p.Name,
+			version:          p.VersionInfo,
+			licenseDeclared:  p.LicenseDeclared,
+			licenseConcluded: p.LicenseConcluded,
+		}
+		for _, ref := range p.ExternalRefs {
+			sp.addExternalRef(string(ref.ReferenceType), ref.ReferenceLocator)
+		}
+		packages = append(packages, sp)
+	}
+	return toPackages(packages), nil, nil
+}
+
+func parseSPDXTagValue(contents []byte) ([]pkg.Package, []artifact.Relationship, error) {
+	doc, err := tvloader.Load2_2(bytes.NewReader(contents))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode SPDX tag-value SBOM: %w", err)
+	}
+
+	var packages []spdxPackage
+	for _, p := range doc.Packages {
+		sp := spdxPackage{
+			name:             p.PackageName,
+			version:          p.PackageVersion,
+			licenseDeclared:  p.PackageLicenseDeclared,
+			licenseConcluded: p.PackageLicenseConcluded,
+		}
+		for _, ref := range p.PackageExternalReferences {
+			sp.addExternalRef(ref.RefType, ref.Locator)
+		}
+		packages = append(packages, sp)
+	}
+	return toPackages(packages), nil, nil
+}
+
+// addExternalRef records the PURL (the first one) and CPEs of the package.
+func (p *spdxPackage) addExternalRef(refType, locator string) {
+	switch refType {
+	case string(model.PurlExternalRefType):
+		if p.purl == "" {
+			p.purl = locator
+		}
+	case string(model.Cpe23ExternalRefType):
+		p.cpes = append(p.cpes, locator)
+	}
+}
+
+// toPackages creates a package for each of the given SPDX packages (ordered by name and version, since the order of
+// SPDX tag-value packages is not preserved), skipping any package without a name.
+func toPackages(spdxPackages []spdxPackage) []pkg.Package {
+	sort.SliceStable(spdxPackages, func(i, j int) bool {
+		if spdxPackages[i].name == spdxPackages[j].name {
+			return spdxPackages[i].version < spdxPackages[j].version
+		}
+		return spdxPackages[i].name < spdxPackages[j].name
+	})
+
+	var packages []pkg.Package
+	for _, sp := range spdxPackages {
+		if sp.name == "" {
+			continue
+		}
+
+		var cpes []pkg.CPE
+		for _, value := range sp.cpes {
+			c, err := pkg.NewCPE(value)
+			if err != nil {
+				continue
+			}
+			cpes = append(cpes, c)
+		}
+
+		packages = append(packages, pkg.Package{
+			Name:     sp.name,
+			Version:  noAssertionAsEmpty(sp.version),
+			Licenses: toLicenses(sp.licenseDeclared, sp.licenseConcluded),
+			Language: pkg.LanguageFromPURL(sp.purl),
+			Type:     pkg.TypeFromPURL(sp.purl),
+			CPEs:     cpes,
+			PURL:     sp.purl,
+		})
+	}
+	return packages
+}
+
+// toLicenses returns the licenses of the given license expressions (preferring the declared license), where
+// conjunctive expressions (e.g. "MIT AND Apache-2.0") are split into the individual licenses. Any other expression is
+// kept as a single license.
+func toLicenses(expressions ...string) []string {
+	for _, expression := range expressions {
+		expression = noAssertionAsEmpty(expression)
+		if expression == "" {
+			continue
+		}
+		if strings.ContainsAny(expression, "()") || strings.Contains(expression, " OR ") {
+			return []string{expression}
+		}
+		return strings.Split(expression, " AND ")
+	}
+	return nil
+}
+
+// noAssertionAsEmpty returns an empty value for the SPDX values that do not describe anything (NOASSERTION and NONE).
+func noAssertionAsEmpty(value string) string {
+	switch strings.TrimSpace(value) {
+	case "NOASSERTION", "NONE":
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
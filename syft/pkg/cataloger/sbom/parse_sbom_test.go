@@ -0,0 +1,110 @@
+package sbom
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseSBOM(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			fixture: "test-fixtures/image-bitnami/opt/bitnami/wordpress/.spdx-wordpress.spdx",
+			expected: []pkg.Package{
+				{
+					Name:     "guzzlehttp/guzzle",
+					Version:  "7.4.5",
+					Licenses: []string{"MIT"},
+					Language: pkg.PHP,
+					Type:     pkg.PhpComposerPkg,
+					PURL:     "pkg:composer/guzzlehttp/guzzle@7.4.5",
+				},
+				{
+					Name:     "php",
+					Version:  "8.0.21",
+					Licenses: []string{"PHP-3.01"},
+					Type:     pkg.UnknownPkg,
+					CPEs:     []pkg.CPE{pkg.MustCPE("cpe:2.3:a:php:php:8.0.21:*:*:*:*:*:*:*")},
+					PURL:     "pkg:generic/php@8.0.21",
+				},
+				{
+					Name:     "wordpress",
+					Version:  "6.0.1",
+					Licenses: []string{"GPL-2.0-or-later"},
+					Type:     pkg.UnknownPkg,
+					CPEs:     []pkg.CPE{pkg.MustCPE("cpe:2.3:a:wordpress:wordpress:6.0.1:*:*:*:*:*:*:*")},
+					PURL:     "pkg:bitnami/wordpress@6.0.1",
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/tag-value/vendor.spdx",
+			expected: []pkg.Package{
+				{
+					Name:     "lodash",
+					Version:  "4.17.21",
+					Licenses: []string{"MIT"},
+					Language: pkg.JavaScript,
+					Type:     pkg.NpmPkg,
+					PURL:     "pkg:npm/lodash@4.17.21",
+				},
+				{
+					Name:     "vendor-app",
+					Version:  "1.2.0",
+					Licenses: []string{"Apache-2.0", "MIT"},
+					Type:     pkg.UnknownPkg,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			require.NoError(t, err)
+			defer fixture.Close()
+
+			actual, relationships, err := parseSBOM(fixture.Name(), fixture)
+			require.NoError(t, err)
+			assert.Empty(t, relationships)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestParseSBOM_syftJSON(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/syft-json/build.syft.json")
+	require.NoError(t, err)
+	defer fixture.Close()
+
+	actual, _, err := parseSBOM(fixture.Name(), fixture)
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	// all details are kept, except for the locations within the source that the SBOM describes
+	for _, p := range actual {
+		assert.Equal(t, pkg.PythonPkg, p.Type)
+		assert.Equal(t, "python-index-cataloger", p.FoundBy)
+		assert.NotEmpty(t, p.CPEs)
+		assert.NotEmpty(t, p.PURL)
+		assert.Empty(t, p.Locations)
+	}
+	assert.Equal(t, "flask", actual[0].Name)
+	assert.Equal(t, "requests", actual[1].Name)
+}
+
+func TestParseSBOM_unsupported(t *testing.T) {
+	fixture, err := os.Open("cataloger.go")
+	require.NoError(t, err)
+	defer fixture.Close()
+
+	_, _, err = parseSBOM(fixture.Name(), fixture)
+	assert.Error(t, err)
+}
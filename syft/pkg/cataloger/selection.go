@@ -0,0 +1,88 @@
+package cataloger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+)
+
+// SelectCatalogers returns the catalogers to run given the default catalogers for a source and a list of cataloger
+// names to select. Names prefixed with "-" remove a cataloger and names prefixed with "+" add a cataloger to the
+// defaults. Any name without a prefix forms an allow-list instead, where only the named (and "+" prefixed) catalogers
+// are run. Names are not case sensitive, and an error listing the valid names is returned for any unknown name.
+func SelectCatalogers(defaults []Cataloger, selections []string) ([]Cataloger, error) {
+	if len(selections) == 0 {
+		return defaults, nil
+	}
+
+	known := knownCatalogers()
+	added := make(map[string]bool)
+	removed := make(map[string]bool)
+	allowList := false
+	for _, selection := range selections {
+		selection = strings.ToLower(strings.TrimSpace(selection))
+		if selection == "" {
+			continue
+		}
+
+		name := strings.TrimLeft(selection, "+-")
+		if !known.Contains(name) {
+			return nil, fmt.Errorf("unknown cataloger given: %q (options: %s)", name, strings.Join(known.ToSlice(), ", "))
+		}
+
+		switch selection[0] {
+		case '-':
+			removed[name] = true
+		case '+':
+			added[name] = true
+		default:
+			allowList = true
+			added[name] = true
+		}
+	}
+
+	var results []Cataloger
+	observed := make(map[string]bool)
+	keep := func(c Cataloger) {
+		name := strings.ToLower(c.Name())
+		if observed[name] || removed[name] {
+			return
+		}
+		observed[name] = true
+		results = append(results, c)
+	}
+
+	if !allowList {
+		for _, c := range defaults {
+			keep(c)
+		}
+	}
+	// added catalogers follow the defaults, in the same order as all catalogers are listed
+	for _, c := range allCatalogerSets() {
+		if added[strings.ToLower(c.Name())] {
+			keep(c)
+		}
+	}
+
+	return results, nil
+}
+
+// allCatalogerSets returns every cataloger from the image, directory, all, and opt-in cataloger sets (note: a
+// cataloger may appear in only some of the sets).
+func allCatalogerSets() []Cataloger {
+	var results []Cataloger
+	results = append(results, AllCatalogers()...)
+	results = append(results, ImageCatalogers()...)
+	results = append(results, DirectoryCatalogers()...)
+	return append(results, OptInCatalogers()...)
+}
+
+// knownCatalogers returns the (lowercase) names of all catalogers.
+func knownCatalogers() internal.StringSet {
+	results := internal.NewStringSet()
+	for _, c := range allCatalogerSets() {
+		results.Add(strings.ToLower(c.Name()))
+	}
+	return results
+}
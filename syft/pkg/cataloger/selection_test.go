@@ -0,0 +1,75 @@
+package cataloger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectCatalogers(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []string
+		expected   []string
+	}{
+		{
+			name:     "defaults",
+			expected: []string{"python-package-cataloger", "dpkgdb-cataloger", "java-cataloger"},
+		},
+		{
+			name:       "allow-list",
+			selections: []string{"java-cataloger", "rust-cataloger"},
+			// catalogers that are not among the defaults may be selected
+			expected: []string{"java-cataloger", "rust-cataloger"},
+		},
+		{
+			name:       "remove from defaults",
+			selections: []string{"-dpkgdb-cataloger"},
+			expected:   []string{"python-package-cataloger", "java-cataloger"},
+		},
+		{
+			name:       "add to defaults",
+			selections: []string{"+rust-cataloger", "+java-cataloger"},
+			expected:   []string{"python-package-cataloger", "dpkgdb-cataloger", "java-cataloger", "rust-cataloger"},
+		},
+		{
+			name:       "add opt-in cataloger",
+			selections: []string{"+sbom-cataloger"},
+			expected:   []string{"python-package-cataloger", "dpkgdb-cataloger", "java-cataloger", "sbom-cataloger"},
+		},
+		{
+			name:       "remove from allow-list",
+			selections: []string{"java-cataloger", "+rust-cataloger", "-java-cataloger"},
+			expected:   []string{"rust-cataloger"},
+		},
+		{
+			name:       "names are not case sensitive",
+			selections: []string{" Java-Cataloger ", ""},
+			expected:   []string{"java-cataloger"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaults, err := SelectCatalogers(nil, []string{"python-package-cataloger", "dpkgdb-cataloger", "java-cataloger"})
+			require.NoError(t, err)
+
+			actual, err := SelectCatalogers(defaults, test.selections)
+			require.NoError(t, err)
+
+			var names []string
+			for _, c := range actual {
+				names = append(names, c.Name())
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestSelectCatalogers_unknown(t *testing.T) {
+	_, err := SelectCatalogers(DirectoryCatalogers(), []string{"+java-cataloger", "-not-a-cataloger"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"not-a-cataloger"`)
+	// the valid names are listed, including catalogers that are only run for some sources
+	assert.Contains(t, err.Error(), "php-composer-installed-cataloger")
+}
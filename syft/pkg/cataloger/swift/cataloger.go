@@ -0,0 +1,17 @@
+/*
+Package swift provides a concrete Cataloger implementation for Swift Package Manager Package.resolved files.
+*/
+package swift
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// NewSwiftPackageManagerCataloger returns a new Swift Package Manager Package.resolved cataloger object.
+func NewSwiftPackageManagerCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Package.resolved": parsePackageResolved,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "swift-package-manager-cataloger")
+}
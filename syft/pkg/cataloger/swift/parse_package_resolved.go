@@ -0,0 +1,91 @@
+package swift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parsePackageResolved
+
+// packageResolved captures both schema versions of a Package.resolved file. Version 1 nests the pins under "object"
+// and describes each pin by "package" and "repositoryURL", while version 2 describes each pin by "identity" and
+// "location".
+type packageResolved struct {
+	Version int `json:"version"`
+	Object  struct {
+		Pins []packagePinV1 `json:"pins"`
+	} `json:"object"`
+	Pins []packagePinV2 `json:"pins"`
+}
+
+type packagePinV1 struct {
+	Package       string          `json:"package"`
+	RepositoryURL string          `json:"repositoryURL"`
+	State         packagePinState `json:"state"`
+}
+
+type packagePinV2 struct {
+	Identity string          `json:"identity"`
+	Kind     string          `json:"kind"`
+	Location string          `json:"location"`
+	State    packagePinState `json:"state"`
+}
+
+type packagePinState struct {
+	Branch   string `json:"branch"`
+	Revision string `json:"revision"`
+	Version  string `json:"version"`
+}
+
+// parsePackageResolved is a parser function for Package.resolved contents, returning all Swift packages discovered.
+func parsePackageResolved(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	var resolved packageResolved
+	if err := json.NewDecoder(reader).Decode(&resolved); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Package.resolved file: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	switch resolved.Version {
+	case 1:
+		for _, pin := range resolved.Object.Pins {
+			pkgs = append(pkgs, newSwiftPackage(pin.Package, pin.RepositoryURL, pin.State))
+		}
+	case 2:
+		for _, pin := range resolved.Pins {
+			pkgs = append(pkgs, newSwiftPackage(pin.Identity, pin.Location, pin.State))
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported Package.resolved version: %d", resolved.Version)
+	}
+
+	return pkgs, nil, nil
+}
+
+func newSwiftPackage(name, repositoryURL string, state packagePinState) pkg.Package {
+	// branch-pinned packages have no version, in which case the resolved commit is the most specific version available
+	version := state.Version
+	if version == "" {
+		version = state.Revision
+	}
+
+	return pkg.Package{
+		Name:         name,
+		Version:      version,
+		Language:     pkg.Swift,
+		Type:         pkg.SwiftPkg,
+		MetadataType: pkg.SwiftPackageResolvedMetadataType,
+		Metadata: pkg.SwiftPackageResolvedMetadata{
+			Name:          name,
+			RepositoryURL: repositoryURL,
+			Revision:      state.Revision,
+			Version:       state.Version,
+			Branch:        state.Branch,
+		},
+	}
+}
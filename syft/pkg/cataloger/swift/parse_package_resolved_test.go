@@ -0,0 +1,174 @@
+package swift
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePackageResolved(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			fixture: "test-fixtures/v1/Package.resolved",
+			expected: []pkg.Package{
+				{
+					Name:         "swift-argument-parser",
+					Version:      "0.5.0",
+					Language:     pkg.Swift,
+					Type:         pkg.SwiftPkg,
+					MetadataType: pkg.SwiftPackageResolvedMetadataType,
+					Metadata: pkg.SwiftPackageResolvedMetadata{
+						Name:          "swift-argument-parser",
+						RepositoryURL: "https://github.com/apple/swift-argument-parser",
+						Revision:      "6b2aa2748a7881eebb9f84fb10c01293e15b52ca",
+						Version:       "0.5.0",
+					},
+				},
+				{
+					Name:         "Alamofire",
+					Version:      "5.4.3",
+					Language:     pkg.Swift,
+					Type:         pkg.SwiftPkg,
+					MetadataType: pkg.SwiftPackageResolvedMetadataType,
+					Metadata: pkg.SwiftPackageResolvedMetadata{
+						Name:          "Alamofire",
+						RepositoryURL: "https://github.com/Alamofire/Alamofire.git",
+						Revision:      "f96b619bcb2383b43d898402283924b80e2c4bae",
+						Version:       "5.4.3",
+					},
+				},
+				{
+					Name:         "swift-log",
+					Version:      "5d66f7ba25daf4f94100e7022febf3c75e37a6c7",
+					Language:     pkg.Swift,
+					Type:         pkg.SwiftPkg,
+					MetadataType: pkg.SwiftPackageResolvedMetadataType,
+					Metadata: pkg.SwiftPackageResolvedMetadata{
+						Name:          "swift-log",
+						RepositoryURL: "git@github.com:apple/swift-log.git",
+						Revision:      "5d66f7ba25daf4f94100e7022febf3c75e37a6c7",
+						Branch:        "main",
+					},
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/v2/Package.resolved",
+			expected: []pkg.Package{
+				{
+					Name:         "swift-argument-parser",
+					Version:      "1.0.3",
+					Language:     pkg.Swift,
+					Type:         pkg.SwiftPkg,
+					MetadataType: pkg.SwiftPackageResolvedMetadataType,
+					Metadata: pkg.SwiftPackageResolvedMetadata{
+						Name:          "swift-argument-parser",
+						RepositoryURL: "https://github.com/apple/swift-argument-parser",
+						Revision:      "e394bf350e38cb100b6bc4172834770ede1b7232",
+						Version:       "1.0.3",
+					},
+				},
+				{
+					Name:         "swift-nio",
+					Version:      "124119f0bb12384cef35aa041d7c3a686108722d",
+					Language:     pkg.Swift,
+					Type:         pkg.SwiftPkg,
+					MetadataType: pkg.SwiftPackageResolvedMetadataType,
+					Metadata: pkg.SwiftPackageResolvedMetadata{
+						Name:          "swift-nio",
+						RepositoryURL: "https://github.com/apple/swift-nio.git",
+						Revision:      "124119f0bb12384cef35aa041d7c3a686108722d",
+						Branch:        "main",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			fixture, err := os.Open(test.fixture)
+			if err != nil {
+				t.Fatalf("failed to open fixture: %+v", err)
+			}
+
+			actual, _, err := parsePackageResolved(fixture.Name(), fixture)
+			if err != nil {
+				t.Fatalf("failed to parse Package.resolved: %+v", err)
+			}
+
+			differences := deep.Equal(test.expected, actual)
+			if differences != nil {
+				t.Errorf("returned package list differed from expectation: %+v", differences)
+			}
+		})
+	}
+}
+
+func TestParsePackageResolved_invalid(t *testing.T) {
+	_, _, err := parsePackageResolved("Package.resolved", strings.NewReader("{not json"))
+	assert.Error(t, err)
+
+	_, _, err = parsePackageResolved("Package.resolved", strings.NewReader(`{"pins": [], "version": 99}`))
+	assert.Error(t, err)
+}
+
+func TestSwiftPackageResolvedMetadata_pURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata pkg.SwiftPackageResolvedMetadata
+		expected string
+	}{
+		{
+			name: "version",
+			metadata: pkg.SwiftPackageResolvedMetadata{
+				Name:          "swift-argument-parser",
+				RepositoryURL: "https://github.com/apple/swift-argument-parser",
+				Revision:      "e394bf350e38cb100b6bc4172834770ede1b7232",
+				Version:       "1.0.3",
+			},
+			expected: "pkg:swift/github.com/apple/swift-argument-parser@1.0.3",
+		},
+		{
+			name: "git suffix",
+			metadata: pkg.SwiftPackageResolvedMetadata{
+				Name:          "Alamofire",
+				RepositoryURL: "https://github.com/Alamofire/Alamofire.git",
+				Version:       "5.4.3",
+			},
+			expected: "pkg:swift/github.com/Alamofire/Alamofire@5.4.3",
+		},
+		{
+			name: "scp-like repository URL",
+			metadata: pkg.SwiftPackageResolvedMetadata{
+				Name:          "swift-log",
+				RepositoryURL: "git@github.com:apple/swift-log.git",
+				Version:       "1.4.2",
+			},
+			expected: "pkg:swift/github.com/apple/swift-log@1.4.2",
+		},
+		{
+			name: "branch pinned",
+			metadata: pkg.SwiftPackageResolvedMetadata{
+				Name:          "swift-nio",
+				RepositoryURL: "https://github.com/apple/swift-nio.git",
+				Revision:      "124119f0bb12384cef35aa041d7c3a686108722d",
+				Branch:        "main",
+			},
+			expected: "pkg:swift/github.com/apple/swift-nio@124119f0bb12384cef35aa041d7c3a686108722d",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metadata.PackageURL())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+/*
+Package windows provides a concrete Cataloger implementation for programs registered as installed within the Windows
+registry (such as programs installed by MSI packages), e.g. from a mounted Windows VHD or a Windows container image.
+*/
+package windows
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// NewRegistryCataloger returns a new Windows registry cataloger object, which reads the SOFTWARE registry hive.
+func NewRegistryCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Windows/System32/config/SOFTWARE": parseSoftwareHive,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "windows-registry-cataloger")
+}
@@ -0,0 +1,107 @@
+package windows
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parseSoftwareHive
+
+// uninstallKeyPaths are the keys within the SOFTWARE hive that hold a subkey for each installed program, for native
+// programs and 32-bit programs on a 64-bit system (respectively).
+var uninstallKeyPaths = [][]string{
+	{"Microsoft", "Windows", "CurrentVersion", "Uninstall"},
+	{"WOW6432Node", "Microsoft", "Windows", "CurrentVersion", "Uninstall"},
+}
+
+// parseSoftwareHive is a parser function for the Windows registry SOFTWARE hive, returning all programs registered as
+// installed. Only programs with a display name are reported (which is what is listed as installed by Windows).
+func parseSoftwareHive(_ string, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
+	// note: cells within the hive refer to each other by offset, so the entire hive is read into memory
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read SOFTWARE hive: %w", err)
+	}
+
+	h, err := newHive(contents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse SOFTWARE hive: %w", err)
+	}
+
+	root, err := h.root()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read SOFTWARE hive root key: %w", err)
+	}
+
+	var packages []pkg.Package
+	for _, path := range uninstallKeyPaths {
+		uninstall, exists, err := root.find(path...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to find uninstall key=%v: %w", path, err)
+		}
+		if !exists {
+			continue
+		}
+
+		programs, err := uninstall.subkeys()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, program := range programs {
+			p, ok, err := newWindowsProgramPackage(program)
+			if err != nil {
+				log.Warnf("unable to read installed program key=%q: %+v", program.name, err)
+				continue
+			}
+			if ok {
+				packages = append(packages, p)
+			}
+		}
+	}
+
+	return packages, nil, nil
+}
+
+func newWindowsProgramPackage(program registryKey) (pkg.Package, bool, error) {
+	displayName, _, err := program.stringValue("DisplayName")
+	if err != nil || displayName == "" {
+		return pkg.Package{}, false, err
+	}
+
+	metadata := pkg.WindowsRegistryMetadata{
+		Key:         program.name,
+		DisplayName: displayName,
+	}
+
+	for name, field := range map[string]*string{
+		"DisplayVersion":  &metadata.DisplayVersion,
+		"Publisher":       &metadata.Publisher,
+		"InstallLocation": &metadata.InstallLocation,
+	} {
+		if *field, _, err = program.stringValue(name); err != nil {
+			return pkg.Package{}, false, err
+		}
+	}
+
+	windowsInstaller, _, err := program.dwordValue("WindowsInstaller")
+	if err != nil {
+		return pkg.Package{}, false, err
+	}
+	metadata.WindowsInstaller = windowsInstaller == 1
+
+	return pkg.Package{
+		Name:         metadata.DisplayName,
+		Version:      metadata.DisplayVersion,
+		Type:         pkg.WindowsPkg,
+		MetadataType: pkg.WindowsRegistryMetadataType,
+		Metadata:     metadata,
+	}, true, nil
+}
@@ -0,0 +1,70 @@
+package windows
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSoftwareHive(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/Windows/System32/config/SOFTWARE")
+	require.NoError(t, err)
+	defer fixture.Close()
+
+	actual, _, err := parseSoftwareHive(fixture.Name(), fixture)
+	require.NoError(t, err)
+
+	// note: the "Connection Manager" key has no display name (it is not listed as an installed program)
+	expected := []pkg.Package{
+		{
+			Name:         "7-Zip 21.07 (x64)",
+			Version:      "21.07",
+			Type:         pkg.WindowsPkg,
+			MetadataType: pkg.WindowsRegistryMetadataType,
+			Metadata: pkg.WindowsRegistryMetadata{
+				Key:             "7-Zip",
+				DisplayName:     "7-Zip 21.07 (x64)",
+				DisplayVersion:  "21.07",
+				Publisher:       "Igor Pavlov",
+				InstallLocation: `C:\Program Files\7-Zip\`,
+			},
+		},
+		{
+			Name:         "Office 16 Click-to-Run Extensibility Component",
+			Version:      "16.0.14326.20404",
+			Type:         pkg.WindowsPkg,
+			MetadataType: pkg.WindowsRegistryMetadataType,
+			Metadata: pkg.WindowsRegistryMetadata{
+				Key:              "{90160000-008C-0000-1000-0000000FF1CE}",
+				DisplayName:      "Office 16 Click-to-Run Extensibility Component",
+				DisplayVersion:   "16.0.14326.20404",
+				Publisher:        "Microsoft Corporation",
+				InstallLocation:  `%ProgramFiles%\Microsoft Office\`,
+				WindowsInstaller: true,
+			},
+		},
+		{
+			Name:         "Notepad++ (32-bit x86)",
+			Version:      "8.1.9.3",
+			Type:         pkg.WindowsPkg,
+			MetadataType: pkg.WindowsRegistryMetadataType,
+			Metadata: pkg.WindowsRegistryMetadata{
+				Key:            "Notepad++",
+				DisplayName:    "Notepad++ (32-bit x86)",
+				DisplayVersion: "8.1.9.3",
+				Publisher:      "Notepad++ Team",
+			},
+		},
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestParseSoftwareHive_notHive(t *testing.T) {
+	_, _, err := parseSoftwareHive("SOFTWARE", strings.NewReader("not a registry hive"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,332 @@
+package windows
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// the registry hive (regf) layout is described at https://github.com/msuhanov/regf (all values are little endian). Only
+// what is needed to read keys and string values is supported, and the hive is never modified.
+const (
+	hiveMagic = "regf"
+
+	// the hive bins (which contain all cells) start after the base block, and all cell offsets are relative to this
+	hiveBinsOffset = 0x1000
+	// the offset of the root key cell within the base block
+	rootCellOffsetField = 0x24
+
+	// names are stored as ASCII (extended) strings instead of UTF-16LE when these flags are set
+	keyCompressedNameFlag   = 0x0020
+	valueCompressedNameFlag = 0x0001
+
+	// the data of values with a data size that has this bit set is stored within the data offset field itself
+	valueInlineDataFlag = 0x80000000
+
+	regSz       = 1
+	regExpandSz = 2
+	regDword    = 4
+
+	// subkey lists may be nested (an index root refers to other subkey lists), but only a single level is expected
+	maxSubkeyListDepth = 2
+)
+
+// hive is a read-only view of a Windows registry hive file (e.g. the SOFTWARE hive).
+type hive struct {
+	data []byte
+}
+
+// registryKey is a single key (nk cell) within a hive.
+type registryKey struct {
+	hive *hive
+	name string
+	cell []byte
+}
+
+// registryValue is a single value (vk cell) of a registry key.
+type registryValue struct {
+	name     string
+	dataType uint32
+	data     []byte
+}
+
+func newHive(data []byte) (*hive, error) {
+	if len(data) < hiveBinsOffset || string(data[:len(hiveMagic)]) != hiveMagic {
+		return nil, errors.New("not a registry hive (bad magic)")
+	}
+	return &hive{data: data}, nil
+}
+
+// root returns the root key of the hive.
+func (h *hive) root() (registryKey, error) {
+	return h.key(binary.LittleEndian.Uint32(h.data[rootCellOffsetField:]))
+}
+
+// cell returns the contents of the allocated cell at the given offset (relative to the start of the hive bins).
+func (h *hive) cell(offset uint32) ([]byte, error) {
+	start := uint64(hiveBinsOffset) + uint64(offset)
+	if start+4 > uint64(len(h.data)) {
+		return nil, fmt.Errorf("cell offset=%#x is out of bounds", offset)
+	}
+
+	// allocated cells have a negative size (which includes the size field itself)
+	size := int32(binary.LittleEndian.Uint32(h.data[start:]))
+	if size >= 0 {
+		return nil, fmt.Errorf("cell offset=%#x is not allocated", offset)
+	}
+	end := start + uint64(-int64(size))
+	if end > uint64(len(h.data)) || end < start+4 {
+		return nil, fmt.Errorf("cell offset=%#x has a bad size=%d", offset, size)
+	}
+	return h.data[start+4 : end], nil
+}
+
+func (h *hive) key(offset uint32) (registryKey, error) {
+	cell, err := h.cell(offset)
+	if err != nil {
+		return registryKey{}, err
+	}
+	if len(cell) < 76 || string(cell[:2]) != "nk" {
+		return registryKey{}, fmt.Errorf("cell offset=%#x is not a key", offset)
+	}
+
+	flags := binary.LittleEndian.Uint16(cell[2:])
+	nameLength := int(binary.LittleEndian.Uint16(cell[72:]))
+	if 76+nameLength > len(cell) {
+		return registryKey{}, fmt.Errorf("key offset=%#x has a bad name length=%d", offset, nameLength)
+	}
+
+	return registryKey{
+		hive: h,
+		name: decodeName(cell[76:76+nameLength], flags&keyCompressedNameFlag != 0),
+		cell: cell,
+	}, nil
+}
+
+// find returns the key at the given path of subkey names below this key (names are not case sensitive).
+func (k registryKey) find(path ...string) (registryKey, bool, error) {
+	current := k
+	for _, name := range path {
+		subkeys, err := current.subkeys()
+		if err != nil {
+			return registryKey{}, false, err
+		}
+
+		found := false
+		for _, subkey := range subkeys {
+			if strings.EqualFold(subkey.name, name) {
+				current, found = subkey, true
+				break
+			}
+		}
+		if !found {
+			return registryKey{}, false, nil
+		}
+	}
+	return current, true, nil
+}
+
+func (k registryKey) subkeys() ([]registryKey, error) {
+	if binary.LittleEndian.Uint32(k.cell[20:]) == 0 {
+		return nil, nil
+	}
+
+	offsets, err := k.hive.subkeyOffsets(binary.LittleEndian.Uint32(k.cell[28:]), 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read subkeys of key=%q: %w", k.name, err)
+	}
+
+	subkeys := make([]registryKey, 0, len(offsets))
+	for _, offset := range offsets {
+		subkey, err := k.hive.key(offset)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read subkey of key=%q: %w", k.name, err)
+		}
+		subkeys = append(subkeys, subkey)
+	}
+	return subkeys, nil
+}
+
+// subkeyOffsets returns the offsets of all keys within the given subkey list, which is either a fast leaf ("lf"), hash
+// leaf ("lh"), index leaf ("li"), or an index root ("ri") of other subkey lists.
+func (h *hive) subkeyOffsets(offset uint32, depth int) ([]uint32, error) {
+	cell, err := h.cell(offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(cell) < 4 {
+		return nil, fmt.Errorf("subkey list offset=%#x is too small", offset)
+	}
+
+	signature := string(cell[:2])
+	count := int(binary.LittleEndian.Uint16(cell[2:]))
+
+	var stride int
+	switch signature {
+	case "lf", "lh":
+		// each element is followed by a hint (or hash) of the subkey name
+		stride = 8
+	case "li", "ri":
+		stride = 4
+	default:
+		return nil, fmt.Errorf("subkey list offset=%#x has an unknown signature=%q", offset, signature)
+	}
+	if signature == "ri" && depth >= maxSubkeyListDepth {
+		return nil, fmt.Errorf("subkey list offset=%#x is nested too deeply", offset)
+	}
+	if 4+count*stride > len(cell) {
+		return nil, fmt.Errorf("subkey list offset=%#x has a bad count=%d", offset, count)
+	}
+
+	var offsets []uint32
+	for i := 0; i < count; i++ {
+		element := binary.LittleEndian.Uint32(cell[4+i*stride:])
+		if signature != "ri" {
+			offsets = append(offsets, element)
+			continue
+		}
+		nested, err := h.subkeyOffsets(element, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, nested...)
+	}
+	return offsets, nil
+}
+
+func (k registryKey) values() ([]registryValue, error) {
+	count := int(binary.LittleEndian.Uint32(k.cell[36:]))
+	if count == 0 {
+		return nil, nil
+	}
+
+	list, err := k.hive.cell(binary.LittleEndian.Uint32(k.cell[40:]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read values of key=%q: %w", k.name, err)
+	}
+	if count*4 > len(list) {
+		return nil, fmt.Errorf("key=%q has a bad value count=%d", k.name, count)
+	}
+
+	values := make([]registryValue, 0, count)
+	for i := 0; i < count; i++ {
+		value, err := k.hive.value(binary.LittleEndian.Uint32(list[i*4:]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read value of key=%q: %w", k.name, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (h *hive) value(offset uint32) (registryValue, error) {
+	cell, err := h.cell(offset)
+	if err != nil {
+		return registryValue{}, err
+	}
+	if len(cell) < 20 || string(cell[:2]) != "vk" {
+		return registryValue{}, fmt.Errorf("cell offset=%#x is not a value", offset)
+	}
+
+	nameLength := int(binary.LittleEndian.Uint16(cell[2:]))
+	size := binary.LittleEndian.Uint32(cell[4:])
+	dataOffset := binary.LittleEndian.Uint32(cell[8:])
+	flags := binary.LittleEndian.Uint16(cell[16:])
+	if 20+nameLength > len(cell) {
+		return registryValue{}, fmt.Errorf("value offset=%#x has a bad name length=%d", offset, nameLength)
+	}
+
+	value := registryValue{
+		name:     decodeName(cell[20:20+nameLength], flags&valueCompressedNameFlag != 0),
+		dataType: binary.LittleEndian.Uint32(cell[12:]),
+	}
+
+	if size&valueInlineDataFlag != 0 {
+		size &^= valueInlineDataFlag
+		if size > 4 {
+			return registryValue{}, fmt.Errorf("value=%q has a bad inline data size=%d", value.name, size)
+		}
+		value.data = cell[8 : 8+size]
+		return value, nil
+	}
+	if size == 0 {
+		return value, nil
+	}
+
+	data, err := h.cell(dataOffset)
+	if err != nil {
+		return registryValue{}, fmt.Errorf("unable to read data of value=%q: %w", value.name, err)
+	}
+	if uint64(size) > uint64(len(data)) {
+		// larger values are split into segments ("db" cells), which are not needed for program details
+		return registryValue{}, fmt.Errorf("value=%q has unsupported data size=%d", value.name, size)
+	}
+	value.data = data[:size]
+	return value, nil
+}
+
+// stringValue returns the value with the given name (which is not case sensitive) as a string, if it is a string value.
+func (k registryKey) stringValue(name string) (string, bool, error) {
+	values, err := k.values()
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, value := range values {
+		if !strings.EqualFold(value.name, name) {
+			continue
+		}
+		if value.dataType != regSz && value.dataType != regExpandSz {
+			return "", false, nil
+		}
+		return decodeUTF16(value.data), true, nil
+	}
+	return "", false, nil
+}
+
+// dwordValue returns the value with the given name (which is not case sensitive) as a number, if it is a DWORD value.
+func (k registryKey) dwordValue(name string) (uint32, bool, error) {
+	values, err := k.values()
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, value := range values {
+		if !strings.EqualFold(value.name, name) {
+			continue
+		}
+		if value.dataType != regDword || len(value.data) != 4 {
+			return 0, false, nil
+		}
+		return binary.LittleEndian.Uint32(value.data), true, nil
+	}
+	return 0, false, nil
+}
+
+func decodeName(name []byte, compressed bool) string {
+	if !compressed {
+		return decodeUTF16(name)
+	}
+
+	// compressed names are extended ASCII (Latin-1), where each byte is the code point
+	runes := make([]rune, len(name))
+	for i, b := range name {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// decodeUTF16 decodes the given UTF-16LE string, which may be NUL-terminated.
+func decodeUTF16(data []byte) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		unit := binary.LittleEndian.Uint16(data[i:])
+		if unit == 0 {
+			break
+		}
+		units = append(units, unit)
+	}
+	return strings.TrimSpace(string(utf16.Decode(units)))
+}
@@ -0,0 +1,97 @@
+package windows
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixtureHive(t *testing.T) []byte {
+	t.Helper()
+	contents, err := ioutil.ReadFile("test-fixtures/Windows/System32/config/SOFTWARE")
+	require.NoError(t, err)
+	return contents
+}
+
+func Test_registryKey_find(t *testing.T) {
+	h, err := newHive(readFixtureHive(t))
+	require.NoError(t, err)
+	root, err := h.root()
+	require.NoError(t, err)
+
+	tests := []struct {
+		path         []string
+		expectedName string
+	}{
+		{path: []string{"Microsoft", "Windows", "CurrentVersion"}, expectedName: "CurrentVersion"},
+		// key names are not case sensitive
+		{path: []string{"microsoft", "WINDOWS"}, expectedName: "Windows"},
+		{path: []string{"Microsoft", "Missing"}},
+		{path: []string{"Classes", "Missing"}},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.path, `\`), func(t *testing.T) {
+			key, exists, err := root.find(test.path...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedName != "", exists)
+			assert.Equal(t, test.expectedName, key.name)
+		})
+	}
+}
+
+func Test_registryKey_values(t *testing.T) {
+	h, err := newHive(readFixtureHive(t))
+	require.NoError(t, err)
+	root, err := h.root()
+	require.NoError(t, err)
+
+	key, exists, err := root.find("Microsoft", "Windows", "CurrentVersion", "Uninstall", "7-Zip")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	value, exists, err := key.stringValue("publisher")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "Igor Pavlov", value)
+
+	// DWORD values are not strings (and vice versa)
+	_, exists, err = key.stringValue("NoModify")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	number, exists, err := key.dwordValue("NoModify")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(1), number)
+
+	_, exists, err = key.dwordValue("DisplayName")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_hive_corrupted(t *testing.T) {
+	fixture := readFixtureHive(t)
+
+	// corrupted hives must result in an error (not a panic), where every word of the hive bins is corrupted in turn
+	for offset := hiveBinsOffset; offset < len(fixture); offset += 4 {
+		for _, corruption := range [][]byte{{0xff, 0xff, 0xff, 0xff}, {0x00, 0x00, 0x00, 0x00}, {0x00, 0x00, 0x00, 0x80}} {
+			contents := append([]byte(nil), fixture...)
+			copy(contents[offset:], corruption)
+			_, _, _ = parseSoftwareHive("SOFTWARE", bytes.NewReader(contents))
+		}
+	}
+
+	// as well as truncated hives
+	for size := 0; size < len(fixture); size += 8 {
+		_, _, _ = parseSoftwareHive("SOFTWARE", bytes.NewReader(fixture[:size]))
+	}
+}
+
+func Test_decodeName(t *testing.T) {
+	assert.Equal(t, "Übersicht", decodeName([]byte("\xdcbersicht"), true))
+	assert.Equal(t, "Übersicht", decodeName([]byte{0xdc, 0, 'b', 0, 'e', 0, 'r', 0, 's', 0, 'i', 0, 'c', 0, 'h', 0, 't', 0}, false))
+}
@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+)
+
+// CondaMetadata represents all captured data for a conda package from a conda-meta/*.json file within a conda
+// environment prefix.
+type CondaMetadata struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Build       string   `json:"build"`
+	BuildNumber int      `json:"buildNumber"`
+	Channel     string   `json:"channel"`
+	Subdir      string   `json:"subdir,omitempty"`
+	License     string   `json:"license,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	MD5         string   `json:"md5,omitempty"`
+	SHA256      string   `json:"sha256,omitempty"`
+	Depends     []string `json:"depends,omitempty"`
+}
+
+// PackageURL returns the PURL for the specific conda package (see https://github.com/package-url/purl-spec)
+func (m CondaMetadata) PackageURL() string {
+	var qualifiers packageurl.Qualifiers
+	for _, q := range []packageurl.Qualifier{
+		{Key: "build", Value: m.Build},
+		{Key: "channel", Value: m.Channel},
+		{Key: "subdir", Value: m.Subdir},
+	} {
+		if q.Value != "" {
+			qualifiers = append(qualifiers, q)
+		}
+	}
+
+	pURL := packageurl.NewPackageURL(
+		// TODO: replace with `packageurl.TypeConda` upon upgrade of the packageurl-go module
+		"conda",
+		"",
+		m.Name,
+		m.Version,
+		qualifiers,
+		"")
+	return pURL.ToString()
+}
@@ -3,6 +3,7 @@ package pkg
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/facebookincubator/nvdtools/wfn"
 )
@@ -44,9 +45,36 @@ func MustCPE(cpeStr string) CPE {
 }
 
 func normalizeCpeField(field string) string {
-	// keep dashes and forward slashes unescaped
+	// keep dashes, forward slashes, and all other punctuation unescaped
 	if field == "*" {
 		return wfn.Any
 	}
-	return strings.ReplaceAll(wfn.StripSlashes(field), `\/`, "/")
+	return unescapePunctuation(wfn.StripSlashes(field))
+}
+
+// unescapePunctuation removes the escaping from all quoted punctuation (e.g. "\@" or "\/") within the given WFN value,
+// since CPEs are constructed elsewhere with raw (unescaped) values. Escaped backslashes and wildcards are left as-is
+// since they are meaningful when binding the value.
+func unescapePunctuation(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i < len(value)-1 {
+			next := rune(value[i+1])
+			switch {
+			case next == '\\' || next == '*' || next == '?':
+				// keep the escaped character as-is (including the escape)
+				sb.WriteByte(value[i])
+				i++
+			case unicode.IsPunct(next) || unicode.IsSymbol(next):
+				// drop the escape
+				continue
+			}
+		}
+		sb.WriteByte(value[i])
+	}
+	return sb.String()
 }
@@ -29,6 +29,11 @@ func TestNewCPE(t *testing.T) {
 			input:    `cpe:/a:7-zip:7-zip:4.56:beta:~~~windows~~`,
 			expected: must(NewCPE(`cpe:2.3:a:7-zip:7-zip:4.56:beta:*:*:*:windows:*:*`)),
 		},
+		{
+			name:     "escaped punctuation",
+			input:    `cpe:2.3:a:\@babel\/code-frame:\@babel\/code-frame:7.14.5:*:*:*:*:*:*:*`,
+			expected: must(NewCPE(`cpe:2.3:a:@babel/code-frame:@babel/code-frame:7.14.5:*:*:*:*:*:*:*`)),
+		},
 		{
 			name:     "URL escape characters",
 			input:    `cpe:/a:%240.99_kindle_books_project:%240.99_kindle_books:6::~~~android~~`,
@@ -65,6 +70,14 @@ func Test_normalizeCpeField(t *testing.T) {
 			field:    "some\\thing",
 			expected: `some\thing`,
 		},
+		{
+			field:    `\@babel\/code-frame`,
+			expected: "@babel/code-frame",
+		},
+		{
+			field:    `some\\\@thing\*`,
+			expected: `some\\@thing\*`,
+		},
 		{
 			field:    "*",
 			expected: "",
@@ -80,3 +93,16 @@ func Test_normalizeCpeField(t *testing.T) {
 		})
 	}
 }
+
+func TestCPE_BindToFmtStringRoundTrip(t *testing.T) {
+	tests := []string{
+		"cpe:2.3:a:@babel/code-frame:@babel/code-frame:7.14.5:*:*:*:*:*:*:*",
+		"cpe:2.3:a:7-zip:7-zip:4.56:beta:*:*:*:windows:*:*",
+		"cpe:2.3:a:python-requests:requests:2.26.0:*:*:*:*:python:*:*",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			assert.Equal(t, test, must(NewCPE(test)).BindToFmtString())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+)
+
+// DefaultDartPubHostedURL is the URL of the official pub.dev repository (older lock files refer to the repository by
+// its previous URL, https://pub.dartlang.org).
+const DefaultDartPubHostedURL = "https://pub.dev"
+
+// DartPubMetadata represents all captured data for a Dart (or Flutter) package resolved within a pubspec.lock file.
+type DartPubMetadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Source      string `json:"source"`
+	Dependency  string `json:"dependency"`
+	HostedURL   string `json:"hostedUrl,omitempty"`
+	VcsURL      string `json:"vcsUrl,omitempty"`
+	ResolvedRef string `json:"resolvedRef,omitempty"`
+	Path        string `json:"path,omitempty"`
+}
+
+// IsDirect indicates if the package is a direct dependency of the Dart project (e.g. a "direct main" or "direct dev"
+// dependency), as opposed to a transitive dependency.
+func (m DartPubMetadata) IsDirect() bool {
+	return strings.HasPrefix(m.Dependency, "direct ")
+}
+
+// IsDevDependency indicates if the package is declared as a direct dev_dependency of the Dart project (note: transitive
+// dependencies of dev_dependencies are not distinguished within pubspec.lock files).
+func (m DartPubMetadata) IsDevDependency() bool {
+	return m.Dependency == "direct dev"
+}
+
+// PackageURL returns the PURL for the specific Dart package (see https://github.com/package-url/purl-spec). Packages
+// from another pub repository are qualified by the repository URL, and git sourced packages by the repository and ref.
+func (m DartPubMetadata) PackageURL() string {
+	var qualifiers packageurl.Qualifiers
+	if m.HostedURL != "" && !isDefaultDartPubHostedURL(m.HostedURL) {
+		qualifiers = append(qualifiers, packageurl.Qualifier{
+			Key:   "repository_url",
+			Value: m.HostedURL,
+		})
+	}
+	if m.VcsURL != "" {
+		vcsURL := "git+" + m.VcsURL
+		if m.ResolvedRef != "" {
+			vcsURL += "@" + m.ResolvedRef
+		}
+		qualifiers = append(qualifiers, packageurl.Qualifier{
+			Key:   "vcs_url",
+			Value: vcsURL,
+		})
+	}
+
+	return packageurl.NewPackageURL(
+		"pub",
+		"",
+		m.Name,
+		m.Version,
+		qualifiers,
+		"",
+	).ToString()
+}
+
+func isDefaultDartPubHostedURL(url string) bool {
+	url = strings.TrimSuffix(url, "/")
+	return url == DefaultDartPubHostedURL || url == "https://pub.dartlang.org"
+}
@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDartPubMetadata_PackageURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata DartPubMetadata
+		expected string
+	}{
+		{
+			name: "hosted package",
+			metadata: DartPubMetadata{
+				Name:       "http",
+				Version:    "0.13.4",
+				Source:     "hosted",
+				Dependency: "direct main",
+				HostedURL:  "https://pub.dartlang.org",
+			},
+			expected: "pkg:pub/http@0.13.4",
+		},
+		{
+			name: "package hosted by another repository",
+			metadata: DartPubMetadata{
+				Name:       "internal_utils",
+				Version:    "1.2.0",
+				Source:     "hosted",
+				Dependency: "transitive",
+				HostedURL:  "https://dart.example.com",
+			},
+			expected: "pkg:pub/internal_utils@1.2.0?repository_url=https:%2F%2Fdart.example.com",
+		},
+		{
+			name: "git package",
+			metadata: DartPubMetadata{
+				Name:        "flutter_markdown",
+				Version:     "0.6.9",
+				Source:      "git",
+				Dependency:  "direct main",
+				VcsURL:      "https://github.com/flutter/packages.git",
+				ResolvedRef: "4a8ee1b0e8e4ba9d4e5ac3c4c2cd6ee0c4b2c0c3",
+			},
+			expected: "pkg:pub/flutter_markdown@0.6.9?vcs_url=git+https:%2F%2Fgithub.com%2Fflutter%2Fpackages.git@4a8ee1b0e8e4ba9d4e5ac3c4c2cd6ee0c4b2c0c3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metadata.PackageURL())
+		})
+	}
+}
+
+func TestDartPubMetadata_dependency(t *testing.T) {
+	tests := []struct {
+		dependency string
+		direct     bool
+		dev        bool
+	}{
+		{dependency: "direct main", direct: true},
+		{dependency: "direct dev", direct: true, dev: true},
+		{dependency: "direct overridden", direct: true},
+		{dependency: "transitive"},
+	}
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			m := DartPubMetadata{Dependency: test.dependency}
+			assert.Equal(t, test.direct, m.IsDirect())
+			assert.Equal(t, test.dev, m.IsDevDependency())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package pkg
+
+// DevDependencyIndicator is the interface that wraps IsDevDependency method.
+//
+// IsDevDependency indicates if a piece of package Metadata describes a package
+// that the lockfile it was found in declares as only needed for development
+// (e.g. for testing or building).
+type DevDependencyIndicator interface {
+	IsDevDependency() bool
+}
+
+// IsDevDependency indicates if the given package is declared as a development-only dependency (which is only known
+// for packages found in lockfiles that make this distinction).
+func IsDevDependency(p Package) bool {
+	if indicator, ok := p.Metadata.(DevDependencyIndicator); ok {
+		return indicator.IsDevDependency()
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+)
+
+// DotnetDepsMetadata represents all captured data for a .NET package library from a *.deps.json file.
+type DotnetDepsMetadata struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Path     string `json:"path"`
+	Sha512   string `json:"sha512"`
+	HashPath string `json:"hashPath"`
+}
+
+// PackageURL returns the PURL for the specific .NET package (see https://github.com/package-url/purl-spec)
+func (m DotnetDepsMetadata) PackageURL() string {
+	return packageurl.NewPackageURL(
+		packageurl.TypeNuget,
+		"",
+		m.Name,
+		m.Version,
+		nil,
+		"",
+	).ToString()
+}
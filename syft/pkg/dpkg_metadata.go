@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/anchore/syft/syft/file"
 
@@ -39,23 +40,47 @@ func (m DpkgMetadata) PackageURL(d *distro.Distro) string {
 	if d == nil {
 		return ""
 	}
+
+	// for purl the epoch is a qualifier, not part of the version (as with RPM packages)
+	epoch, version := m.epochAndVersion()
+
 	pURL := packageurl.NewPackageURL(
 		// TODO: replace with `packageurl.TypeDebian` upon merge of https://github.com/package-url/packageurl-go/pull/21
 		// TODO: or, since we're now using an Anchore fork of this module, we could do this sooner.
 		"deb",
 		d.Type.String(),
 		m.Package,
-		m.Version,
-		packageurl.Qualifiers{
-			{
-				Key:   "arch",
-				Value: m.Architecture,
-			},
-		},
+		version,
+		purlQualifiers(d, map[string]string{
+			purlArchQualifier:     m.Architecture,
+			purlEpochQualifier:    epoch,
+			purlUpstreamQualifier: m.upstream(),
+		}),
 		"")
 	return pURL.ToString()
 }
 
+// epochAndVersion splits the epoch from the package version (e.g. "1:2.30-1" has an epoch of "1"), where the epoch is
+// empty if not given.
+func (m DpkgMetadata) epochAndVersion() (string, string) {
+	if fields := strings.SplitN(m.Version, ":", 2); len(fields) == 2 {
+		return fields[0], fields[1]
+	}
+	return "", m.Version
+}
+
+// upstream returns the source package (with the source version, when it differs from the binary package version)
+// that this binary package was built from, or an empty string if the binary package is its own source.
+func (m DpkgMetadata) upstream() string {
+	if m.SourceVersion != "" {
+		return m.Source + "@" + m.SourceVersion
+	}
+	if m.Source != "" && m.Source != m.Package {
+		return m.Source
+	}
+	return ""
+}
+
 func (m DpkgMetadata) OwnedFiles() (result []string) {
 	s := strset.New()
 	for _, f := range m.Files {
@@ -26,7 +26,7 @@ func TestDpkgMetadata_pURL(t *testing.T) {
 				Version:      "v",
 				Architecture: "a",
 			},
-			expected: "pkg:deb/debian/p@v?arch=a",
+			expected: "pkg:deb/debian/p@v?arch=a&upstream=s",
 		},
 		{
 			distro: distro.Distro{
@@ -38,7 +38,58 @@ func TestDpkgMetadata_pURL(t *testing.T) {
 				Version:      "v",
 				Architecture: "a",
 			},
-			expected: "pkg:deb/ubuntu/p@v?arch=a",
+			expected: "pkg:deb/ubuntu/p@v?arch=a&upstream=s",
+		},
+		{
+			distro: distro.Distro{
+				Type: distro.Debian,
+			},
+			metadata: DpkgMetadata{
+				Package:       "p",
+				Source:        "s",
+				Version:       "v",
+				SourceVersion: "sv",
+				Architecture:  "a",
+			},
+			expected: "pkg:deb/debian/p@v?arch=a&upstream=s@sv",
+		},
+		{
+			distro: distro.Distro{
+				Type: distro.Debian,
+			},
+			metadata: DpkgMetadata{
+				Package:      "p",
+				Source:       "p",
+				Version:      "v",
+				Architecture: "a",
+			},
+			expected: "pkg:deb/debian/p@v?arch=a",
+		},
+		{
+			distro: distro.Distro{
+				Type:       distro.Debian,
+				RawVersion: "11",
+			},
+			metadata: DpkgMetadata{
+				Package:      "libgcrypt20",
+				Source:       "libgcrypt20",
+				Version:      "1:1.8.7-6",
+				Architecture: "amd64",
+			},
+			expected: "pkg:deb/debian/libgcrypt20@1.8.7-6?arch=amd64&distro=debian-11&epoch=1",
+		},
+		{
+			distro: distro.Distro{
+				Type:       distro.Ubuntu,
+				RawVersion: "20.04",
+			},
+			metadata: DpkgMetadata{
+				Package:      "p",
+				Source:       "s",
+				Version:      "v",
+				Architecture: "a",
+			},
+			expected: "pkg:deb/ubuntu/p@v?arch=a&distro=ubuntu-20.04&upstream=s",
 		},
 	}
 
@@ -1,5 +1,7 @@
 package pkg
 
+import "github.com/anchore/syft/syft/file"
+
 // FileOwner is the interface that wraps OwnedFiles method.
 //
 // OwnedFiles returns a list of files that a piece of
@@ -7,3 +9,11 @@ package pkg
 type FileOwner interface {
 	OwnedFiles() []string
 }
+
+// FileDigestRecorder is the interface that wraps RecordedFileDigests method.
+//
+// RecordedFileDigests returns the digests (keyed by path) that a piece of
+// package Metadata has recorded for the files owned by the package.
+type FileDigestRecorder interface {
+	RecordedFileDigests() map[string][]file.Digest
+}
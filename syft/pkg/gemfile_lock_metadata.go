@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+)
+
+// GemfileLockMetadata represents all captured data for a Ruby gem resolved within a bundler Gemfile.lock file
+type GemfileLockMetadata struct {
+	Name           string   `json:"name"`
+	Version        string   `json:"version"`
+	Platform       string   `json:"platform,omitempty"`
+	Remote         string   `json:"remote,omitempty"`
+	Dependencies   []string `json:"dependencies,omitempty"`
+	Direct         bool     `json:"direct"`
+	BundlerVersion string   `json:"bundlerVersion,omitempty"`
+}
+
+// PackageURL returns the PURL for the specific Ruby gem (see https://github.com/package-url/purl-spec)
+func (m GemfileLockMetadata) PackageURL() string {
+	var qualifiers packageurl.Qualifiers
+	if m.Platform != "" {
+		qualifiers = packageurl.QualifiersFromMap(map[string]string{
+			"platform": m.Platform,
+		})
+	}
+
+	pURL := packageurl.NewPackageURL(
+		packageurl.TypeGem,
+		"",
+		m.Name,
+		m.Version,
+		qualifiers,
+		"")
+	return pURL.ToString()
+}
@@ -2,7 +2,9 @@ package pkg
 
 // GolangBinMetadata represents all captured data for a Golang Binary
 type GolangBinMetadata struct {
-	GoCompiledVersion string `json:"goCompiledVersion"`
-	Architecture      string `json:"architecture"`
-	H1Digest          string `json:"h1Digest"`
+	BuildSettings     map[string]string `json:"goBuildSettings,omitempty"` // the build settings of the main module (e.g. "vcs.revision" or "-trimpath"), only captured for the main module package
+	GoCompiledVersion string            `json:"goCompiledVersion"`
+	Architecture      string            `json:"architecture"`
+	H1Digest          string            `json:"h1Digest,omitempty"`
+	MainModule        string            `json:"mainModule,omitempty"` // the path of the main module that the binary was built from
 }
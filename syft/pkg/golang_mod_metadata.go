@@ -0,0 +1,7 @@
+package pkg
+
+// GolangModMetadata represents all captured data for a Go module required within a go.mod file
+type GolangModMetadata struct {
+	Indirect bool   `json:"indirect"`
+	Replaces string `json:"replaces,omitempty"`
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+)
+
+// HaskellMetadata represents all captured data for a Haskell package pinned in a stack.yaml.lock or
+// cabal.project.freeze file.
+type HaskellMetadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	PkgHash     string `json:"pkgHash,omitempty"`
+	SnapshotURL string `json:"snapshotURL,omitempty"`
+	Git         string `json:"git,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+}
+
+// PackageURL returns the PURL for the specific Haskell package (see https://github.com/package-url/purl-spec). Git
+// sourced packages (which may not be published to hackage) are qualified by the repository and commit.
+func (m HaskellMetadata) PackageURL() string {
+	var qualifiers packageurl.Qualifiers
+	if m.Git != "" {
+		vcsURL := "git+" + m.Git
+		if m.Commit != "" {
+			vcsURL += "@" + m.Commit
+		}
+		qualifiers = append(qualifiers, packageurl.Qualifier{
+			Key:   "vcs_url",
+			Value: vcsURL,
+		})
+	}
+
+	version := m.Version
+	if version == "" {
+		// git sourced packages without a version are only identifiable by the commit
+		version = m.Commit
+	}
+
+	return packageurl.NewPackageURL(
+		"hackage",
+		"",
+		m.Name,
+		version,
+		qualifiers,
+		"",
+	).ToString()
+}
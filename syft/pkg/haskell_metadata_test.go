@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaskellMetadata_PackageURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata HaskellMetadata
+		expected string
+	}{
+		{
+			name: "hackage package",
+			metadata: HaskellMetadata{
+				Name:    "HTTP",
+				Version: "4000.3.16",
+				PkgHash: "6042643c15a0b43e522a6693f1e322f05000d519543a84149cb80aeffee34f71",
+			},
+			expected: "pkg:hackage/HTTP@4000.3.16",
+		},
+		{
+			name: "git package",
+			metadata: HaskellMetadata{
+				Name:    "servant-options",
+				Version: "0.1.0.0",
+				Git:     "https://github.com/sordina/servant-options.git",
+				Commit:  "1cc7a3d3a7a5f9e5ab4c0aa6b7b1de36e9a1a3e0",
+			},
+			expected: "pkg:hackage/servant-options@0.1.0.0?vcs_url=git+https:%2F%2Fgithub.com%2Fsordina%2Fservant-options.git@1cc7a3d3a7a5f9e5ab4c0aa6b7b1de36e9a1a3e0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metadata.PackageURL())
+		})
+	}
+}
@@ -13,6 +13,11 @@ const (
 	Ruby            Language = "ruby"
 	Go              Language = "go"
 	Rust            Language = "rust"
+	Dotnet          Language = "dotnet"
+	Swift           Language = "swift"
+	Haskell         Language = "haskell"
+	Elixir          Language = "elixir"
+	Dart            Language = "dart"
 )
 
 // AllLanguages is a set of all programming languages detected by syft.
@@ -24,9 +29,47 @@ var AllLanguages = []Language{
 	Ruby,
 	Go,
 	Rust,
+	Dotnet,
+	Swift,
+	Haskell,
+	Elixir,
+	Dart,
 }
 
 // String returns the string representation of the language.
 func (l Language) String() string {
 	return string(l)
 }
+
+// LanguageFromPURL returns the programming language of the package with the given PURL, which is empty for PURL types
+// that are not specific to a language (the same as for the OS packages that syft finds).
+func LanguageFromPURL(purl string) Language {
+	switch TypeFromPURL(purl) {
+	case GemPkg:
+		return Ruby
+	case PythonPkg:
+		return Python
+	case PhpComposerPkg:
+		return PHP
+	case NpmPkg:
+		return JavaScript
+	case JavaPkg:
+		return Java
+	case GoModulePkg:
+		return Go
+	case RustPkg:
+		return Rust
+	case DotnetPkg:
+		return Dotnet
+	case SwiftPkg:
+		return Swift
+	case HackagePkg:
+		return Haskell
+	case HexPkg:
+		return Elixir
+	case DartPubPkg:
+		return Dart
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import "github.com/anchore/syft/internal/spdxlicense"
+
+// License is a single license value declared by a package, which may be a compound license expression that
+// references several licenses (e.g. "MIT OR Apache-2.0").
+type License struct {
+	Value      string   // the license value as originally discovered
+	Expression string   // the normalized license expression (using SPDX license list identifiers where possible)
+	Licenses   []string // the individual licenses referenced within the expression
+	Compound   bool     // true if the expression combines licenses with AND, OR, or WITH operators
+}
+
+// ParseLicense parses the given license value into a license expression, normalizing each license to an SPDX license
+// list identifier where possible (otherwise the license name is kept as-is). Values are only parsed as compound
+// expressions when every license within them is a known SPDX license list identifier (or a LicenseRef), so free-form
+// names (e.g. "GPL or Artistic") are kept as a single license.
+func ParseLicense(value string) License {
+	expression, licenses, compound := spdxlicense.ParseExpression(value, func(name string) string {
+		if id, exists := spdxlicense.ID(name); exists {
+			return id
+		}
+		return name
+	})
+
+	return License{
+		Value:      value,
+		Expression: expression,
+		Licenses:   licenses,
+		Compound:   compound,
+	}
+}
+
+// LicenseExpressions returns the parsed representation of all discovered licenses (see Licenses), skipping empty values.
+func (p Package) LicenseExpressions() (result []License) {
+	for _, value := range p.Licenses {
+		l := ParseLicense(value)
+		if l.Expression == "" {
+			continue
+		}
+		result = append(result, l)
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLicense(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected License
+	}{
+		{
+			value: "mit",
+			expected: License{
+				Value:      "mit",
+				Expression: "MIT",
+				Licenses:   []string{"MIT"},
+			},
+		},
+		{
+			value: "GPL-2",
+			expected: License{
+				Value:      "GPL-2",
+				Expression: "GPL-2.0",
+				Licenses:   []string{"GPL-2.0"},
+			},
+		},
+		{
+			value: "The Apache Software License, Version 2.0",
+			expected: License{
+				Value:      "The Apache Software License, Version 2.0",
+				Expression: "The Apache Software License, Version 2.0",
+				Licenses:   []string{"The Apache Software License, Version 2.0"},
+			},
+		},
+		{
+			value: "(MIT OR apache-2.0)",
+			expected: License{
+				Value:      "(MIT OR apache-2.0)",
+				Expression: "(MIT OR Apache-2.0)",
+				Licenses:   []string{"MIT", "Apache-2.0"},
+				Compound:   true,
+			},
+		},
+		{
+			value: "GPL-2.0 WITH Classpath-exception-2.0",
+			expected: License{
+				Value:      "GPL-2.0 WITH Classpath-exception-2.0",
+				Expression: "GPL-2.0 WITH Classpath-exception-2.0",
+				Licenses:   []string{"GPL-2.0"},
+				Compound:   true,
+			},
+		},
+		{
+			value: "GPL or Artistic",
+			expected: License{
+				Value:      "GPL or Artistic",
+				Expression: "GPL or Artistic",
+				Licenses:   []string{"GPL or Artistic"},
+			},
+		},
+		{
+			value: "BSD (3 clause)",
+			expected: License{
+				Value:      "BSD (3 clause)",
+				Expression: "BSD (3 clause)",
+				Licenses:   []string{"BSD (3 clause)"},
+			},
+		},
+		{
+			value: "MIT OR my license",
+			expected: License{
+				Value:      "MIT OR my license",
+				Expression: "MIT OR my license",
+				Licenses:   []string{"MIT OR my license"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseLicense(test.value))
+		})
+	}
+}
+
+func TestPackage_LicenseExpressions(t *testing.T) {
+	p := Package{
+		Licenses: []string{"MIT", " ", "BSD-3-Clause OR GPL-2.0"},
+	}
+
+	var expressions []string
+	for _, l := range p.LicenseExpressions() {
+		expressions = append(expressions, l.Expression)
+	}
+
+	assert.Equal(t, []string{"MIT", "BSD-3-Clause OR GPL-2.0"}, expressions)
+}
@@ -0,0 +1,11 @@
+package pkg
+
+// LinuxKernelMetadata represents all captured data for a Linux kernel that was identified from its modules directory
+// (e.g. /lib/modules/5.10.0-8-amd64), which is named after the kernel release.
+type LinuxKernelMetadata struct {
+	// Release is the full kernel release as reported by `uname -r` (e.g. "5.10.0-8-amd64"), which includes any
+	// distribution-specific ABI and flavor suffixes
+	Release string `json:"release"`
+	// Version is the upstream kernel version within the release (e.g. "5.10.0")
+	Version string `json:"version"`
+}
@@ -5,28 +5,60 @@ type MetadataType string
 
 const (
 	// this is the full set of data shapes that can be represented within the pkg.Package.Metadata field
-	UnknownMetadataType          MetadataType = "UnknownMetadata"
-	ApkMetadataType              MetadataType = "ApkMetadata"
-	DpkgMetadataType             MetadataType = "DpkgMetadata"
-	GemMetadataType              MetadataType = "GemMetadata"
-	JavaMetadataType             MetadataType = "JavaMetadata"
-	NpmPackageJSONMetadataType   MetadataType = "NpmPackageJsonMetadata"
-	RpmdbMetadataType            MetadataType = "RpmdbMetadata"
-	PythonPackageMetadataType    MetadataType = "PythonPackageMetadata"
-	RustCargoPackageMetadataType MetadataType = "RustCargoPackageMetadata"
-	KbPackageMetadataType        MetadataType = "KbPackageMetadata"
-	GolangBinMetadataType        MetadataType = "GolangBinMetadata"
+	UnknownMetadataType              MetadataType = "UnknownMetadata"
+	ApkMetadataType                  MetadataType = "ApkMetadata"
+	DpkgMetadataType                 MetadataType = "DpkgMetadata"
+	GemMetadataType                  MetadataType = "GemMetadata"
+	GemfileLockMetadataType          MetadataType = "GemfileLockMetadata"
+	JavaMetadataType                 MetadataType = "JavaMetadata"
+	NpmPackageJSONMetadataType       MetadataType = "NpmPackageJsonMetadata"
+	NpmPackageLockJSONMetadataType   MetadataType = "NpmPackageLockJsonMetadata"
+	RpmdbMetadataType                MetadataType = "RpmdbMetadata"
+	PythonPackageMetadataType        MetadataType = "PythonPackageMetadata"
+	PythonPoetryLockMetadataType     MetadataType = "PythonPoetryLockMetadata"
+	PythonRequirementsMetadataType   MetadataType = "PythonRequirementsMetadata"
+	RustCargoPackageMetadataType     MetadataType = "RustCargoPackageMetadata"
+	KbPackageMetadataType            MetadataType = "KbPackageMetadata"
+	GolangBinMetadataType            MetadataType = "GolangBinMetadata"
+	GolangModMetadataType            MetadataType = "GolangModMetadata"
+	PhpComposerMetadataType          MetadataType = "PhpComposerMetadata"
+	CondaMetadataType                MetadataType = "CondaMetadata"
+	DotnetDepsMetadataType           MetadataType = "DotnetDepsMetadata"
+	SwiftPackageResolvedMetadataType MetadataType = "SwiftPackageResolvedMetadata"
+	BinaryMetadataType               MetadataType = "BinaryMetadata"
+	HaskellMetadataType              MetadataType = "HaskellMetadata"
+	MixLockMetadataType              MetadataType = "MixLockMetadata"
+	DartPubMetadataType              MetadataType = "DartPubMetadata"
+	WindowsRegistryMetadataType      MetadataType = "WindowsRegistryMetadata"
+	LinuxKernelMetadataType          MetadataType = "LinuxKernelMetadata"
+	ApkbuildMetadataType             MetadataType = "ApkbuildMetadata"
 )
 
 var AllMetadataTypes = []MetadataType{
 	ApkMetadataType,
 	DpkgMetadataType,
 	GemMetadataType,
+	GemfileLockMetadataType,
 	JavaMetadataType,
 	NpmPackageJSONMetadataType,
+	NpmPackageLockJSONMetadataType,
 	RpmdbMetadataType,
 	PythonPackageMetadataType,
+	PythonPoetryLockMetadataType,
+	PythonRequirementsMetadataType,
 	RustCargoPackageMetadataType,
 	KbPackageMetadataType,
 	GolangBinMetadataType,
+	GolangModMetadataType,
+	PhpComposerMetadataType,
+	CondaMetadataType,
+	DotnetDepsMetadataType,
+	SwiftPackageResolvedMetadataType,
+	BinaryMetadataType,
+	HaskellMetadataType,
+	MixLockMetadataType,
+	DartPubMetadataType,
+	WindowsRegistryMetadataType,
+	LinuxKernelMetadataType,
+	ApkbuildMetadataType,
 }
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+)
+
+// MixLockMetadata represents all captured data for an Elixir (or Erlang) dependency locked in a mix.lock file.
+type MixLockMetadata struct {
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+	PkgHash    string `json:"pkgHash,omitempty"`
+	PkgHashExt string `json:"pkgHashExt,omitempty"`
+	Repo       string `json:"repo,omitempty"`
+	Git        string `json:"git,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+}
+
+// PackageURL returns the PURL for the specific Hex package (see https://github.com/package-url/purl-spec). Git sourced
+// dependencies (which may not be published to hex) are qualified by the repository and ref.
+func (m MixLockMetadata) PackageURL() string {
+	var qualifiers packageurl.Qualifiers
+	if m.Git != "" {
+		vcsURL := "git+" + m.Git
+		if m.Ref != "" {
+			vcsURL += "@" + m.Ref
+		}
+		qualifiers = append(qualifiers, packageurl.Qualifier{
+			Key:   "vcs_url",
+			Value: vcsURL,
+		})
+	}
+
+	version := m.Version
+	if version == "" {
+		// git sourced dependencies are only identifiable by the locked ref
+		version = m.Ref
+	}
+
+	return packageurl.NewPackageURL(
+		"hex",
+		"",
+		m.Name,
+		version,
+		qualifiers,
+		"",
+	).ToString()
+}
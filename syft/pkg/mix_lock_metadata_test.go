@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMixLockMetadata_PackageURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata MixLockMetadata
+		expected string
+	}{
+		{
+			name: "hex package",
+			metadata: MixLockMetadata{
+				Name:       "plug",
+				Version:    "1.12.1",
+				PkgHash:    "645678c800601d8d9f27ad1aebba1fdb9ce5b2623ddb961a074da0b96c35187d",
+				PkgHashExt: "d7453c6b1ac1e6a1d6c5a4a2e3a6a9d6a2d3f4c5b6a7e8f9a0b1c2d3e4f5a6b7",
+				Repo:       "hexpm",
+			},
+			expected: "pkg:hex/plug@1.12.1",
+		},
+		{
+			name: "git package",
+			metadata: MixLockMetadata{
+				Name: "ecto_network",
+				Git:  "https://github.com/vic/ecto_network.git",
+				Ref:  "7bd4f5ea7b2d5b2a0c1ae4e6c4d5c59d3b0d4f0a",
+			},
+			expected: "pkg:hex/ecto_network@7bd4f5ea7b2d5b2a0c1ae4e6c4d5c59d3b0d4f0a?vcs_url=git+https:%2F%2Fgithub.com%2Fvic%2Fecto_network.git@7bd4f5ea7b2d5b2a0c1ae4e6c4d5c59d3b0d4f0a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metadata.PackageURL())
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package pkg
+
+var _ DevDependencyIndicator = (*NpmPackageLockJSONMetadata)(nil)
+
+// NpmPackageLockJSONMetadata represents all captured data for a npm package from a package-lock.json file
+type NpmPackageLockJSONMetadata struct {
+	Resolved  string `json:"resolved,omitempty"`
+	Integrity string `json:"integrity,omitempty"`
+	Dev       bool   `json:"dev"`
+}
+
+// IsDevDependency indicates if the package is only needed for development (listed within devDependencies).
+func (m NpmPackageLockJSONMetadata) IsDevDependency() bool {
+	return m.Dev
+}
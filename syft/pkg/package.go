@@ -14,17 +14,19 @@ import (
 // Package represents an application or library that has been bundled into a distributable format.
 // TODO: if we ignore FoundBy for ID generation should we merge the field to show it was found in two places?
 type Package struct {
-	Name         string            // the package name
-	Version      string            // the version of the package
-	FoundBy      string            // the specific cataloger that discovered this package
-	Locations    []source.Location // the locations that lead to the discovery of this package (note: this is not necessarily the locations that make up this package)
-	Licenses     []string          // licenses discovered with the package metadata
-	Language     Language          // the language ecosystem this package belongs to (e.g. JavaScript, Python, etc)
-	Type         Type              // the package type (e.g. Npm, Yarn, Python, Rpm, Deb, etc)
-	CPEs         []CPE             // all possible Common Platform Enumerators
-	PURL         string            // the Package URL (see https://github.com/package-url/purl-spec)
-	MetadataType MetadataType      // the shape of the additional data in the "metadata" field
-	Metadata     interface{}       // additional data found while parsing the package source
+	Name              string            // the package name
+	Version           string            // the version of the package
+	NormalizedVersion string            `hash:"ignore"` // the version in a form comparable across ecosystems (e.g. without a deb epoch or a "v" prefix), only derived from the version when configured
+	FoundBy           string            // the specific cataloger that discovered this package
+	Locations         []source.Location // the locations that lead to the discovery of this package (note: this is not necessarily the locations that make up this package)
+	Licenses          []string          // licenses discovered with the package metadata (or concluded from the package files, see LicensesConcluded)
+	LicensesConcluded bool              // indicates the licenses were concluded from the files owned by the package (e.g. a LICENSE file) instead of declared within the package metadata
+	Language          Language          // the language ecosystem this package belongs to (e.g. JavaScript, Python, etc)
+	Type              Type              // the package type (e.g. Npm, Yarn, Python, Rpm, Deb, etc)
+	CPEs              []CPE             // all possible Common Platform Enumerators
+	PURL              string            // the Package URL (see https://github.com/package-url/purl-spec)
+	MetadataType      MetadataType      // the shape of the additional data in the "metadata" field
+	Metadata          interface{}       // additional data found while parsing the package source
 }
 
 func (p Package) ID() artifact.ID {
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/distro"
+)
+
+const (
+	purlArchQualifier     = "arch"
+	purlDistroQualifier   = "distro"
+	purlEpochQualifier    = "epoch"
+	purlUpstreamQualifier = "upstream"
+)
+
+// purlQualifiers returns the given qualifiers (as key-value pairs) in the canonical (sorted by key) order, dropping any
+// qualifiers without a value. The distro qualifier (e.g. "debian-11") is added when the distro version is known.
+func purlQualifiers(d *distro.Distro, values map[string]string) packageurl.Qualifiers {
+	if d != nil && d.RawVersion != "" {
+		values[purlDistroQualifier] = d.Type.String() + "-" + d.RawVersion
+	}
+
+	var qualifiers packageurl.Qualifiers
+	for _, key := range []string{purlArchQualifier, purlDistroQualifier, purlEpochQualifier, purlUpstreamQualifier} {
+		if value := values[key]; value != "" {
+			qualifiers = append(qualifiers, packageurl.Qualifier{
+				Key:   key,
+				Value: value,
+			})
+		}
+	}
+	return qualifiers
+}
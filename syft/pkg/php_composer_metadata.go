@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+)
+
+var _ DevDependencyIndicator = (*PhpComposerMetadata)(nil)
+
+// PhpComposerMetadata represents all captured data for a PHP composer package from a composer.lock file
+type PhpComposerMetadata struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Licenses []string `json:"licenses,omitempty"`
+	Homepage string   `json:"homepage,omitempty"`
+	Dev      bool     `json:"dev"`
+}
+
+// PackageURL returns the PURL for the specific PHP composer package (see https://github.com/package-url/purl-spec)
+func (m PhpComposerMetadata) PackageURL() string {
+	var vendor string
+	name := m.Name
+	if fields := strings.SplitN(m.Name, "/", 2); len(fields) == 2 {
+		vendor, name = fields[0], fields[1]
+	}
+
+	pURL := packageurl.NewPackageURL(
+		packageurl.TypeComposer,
+		vendor,
+		name,
+		m.Version,
+		nil,
+		"")
+	return pURL.ToString()
+}
+
+// IsDevDependency indicates if the package is only needed for development (listed within "packages-dev").
+func (m PhpComposerMetadata) IsDevDependency() bool {
+	return m.Dev
+}
@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhpComposerMetadata_PackageURL(t *testing.T) {
+	tests := []struct {
+		metadata PhpComposerMetadata
+		expected string
+	}{
+		{
+			metadata: PhpComposerMetadata{
+				Name:    "adoy/fastcgi-client",
+				Version: "1.0.2",
+			},
+			expected: "pkg:composer/adoy/fastcgi-client@1.0.2",
+		},
+		{
+			metadata: PhpComposerMetadata{
+				Name:    "no-vendor",
+				Version: "v4.6.2",
+			},
+			expected: "pkg:composer/no-vendor@v4.6.2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metadata.PackageURL())
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package pkg
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	"github.com/anchore/syft/syft/file"
 	"github.com/scylladb/go-set/strset"
 )
 
-var _ FileOwner = (*PythonPackageMetadata)(nil)
+var (
+	_ FileOwner          = (*PythonPackageMetadata)(nil)
+	_ FileDigestRecorder = (*PythonPackageMetadata)(nil)
+)
 
 // PythonFileDigest represents the file metadata for a single file attributed to a python package.
 type PythonFileDigest struct {
@@ -29,6 +37,7 @@ type PythonPackageMetadata struct {
 	Author               string             `json:"author" mapstruct:"Author"`
 	AuthorEmail          string             `json:"authorEmail" mapstruct:"Authoremail"`
 	Platform             string             `json:"platform" mapstruct:"Platform"`
+	Homepage             string             `json:"homepage,omitempty" mapstruct:"Homepage"`
 	Files                []PythonFileRecord `json:"files,omitempty"`
 	SitePackagesRootPath string             `json:"sitePackagesRootPath"`
 	TopLevelPackages     []string           `json:"topLevelPackages,omitempty"`
@@ -38,10 +47,41 @@ func (m PythonPackageMetadata) OwnedFiles() (result []string) {
 	s := strset.New()
 	for _, f := range m.Files {
 		if f.Path != "" {
-			s.Add(f.Path)
+			s.Add(m.resolvePath(f.Path))
 		}
 	}
 	result = s.List()
 	sort.Strings(result)
 	return result
 }
+
+// RecordedFileDigests returns the digests from the RECORD file keyed by the resolved path of each file. RECORD digests
+// are URL-safe base64 encoded (see https://www.python.org/dev/peps/pep-0376/#record), which are converted to the
+// hex encoding used for all other file digests.
+func (m PythonPackageMetadata) RecordedFileDigests() map[string][]file.Digest {
+	results := make(map[string][]file.Digest)
+	for _, f := range m.Files {
+		if f.Path == "" || f.Digest == nil {
+			continue
+		}
+		value, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(f.Digest.Value, "="))
+		if err != nil {
+			continue
+		}
+		path := m.resolvePath(f.Path)
+		results[path] = append(results[path], file.Digest{
+			Algorithm: file.CleanDigestAlgorithmName(f.Digest.Algorithm),
+			Value:     hex.EncodeToString(value),
+		})
+	}
+	return results
+}
+
+// resolvePath returns the path for the given RECORD entry, where relative paths are relative to the site-packages
+// root (the parent directory of the dist-info or egg-info directory).
+func (m PythonPackageMetadata) resolvePath(path string) string {
+	if filepath.IsAbs(path) || m.SitePackagesRootPath == "" {
+		return path
+	}
+	return filepath.Join(m.SitePackagesRootPath, path)
+}
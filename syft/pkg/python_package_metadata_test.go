@@ -35,6 +35,21 @@ func TestPythonMetadata_FileOwner(t *testing.T) {
 				"/somewhere",
 			},
 		},
+		{
+			metadata: PythonPackageMetadata{
+				SitePackagesRootPath: "/usr/lib/python3/site-packages",
+				Files: []PythonFileRecord{
+					{Path: "../../../bin/pygmentize"},
+					{Path: "pygments/util.py"},
+					{Path: "/etc/absolute"},
+				},
+			},
+			expected: []string{
+				"/etc/absolute",
+				"/usr/bin/pygmentize",
+				"/usr/lib/python3/site-packages/pygments/util.py",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,15 @@
+package pkg
+
+var _ DevDependencyIndicator = (*PythonPoetryLockMetadata)(nil)
+
+// PythonPoetryLockMetadata represents all captured data for a python package from a poetry.lock file
+type PythonPoetryLockMetadata struct {
+	Category string `json:"category,omitempty"`
+	Optional bool   `json:"optional"`
+	Dev      bool   `json:"dev"`
+}
+
+// IsDevDependency indicates if the package is only needed for development (within the "dev" category).
+func (m PythonPoetryLockMetadata) IsDevDependency() bool {
+	return m.Dev
+}
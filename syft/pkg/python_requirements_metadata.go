@@ -0,0 +1,15 @@
+package pkg
+
+// PythonRequirementsMetadata represents all captured data for a python package declared within a requirements.txt
+// file (see https://pip.pypa.io/en/stable/reference/requirements-file-format/).
+type PythonRequirementsMetadata struct {
+	// Extras are the optional features requested for the package (e.g. "security" for "requests[security]")
+	Extras []string `json:"extras,omitempty"`
+	// VersionConstraint is the version specifier as declared (e.g. "==2.26.0" or ">=1.0,<2"), where the package
+	// version is only known when the constraint pins an exact version
+	VersionConstraint string `json:"versionConstraint"`
+	// Markers are the environment markers that the requirement applies to (e.g. `python_version < "3.8"`)
+	Markers string `json:"markers,omitempty"`
+	// Hashes are the expected digests of the package archive (from --hash options), in the form "<algorithm>:<value>"
+	Hashes []string `json:"hashes,omitempty"`
+}
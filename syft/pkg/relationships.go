@@ -4,5 +4,5 @@ import "github.com/anchore/syft/syft/artifact"
 
 // TODO: as more relationships are added, this function signature will probably accommodate selection
 func NewRelationships(catalog *Catalog) []artifact.Relationship {
-	return RelationshipsByFileOwnership(catalog)
+	return append(RelationshipsByFileOwnership(catalog), RelationshipsBySharedFileOwnership(catalog)...)
 }
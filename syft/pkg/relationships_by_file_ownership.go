@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"sort"
+
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/bmatcuk/doublestar/v2"
@@ -22,19 +24,36 @@ type ownershipByFilesMetadata struct {
 }
 
 // RelationshipsByFileOwnership creates a package-to-package relationship based on discovering which packages have
-// evidence locations that overlap with ownership claim from another package's package manager metadata.
+// evidence locations that overlap with ownership claim from another package's package manager metadata (e.g. an RPM
+// that installed a JAR owns all java packages found within that JAR). The relationships are sorted by parent and child
+// package ID, so the results are stable between runs.
 func RelationshipsByFileOwnership(catalog *Catalog) []artifact.Relationship {
 	var relationships = findOwnershipByFilesRelationships(catalog)
 
+	var parents []artifact.ID
+	for parent := range relationships {
+		parents = append(parents, parent)
+	}
+	sortIDs(parents)
+
 	var edges []artifact.Relationship
-	for parent, children := range relationships {
-		for child, files := range children {
+	for _, parent := range parents {
+		var children []artifact.ID
+		for child := range relationships[parent] {
+			children = append(children, child)
+		}
+		sortIDs(children)
+
+		for _, child := range children {
+			files := relationships[parent][child].List()
+			sort.Strings(files)
+
 			edges = append(edges, artifact.Relationship{
 				From: catalog.byID[parent],
 				To:   catalog.byID[child],
 				Type: artifact.OwnershipByFileOverlapRelationship,
 				Data: ownershipByFilesMetadata{
-					Files: files.List(),
+					Files: files,
 				},
 			})
 		}
@@ -91,6 +110,12 @@ func findOwnershipByFilesRelationships(catalog *Catalog) map[artifact.ID]map[art
 	return relationships
 }
 
+func sortIDs(ids []artifact.ID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+}
+
 func matchesAny(s string, globs []string) bool {
 	for _, g := range globs {
 		matches, err := doublestar.Match(g, s)
@@ -144,3 +144,96 @@ func TestOwnershipByFilesRelationship(t *testing.T) {
 		})
 	}
 }
+
+func TestOwnershipByFilesRelationship_javaArchives(t *testing.T) {
+	jarPath := "/usr/share/java/log4j/log4j-core.jar"
+
+	rpm := Package{
+		Name:         "log4j",
+		Version:      "2.17.0-1.fc35",
+		Locations:    []source.Location{source.NewLocation("/var/lib/rpm/Packages")},
+		Type:         RpmPkg,
+		MetadataType: RpmdbMetadataType,
+		Metadata: RpmdbMetadata{
+			Name: "log4j",
+			Files: []RpmdbFileRecord{
+				{Path: "/usr/share/java/log4j"},
+				{Path: jarPath},
+				{Path: "/usr/share/java/log4j/log4j-api.jar"},
+			},
+		},
+	}
+
+	deb := Package{
+		Name:         "liblog4j2-java",
+		Version:      "2.17.0-1",
+		Locations:    []source.Location{source.NewLocation("/var/lib/dpkg/status")},
+		Type:         DebPkg,
+		MetadataType: DpkgMetadataType,
+		Metadata: DpkgMetadata{
+			Package: "liblog4j2-java",
+			Files: []DpkgFileRecord{
+				{Path: jarPath},
+			},
+		},
+	}
+
+	// the archive itself and a java package nested within the archive are both found by the path to the JAR
+	archive := Package{
+		Name:         "log4j-core",
+		Version:      "2.17.0",
+		Locations:    []source.Location{source.NewLocation(jarPath)},
+		Type:         JavaPkg,
+		MetadataType: JavaMetadataType,
+		Metadata: JavaMetadata{
+			VirtualPath: jarPath,
+		},
+	}
+
+	nested := Package{
+		Name:         "disruptor",
+		Version:      "3.4.4",
+		Locations:    []source.Location{source.NewLocation(jarPath)},
+		Type:         JavaPkg,
+		MetadataType: JavaMetadataType,
+		Metadata: JavaMetadata{
+			VirtualPath: jarPath + ":disruptor.jar",
+		},
+	}
+
+	// a JAR that is not installed by any OS package
+	unowned := Package{
+		Name:         "spring-core",
+		Version:      "5.3.14",
+		Locations:    []source.Location{source.NewLocation("/app/lib/spring-core.jar")},
+		Type:         JavaPkg,
+		MetadataType: JavaMetadataType,
+		Metadata: JavaMetadata{
+			VirtualPath: "/app/lib/spring-core.jar",
+		},
+	}
+
+	relationships := RelationshipsByFileOwnership(NewCatalog(rpm, deb, archive, nested, unowned))
+
+	type edge struct {
+		from, to artifact.ID
+	}
+	var actual []edge
+	for _, r := range relationships {
+		assert.Equal(t, artifact.OwnershipByFileOverlapRelationship, r.Type)
+		assert.Equal(t, ownershipByFilesMetadata{Files: []string{jarPath}}, r.Data)
+		actual = append(actual, edge{from: r.From.ID(), to: r.To.ID()})
+	}
+
+	assert.ElementsMatch(t, []edge{
+		{from: rpm.ID(), to: archive.ID()},
+		{from: rpm.ID(), to: nested.ID()},
+		{from: deb.ID(), to: archive.ID()},
+		{from: deb.ID(), to: nested.ID()},
+	}, actual)
+
+	// the results must be stable between runs
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, relationships, RelationshipsByFileOwnership(NewCatalog(rpm, deb, archive, nested, unowned)))
+	}
+}
@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+// RelationshipsByIntroducingLayer creates a package-to-layer relationship for every package in the catalog, pointing to
+// the image layer that introduced the package. For packages with metadata that claims ownership of files (e.g. dpkg,
+// rpm and apk packages) this is the first layer containing any owned file, since all such packages are recorded in a
+// shared database file that was created by the base layer. Otherwise (or when no owned file is within the image) this
+// is the first layer containing the locations the package was discovered by. Packages without any locations within an
+// image layer are skipped.
+func RelationshipsByIntroducingLayer(catalog *Catalog, src *source.Source) []artifact.Relationship {
+	var relationships []artifact.Relationship
+	for _, p := range catalog.Sorted() {
+		layer, ok := introducingLayer(p, src)
+		if !ok {
+			continue
+		}
+
+		relationships = append(relationships, artifact.Relationship{
+			From: p,
+			To:   layer,
+			Type: artifact.ContainedByLayerRelationship,
+		})
+	}
+	return relationships
+}
+
+func introducingLayer(p Package, src *source.Source) (source.LayerMetadata, bool) {
+	if owner, ok := p.Metadata.(FileOwner); ok {
+		if layer, ok := src.IntroducingLayer(owner.OwnedFiles()...); ok {
+			return layer, true
+		}
+	}
+
+	var paths []string
+	for _, l := range p.Locations {
+		paths = append(paths, l.RealPath)
+	}
+	return src.IntroducingLayer(paths...)
+}
@@ -0,0 +1,148 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelationshipsByIntroducingLayer(t *testing.T) {
+	// note: this fixture is a checked-in docker archive (no docker daemon is needed) with two layers:
+	//   layer 1: adds /etc/base.txt
+	//   layer 2: modifies /etc/base.txt and adds /app/package.json
+	src, cleanup, err := source.New("docker-archive:test-fixtures/image-multi-layer.tar", nil)
+	t.Cleanup(cleanup)
+	require.NoError(t, err)
+
+	layers := src.Metadata.ImageMetadata.Layers
+	require.Len(t, layers, 2)
+	require.Equal(t, "sha256:2c617fd5ac4609ec1e501f6f9a72690b43dce3318d0fb2a3a29136c2b910f4c3", layers[0].Digest)
+	require.Equal(t, "sha256:2e35f33bc044b4a3ae0e5f6bef31cc1f4966463066b026968fd1a782350a893b", layers[1].Digest)
+
+	basePkg := Package{
+		Name:      "base",
+		Version:   "1.0",
+		Type:      RpmPkg,
+		Locations: []source.Location{source.NewLocation("/etc/base.txt")},
+	}
+	appPkg := Package{
+		Name:      "app",
+		Version:   "1.0.0",
+		Type:      NpmPkg,
+		Locations: []source.Location{source.NewLocation("/app/package.json")},
+	}
+	multiPkg := Package{
+		Name:    "multi",
+		Version: "2.0",
+		Type:    NpmPkg,
+		Locations: []source.Location{
+			source.NewLocation("/app/package.json"),
+			source.NewLocation("/etc/base.txt"),
+		},
+	}
+	missingPkg := Package{
+		Name:      "missing",
+		Version:   "3.0",
+		Type:      NpmPkg,
+		Locations: []source.Location{source.NewLocation("/does/not/exist")},
+	}
+
+	expected := map[string]string{
+		// the file was modified by the second layer, but first appears in the first layer
+		basePkg.Name: layers[0].Digest,
+		appPkg.Name:  layers[1].Digest,
+		// the earliest layer across all locations is used
+		multiPkg.Name: layers[0].Digest,
+	}
+
+	relationships := RelationshipsByIntroducingLayer(NewCatalog(basePkg, appPkg, multiPkg, missingPkg), src)
+
+	actual := make(map[string]string)
+	for _, r := range relationships {
+		assert.Equal(t, artifact.ContainedByLayerRelationship, r.Type)
+		p, ok := r.From.(Package)
+		require.True(t, ok)
+		layer, ok := r.To.(source.LayerMetadata)
+		require.True(t, ok)
+		assert.Equal(t, artifact.ID(layer.Digest), r.To.ID())
+		actual[p.Name] = layer.Digest
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestRelationshipsByIntroducingLayer_osPackages(t *testing.T) {
+	// note: this fixture is a checked-in docker archive (no docker daemon is needed) with two layers:
+	//   layer 1 (ADD rootfs): adds /var/lib/dpkg/status (with base-files) and /usr/bin/base-tool
+	//   layer 2 (RUN apt-get install -y curl): modifies /var/lib/dpkg/status (adding curl) and adds /usr/bin/curl
+	src, cleanup, err := source.New("docker-archive:test-fixtures/image-os-packages.tar", nil)
+	t.Cleanup(cleanup)
+	require.NoError(t, err)
+
+	layers := src.Metadata.ImageMetadata.Layers
+	require.Len(t, layers, 2)
+
+	statusFile := []source.Location{source.NewLocation("/var/lib/dpkg/status")}
+	basePkg := Package{
+		Name:         "base-files",
+		Version:      "11.1",
+		Type:         DebPkg,
+		Locations:    statusFile,
+		MetadataType: DpkgMetadataType,
+		Metadata: DpkgMetadata{
+			Package: "base-files",
+			Files:   []DpkgFileRecord{{Path: "/usr/bin/base-tool"}},
+		},
+	}
+	curlPkg := Package{
+		Name:         "curl",
+		Version:      "7.74.0-1.3",
+		Type:         DebPkg,
+		Locations:    statusFile,
+		MetadataType: DpkgMetadataType,
+		Metadata: DpkgMetadata{
+			Package: "curl",
+			Files: []DpkgFileRecord{
+				// the directory is already within the base layer, so is not evidence of the introducing layer
+				{Path: "/usr/bin"},
+				{Path: "/usr/bin/curl"},
+			},
+		},
+	}
+	docsPkg := Package{
+		Name:         "docs-only",
+		Version:      "1.0",
+		Type:         DebPkg,
+		Locations:    statusFile,
+		MetadataType: DpkgMetadataType,
+		Metadata: DpkgMetadata{
+			Package: "docs-only",
+			// e.g. excluded from the image by a dpkg path-exclude config
+			Files: []DpkgFileRecord{{Path: "/usr/share/doc/docs-only/copyright"}},
+		},
+	}
+
+	expected := map[string]string{
+		basePkg.Name: layers[0].Digest,
+		// the package database was created by the base layer, however, the package files were installed later
+		curlPkg.Name: layers[1].Digest,
+		// without any owned files within the image, the package database is the only evidence
+		docsPkg.Name: layers[0].Digest,
+	}
+
+	relationships := RelationshipsByIntroducingLayer(NewCatalog(basePkg, curlPkg, docsPkg), src)
+
+	actual := make(map[string]string)
+	for _, r := range relationships {
+		p, ok := r.From.(Package)
+		require.True(t, ok)
+		layer, ok := r.To.(source.LayerMetadata)
+		require.True(t, ok)
+		actual[p.Name] = layer.Digest
+	}
+
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"sort"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+type sharedFilesMetadata struct {
+	Files []string `json:"files"`
+}
+
+// packagePair is a pair of packages that claim ownership of the same files, where the first package is the first in
+// catalog order (see Catalog.Sorted).
+type packagePair struct {
+	first, second artifact.ID
+}
+
+// RelationshipsBySharedFileOwnership creates a package-to-package relationship between packages of different types
+// (i.e. from different package managers) that claim ownership of the same files within their package manager metadata,
+// such as an RPM and a pip-installed python package that both own the same .py file. Only one of the installations
+// can be present on disk, so the files of the other are shadowed. Packages of the same type are not related, since
+// package managers commonly share directories among their own packages. Each relationship is from the package that is
+// first in catalog order, and the relationships are sorted by package ID, so the results are stable between runs.
+func RelationshipsBySharedFileOwnership(catalog *Catalog) []artifact.Relationship {
+	if catalog == nil {
+		return nil
+	}
+
+	ownersByPath := make(map[string][]artifact.ID)
+	for _, p := range catalog.Sorted() {
+		fileOwner, ok := p.Metadata.(FileOwner)
+		if !ok {
+			continue
+		}
+		id := p.ID()
+		for _, path := range fileOwner.OwnedFiles() {
+			if matchesAny(path, globsForbiddenFromBeingOwned) {
+				continue
+			}
+			ownersByPath[path] = append(ownersByPath[path], id)
+		}
+	}
+
+	sharedFiles := make(map[packagePair][]string)
+	for path, owners := range ownersByPath {
+		for i, a := range owners {
+			for _, b := range owners[i+1:] {
+				if catalog.byID[a].Type == catalog.byID[b].Type {
+					continue
+				}
+				// the owners are in catalog order, so each pair is always ordered the same way
+				pair := packagePair{first: a, second: b}
+				sharedFiles[pair] = append(sharedFiles[pair], path)
+			}
+		}
+	}
+
+	pairs := make([]packagePair, 0, len(sharedFiles))
+	for pair := range sharedFiles {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].first != pairs[j].first {
+			return pairs[i].first < pairs[j].first
+		}
+		return pairs[i].second < pairs[j].second
+	})
+
+	var edges []artifact.Relationship
+	for _, pair := range pairs {
+		files := sharedFiles[pair]
+		sort.Strings(files)
+
+		edges = append(edges, artifact.Relationship{
+			From: catalog.byID[pair.first],
+			To:   catalog.byID[pair.second],
+			Type: artifact.SharedFileOwnershipRelationship,
+			Data: sharedFilesMetadata{
+				Files: files,
+			},
+		})
+	}
+
+	return edges
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rpmOwning(name string, paths ...string) Package {
+	var files []RpmdbFileRecord
+	for _, p := range paths {
+		files = append(files, RpmdbFileRecord{Path: p})
+	}
+	return Package{
+		Name:         name,
+		Type:         RpmPkg,
+		MetadataType: RpmdbMetadataType,
+		Metadata:     RpmdbMetadata{Name: name, Files: files},
+	}
+}
+
+func TestRelationshipsBySharedFileOwnership(t *testing.T) {
+	pip := Package{
+		Name:         "six",
+		Version:      "1.16.0",
+		Type:         PythonPkg,
+		MetadataType: PythonPackageMetadataType,
+		Metadata: PythonPackageMetadata{
+			Name:                 "six",
+			SitePackagesRootPath: "/usr/lib/python3.9/site-packages",
+			Files: []PythonFileRecord{
+				{Path: "six.py"},
+				{Path: "six-1.16.0.dist-info/METADATA"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		packages      []Package
+		expectedPairs [][2]string
+		expectedFiles [][]string
+	}{
+		{
+			name: "rpm and pip own the same file",
+			packages: []Package{
+				pip,
+				rpmOwning("python3-six", "/usr/lib/python3.9/site-packages/six.py", "/usr/lib/python3.9/site-packages"),
+			},
+			expectedPairs: [][2]string{{"six", "python3-six"}},
+			expectedFiles: [][]string{{"/usr/lib/python3.9/site-packages/six.py"}},
+		},
+		{
+			name: "packages of the same type share files",
+			packages: []Package{
+				rpmOwning("python3-libs", "/usr/lib/python3.9/site-packages"),
+				rpmOwning("python3-six", "/usr/lib/python3.9/site-packages"),
+			},
+		},
+		{
+			name: "files that may not be owned",
+			packages: []Package{
+				rpmOwning("rpm", "/var/lib/rpm/Packages"),
+				{
+					Name:         "rpm",
+					Type:         DebPkg,
+					MetadataType: DpkgMetadataType,
+					Metadata: DpkgMetadata{
+						Package: "rpm",
+						Files:   []DpkgFileRecord{{Path: "/var/lib/rpm/Packages"}},
+					},
+				},
+			},
+		},
+		{
+			name: "no shared files",
+			packages: []Package{
+				pip,
+				rpmOwning("python3-requests", "/usr/lib/python3.9/site-packages/requests/__init__.py"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			relationships := RelationshipsBySharedFileOwnership(NewCatalog(test.packages...))
+			require.Len(t, relationships, len(test.expectedPairs))
+
+			for i, r := range relationships {
+				assert.Equal(t, artifact.SharedFileOwnershipRelationship, r.Type)
+				assert.Equal(t, test.expectedPairs[i][0], r.From.(Package).Name)
+				assert.Equal(t, test.expectedPairs[i][1], r.To.(Package).Name)
+				assert.Equal(t, sharedFilesMetadata{Files: test.expectedFiles[i]}, r.Data)
+			}
+		})
+	}
+}
@@ -15,7 +15,10 @@ import (
 
 const RpmDBGlob = "**/var/lib/rpm/Packages"
 
-var _ FileOwner = (*RpmdbMetadata)(nil)
+var (
+	_ FileOwner          = (*RpmdbMetadata)(nil)
+	_ FileDigestRecorder = (*RpmdbMetadata)(nil)
+)
 
 // RpmdbMetadata represents all captured data for a RPM DB package entry.
 type RpmdbMetadata struct {
@@ -51,20 +54,9 @@ func (m RpmdbMetadata) PackageURL(d *distro.Distro) string {
 		return ""
 	}
 
-	qualifiers := packageurl.Qualifiers{
-		{
-			Key:   "arch",
-			Value: m.Arch,
-		},
-	}
-
+	var epoch string
 	if m.Epoch != nil {
-		qualifiers = append(qualifiers,
-			packageurl.Qualifier{
-				Key:   "epoch",
-				Value: strconv.Itoa(*m.Epoch),
-			},
-		)
+		epoch = strconv.Itoa(*m.Epoch)
 	}
 
 	pURL := packageurl.NewPackageURL(
@@ -74,7 +66,10 @@ func (m RpmdbMetadata) PackageURL(d *distro.Distro) string {
 		// for purl the epoch is a qualifier, not part of the version
 		// see https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst under the RPM section
 		fmt.Sprintf("%s-%s", m.Version, m.Release),
-		qualifiers,
+		purlQualifiers(d, map[string]string{
+			purlArchQualifier:  m.Arch,
+			purlEpochQualifier: epoch,
+		}),
 		"")
 	return pURL.ToString()
 }
@@ -90,3 +85,19 @@ func (m RpmdbMetadata) OwnedFiles() (result []string) {
 	sort.Strings(result)
 	return result
 }
+
+// RecordedFileDigests returns the file digests from the RPM DB entry (keyed by path). Entries without a digest (such as
+// directories and symlinks) are not included.
+func (m RpmdbMetadata) RecordedFileDigests() map[string][]file.Digest {
+	results := make(map[string][]file.Digest)
+	for _, f := range m.Files {
+		if f.Path == "" || f.Digest.Value == "" {
+			continue
+		}
+		results[f.Path] = append(results[f.Path], file.Digest{
+			Algorithm: file.CleanDigestAlgorithmName(f.Digest.Algorithm),
+			Value:     f.Digest.Value,
+		})
+	}
+	return results
+}
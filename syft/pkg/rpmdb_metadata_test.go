@@ -7,6 +7,7 @@ import (
 	"github.com/go-test/deep"
 
 	"github.com/anchore/syft/syft/distro"
+	"github.com/anchore/syft/syft/file"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
@@ -42,6 +43,20 @@ func TestRpmMetadata_pURL(t *testing.T) {
 			},
 			expected: "pkg:rpm/redhat/p@v-r?arch=a",
 		},
+		{
+			distro: distro.Distro{
+				Type:       distro.CentOS,
+				RawVersion: "8",
+			},
+			metadata: RpmdbMetadata{
+				Name:    "p",
+				Version: "v",
+				Arch:    "a",
+				Release: "r",
+				Epoch:   intRef(1),
+			},
+			expected: "pkg:rpm/centos/p@v-r?arch=a&distro=centos-8&epoch=1",
+		},
 	}
 
 	for _, test := range tests {
@@ -98,6 +113,44 @@ func TestRpmMetadata_FileOwner(t *testing.T) {
 	}
 }
 
+func TestRpmMetadata_RecordedFileDigests(t *testing.T) {
+	metadata := RpmdbMetadata{
+		Files: []RpmdbFileRecord{
+			{
+				Path:   "/usr/bin/app",
+				Digest: file.Digest{Algorithm: "sha256", Value: "81d29f327ba23096b3c52ff6fe1c425641e618bc87b5c05ee377edc650afaa55"},
+			},
+			{
+				// directories (and symlinks) do not have digests
+				Path:   "/usr/share/app",
+				Digest: file.Digest{Algorithm: "sha256"},
+			},
+			{
+				Path:   "/etc/app.conf",
+				Digest: file.Digest{Algorithm: "MD5", Value: "d41d8cd98f00b204e9800998ecf8427e"},
+			},
+			{
+				Digest: file.Digest{Algorithm: "sha256", Value: "deadbeef"},
+			},
+		},
+	}
+
+	expected := map[string][]file.Digest{
+		"/usr/bin/app": {
+			{Algorithm: "sha256", Value: "81d29f327ba23096b3c52ff6fe1c425641e618bc87b5c05ee377edc650afaa55"},
+		},
+		"/etc/app.conf": {
+			{Algorithm: "md5", Value: "d41d8cd98f00b204e9800998ecf8427e"},
+		},
+	}
+
+	var i interface{} = metadata
+	actual := i.(FileDigestRecorder).RecordedFileDigests()
+	for _, d := range deep.Equal(expected, actual) {
+		t.Errorf("diff: %+v", d)
+	}
+}
+
 func intRef(i int) *int {
 	return &i
 }
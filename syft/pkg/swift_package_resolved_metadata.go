@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+)
+
+// SwiftPackageResolvedMetadata represents all captured data for a Swift package pin from a Package.resolved file.
+type SwiftPackageResolvedMetadata struct {
+	Name          string `json:"name"`
+	RepositoryURL string `json:"repositoryURL"`
+	Revision      string `json:"revision"`
+	Version       string `json:"version,omitempty"`
+	Branch        string `json:"branch,omitempty"`
+}
+
+// PackageURL returns the PURL for the specific Swift package (see https://github.com/package-url/purl-spec), where
+// the namespace and name are derived from the repository URL.
+func (m SwiftPackageResolvedMetadata) PackageURL() string {
+	namespace, name := swiftPackageURLNamespaceAndName(m.RepositoryURL)
+	if name == "" {
+		name = m.Name
+	}
+
+	version := m.Version
+	if version == "" {
+		// branch-pinned packages are only identifiable by the commit
+		version = m.Revision
+	}
+
+	return packageurl.NewPackageURL(
+		"swift",
+		namespace,
+		name,
+		version,
+		nil,
+		"",
+	).ToString()
+}
+
+// swiftPackageURLNamespaceAndName splits the given repository URL (e.g. "https://github.com/apple/swift-nio.git" or
+// "git@github.com:apple/swift-nio.git") into the host and path prefix (e.g. "github.com/apple") and the repository
+// name (e.g. "swift-nio").
+func swiftPackageURLNamespaceAndName(repositoryURL string) (string, string) {
+	location := strings.TrimSpace(repositoryURL)
+	if u, err := url.Parse(location); err == nil && u.Host != "" {
+		location = u.Host + u.Path
+	} else if idx := strings.Index(location, "@"); idx >= 0 {
+		// scp-like syntax (e.g. "git@github.com:apple/swift-nio.git")
+		location = strings.Replace(location[idx+1:], ":", "/", 1)
+	}
+
+	location = strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+
+	idx := strings.LastIndex(location, "/")
+	if idx < 0 {
+		return "", location
+	}
+	return location[:idx], location[idx+1:]
+}
@@ -1,6 +1,10 @@
 package pkg
 
-import "github.com/anchore/packageurl-go"
+import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+)
 
 // Type represents a Package Type for or within a language ecosystem (there may be multiple package types within a language ecosystem)
 type Type string
@@ -9,6 +13,7 @@ const (
 	// the full set of supported packages
 	UnknownPkg       Type = "UnknownPackage"
 	ApkPkg           Type = "apk"
+	ApkbuildPkg      Type = "apkbuild"
 	GemPkg           Type = "gem"
 	DebPkg           Type = "deb"
 	RpmPkg           Type = "rpm"
@@ -19,12 +24,22 @@ const (
 	JenkinsPluginPkg Type = "jenkins-plugin"
 	GoModulePkg      Type = "go-module"
 	RustPkg          Type = "rust-crate"
+	CondaPkg         Type = "conda"
+	DotnetPkg        Type = "dotnet"
+	SwiftPkg         Type = "swift"
+	HackagePkg       Type = "hackage"
+	HexPkg           Type = "hex"
+	DartPubPkg       Type = "dart-pub"
 	KbPkg            Type = "msrc-kb"
+	BinaryPkg        Type = "binary"
+	WindowsPkg       Type = "windows-program"
+	LinuxKernelPkg   Type = "linux-kernel"
 )
 
 // AllPkgs represents all supported package types
 var AllPkgs = []Type{
 	ApkPkg,
+	ApkbuildPkg,
 	GemPkg,
 	DebPkg,
 	RpmPkg,
@@ -35,7 +50,16 @@ var AllPkgs = []Type{
 	JenkinsPluginPkg,
 	GoModulePkg,
 	RustPkg,
+	CondaPkg,
+	DotnetPkg,
+	SwiftPkg,
+	HackagePkg,
+	HexPkg,
+	DartPubPkg,
 	KbPkg,
+	BinaryPkg,
+	WindowsPkg,
+	LinuxKernelPkg,
 }
 
 // PackageURLType returns the PURL package type for the current package.
@@ -61,8 +85,55 @@ func (t Type) PackageURLType() string {
 		return packageurl.TypeGolang
 	case RustPkg:
 		return "cargo"
+	case CondaPkg:
+		return "conda"
+	case DotnetPkg:
+		return packageurl.TypeNuget
+	case SwiftPkg:
+		return "swift"
+	case HackagePkg:
+		return "hackage"
+	case HexPkg:
+		return "hex"
+	case DartPubPkg:
+		return "pub"
+	case BinaryPkg, WindowsPkg, LinuxKernelPkg:
+		return packageurl.TypeGeneric
+	case ApkbuildPkg:
+		// a build recipe is not an installed package, so it must not share the purl of the package it builds
+		return ""
 	default:
 		// TODO: should this be a "generic" purl type instead?
 		return ""
 	}
 }
+
+// TypeFromPURL returns the package type for the given PURL (e.g. from an SBOM that syft did not create). PURL types
+// that are shared by several package types resolve to the most common package type (e.g. maven to java-archive), and
+// generic or unsupported PURL types resolve to UnknownPkg.
+func TypeFromPURL(purl string) Type {
+	purlType := purlTypeOf(purl)
+	if purlType == "" || purlType == packageurl.TypeGeneric {
+		return UnknownPkg
+	}
+
+	for _, t := range AllPkgs {
+		if t.PackageURLType() == purlType {
+			return t
+		}
+	}
+	return UnknownPkg
+}
+
+// purlTypeOf returns the (lowercase) type of the given PURL (e.g. "npm" for "pkg:npm/lodash@4.17.21"), which is empty
+// when the value is not a PURL.
+func purlTypeOf(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+	purlType := strings.TrimLeft(strings.TrimPrefix(purl, "pkg:"), "/")
+	if idx := strings.Index(purlType, "/"); idx >= 0 {
+		purlType = purlType[:idx]
+	}
+	return strings.ToLower(purlType)
+}
@@ -0,0 +1,34 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeFromPURL(t *testing.T) {
+	tests := []struct {
+		purl         string
+		expectedType Type
+		expectedLang Language
+	}{
+		{purl: "pkg:npm/%40babel/core@7.17.0", expectedType: NpmPkg, expectedLang: JavaScript},
+		{purl: "pkg:pypi/six@1.16.0", expectedType: PythonPkg, expectedLang: Python},
+		{purl: "pkg:maven/org.apache.commons/commons-lang3@3.12.0", expectedType: JavaPkg, expectedLang: Java},
+		{purl: "pkg:golang/github.com/anchore/syft@v0.46.0", expectedType: GoModulePkg, expectedLang: Go},
+		{purl: "pkg:deb/debian/libc6@2.31-13?arch=amd64", expectedType: DebPkg},
+		{purl: "pkg:alpine/musl@1.2.2-r7?distro=alpine-3.15.0", expectedType: ApkPkg},
+		{purl: "pkg:RPM/fedora/curl@7.50.3-1.fc25", expectedType: RpmPkg},
+		{purl: "pkg:generic/openssl@1.1.1q", expectedType: UnknownPkg},
+		{purl: "pkg:bitnami/wordpress@6.0.1", expectedType: UnknownPkg},
+		{purl: "not-a-purl", expectedType: UnknownPkg},
+		{purl: "", expectedType: UnknownPkg},
+	}
+
+	for _, test := range tests {
+		t.Run(test.purl, func(t *testing.T) {
+			assert.Equal(t, test.expectedType, TypeFromPURL(test.purl))
+			assert.Equal(t, test.expectedLang, LanguageFromPURL(test.purl))
+		})
+	}
+}
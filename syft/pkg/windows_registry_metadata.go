@@ -0,0 +1,31 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+)
+
+// WindowsRegistryMetadata represents all captured data for a Windows program registered as installed within the
+// registry (an "Uninstall" key of the SOFTWARE hive), such as programs installed by MSI packages.
+type WindowsRegistryMetadata struct {
+	// Key is the name of the registry key for the program (the product code for MSI installed programs)
+	Key             string `json:"key"`
+	DisplayName     string `json:"displayName"`
+	DisplayVersion  string `json:"displayVersion,omitempty"`
+	Publisher       string `json:"publisher,omitempty"`
+	InstallLocation string `json:"installLocation,omitempty"`
+	// WindowsInstaller indicates the program was installed by an MSI package
+	WindowsInstaller bool `json:"windowsInstaller,omitempty"`
+}
+
+// PackageURL returns a best-effort generic PURL for the program (see https://github.com/package-url/purl-spec), since
+// there is no PURL type for Windows programs.
+func (m WindowsRegistryMetadata) PackageURL() string {
+	return packageurl.NewPackageURL(
+		packageurl.TypeGeneric,
+		"",
+		m.DisplayName,
+		m.DisplayVersion,
+		nil,
+		"",
+	).ToString()
+}
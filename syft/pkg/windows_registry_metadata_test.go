@@ -0,0 +1,39 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowsRegistryMetadata_PackageURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata WindowsRegistryMetadata
+		expected string
+	}{
+		{
+			name: "go-case",
+			metadata: WindowsRegistryMetadata{
+				Key:            "7-Zip",
+				DisplayName:    "7-Zip 21.07 (x64)",
+				DisplayVersion: "21.07",
+				Publisher:      "Igor Pavlov",
+			},
+			expected: "pkg:generic/7-Zip%2021.07%20%28x64%29@21.07",
+		},
+		{
+			name: "no version",
+			metadata: WindowsRegistryMetadata{
+				Key:         "Connection Manager",
+				DisplayName: "Connection Manager",
+			},
+			expected: "pkg:generic/Connection%20Manager",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metadata.PackageURL())
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package sbom
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// Merge combines the results of cataloging several sources into a single SBOM. Every package is related to the source
+// it was found in (with a FoundInSourceRelationship). Since locations within directory sources are relative to the
+// scanned directory, these are prefixed with the directory path so that the same package found in two directories is
+// kept as two packages with distinct locations. SBOMs that are themselves the result of a merge (with several sources)
+// are combined as-is. The descriptor and distro are taken from the first SBOM (with a distro).
+func Merge(sboms ...SBOM) SBOM {
+	if len(sboms) == 1 {
+		return sboms[0]
+	}
+
+	var result = SBOM{
+		Artifacts: Artifacts{
+			PackageCatalog:      pkg.NewCatalog(),
+			FileMetadata:        make(map[source.Coordinates]source.FileMetadata),
+			FileDigests:         make(map[source.Coordinates][]file.Digest),
+			FileClassifications: make(map[source.Coordinates][]file.Classification),
+			FileContents:        make(map[source.Coordinates]string),
+			Secrets:             make(map[source.Coordinates][]file.SearchResult),
+		},
+	}
+
+	observedSources := make(map[artifact.ID]struct{})
+	addSources := func(sources ...source.Metadata) {
+		for _, src := range sources {
+			if _, exists := observedSources[src.ID()]; exists {
+				continue
+			}
+			observedSources[src.ID()] = struct{}{}
+			result.Sources = append(result.Sources, src)
+		}
+	}
+
+	for idx, s := range sboms {
+		if idx == 0 {
+			result.Source = s.Source
+			result.Descriptor = s.Descriptor
+		}
+		// note: decoded SBOMs have a distro without a type when no distro was found
+		if result.Artifacts.Distro == nil || (result.Artifacts.Distro.Type == "" && s.Artifacts.Distro != nil) {
+			result.Artifacts.Distro = s.Artifacts.Distro
+		}
+
+		m := newMerger(s.Source)
+		if len(s.Sources) > 0 {
+			// the locations are already qualified and the packages are already related to their sources
+			m.merged = true
+			addSources(s.Sources...)
+		} else {
+			addSources(s.Source)
+		}
+
+		m.mergeArtifacts(&result.Artifacts, s.Artifacts)
+		result.Relationships = append(result.Relationships, m.relationships(s.Relationships)...)
+	}
+
+	return result
+}
+
+type merger struct {
+	src      source.Metadata
+	merged   bool                        // the SBOM being merged is itself the result of a merge
+	packages map[artifact.ID]pkg.Package // original package ID -> package with qualified locations
+	ordered  []pkg.Package               // all packages with qualified locations (in sorted order)
+}
+
+func newMerger(src source.Metadata) *merger {
+	return &merger{
+		src:      src,
+		packages: make(map[artifact.ID]pkg.Package),
+	}
+}
+
+func (m *merger) mergeArtifacts(dest *Artifacts, artifacts Artifacts) {
+	if artifacts.PackageCatalog != nil {
+		for _, p := range artifacts.PackageCatalog.Sorted() {
+			originalID := p.ID()
+
+			locations := make([]source.Location, len(p.Locations))
+			for i, l := range p.Locations {
+				l.Coordinates = m.coordinates(l.Coordinates)
+				locations[i] = l
+			}
+			p.Locations = locations
+
+			m.packages[originalID] = p
+			m.ordered = append(m.ordered, p)
+			dest.PackageCatalog.Add(p)
+		}
+	}
+
+	for c, v := range artifacts.FileMetadata {
+		dest.FileMetadata[m.coordinates(c)] = v
+	}
+	for c, v := range artifacts.FileDigests {
+		dest.FileDigests[m.coordinates(c)] = v
+	}
+	for c, v := range artifacts.FileClassifications {
+		dest.FileClassifications[m.coordinates(c)] = v
+	}
+	for c, v := range artifacts.FileContents {
+		dest.FileContents[m.coordinates(c)] = v
+	}
+	for c, v := range artifacts.Secrets {
+		dest.Secrets[m.coordinates(c)] = v
+	}
+}
+
+// relationships returns the given relationships updated to refer to the merged packages and coordinates, along with a
+// relationship from every package to the source it was found in.
+func (m *merger) relationships(relationships []artifact.Relationship) (results []artifact.Relationship) {
+	for _, r := range relationships {
+		r.From = m.identifiable(r.From)
+		r.To = m.identifiable(r.To)
+		results = append(results, r)
+	}
+	if m.merged {
+		return results
+	}
+
+	for _, p := range m.ordered {
+		results = append(results, artifact.Relationship{
+			From: p,
+			To:   m.src,
+			Type: artifact.FoundInSourceRelationship,
+		})
+	}
+	return results
+}
+
+func (m *merger) identifiable(identifiable artifact.Identifiable) artifact.Identifiable {
+	switch v := identifiable.(type) {
+	case pkg.Package:
+		if p, exists := m.packages[v.ID()]; exists {
+			return p
+		}
+	case source.Coordinates:
+		return m.coordinates(v)
+	}
+	return identifiable
+}
+
+func (m *merger) coordinates(c source.Coordinates) source.Coordinates {
+	if m.merged || m.src.Scheme != source.DirectoryScheme {
+		return c
+	}
+
+	if m.src.GitMetadata.URL != "" {
+		// the path is a repository URL (not a filesystem path), which must not be cleaned
+		c.RealPath = strings.TrimSuffix(m.src.GitMetadata.URL, "/") + "/" + strings.TrimPrefix(c.RealPath, "/")
+		return c
+	}
+
+	if m.src.SifMetadata.Path != "" {
+		// the path is the SIF file (not the directory the root filesystem was extracted to)
+		c.RealPath = m.src.SifMetadata.Path + ":" + c.RealPath
+		return c
+	}
+
+	// note: directory sources given as a relative path already report locations that include the given path
+	root := filepath.Clean(m.src.Path)
+	if !strings.HasPrefix(filepath.Clean(c.RealPath), root+string(filepath.Separator)) {
+		c.RealPath = filepath.Join(root, c.RealPath)
+	}
+	return c
+}
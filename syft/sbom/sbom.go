@@ -12,6 +12,7 @@ type SBOM struct {
 	Artifacts     Artifacts
 	Relationships []artifact.Relationship
 	Source        source.Metadata
+	Sources       []source.Metadata // all sources cataloged when more than one source was merged (Source is the first of these)
 	Descriptor    Descriptor
 }
 
@@ -31,6 +32,33 @@ type Descriptor struct {
 	Configuration interface{}
 }
 
+// FilterPackages returns a copy of the given SBOM with only the packages for which the given function returns true. Any
+// relationships to packages that are no longer present are dropped. The given SBOM is not modified.
+func FilterPackages(sbom SBOM, keep func(*pkg.Package) bool) SBOM {
+	if sbom.Artifacts.PackageCatalog == nil {
+		return sbom
+	}
+
+	catalog := sbom.Artifacts.PackageCatalog.Filter(keep)
+
+	var relationships []artifact.Relationship
+	for _, relationship := range sbom.Relationships {
+		if isMissingPackage(catalog, relationship.From) || isMissingPackage(catalog, relationship.To) {
+			continue
+		}
+		relationships = append(relationships, relationship)
+	}
+
+	sbom.Artifacts.PackageCatalog = catalog
+	sbom.Relationships = relationships
+	return sbom
+}
+
+func isMissingPackage(catalog *pkg.Catalog, identifiable artifact.Identifiable) bool {
+	p, ok := identifiable.(pkg.Package)
+	return ok && catalog.Package(p.ID()) == nil
+}
+
 func AllCoordinates(sbom SBOM) []source.Coordinates {
 	set := source.NewCoordinateSet()
 	for coordinates := range sbom.Artifacts.FileMetadata {
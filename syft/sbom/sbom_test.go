@@ -0,0 +1,169 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterPackages(t *testing.T) {
+	apkPkg := pkg.Package{
+		Name:      "musl",
+		Version:   "1.2.2-r3",
+		Type:      pkg.ApkPkg,
+		Locations: []source.Location{source.NewLocation("/lib/apk/db/installed")},
+	}
+	npmPkg := pkg.Package{
+		Name:      "left-pad",
+		Version:   "1.3.0",
+		Type:      pkg.NpmPkg,
+		Locations: []source.Location{source.NewLocation("/app/node_modules/left-pad/package.json")},
+	}
+	installed := source.NewLocation("/lib/ld-musl-x86_64.so.1").Coordinates
+
+	original := SBOM{
+		Artifacts: Artifacts{
+			PackageCatalog: pkg.NewCatalog(apkPkg, npmPkg),
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From: apkPkg,
+				To:   installed,
+				Type: artifact.ContainsRelationship,
+			},
+			{
+				From: apkPkg,
+				To:   npmPkg,
+				Type: artifact.OwnershipByFileOverlapRelationship,
+			},
+		},
+	}
+
+	filtered := FilterPackages(original, func(p *pkg.Package) bool {
+		return p.Type == pkg.ApkPkg
+	})
+
+	// the retained package keeps its locations and any relationships not involving removed packages
+	require.Equal(t, 1, filtered.Artifacts.PackageCatalog.PackageCount())
+	actual := filtered.Artifacts.PackageCatalog.Package(apkPkg.ID())
+	require.NotNil(t, actual)
+	assert.Equal(t, apkPkg.Locations, actual.Locations)
+	assert.Equal(t, []artifact.Relationship{original.Relationships[0]}, filtered.Relationships)
+
+	// the original SBOM is not modified
+	assert.Equal(t, 2, original.Artifacts.PackageCatalog.PackageCount())
+	assert.Len(t, original.Relationships, 2)
+}
+
+func TestMerge(t *testing.T) {
+	// note: directories given as an absolute path report locations relative to the directory
+	p := pkg.Package{
+		Name:      "requests",
+		Version:   "2.25.1",
+		Type:      pkg.PythonPkg,
+		Locations: []source.Location{source.NewLocation("requirements.txt")},
+	}
+	contained := source.NewLocation("requirements.txt").Coordinates
+
+	newSBOM := func(path string) SBOM {
+		return SBOM{
+			Artifacts: Artifacts{
+				PackageCatalog: pkg.NewCatalog(p),
+				FileDigests: map[source.Coordinates][]file.Digest{
+					contained: {{Algorithm: "sha256", Value: "abc"}},
+				},
+			},
+			Relationships: []artifact.Relationship{
+				{
+					From: p,
+					To:   contained,
+					Type: artifact.ContainsRelationship,
+				},
+			},
+			Source: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   path,
+			},
+		}
+	}
+
+	first, second := newSBOM("/first"), newSBOM("/second")
+	merged := Merge(first, second)
+
+	assert.Equal(t, first.Source, merged.Source)
+	assert.Equal(t, []source.Metadata{first.Source, second.Source}, merged.Sources)
+
+	packages := merged.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, packages, 2)
+	assert.Equal(t, "/first/requirements.txt", packages[0].Locations[0].RealPath)
+	assert.Equal(t, "/second/requirements.txt", packages[1].Locations[0].RealPath)
+
+	assert.Len(t, merged.Artifacts.FileDigests, 2)
+	assert.Contains(t, merged.Artifacts.FileDigests, source.Coordinates{RealPath: "/first/requirements.txt"})
+	assert.Contains(t, merged.Artifacts.FileDigests, source.Coordinates{RealPath: "/second/requirements.txt"})
+
+	// existing relationships refer to the merged packages and locations, and each package refers to its source
+	assert.ElementsMatch(t, []artifact.Relationship{
+		{
+			From: packages[0],
+			To:   source.Coordinates{RealPath: "/first/requirements.txt"},
+			Type: artifact.ContainsRelationship,
+		},
+		{
+			From: packages[0],
+			To:   first.Source,
+			Type: artifact.FoundInSourceRelationship,
+		},
+		{
+			From: packages[1],
+			To:   source.Coordinates{RealPath: "/second/requirements.txt"},
+			Type: artifact.ContainsRelationship,
+		},
+		{
+			From: packages[1],
+			To:   second.Source,
+			Type: artifact.FoundInSourceRelationship,
+		},
+	}, merged.Relationships)
+
+	// merging a single SBOM is a no-op
+	assert.Equal(t, first, Merge(first))
+}
+
+func TestMerge_gitSource(t *testing.T) {
+	p := pkg.Package{
+		Name:      "requests",
+		Version:   "2.25.1",
+		Type:      pkg.PythonPkg,
+		Locations: []source.Location{source.NewLocation("/requirements.txt")},
+	}
+
+	newSBOM := func(url string) SBOM {
+		return SBOM{
+			Artifacts: Artifacts{
+				PackageCatalog: pkg.NewCatalog(p),
+			},
+			Source: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   url,
+				GitMetadata: source.GitMetadata{
+					URL:    url,
+					Commit: "abc",
+				},
+			},
+		}
+	}
+
+	merged := Merge(newSBOM("https://github.com/org/first"), newSBOM("https://github.com/org/second/"))
+
+	// repository URLs are used as-is (without cleaning the path, which would mangle the URL)
+	packages := merged.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, packages, 2)
+	assert.Equal(t, "https://github.com/org/first/requirements.txt", packages[0].Locations[0].RealPath)
+	assert.Equal(t, "https://github.com/org/second/requirements.txt", packages[1].Locations[0].RealPath)
+}
@@ -0,0 +1,216 @@
+package source
+
+import (
+	"archive/tar"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/mholt/archiver/v3"
+	"github.com/mitchellh/go-homedir"
+)
+
+// ArchiveInputPrefix is the prefix for user input that indicates an archive (e.g. "archive:path/to/project.tar.gz")
+// should be extracted and cataloged as a directory.
+const ArchiveInputPrefix = "archive:"
+
+func generateArchiveSource(userInput string, exclusions []string) (*Source, func(), error) {
+	path, err := homedir.Expand(strings.TrimPrefix(userInput, ArchiveInputPrefix))
+	if err != nil {
+		return &Source{}, func() {}, fmt.Errorf("unable to expand archive path: %w", err)
+	}
+
+	s, cleanupFn, err := NewFromArchive(path)
+	if err != nil {
+		return &Source{}, cleanupFn, err
+	}
+
+	s.pathFilterFns, err = getDirectoryExclusionFunctions(s.path, exclusions)
+	if err != nil {
+		cleanupFn()
+		return &Source{}, func() {}, err
+	}
+
+	return &s, cleanupFn, nil
+}
+
+// NewFromArchive creates a new source object tailored to catalog the contents of the given archive (e.g. a zip file or
+// gzipped tarball, as indicated by the file extension) as a directory. The archive is extracted to a temp dir, which is
+// removed by the returned cleanup function. Note: archives within the archive are not extracted.
+func NewFromArchive(path string) (Source, func(), error) {
+	envelopedUnarchiver, err := archiver.ByExtension(path)
+	if err != nil {
+		return Source{}, func() {}, fmt.Errorf("unsupported archive=%q: %w", path, err)
+	}
+	unarchiver, ok := envelopedUnarchiver.(archiver.Unarchiver)
+	if !ok {
+		return Source{}, func() {}, fmt.Errorf("unsupported archive=%q: compressed files must be an archive (e.g. .tar.gz)", path)
+	}
+
+	extractedPath, cleanupFn, err := unarchiveToTmp(path, unarchiver)
+	if err != nil {
+		cleanupFn()
+		return Source{}, func() {}, fmt.Errorf("unable to extract archive=%q: %w", path, err)
+	}
+
+	s, err := NewFromDirectory(extractedPath)
+	if err != nil {
+		cleanupFn()
+		return Source{}, func() {}, err
+	}
+
+	// the extraction location is not meaningful (and changes on every run), so the archive is described instead
+	s.Metadata.Path = path
+
+	return s, cleanupFn, nil
+}
+
+// maxArchiveLinkHops is the maximum number of links followed when resolving a path within an archive (as with the
+// kernel limit on nested symlinks, this guards against link cycles).
+const maxArchiveLinkHops = 40
+
+// checkArchiveLinks ensures that every symlink and hardlink within the given archive resolves within the archive, since
+// extracting a link that escapes the destination dir (e.g. "evil -> ../../outside") would let later entries (such as
+// "evil/pwned.txt") be written outside of the destination, or let host files be cataloged as archive contents.
+// Absolute symlink targets are common within root filesystem archives (e.g. "bin/sh -> /bin/busybox"), so these are
+// resolved relative to the root of the archive. However, as the link is extracted as-is, no entry may be written
+// through such a link. The absolute symlinks (entry name to link target) are returned, which must be rewritten once
+// extracted (see rewriteAbsoluteSymlinks).
+func checkArchiveLinks(archivePath string, unarchiver archiver.Unarchiver) (map[string]string, error) {
+	walker, ok := unarchiver.(archiver.Walker)
+	if !ok {
+		return nil, fmt.Errorf("unable to inspect archive=%q before extraction", archivePath)
+	}
+
+	var names []string
+	symlinks := make(map[string]string)
+	hardlinks := make(map[string]string)
+	err := walker.Walk(archivePath, func(f archiver.File) error {
+		switch header := f.Header.(type) {
+		case *tar.Header:
+			names = append(names, cleanArchivePath(header.Name))
+			switch header.Typeflag {
+			case tar.TypeSymlink:
+				symlinks[cleanArchivePath(header.Name)] = header.Linkname
+			case tar.TypeLink:
+				hardlinks[cleanArchivePath(header.Name)] = header.Linkname
+			}
+		case zip.FileHeader:
+			names = append(names, cleanArchivePath(header.Name))
+			if header.Mode()&os.ModeSymlink != 0 {
+				// the symlink target is the contents of the entry
+				target, err := ioutil.ReadAll(f)
+				if err != nil {
+					return fmt.Errorf("unable to read symlink target of %q: %w", header.Name, err)
+				}
+				symlinks[cleanArchivePath(header.Name)] = strings.TrimSpace(string(target))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	absoluteSymlinks := make(map[string]string)
+	for name, target := range symlinks {
+		if _, ok := resolveArchivePath(symlinks, archiveLinkTarget(name, target)); !ok {
+			return nil, fmt.Errorf("archive contains a symlink that points outside of the archive: %q -> %q", name, target)
+		}
+		if path.IsAbs(target) {
+			absoluteSymlinks[name] = target
+		}
+	}
+	for name, target := range hardlinks {
+		// the hardlink target is read from the extracted archive, so must not be read through an absolute symlink
+		if viaAbsolute, ok := resolveArchivePath(symlinks, cleanArchivePath(target)); !ok || viaAbsolute {
+			return nil, fmt.Errorf("archive contains a hardlink that points outside of the archive: %q -> %q", name, target)
+		}
+	}
+	for _, name := range names {
+		// each entry is written to the extracted parent dir, which must not be reached through an absolute symlink
+		if viaAbsolute, ok := resolveArchivePath(symlinks, path.Dir(name)); !ok || viaAbsolute {
+			return nil, fmt.Errorf("archive contains an entry that is written through a symlink that points outside of the archive: %q", name)
+		}
+	}
+	return absoluteSymlinks, nil
+}
+
+// rewriteAbsoluteSymlinks replaces the given absolute symlinks (entry name to link target) extracted to the given dir
+// with the equivalent relative symlink within the dir, so that the extracted archive never refers to host files.
+func rewriteAbsoluteSymlinks(dir string, symlinks map[string]string) error {
+	for name, target := range symlinks {
+		linkPath := filepath.Join(dir, filepath.FromSlash(name))
+		if info, err := os.Lstat(linkPath); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			// the link was not extracted (or was replaced by a later entry)
+			continue
+		}
+
+		relTarget, err := filepath.Rel(filepath.Dir(linkPath), filepath.Join(dir, filepath.FromSlash(cleanArchivePath(target))))
+		if err != nil {
+			return fmt.Errorf("unable to resolve symlink %q -> %q within archive: %w", name, target, err)
+		}
+		if err := os.Remove(linkPath); err != nil {
+			return fmt.Errorf("unable to rewrite symlink %q: %w", name, err)
+		}
+		if err := os.Symlink(relTarget, linkPath); err != nil {
+			return fmt.Errorf("unable to rewrite symlink %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// cleanArchivePath returns the given archive entry name relative to the root of the archive.
+func cleanArchivePath(name string) string {
+	return path.Clean(strings.TrimPrefix(filepath.ToSlash(name), "/"))
+}
+
+// archiveLinkTarget returns the path (relative to the root of the archive) that the given symlink entry points to,
+// where absolute targets are relative to the root of the archive.
+func archiveLinkTarget(name, target string) string {
+	if path.IsAbs(target) {
+		return cleanArchivePath(target)
+	}
+	return path.Join(path.Dir(name), target)
+}
+
+// resolveArchivePath follows all of the given symlinks (entry name to link target) along the given path (relative to
+// the root of the archive), indicating if the path remains within the archive and if any absolute symlink was followed.
+func resolveArchivePath(symlinks map[string]string, p string) (viaAbsolute bool, ok bool) {
+	hops := 0
+	for {
+		p = path.Clean(p)
+		if p == ".." || strings.HasPrefix(p, "../") {
+			return viaAbsolute, false
+		}
+
+		resolved := true
+		components := strings.Split(p, "/")
+		for i := range components {
+			prefix := path.Join(components[:i+1]...)
+			target, isLink := symlinks[prefix]
+			if !isLink {
+				continue
+			}
+
+			hops++
+			if hops > maxArchiveLinkHops {
+				return viaAbsolute, false
+			}
+			if path.IsAbs(target) {
+				viaAbsolute = true
+			}
+			p = path.Join(append([]string{archiveLinkTarget(prefix, target)}, components[i+1:]...)...)
+			resolved = false
+			break
+		}
+
+		if resolved {
+			return viaAbsolute, true
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package source
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromArchive(t *testing.T) {
+	dir := t.TempDir()
+	projectDir := filepath.Join(dir, "project")
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "app"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(projectDir, "app", "requirements.txt"), []byte("requests==2.26.0\n"), 0644))
+
+	for _, name := range []string{"project.tar.gz", "project.zip"} {
+		t.Run(name, func(t *testing.T) {
+			archivePath := filepath.Join(dir, name)
+			require.NoError(t, archiver.Archive([]string{projectDir}, archivePath))
+
+			src, cleanup, err := NewFromArchive(archivePath)
+			require.NoError(t, err)
+
+			assert.Equal(t, DirectoryScheme, src.Metadata.Scheme)
+			assert.Equal(t, archivePath, src.Metadata.Path)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+			locations, err := resolver.FilesByGlob("**/requirements.txt")
+			require.NoError(t, err)
+			require.Len(t, locations, 1)
+			assert.Equal(t, "project/app/requirements.txt", locations[0].RealPath)
+
+			// the extracted contents are removed by the cleanup function
+			cleanup()
+			_, err = os.Stat(src.path)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestNewFromArchive_unsupported(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"project.txt", "requirements.txt.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			require.NoError(t, ioutil.WriteFile(path, []byte("not an archive"), 0644))
+
+			_, cleanup, err := NewFromArchive(path)
+			cleanup()
+			assert.Error(t, err)
+		})
+	}
+
+	// corrupt archives fail to extract (instead of cataloging nothing)
+	path := filepath.Join(dir, "corrupt.zip")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not an archive"), 0644))
+	_, cleanup, err := NewFromArchive(path)
+	cleanup()
+	assert.Error(t, err)
+}
+
+// archiveEntry describes an entry of a test archive, which is a symlink (or hardlink) when a link target is given.
+type archiveEntry struct {
+	name     string
+	contents string
+	symlink  string
+	hardlink string
+}
+
+func writeTarFixture(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := tar.NewWriter(f)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(e.contents))}
+		switch {
+		case e.symlink != "":
+			header = &tar.Header{Name: e.name, Mode: 0777, Typeflag: tar.TypeSymlink, Linkname: e.symlink}
+		case e.hardlink != "":
+			header = &tar.Header{Name: e.name, Mode: 0644, Typeflag: tar.TypeLink, Linkname: e.hardlink}
+		}
+		require.NoError(t, w.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err := w.Write([]byte(e.contents))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, w.Close())
+}
+
+func writeZipFixture(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Store}
+		contents := e.contents
+		header.SetMode(0644)
+		if e.symlink != "" {
+			// zip symlinks store the target as the contents of the entry
+			header.SetMode(0777 | os.ModeSymlink)
+			contents = e.symlink
+		}
+		entry, err := w.CreateHeader(header)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestNewFromArchive_pathTraversal(t *testing.T) {
+	outside := t.TempDir()
+
+	tests := []struct {
+		name    string
+		entries []archiveEntry
+		zip     bool
+	}{
+		{
+			name: "absolute symlink followed by a write through it",
+			entries: []archiveEntry{
+				{name: "evil", symlink: outside},
+				{name: "evil/pwned.txt", contents: "pwned"},
+			},
+		},
+		{
+			name: "relative symlink escaping the archive",
+			entries: []archiveEntry{
+				{name: "project/evil", symlink: "../../../../../../../../.." + outside},
+				{name: "project/evil/pwned.txt", contents: "pwned"},
+			},
+		},
+		{
+			name: "chained symlinks escaping the archive",
+			entries: []archiveEntry{
+				{name: "a", symlink: "b/c"},
+				{name: "b", symlink: "."},
+				{name: "c", symlink: outside},
+				{name: "a/pwned.txt", contents: "pwned"},
+			},
+		},
+		{
+			name: "hardlink to a host file",
+			entries: []archiveEntry{
+				{name: "passwd", hardlink: "../../../../../../etc/passwd"},
+			},
+		},
+		{
+			name: "zip symlink followed by a write through it",
+			zip:  true,
+			entries: []archiveEntry{
+				{name: "evil", symlink: outside},
+				{name: "evil/pwned.txt", contents: "pwned"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "project.tar")
+			if test.zip {
+				archivePath = filepath.Join(t.TempDir(), "project.zip")
+				writeZipFixture(t, archivePath, test.entries)
+			} else {
+				writeTarFixture(t, archivePath, test.entries)
+			}
+
+			_, cleanup, err := NewFromArchive(archivePath)
+			cleanup()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "points outside of the archive")
+
+			_, err = os.Stat(filepath.Join(outside, "pwned.txt"))
+			assert.True(t, os.IsNotExist(err), "a file was written outside of the extraction dir")
+		})
+	}
+}
+
+func TestNewFromArchive_symlinksWithinArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "project.tar")
+	writeTarFixture(t, archivePath, []archiveEntry{
+		{name: "project/releases/v1/requirements.txt", contents: "requests==2.26.0\n"},
+		{name: "project/current", symlink: "releases/v1"},
+		{name: "project/link.txt", hardlink: "project/releases/v1/requirements.txt"},
+	})
+
+	src, cleanup, err := NewFromArchive(archivePath)
+	t.Cleanup(cleanup)
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+	locations, err := resolver.FilesByPath("project/current/requirements.txt")
+	require.NoError(t, err)
+	assert.Len(t, locations, 1)
+}
+
+func TestNewFromArchive_rootfsAbsoluteSymlinks(t *testing.T) {
+	// root filesystem archives (e.g. the alpine minirootfs) link most tools to busybox with absolute targets
+	archivePath := filepath.Join(t.TempDir(), "rootfs.tar")
+	writeTarFixture(t, archivePath, []archiveEntry{
+		{name: "bin/busybox", contents: "busybox"},
+		{name: "bin/sh", symlink: "/bin/busybox"},
+		{name: "sbin/init", symlink: "/bin/busybox"},
+		{name: "usr/bin/env", symlink: "/bin/busybox"},
+		{name: "etc/os-release", contents: "ID=alpine\nVERSION_ID=3.15.0\n"},
+		{name: "lib/apk/db/installed", contents: "P:busybox\nV:1.34.1-r3\n\n"},
+		// a dangling link (the target only exists at runtime)
+		{name: "var/run", symlink: "/run"},
+	})
+
+	src, cleanup, err := NewFromArchive(archivePath)
+	t.Cleanup(cleanup)
+	require.NoError(t, err)
+
+	// the absolute links are rewritten to point within the extracted archive (never to host files)
+	for _, link := range []string{"bin/sh", "sbin/init", "usr/bin/env", "var/run"} {
+		linkPath := filepath.Join(src.path, link)
+		target, err := os.Readlink(linkPath)
+		require.NoError(t, err)
+		assert.False(t, filepath.IsAbs(target), "link=%q is absolute: %q", link, target)
+		rel, err := filepath.Rel(src.path, filepath.Join(filepath.Dir(linkPath), target))
+		require.NoError(t, err)
+		assert.False(t, strings.HasPrefix(rel, ".."), "link=%q points outside of the archive: %q", link, target)
+	}
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+	locations, err := resolver.FilesByPath("/usr/bin/env")
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	reader, err := resolver.FileContentsByLocation(locations[0])
+	require.NoError(t, err)
+	contents, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "busybox", string(contents))
+
+	// archives given as a file source are extracted in the same way (rather than cataloging the archive file itself)
+	fileSrc, fileCleanup := NewFromFile(archivePath)
+	t.Cleanup(fileCleanup)
+	assert.NotEqual(t, archivePath, fileSrc.path)
+}
+
+func Test_resolveArchivePath(t *testing.T) {
+	symlinks := map[string]string{
+		"current":      "releases/v1",
+		"releases/v1":  "../releases/v2",
+		"up":           "..",
+		"loop":         "loop",
+		"nested/outer": "../current",
+		"bin/sh":       "/bin/busybox",
+		"lib64":        "/lib",
+		"root":         "/",
+	}
+
+	tests := []struct {
+		path                string
+		expected            bool
+		expectedViaAbsolute bool
+	}{
+		{path: "plain/file.txt", expected: true},
+		{path: "current/file.txt", expected: true},
+		{path: "nested/outer/file.txt", expected: true},
+		{path: "up/file.txt", expected: false},
+		{path: "../file.txt", expected: false},
+		{path: "loop/file.txt", expected: false},
+		// absolute targets are relative to the root of the archive
+		{path: "bin/sh", expected: true, expectedViaAbsolute: true},
+		{path: "lib64/libc.so", expected: true, expectedViaAbsolute: true},
+		{path: "root/etc/passwd", expected: true, expectedViaAbsolute: true},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			viaAbsolute, ok := resolveArchivePath(symlinks, test.path)
+			assert.Equal(t, test.expected, ok)
+			if ok {
+				assert.Equal(t, test.expectedViaAbsolute, viaAbsolute)
+			}
+		})
+	}
+}
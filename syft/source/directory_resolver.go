@@ -121,6 +121,10 @@ func (r *directoryResolver) indexPath(path string, info os.FileInfo, err error)
 	// ignore any path which a filter function returns true
 	for _, filterFn := range r.pathFilterFns {
 		if filterFn(path) {
+			if info != nil && info.IsDir() {
+				// there is no need to descend into a filtered directory
+				return "", filepath.SkipDir
+			}
 			return "", nil
 		}
 	}
@@ -280,9 +284,15 @@ func (r directoryResolver) FilesByPath(userPaths ...string) ([]Location, error)
 			continue
 		}
 
-		exists, ref, err := r.fileTree.File(file.Path(userStrPath))
+		// the path may be a symlink (or have a symlink as an ancestor), in which case the resolved file is returned and
+		// the requested path is kept as the virtual path
+		exists, ref, err := r.fileTree.File(file.Path(userStrPath), filetree.FollowBasenameLinks)
 		if err == nil && exists {
-			references = append(references, NewLocationFromDirectory(r.responsePath(userStrPath), *ref))
+			if string(ref.RealPath) != userStrPath {
+				references = append(references, NewVirtualLocationFromDirectory(r.responsePath(string(ref.RealPath)), r.responsePath(userStrPath), *ref))
+			} else {
+				references = append(references, NewLocationFromDirectory(r.responsePath(userStrPath), *ref))
+			}
 		} else {
 			log.Warnf("path (%s) not found in file tree: Exists: %t Err:%+v", userStrPath, exists, err)
 		}
@@ -301,6 +311,12 @@ func (r directoryResolver) FilesByGlob(patterns ...string) ([]Location, error) {
 			return nil, err
 		}
 		for _, globResult := range globResults {
+			// the match may be through a symlink, in which case the resolved file is returned and the matched path is kept
+			// as the virtual path
+			if globResult.Reference.RealPath != globResult.MatchPath {
+				result = append(result, NewVirtualLocationFromDirectory(r.responsePath(string(globResult.Reference.RealPath)), r.responsePath(string(globResult.MatchPath)), globResult.Reference))
+				continue
+			}
 			result = append(result, NewLocationFromDirectory(r.responsePath(string(globResult.MatchPath)), globResult.Reference))
 		}
 	}
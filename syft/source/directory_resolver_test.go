@@ -186,6 +186,26 @@ func TestDirectoryResolver_MultipleFilesByPath(t *testing.T) {
 	}
 }
 
+func TestDirectoryResolver_FilesByPath_symlink(t *testing.T) {
+	resolver, err := newDirectoryResolver("./test-fixtures/symlinked-config")
+	require.NoError(t, err)
+
+	refs, err := resolver.FilesByPath("/app.conf")
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+
+	assert.Equal(t, "test-fixtures/symlinked-config/etc/app/config.yaml", refs[0].RealPath)
+	assert.Equal(t, "test-fixtures/symlinked-config/app.conf", refs[0].VirtualPath)
+
+	// regular files are not given a virtual path
+	refs, err = resolver.FilesByPath("/etc/app/config.yaml")
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+
+	assert.Equal(t, "test-fixtures/symlinked-config/etc/app/config.yaml", refs[0].RealPath)
+	assert.Empty(t, refs[0].VirtualPath)
+}
+
 func TestDirectoryResolver_FilesByGlobMultiple(t *testing.T) {
 	resolver, err := newDirectoryResolver("./test-fixtures")
 	assert.NoError(t, err)
@@ -0,0 +1,52 @@
+package source
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v2"
+)
+
+// getDirectoryExclusionFunctions returns path filter functions for the given glob patterns, which are matched against
+// paths relative to the given root (e.g. "**/node_modules" or "./vendor"). Since these are path filters, a pattern that
+// matches a directory prunes the entire subtree from the index.
+func getDirectoryExclusionFunctions(root string, exclusions []string) ([]pathFilterFn, error) {
+	if len(exclusions) == 0 {
+		return nil, nil
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve root=%q for exclusions: %w", root, err)
+	}
+
+	var patterns []string
+	for _, exclusion := range exclusions {
+		pattern := strings.TrimPrefix(filepath.ToSlash(exclusion), "./")
+		// note: doublestar only reports malformed patterns when matching reaches the malformed portion
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclusion pattern=%q: %w", exclusion, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return []pathFilterFn{
+		func(p string) bool {
+			relPath, err := filepath.Rel(root, p)
+			if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+				// only paths within the root are subject to exclusion
+				return false
+			}
+			relPath = filepath.ToSlash(relPath)
+
+			for _, pattern := range patterns {
+				if matches, _ := doublestar.Match(pattern, relPath); matches {
+					return true
+				}
+			}
+			return false
+		},
+	}, nil
+}
@@ -0,0 +1,122 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_DirectoryExclusions(t *testing.T) {
+	tests := []struct {
+		name       string
+		exclusions []string
+		expected   []string
+	}{
+		{
+			name: "no exclusions",
+			expected: []string{
+				"README.md",
+				"app/node_modules/some-dep/index.js",
+				"app/node_modules/some-dep/package.json",
+				"app/src/main.js",
+				"docs/README.md",
+				"vendor/lib/lib.txt",
+			},
+		},
+		{
+			name:       "exclude a subtree at any depth",
+			exclusions: []string{"**/node_modules"},
+			expected: []string{
+				"README.md",
+				"app/src/main.js",
+				"docs/README.md",
+				"vendor/lib/lib.txt",
+			},
+		},
+		{
+			name:       "exclude a subtree relative to the root",
+			exclusions: []string{"./vendor"},
+			expected: []string{
+				"README.md",
+				"app/node_modules/some-dep/index.js",
+				"app/node_modules/some-dep/package.json",
+				"app/src/main.js",
+				"docs/README.md",
+			},
+		},
+		{
+			name:       "exclude files at any depth",
+			exclusions: []string{"**/*.md", "**/*.json"},
+			expected: []string{
+				"app/node_modules/some-dep/index.js",
+				"app/src/main.js",
+				"vendor/lib/lib.txt",
+			},
+		},
+		{
+			name:       "multiple exclusions",
+			exclusions: []string{"app/node_modules", "vendor/**", "docs"},
+			expected: []string{
+				"README.md",
+				"app/src/main.js",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := "test-fixtures/exclusions"
+			src, cleanup, err := NewWithOptions("dir:"+root, Options{Exclusions: test.exclusions})
+			t.Cleanup(cleanup)
+			require.NoError(t, err)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			locations, err := resolver.FilesByGlob("**/*")
+			require.NoError(t, err)
+
+			var actual []string
+			for _, l := range locations {
+				info, err := os.Stat(l.RealPath)
+				require.NoError(t, err)
+				if info.IsDir() {
+					continue
+				}
+				relPath, err := filepath.Rel(root, l.RealPath)
+				require.NoError(t, err)
+				actual = append(actual, filepath.ToSlash(relPath))
+			}
+
+			assert.ElementsMatch(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNew_DirectoryExclusionsPruneSubtree(t *testing.T) {
+	root := "test-fixtures/exclusions"
+	src, cleanup, err := NewWithOptions("dir:"+root, Options{Exclusions: []string{"**/node_modules"}})
+	t.Cleanup(cleanup)
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+
+	absRoot, err := filepath.Abs(root)
+	require.NoError(t, err)
+
+	// the excluded directory should never have been indexed (nor anything beneath it)
+	dirResolver := resolver.(*directoryResolver)
+	assert.False(t, dirResolver.fileTree.HasPath(file.Path(filepath.Join(absRoot, "app/node_modules"))))
+	assert.False(t, dirResolver.fileTree.HasPath(file.Path(filepath.Join(absRoot, "app/node_modules/some-dep"))))
+	assert.True(t, dirResolver.fileTree.HasPath(file.Path(filepath.Join(absRoot, "app/src/main.js"))))
+}
+
+func TestNew_InvalidDirectoryExclusion(t *testing.T) {
+	_, cleanup, err := NewWithOptions("dir:test-fixtures/exclusions", Options{Exclusions: []string{"[bad-pattern"}})
+	t.Cleanup(cleanup)
+	assert.Error(t, err)
+}
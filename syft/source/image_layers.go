@@ -0,0 +1,30 @@
+package source
+
+import "github.com/anchore/stereoscope/pkg/file"
+
+// IntroducingLayer returns the metadata for the lowest image layer that contains any of the given paths (that is, the
+// layer where the paths first appear). Directories are ignored, since a directory (e.g. /usr/bin) is shared by any
+// number of layers. Returns false if the source is not an image or no layer contains the paths.
+func (s Source) IntroducingLayer(paths ...string) (LayerMetadata, bool) {
+	if s.Image == nil {
+		return LayerMetadata{}, false
+	}
+
+	for idx, layer := range s.Image.Layers {
+		if layer.Tree == nil || idx >= len(s.Metadata.ImageMetadata.Layers) {
+			continue
+		}
+		for _, p := range paths {
+			exists, ref, err := layer.Tree.File(file.Path(p))
+			if err != nil || !exists || ref == nil {
+				continue
+			}
+			if entry, err := s.Image.FileCatalog.Get(*ref); err == nil && entry.Metadata.IsDir {
+				continue
+			}
+			return s.Metadata.ImageMetadata.Layers[idx], true
+		}
+	}
+
+	return LayerMetadata{}, false
+}
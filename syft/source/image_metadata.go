@@ -1,6 +1,9 @@
 package source
 
-import "github.com/anchore/stereoscope/pkg/image"
+import (
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/syft/artifact"
+)
 
 // ImageMetadata represents all static metadata that defines what a container image is. This is useful to later describe
 // "what" was cataloged without needing the more complicated stereoscope Image objects or FileResolver objects.
@@ -15,6 +18,9 @@ type ImageMetadata struct {
 	RawManifest    []byte          `json:"manifest"`
 	RawConfig      []byte          `json:"config"`
 	RepoDigests    []string        `json:"repoDigests"`
+	Architecture   string          `json:"architecture"`
+	Variant        string          `json:"architectureVariant,omitempty"`
+	OS             string          `json:"os"`
 }
 
 // LayerMetadata represents all static metadata that defines what a container image layer is.
@@ -24,6 +30,11 @@ type LayerMetadata struct {
 	Size      int64  `json:"size"`
 }
 
+// ID returns the layer digest, which uniquely identifies the layer within an image.
+func (l LayerMetadata) ID() artifact.ID {
+	return artifact.ID(l.Digest)
+}
+
 // NewImageMetadata creates a new ImageMetadata object populated from the given stereoscope Image object and user configuration.
 func NewImageMetadata(img *image.Image, userInput string) ImageMetadata {
 	// populate artifacts...
@@ -42,6 +53,9 @@ func NewImageMetadata(img *image.Image, userInput string) ImageMetadata {
 		RawConfig:      img.Metadata.RawConfig,
 		RawManifest:    img.Metadata.RawManifest,
 		RepoDigests:    img.Metadata.RepoDigests,
+		Architecture:   img.Metadata.Config.Architecture,
+		Variant:        configVariant(img.Metadata.RawConfig),
+		OS:             img.Metadata.Config.OS,
 	}
 
 	// populate image metadata
@@ -80,6 +80,48 @@ func NewLocationFromDirectory(responsePath string, ref file.Reference) Location
 	}
 }
 
+// NewVirtualLocationFromDirectory creates a new Location representing the given path (extracted from the ref) relative to the given directory, accessed by a virtual path (a path with a symlink somewhere in the path).
+func NewVirtualLocationFromDirectory(responsePath, virtualResponsePath string, ref file.Reference) Location {
+	return Location{
+		Coordinates: Coordinates{
+			RealPath: responsePath,
+		},
+		VirtualPath: virtualResponsePath,
+		ref:         ref,
+	}
+}
+
+// IsCanonical indicates that the location was not reached through a symlink (or hardlink), where the file is found at
+// the given real path without an alias.
+func (l Location) IsCanonical() bool {
+	return l.VirtualPath == "" || l.VirtualPath == l.RealPath
+}
+
+// CanonicalLocations returns the given locations without any aliases of the same file (e.g. the same lockfile reached
+// both directly and through a symlinked directory), in the order that each file was first given. Of all locations for
+// the same file, the canonical location is kept, otherwise the location with the first virtual path (when sorted).
+func CanonicalLocations(locations []Location) []Location {
+	var results []Location
+	indexes := make(map[Coordinates]int)
+	for _, l := range locations {
+		idx, exists := indexes[l.Coordinates]
+		if !exists {
+			indexes[l.Coordinates] = len(results)
+			results = append(results, l)
+			continue
+		}
+
+		existing := results[idx]
+		switch {
+		case existing.IsCanonical():
+			continue
+		case l.IsCanonical() || l.VirtualPath < existing.VirtualPath:
+			results[idx] = l
+		}
+	}
+	return results
+}
+
 func (l Location) String() string {
 	str := ""
 	if l.ref.ID() != 0 {
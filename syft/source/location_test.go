@@ -0,0 +1,51 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalLocations(t *testing.T) {
+	direct := NewLocation("/projects/app/Gemfile.lock")
+	viaCurrent := NewVirtualLocation("/projects/app/Gemfile.lock", "/current/Gemfile.lock")
+	viaLatest := NewVirtualLocation("/projects/app/Gemfile.lock", "/latest/Gemfile.lock")
+	other := NewVirtualLocation("/projects/other/Gemfile.lock", "/other/Gemfile.lock")
+	otherLayer := Location{Coordinates: Coordinates{RealPath: "/projects/app/Gemfile.lock", FileSystemID: "sha256:upper"}}
+
+	tests := []struct {
+		name      string
+		locations []Location
+		expected  []Location
+	}{
+		{
+			name: "no locations",
+		},
+		{
+			name:      "no aliases",
+			locations: []Location{direct, other},
+			expected:  []Location{direct, other},
+		},
+		{
+			name:      "canonical location is kept",
+			locations: []Location{viaCurrent, direct, viaLatest},
+			expected:  []Location{direct},
+		},
+		{
+			name:      "first virtual path is kept without a canonical location",
+			locations: []Location{viaLatest, other, viaCurrent},
+			expected:  []Location{viaCurrent, other},
+		},
+		{
+			name:      "the same path within different layers is not an alias",
+			locations: []Location{direct, otherLayer},
+			expected:  []Location{direct, otherLayer},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CanonicalLocations(test.locations))
+		})
+	}
+}
@@ -1,8 +1,26 @@
 package source
 
+import (
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+)
+
 // Metadata represents any static source data that helps describe "what" was cataloged.
 type Metadata struct {
 	Scheme        Scheme        // the source data scheme type (directory or image)
 	ImageMetadata ImageMetadata // all image info (image only)
 	Path          string        // the root path to be cataloged (directory only)
+	GitMetadata   GitMetadata   // the cloned repository info, where Path is the repository URL (git only)
+	SifMetadata   SifMetadata   // the Singularity image info, where Path is the SIF file path (sif only)
+}
+
+func (m Metadata) ID() artifact.ID {
+	f, err := artifact.IDFromHash(m)
+	if err != nil {
+		// TODO: what to do in this case?
+		log.Warnf("unable to get fingerprint of source metadata=%+v: %+v", m, err)
+		return ""
+	}
+
+	return f
 }
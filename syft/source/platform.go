@@ -0,0 +1,89 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Platform describes the OS and CPU architecture (and optional architecture variant) that an image was built for,
+// which is used to select a single image from a multi-arch image (manifest list).
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+// ParsePlatform parses the given platform in the form "os/arch[/variant]" (e.g. "linux/arm64" or "linux/arm/v7").
+func ParsePlatform(platform string) (*Platform, error) {
+	fields := strings.Split(strings.TrimSpace(platform), "/")
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("bad platform %q: must be in the form os/arch[/variant] (e.g. linux/arm64)", platform)
+	}
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			return nil, fmt.Errorf("bad platform %q: must be in the form os/arch[/variant] (e.g. linux/arm64)", platform)
+		}
+	}
+
+	p := Platform{
+		OS:           fields[0],
+		Architecture: fields[1],
+	}
+	if len(fields) == 3 {
+		p.Variant = fields[2]
+	}
+	return &p, nil
+}
+
+func (p Platform) String() string {
+	if p.Variant == "" {
+		return fmt.Sprintf("%s/%s", p.OS, p.Architecture)
+	}
+	return fmt.Sprintf("%s/%s/%s", p.OS, p.Architecture, p.Variant)
+}
+
+// matches indicates if an image built for the given platform satisfies this (requested) platform. The variant is only
+// compared when recorded by both, since many images do not record a variant at all.
+func (p Platform) matches(other Platform) bool {
+	if p.OS != other.OS || p.Architecture != other.Architecture {
+		return false
+	}
+	return p.Variant == "" || other.Variant == "" || p.Variant == other.Variant
+}
+
+// imagePlatform returns the platform that the image with the given metadata was built for.
+func imagePlatform(metadata ImageMetadata) Platform {
+	return Platform{
+		OS:           metadata.OS,
+		Architecture: metadata.Architecture,
+		Variant:      metadata.Variant,
+	}
+}
+
+// checkImagePlatform ensures that the image with the given metadata was built for the requested platform (if any).
+// This is necessary since the platform can only be used to select the image of a multi-arch image that is pulled
+// directly from a registry (e.g. an image already present within the docker daemon is used as-is).
+func checkImagePlatform(metadata ImageMetadata, platform *Platform) error {
+	if platform == nil {
+		return nil
+	}
+	if actual := imagePlatform(metadata); !platform.matches(actual) {
+		return fmt.Errorf("image platform %q does not match the requested platform %q", actual, platform)
+	}
+	return nil
+}
+
+// configVariant returns the architecture variant recorded within the given raw image config (if any).
+func configVariant(rawConfig []byte) string {
+	if len(rawConfig) == 0 {
+		return ""
+	}
+	var config struct {
+		Variant string `json:"variant"`
+	}
+	if err := json.Unmarshal(rawConfig, &config); err != nil {
+		return ""
+	}
+	return config.Variant
+}
@@ -0,0 +1,122 @@
+package source
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Platform
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			input:    "linux/amd64",
+			expected: &Platform{OS: "linux", Architecture: "amd64"},
+		},
+		{
+			input:    "linux/arm/v7",
+			expected: &Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+		},
+		{
+			input:    " linux/arm64 ",
+			expected: &Platform{OS: "linux", Architecture: "arm64"},
+		},
+		{
+			input:   "linux",
+			wantErr: require.Error,
+		},
+		{
+			input:   "linux/",
+			wantErr: require.Error,
+		},
+		{
+			input:   "/amd64",
+			wantErr: require.Error,
+		},
+		{
+			input:   "linux/arm/v7/extra",
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			actual, err := ParsePlatform(test.input)
+			test.wantErr(t, err)
+			assert.Equal(t, test.expected, actual)
+			if actual != nil {
+				assert.Equal(t, strings.TrimSpace(test.input), actual.String())
+			}
+		})
+	}
+}
+
+func Test_checkImagePlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata ImageMetadata
+		platform *Platform
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:     "no platform requested",
+			metadata: ImageMetadata{OS: "linux", Architecture: "arm64"},
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "matches",
+			metadata: ImageMetadata{OS: "linux", Architecture: "arm64"},
+			platform: &Platform{OS: "linux", Architecture: "arm64"},
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "variant not recorded by the image",
+			metadata: ImageMetadata{OS: "linux", Architecture: "arm"},
+			platform: &Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "variant not requested",
+			metadata: ImageMetadata{OS: "linux", Architecture: "arm", Variant: "v6"},
+			platform: &Platform{OS: "linux", Architecture: "arm"},
+			wantErr:  require.NoError,
+		},
+		{
+			name:     "different variant",
+			metadata: ImageMetadata{OS: "linux", Architecture: "arm", Variant: "v6"},
+			platform: &Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			wantErr:  require.Error,
+		},
+		{
+			name:     "different architecture",
+			metadata: ImageMetadata{OS: "linux", Architecture: "amd64"},
+			platform: &Platform{OS: "linux", Architecture: "arm64"},
+			wantErr:  require.Error,
+		},
+		{
+			name:     "different os",
+			metadata: ImageMetadata{OS: "windows", Architecture: "amd64"},
+			platform: &Platform{OS: "linux", Architecture: "amd64"},
+			wantErr:  require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.wantErr(t, checkImagePlatform(test.metadata, test.platform))
+		})
+	}
+}
+
+func Test_configVariant(t *testing.T) {
+	assert.Equal(t, "v7", configVariant([]byte(`{"architecture": "arm", "os": "linux", "variant": "v7"}`)))
+	assert.Equal(t, "", configVariant([]byte(`{"architecture": "amd64", "os": "linux"}`)))
+	assert.Equal(t, "", configVariant(nil))
+	assert.Equal(t, "", configVariant([]byte(`not json`)))
+}
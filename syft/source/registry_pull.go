@@ -0,0 +1,200 @@
+package source
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/log"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// defaultPullBackoff is the delay before the first retry of a failed registry pull, when not otherwise configured.
+const defaultPullBackoff = time.Second
+
+// RegistryPullOptions describes how images are pulled directly from a registry.
+type RegistryPullOptions struct {
+	// Timeout is the maximum duration of each pull attempt (zero indicates no timeout).
+	Timeout time.Duration
+	// Retries is the number of times a failed (or timed out) pull is retried.
+	Retries int
+	// Backoff is the delay before the first retry, which is doubled before every subsequent retry.
+	Backoff time.Duration
+	// Platform selects the image to pull from a multi-arch image (manifest list), where nil indicates the registry
+	// default (typically linux/amd64). Images from all other sources must already match the platform.
+	Platform *Platform
+}
+
+// platform returns the requested platform (if any), where the options may be nil.
+func (o *RegistryPullOptions) platform() *Platform {
+	if o == nil {
+		return nil
+	}
+	return o.Platform
+}
+
+// pullRegistryImage pulls the given image from a registry, retrying failed attempts with an exponential backoff. Any
+// partially pulled image content is removed after each failed attempt. The returned cleanup function removes the
+// pulled image content.
+func pullRegistryImage(imgStr string, registryOptions *image.RegistryOptions, pullOptions *RegistryPullOptions) (*image.Image, func(), error) {
+	var opts RegistryPullOptions
+	if pullOptions != nil {
+		opts = *pullOptions
+	}
+
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = defaultPullBackoff
+	}
+
+	attempts := opts.Retries + 1
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var img *image.Image
+		var cleanup func()
+		img, cleanup, err = pullRegistryImageAttempt(imgStr, registryOptions, opts.Platform, opts.Timeout)
+		if err == nil {
+			return img, cleanup, nil
+		}
+
+		if attempt < attempts {
+			log.Warnf("unable to pull image=%q (attempt %d of %d), retrying in %s: %+v", imgStr, attempt, attempts, backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return nil, func() {}, fmt.Errorf("unable to pull image=%q after %d attempt(s): %w", imgStr, attempts, err)
+}
+
+// pullRegistryImageAttempt makes a single attempt to pull (and read) the given image from a registry, giving up after
+// the given timeout (if any), at which point all in-flight registry requests are cancelled. When a platform is given,
+// the matching image is selected from a multi-arch image. All image content is written to a dedicated temp dir, which
+// is removed if the attempt fails, otherwise it is removed by the returned cleanup function.
+func pullRegistryImageAttempt(imgStr string, registryOptions *image.RegistryOptions, platform *Platform, timeout time.Duration) (*image.Image, func(), error) {
+	tempDirGenerator := file.NewTempDirGenerator()
+	cleanup := func() {
+		if err := tempDirGenerator.Cleanup(); err != nil {
+			log.Errorf("unable to cleanup image pull for image=%q: %+v", imgStr, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	defer cancel()
+
+	img, err := provideRegistryImage(ctx, imgStr, &tempDirGenerator, registryOptions, platform)
+	if err == nil {
+		if err = img.Read(); err != nil {
+			err = fmt.Errorf("could not read image: %w", err)
+		}
+	}
+
+	if err != nil {
+		cleanup()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, func() {}, fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+		return nil, func() {}, err
+	}
+	return img, cleanup, nil
+}
+
+// provideRegistryImage fetches the given image from a registry, selecting the image for the given platform (if any)
+// from a multi-arch image. This is the same as the stereoscope registry provider, however, the stereoscope provider
+// can neither select a platform nor be cancelled, so the image is fetched with the platform and the given context as
+// remote options (where the context applies to all requests, including the layer downloads while reading the image).
+func provideRegistryImage(ctx context.Context, imgStr string, tempDirGenerator *file.TempDirGenerator, registryOptions *image.RegistryOptions, platform *Platform) (*image.Image, error) {
+	if registryOptions == nil {
+		registryOptions = &image.RegistryOptions{}
+	}
+
+	if platform != nil {
+		log.Debugf("pulling image info directly from registry image=%q platform=%q", imgStr, platform)
+	} else {
+		log.Debugf("pulling image info directly from registry image=%q", imgStr)
+	}
+
+	imageTempDir, err := tempDirGenerator.NewTempDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var refOptions []name.Option
+	if registryOptions.InsecureUseHTTP {
+		refOptions = append(refOptions, name.Insecure)
+	}
+
+	ref, err := name.ParseReference(imgStr, refOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse registry reference=%q: %w", imgStr, err)
+	}
+
+	descriptor, err := remote.Get(ref, registryRemoteOptions(ctx, ref, registryOptions, platform)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image descriptor from registry: %w", err)
+	}
+
+	// selects the image for the platform when the descriptor is a manifest list
+	img, err := descriptor.Image()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image for platform=%q from registry: %w", platform, err)
+	}
+
+	// note: the repo digest refers to the manifest list (when given), which is what the user would pull
+	repoDigest := fmt.Sprintf("%s/%s@%s", ref.Context().RegistryStr(), ref.Context().RepositoryStr(), descriptor.Digest.String())
+
+	metadata := []image.AdditionalMetadata{
+		image.WithRepoDigests([]string{repoDigest}),
+	}
+
+	// make a best effort to get the manifest, should not block getting an image though if it fails
+	if manifestBytes, err := img.RawManifest(); err == nil {
+		metadata = append(metadata, image.WithManifest(manifestBytes))
+	}
+
+	return image.NewImage(img, imageTempDir, metadata...), nil
+}
+
+// registryRemoteOptions returns the options to fetch an image with the given reference for the given platform (if any)
+// within the given context (as configured by the stereoscope registry provider).
+func registryRemoteOptions(ctx context.Context, ref name.Reference, registryOptions *image.RegistryOptions, platform *Platform) []remote.Option {
+	opts := []remote.Option{
+		remote.WithContext(ctx),
+	}
+
+	if platform != nil {
+		opts = append(opts, remote.WithPlatform(v1.Platform{
+			OS:           platform.OS,
+			Architecture: platform.Architecture,
+			Variant:      platform.Variant,
+		}))
+	}
+
+	if registryOptions.InsecureSkipTLSVerify {
+		t := &http.Transport{
+			// nolint: gosec
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+		opts = append(opts, remote.WithTransport(t))
+	}
+
+	// note: the authenticator and keychain options are mutually exclusive, where the keychain is the fallback
+	if authenticator := registryOptions.Authenticator(ref.Context().RegistryStr()); authenticator != nil {
+		opts = append(opts, remote.WithAuth(authenticator))
+	} else {
+		opts = append(opts, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+
+	return opts
+}
@@ -0,0 +1,332 @@
+package source
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/registrytest"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyRegistry is a stub registry middleware that fails (or hangs on, until the client gives up) the first N manifest
+// requests before serving them.
+type flakyRegistry struct {
+	next     http.Handler
+	failures int
+	hang     chan struct{}
+	lock     sync.Mutex
+	requests int
+	hanging  int
+}
+
+func (r *flakyRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/manifests/") {
+		r.lock.Lock()
+		r.requests++
+		fail := r.requests <= r.failures
+		r.lock.Unlock()
+
+		if fail {
+			if r.hang != nil {
+				r.lock.Lock()
+				r.hanging++
+				r.lock.Unlock()
+				defer func() {
+					r.lock.Lock()
+					r.hanging--
+					r.lock.Unlock()
+				}()
+
+				select {
+				case <-r.hang:
+				case <-req.Context().Done():
+					// the client gave up on the request
+					return
+				}
+			}
+			http.Error(w, "registry unavailable", http.StatusInternalServerError)
+			return
+		}
+	}
+	r.next.ServeHTTP(w, req)
+}
+
+func (r *flakyRegistry) manifestRequests() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests
+}
+
+func (r *flakyRegistry) hangingRequests() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.hanging
+}
+
+// newFlakyRegistry starts a stub registry serving a single random image, returning the registry and image reference.
+func newFlakyRegistry(t *testing.T, failures int, hang chan struct{}) (*flakyRegistry, string) {
+	stub := &flakyRegistry{
+		failures: failures,
+		hang:     hang,
+	}
+	server := registrytest.New(t, func(next http.Handler) http.Handler {
+		stub.next = next
+		return stub
+	})
+	return stub, server.Image
+}
+
+// withTempDir redirects all temp dirs (e.g. for pulled image content) to a dedicated directory for the given test.
+func withTempDir(t *testing.T) string {
+	dir := t.TempDir()
+	registrytest.Setenv(t, "TMPDIR", dir)
+	return dir
+}
+
+func assertNoPulledContent(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasPrefix(e.Name(), "stereoscope-cache"), "partial pull was not cleaned up: %s", e.Name())
+	}
+}
+
+func Test_pullRegistryImage_retries(t *testing.T) {
+	registryOptions := &image.RegistryOptions{InsecureUseHTTP: true}
+
+	tests := []struct {
+		name             string
+		failures         int
+		retries          int
+		expectedRequests int
+		wantErr          require.ErrorAssertionFunc
+	}{
+		{
+			name:             "no failures",
+			failures:         0,
+			retries:          2,
+			expectedRequests: 1,
+			wantErr:          require.NoError,
+		},
+		{
+			name:             "succeeds on the last retry",
+			failures:         2,
+			retries:          2,
+			expectedRequests: 3,
+			wantErr:          require.NoError,
+		},
+		{
+			name:             "retries exhausted",
+			failures:         3,
+			retries:          2,
+			expectedRequests: 3,
+			wantErr:          require.Error,
+		},
+		{
+			name:             "no retries",
+			failures:         1,
+			retries:          0,
+			expectedRequests: 1,
+			wantErr:          require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir := withTempDir(t)
+			stub, imgStr := newFlakyRegistry(t, test.failures, nil)
+
+			img, cleanup, err := pullRegistryImage(imgStr, registryOptions, &RegistryPullOptions{
+				Retries: test.retries,
+				Backoff: time.Millisecond,
+			})
+			test.wantErr(t, err)
+			assert.Equal(t, test.expectedRequests, stub.manifestRequests())
+
+			if err != nil {
+				assert.Nil(t, img)
+				assert.Contains(t, err.Error(), fmt.Sprintf("after %d attempt(s)", test.retries+1))
+			} else {
+				require.NotNil(t, img)
+				assert.NotEmpty(t, img.Layers)
+			}
+
+			cleanup()
+			assertNoPulledContent(t, tempDir)
+		})
+	}
+}
+
+func Test_pullRegistryImage_timeout(t *testing.T) {
+	tempDir := withTempDir(t)
+
+	hang := make(chan struct{})
+	stub, imgStr := newFlakyRegistry(t, 2, hang)
+	// release any requests that are still hanging before the registry is shut down
+	t.Cleanup(func() {
+		close(hang)
+	})
+
+	start := time.Now()
+	_, cleanup, err := pullRegistryImage(imgStr, &image.RegistryOptions{InsecureUseHTTP: true}, &RegistryPullOptions{
+		Timeout: 50 * time.Millisecond,
+		Retries: 1,
+		Backoff: time.Millisecond,
+	})
+	elapsed := time.Since(start)
+	cleanup()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Less(t, int64(elapsed), int64(5*time.Second), "the pull should not block on a hanging registry")
+	assert.Equal(t, 2, stub.manifestRequests())
+
+	// the timed out attempts are cancelled (not abandoned while still pulling), so nothing is left behind
+	assertNoPulledContent(t, tempDir)
+	assert.Eventually(t, func() bool {
+		return stub.hangingRequests() == 0
+	}, 5*time.Second, 10*time.Millisecond, "the timed out requests were not cancelled")
+}
+
+// recordingRegistry is a stub registry middleware that records the path of every manifest request.
+type recordingRegistry struct {
+	next      http.Handler
+	lock      sync.Mutex
+	manifests []string
+}
+
+func (r *recordingRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/manifests/") {
+		r.lock.Lock()
+		r.manifests = append(r.manifests, req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:])
+		r.lock.Unlock()
+	}
+	r.next.ServeHTTP(w, req)
+}
+
+func (r *recordingRegistry) manifestRequests() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.manifests...)
+}
+
+// newMultiArchRegistry starts a stub registry serving a manifest list with a random image for each of the given
+// platforms, returning the registry, the image reference, and the manifest digest of the image for each platform.
+func newMultiArchRegistry(t *testing.T, platforms ...v1.Platform) (*recordingRegistry, string, map[string]v1.Hash) {
+	digests := make(map[string]v1.Hash)
+	var index v1.ImageIndex = empty.Index
+	for _, p := range platforms {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+
+		cfg, err := img.ConfigFile()
+		require.NoError(t, err)
+		cfg = cfg.DeepCopy()
+		cfg.OS = p.OS
+		cfg.Architecture = p.Architecture
+		img, err = mutate.ConfigFile(img, cfg)
+		require.NoError(t, err)
+
+		digest, err := img.Digest()
+		require.NoError(t, err)
+		digests[Platform{OS: p.OS, Architecture: p.Architecture, Variant: p.Variant}.String()] = digest
+
+		platform := p
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				Platform: &platform,
+			},
+		})
+	}
+
+	stub := &recordingRegistry{}
+	server := registrytest.NewWithIndex(t, index, func(next http.Handler) http.Handler {
+		stub.next = next
+		return stub
+	})
+	return stub, server.Image, digests
+}
+
+func Test_pullRegistryImage_platform(t *testing.T) {
+	platforms := []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+		{OS: "linux", Architecture: "arm", Variant: "v7"},
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+	}{
+		{
+			name:     "arm64",
+			platform: "linux/arm64",
+		},
+		{
+			name:     "with variant",
+			platform: "linux/arm/v7",
+		},
+		{
+			name:     "amd64",
+			platform: "linux/amd64",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withTempDir(t)
+			stub, imgStr, digests := newMultiArchRegistry(t, platforms...)
+
+			platform, err := ParsePlatform(test.platform)
+			require.NoError(t, err)
+
+			src, cleanup, err := NewWithOptions("registry:"+imgStr, Options{
+				RegistryOptions: &image.RegistryOptions{InsecureUseHTTP: true},
+				PullOptions:     &RegistryPullOptions{Platform: platform},
+			})
+			t.Cleanup(cleanup)
+			require.NoError(t, err)
+
+			// only the manifest of the requested platform is fetched (after the manifest list)
+			expected := digests[test.platform]
+			requests := stub.manifestRequests()
+			assert.Contains(t, requests, expected.String())
+			for p, digest := range digests {
+				if p != test.platform {
+					assert.NotContains(t, requests, digest.String(), "fetched the manifest for platform=%q", p)
+				}
+			}
+
+			metadata := src.Metadata.ImageMetadata
+			assert.Equal(t, expected.String(), metadata.ManifestDigest)
+			assert.Equal(t, platform.OS, metadata.OS)
+			assert.Equal(t, platform.Architecture, metadata.Architecture)
+		})
+	}
+}
+
+func Test_pullRegistryImage_platformMismatch(t *testing.T) {
+	withTempDir(t)
+	_, imgStr, _ := newMultiArchRegistry(t, v1.Platform{OS: "linux", Architecture: "amd64"})
+
+	_, cleanup, err := NewWithOptions("registry:"+imgStr, Options{
+		RegistryOptions: &image.RegistryOptions{InsecureUseHTTP: true},
+		PullOptions: &RegistryPullOptions{
+			Platform: &Platform{OS: "linux", Architecture: "s390x"},
+		},
+	})
+	t.Cleanup(cleanup)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "linux/s390x")
+}
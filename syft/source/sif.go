@@ -0,0 +1,305 @@
+package source
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/mitchellh/go-homedir"
+)
+
+// SifInputPrefix is the prefix for user input that indicates a Singularity Image Format (SIF) file should be cataloged
+// (e.g. "sif:path/to/image.sif"). The root filesystem of the image is extracted and cataloged as a directory.
+const SifInputPrefix = "sif:"
+
+// SifMetadata represents the Singularity Image Format (SIF) file that was cataloged (sif only).
+type SifMetadata struct {
+	Path         string            `json:"path"`
+	ID           string            `json:"id"`
+	Architecture string            `json:"architecture,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// the SIF layout is described at https://github.com/sylabs/sif (all values are little endian)
+const (
+	sifMagic = "SIF_MAGIC"
+
+	sifDataLabels    int32 = 0x4003
+	sifDataPartition int32 = 0x4004
+
+	sifFsSquash          int32 = 1
+	sifFsEncryptedSquash int32 = 5
+
+	sifPartSystem     int32 = 1
+	sifPartPrimarySys int32 = 2
+)
+
+// sifArchitectures maps the SIF architecture codes to GOARCH values.
+var sifArchitectures = map[string]string{
+	"01": "386",
+	"02": "amd64",
+	"03": "arm",
+	"04": "arm64",
+	"05": "ppc64",
+	"06": "ppc64le",
+	"07": "mips",
+	"08": "mipsle",
+	"09": "mips64",
+	"10": "mips64le",
+	"11": "s390x",
+	"12": "riscv64",
+}
+
+// sifHeader is the global header at the start of every SIF file.
+type sifHeader struct {
+	LaunchScript      [32]byte
+	Magic             [10]byte
+	Version           [3]byte
+	Arch              [3]byte
+	ID                [16]byte
+	CreatedAt         int64
+	ModifiedAt        int64
+	DescriptorsFree   int64
+	DescriptorsTotal  int64
+	DescriptorsOffset int64
+	DescriptorsSize   int64
+	DataOffset        int64
+	DataSize          int64
+}
+
+// sifDescriptor describes a single data object within a SIF file.
+type sifDescriptor struct {
+	DataType        int32
+	Used            bool
+	ID              uint32
+	GroupID         uint32
+	LinkedID        uint32
+	Offset          int64
+	Size            int64
+	SizeWithPadding int64
+	CreatedAt       int64
+	ModifiedAt      int64
+	UID             int64
+	GID             int64
+	Name            [128]byte
+	Extra           [384]byte
+}
+
+// sifPartition is the data type specific information (within sifDescriptor.Extra) for a partition.
+type sifPartition struct {
+	FsType   int32
+	PartType int32
+	Arch     [3]byte
+}
+
+func generateSifSource(userInput string, exclusions []string) (*Source, func(), error) {
+	path, err := homedir.Expand(strings.TrimPrefix(userInput, SifInputPrefix))
+	if err != nil {
+		return &Source{}, func() {}, fmt.Errorf("unable to expand SIF path: %w", err)
+	}
+
+	s, cleanupFn, err := NewFromSif(path)
+	if err != nil {
+		return &Source{}, cleanupFn, err
+	}
+
+	s.pathFilterFns, err = getDirectoryExclusionFunctions(s.path, exclusions)
+	if err != nil {
+		cleanupFn()
+		return &Source{}, func() {}, err
+	}
+
+	return &s, cleanupFn, nil
+}
+
+// NewFromSif creates a new source object tailored to catalog the given Singularity Image Format (SIF) file. The
+// squashfs root filesystem of the image (the primary system partition) is extracted to a temp dir (with the
+// unsquashfs client) and cataloged as a directory. The temp dir is removed by the returned cleanup function.
+func NewFromSif(path string) (Source, func(), error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Source{}, func() {}, fmt.Errorf("unable to open SIF file=%q: %w", path, err)
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	metadata, partition, err := readSif(f)
+	if err != nil {
+		return Source{}, func() {}, fmt.Errorf("unable to read SIF file=%q: %w", path, err)
+	}
+	metadata.Path = path
+
+	rootDir, cleanupFn, err := extractSquashfsToTmp(partition)
+	if err != nil {
+		return Source{}, func() {}, fmt.Errorf("unable to extract root filesystem from SIF file=%q: %w", path, err)
+	}
+
+	s, err := NewFromDirectory(rootDir)
+	if err != nil {
+		cleanupFn()
+		return Source{}, func() {}, err
+	}
+
+	// the extraction location is not meaningful (and changes on every run), so the SIF file is described instead
+	s.Metadata.Path = path
+	s.Metadata.SifMetadata = metadata
+
+	return s, cleanupFn, nil
+}
+
+// readSif reads the metadata of the given SIF file, returning a reader for the squashfs root filesystem partition.
+func readSif(r io.ReaderAt) (SifMetadata, *io.SectionReader, error) {
+	var header sifHeader
+	if err := binary.Read(io.NewSectionReader(r, 0, int64(binary.Size(header))), binary.LittleEndian, &header); err != nil {
+		return SifMetadata{}, nil, fmt.Errorf("unable to read header: %w", err)
+	}
+	if cString(header.Magic[:]) != sifMagic {
+		return SifMetadata{}, nil, errors.New("not a SIF file (bad magic)")
+	}
+
+	metadata := SifMetadata{
+		ID:           formatUUID(header.ID),
+		Architecture: sifArchitectures[cString(header.Arch[:])],
+		CreatedAt:    time.Unix(header.CreatedAt, 0).UTC(),
+	}
+
+	descriptors := io.NewSectionReader(r, header.DescriptorsOffset, header.DescriptorsSize)
+	var rootfs, system *io.SectionReader
+	for i := int64(0); i < header.DescriptorsTotal; i++ {
+		var descriptor sifDescriptor
+		if err := binary.Read(descriptors, binary.LittleEndian, &descriptor); err != nil {
+			return SifMetadata{}, nil, fmt.Errorf("unable to read descriptor %d: %w", i, err)
+		}
+		if !descriptor.Used {
+			continue
+		}
+		data := io.NewSectionReader(r, descriptor.Offset, descriptor.Size)
+
+		switch descriptor.DataType {
+		case sifDataLabels:
+			labels, err := readSifLabels(data)
+			if err != nil {
+				log.Warnf("unable to read SIF labels: %+v", err)
+				continue
+			}
+			metadata.Labels = labels
+		case sifDataPartition:
+			var partition sifPartition
+			if err := binary.Read(bytes.NewReader(descriptor.Extra[:]), binary.LittleEndian, &partition); err != nil {
+				return SifMetadata{}, nil, fmt.Errorf("unable to read partition descriptor %d: %w", i, err)
+			}
+			if partition.PartType != sifPartPrimarySys && partition.PartType != sifPartSystem {
+				continue
+			}
+			if partition.FsType == sifFsEncryptedSquash {
+				return SifMetadata{}, nil, errors.New("encrypted root filesystems are not supported")
+			}
+			if partition.FsType != sifFsSquash {
+				return SifMetadata{}, nil, fmt.Errorf("unsupported root filesystem type=%d (only squashfs is supported)", partition.FsType)
+			}
+			if partition.PartType == sifPartPrimarySys && rootfs == nil {
+				rootfs = data
+			} else if system == nil {
+				system = data
+			}
+		}
+	}
+
+	if rootfs == nil {
+		// older images may not distinguish the primary system partition
+		rootfs = system
+	}
+	if rootfs == nil {
+		return SifMetadata{}, nil, errors.New("no root filesystem partition found")
+	}
+	return metadata, rootfs, nil
+}
+
+// readSifLabels reads the image labels, which are stored as a JSON object (any non-string values are ignored).
+func readSifLabels(r io.Reader) (map[string]string, error) {
+	var raw map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]string)
+	for key, value := range raw {
+		if s, ok := value.(string); ok {
+			labels[key] = s
+		}
+	}
+	return labels, nil
+}
+
+// extractSquashfsToTmp extracts the given squashfs filesystem to a temp dir, returning the root of the extracted
+// filesystem.
+func extractSquashfsToTmp(squashfs io.Reader) (string, func(), error) {
+	if _, err := exec.LookPath("unsquashfs"); err != nil {
+		return "", func() {}, fmt.Errorf("the unsquashfs client (squashfs-tools) is required: %w", err)
+	}
+
+	tempDir, err := ioutil.TempDir("", "syft-sif-rootfs-")
+	if err != nil {
+		return "", func() {}, fmt.Errorf("unable to create tempdir for SIF extraction: %w", err)
+	}
+
+	cleanupFn := func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.Warnf("unable to cleanup SIF extraction tempdir: %+v", err)
+		}
+	}
+
+	// unsquashfs requires a file (which is not part of the extracted root filesystem)
+	squashfsPath := filepath.Join(tempDir, "rootfs.squashfs")
+	squashfsFile, err := os.Create(squashfsPath)
+	if err != nil {
+		cleanupFn()
+		return "", func() {}, fmt.Errorf("unable to create squashfs file: %w", err)
+	}
+	_, err = io.Copy(squashfsFile, squashfs)
+	if closeErr := squashfsFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanupFn()
+		return "", func() {}, fmt.Errorf("unable to write squashfs file: %w", err)
+	}
+
+	rootDir := filepath.Join(tempDir, "rootfs")
+	var stderr bytes.Buffer
+	cmd := exec.Command("unsquashfs", "-no-progress", "-no-xattrs", "-dest", rootDir, squashfsPath)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		cleanupFn()
+		return "", func() {}, fmt.Errorf("unsquashfs: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	if err := os.Remove(squashfsPath); err != nil {
+		log.Debugf("unable to remove squashfs file after extraction: %+v", err)
+	}
+
+	return rootDir, cleanupFn, nil
+}
+
+// cString returns the given NUL-terminated (or padded) string value.
+func cString(value []byte) string {
+	if idx := bytes.IndexByte(value, 0); idx >= 0 {
+		value = value[:idx]
+	}
+	return string(value)
+}
+
+func formatUUID(id [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
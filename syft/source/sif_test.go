@@ -0,0 +1,214 @@
+package source
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sifObject is a data object to write into a SIF file (see writeSif).
+type sifObject struct {
+	dataType  int32
+	partition *sifPartition
+	data      []byte
+}
+
+// writeSif writes a SIF file with the given data objects into the given directory, returning the file path.
+func writeSif(t *testing.T, dir string, created time.Time, objects ...sifObject) string {
+	t.Helper()
+
+	header := sifHeader{
+		ID:                [16]byte{0xde, 0xad, 0xbe, 0xef, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c},
+		CreatedAt:         created.Unix(),
+		ModifiedAt:        created.Unix(),
+		DescriptorsTotal:  int64(len(objects)),
+		DescriptorsOffset: int64(binary.Size(sifHeader{})),
+		DescriptorsSize:   int64(len(objects) * binary.Size(sifDescriptor{})),
+	}
+	copy(header.LaunchScript[:], "#!/usr/bin/env run-singularity\n")
+	copy(header.Magic[:], sifMagic)
+	copy(header.Version[:], "01")
+	copy(header.Arch[:], "02")
+	header.DataOffset = header.DescriptorsOffset + header.DescriptorsSize
+
+	var descriptors, data bytes.Buffer
+	for i, object := range objects {
+		descriptor := sifDescriptor{
+			DataType: object.dataType,
+			Used:     true,
+			ID:       uint32(i + 1),
+			Offset:   header.DataOffset + int64(data.Len()),
+			Size:     int64(len(object.data)),
+		}
+		descriptor.SizeWithPadding = descriptor.Size
+		if object.partition != nil {
+			var extra bytes.Buffer
+			require.NoError(t, binary.Write(&extra, binary.LittleEndian, object.partition))
+			copy(descriptor.Extra[:], extra.Bytes())
+		}
+		require.NoError(t, binary.Write(&descriptors, binary.LittleEndian, descriptor))
+		data.Write(object.data)
+	}
+	header.DataSize = int64(data.Len())
+
+	var contents bytes.Buffer
+	require.NoError(t, binary.Write(&contents, binary.LittleEndian, header))
+	contents.Write(descriptors.Bytes())
+	contents.Write(data.Bytes())
+
+	path := filepath.Join(dir, "image.sif")
+	require.NoError(t, ioutil.WriteFile(path, contents.Bytes(), 0644))
+	return path
+}
+
+func Test_readSif(t *testing.T) {
+	created := time.Date(2022, 1, 20, 10, 30, 0, 0, time.UTC)
+	labels := sifObject{
+		dataType: sifDataLabels,
+		data:     []byte(`{"org.label-schema.build-arch": "amd64", "org.label-schema.schema-version": "1.0", "count": 1}`),
+	}
+	squashfs := func(partType int32, data string) sifObject {
+		return sifObject{
+			dataType:  sifDataPartition,
+			partition: &sifPartition{FsType: sifFsSquash, PartType: partType},
+			data:      []byte(data),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		objects       []sifObject
+		expected      SifMetadata
+		expectedRoot  string
+		expectedError bool
+	}{
+		{
+			name: "primary system partition",
+			objects: []sifObject{
+				labels,
+				{dataType: 0x4001, data: []byte("Bootstrap: docker\nFrom: alpine:3.15\n")},
+				squashfs(sifPartSystem, "other system partition"),
+				squashfs(sifPartPrimarySys, "root filesystem"),
+				// the overlay partition is never the root filesystem
+				{
+					dataType:  sifDataPartition,
+					partition: &sifPartition{FsType: 2, PartType: 4},
+					data:      []byte("overlay"),
+				},
+			},
+			expected: SifMetadata{
+				ID:           "deadbeef-0102-0304-0506-0708090a0b0c",
+				Architecture: "amd64",
+				CreatedAt:    created,
+				Labels: map[string]string{
+					"org.label-schema.build-arch":     "amd64",
+					"org.label-schema.schema-version": "1.0",
+				},
+			},
+			expectedRoot: "root filesystem",
+		},
+		{
+			name:    "system partition without a primary system partition",
+			objects: []sifObject{squashfs(sifPartSystem, "root filesystem")},
+			expected: SifMetadata{
+				ID:           "deadbeef-0102-0304-0506-0708090a0b0c",
+				Architecture: "amd64",
+				CreatedAt:    created,
+			},
+			expectedRoot: "root filesystem",
+		},
+		{
+			name:          "no partitions",
+			objects:       []sifObject{labels},
+			expectedError: true,
+		},
+		{
+			name: "encrypted root filesystem",
+			objects: []sifObject{{
+				dataType:  sifDataPartition,
+				partition: &sifPartition{FsType: sifFsEncryptedSquash, PartType: sifPartPrimarySys},
+				data:      []byte("encrypted"),
+			}},
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(writeSif(t, t.TempDir(), created, test.objects...))
+			require.NoError(t, err)
+			defer f.Close()
+
+			actual, rootfs, err := readSif(f)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+
+			contents, err := ioutil.ReadAll(rootfs)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRoot, string(contents))
+		})
+	}
+}
+
+func Test_readSif_notSif(t *testing.T) {
+	f, err := os.Open("test-fixtures/path-detected/.vimrc")
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, _, err = readSif(f)
+	assert.Error(t, err)
+}
+
+func TestNewFromSif(t *testing.T) {
+	for _, tool := range []string{"mksquashfs", "unsquashfs"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not available: %+v", tool, err)
+		}
+	}
+
+	rootfs := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootfs, "lib", "apk", "db"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(rootfs, "lib", "apk", "db", "installed"), []byte("P:musl\nV:1.2.2-r7\n\n"), 0644))
+
+	squashfsPath := filepath.Join(t.TempDir(), "rootfs.squashfs")
+	out, err := exec.Command("mksquashfs", rootfs, squashfsPath, "-no-progress", "-noappend").CombinedOutput()
+	require.NoError(t, err, string(out))
+	squashfs, err := ioutil.ReadFile(squashfsPath)
+	require.NoError(t, err)
+
+	sifPath := writeSif(t, t.TempDir(), time.Now(), sifObject{
+		dataType:  sifDataPartition,
+		partition: &sifPartition{FsType: sifFsSquash, PartType: sifPartPrimarySys},
+		data:      squashfs,
+	})
+
+	src, cleanup, err := generateSifSource(SifInputPrefix+sifPath, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, DirectoryScheme, src.Metadata.Scheme)
+	assert.Equal(t, sifPath, src.Metadata.Path)
+	assert.Equal(t, sifPath, src.Metadata.SifMetadata.Path)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+	locations, err := resolver.FilesByPath("/lib/apk/db/installed")
+	require.NoError(t, err)
+	assert.Len(t, locations, 1)
+
+	// the extracted root filesystem is removed on cleanup
+	extracted := src.path
+	assert.DirExists(t, extracted)
+	cleanup()
+	assert.NoDirExists(t, extracted)
+}
@@ -7,8 +7,10 @@ package source
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/anchore/stereoscope"
@@ -25,13 +27,54 @@ type Source struct {
 	Metadata          Metadata
 	directoryResolver *directoryResolver
 	path              string
+	pathFilterFns     []pathFilterFn
 	mutex             *sync.Mutex
 }
 
 type sourceDetector func(string) (image.Source, string, error)
 
+// StdinInput is the user input that indicates an image archive (a docker or OCI tarball) should be read from stdin.
+const StdinInput = "-"
+
+// stdinUserInput is the user input recorded in the image metadata for image archives read from stdin.
+const stdinUserInput = "stdin"
+
+// Options are the optional settings for producing a Source (see NewWithOptions).
+type Options struct {
+	// RegistryOptions are used to fetch images from a registry (which may be nil).
+	RegistryOptions *image.RegistryOptions
+	// PullOptions describe how images are pulled directly from a registry (which may be nil), except for the platform,
+	// which images from all other sources must match.
+	PullOptions *RegistryPullOptions
+	// Exclusions are glob patterns (relative to the scanned path) for paths to skip, which are only applied to
+	// directory and file sources.
+	Exclusions []string
+}
+
 // New produces a Source based on userInput like dir: or image:tag
 func New(userInput string, registryOptions *image.RegistryOptions) (*Source, func(), error) {
+	return NewWithOptions(userInput, Options{RegistryOptions: registryOptions})
+}
+
+// NewWithOptions produces a Source based on userInput like dir: or image:tag, using the given options (see New).
+func NewWithOptions(userInput string, opts Options) (*Source, func(), error) {
+	registryOptions, pullOptions, exclusions := opts.RegistryOptions, opts.PullOptions, opts.Exclusions
+	if userInput == StdinInput {
+		return generateImageArchiveSource(os.Stdin, "", registryOptions, pullOptions)
+	}
+
+	if strings.HasPrefix(userInput, GitInputPrefix) {
+		return generateGitSource(userInput, exclusions)
+	}
+
+	if strings.HasPrefix(userInput, SifInputPrefix) {
+		return generateSifSource(userInput, exclusions)
+	}
+
+	if strings.HasPrefix(userInput, ArchiveInputPrefix) {
+		return generateArchiveSource(userInput, exclusions)
+	}
+
 	fs := afero.NewOsFs()
 	parsedScheme, imageSource, location, err := detectScheme(fs, image.DetectSource, userInput)
 	if err != nil {
@@ -40,17 +83,21 @@ func New(userInput string, registryOptions *image.RegistryOptions) (*Source, fun
 
 	switch parsedScheme {
 	case FileScheme:
-		return generateFileSource(fs, location)
+		return generateFileSource(fs, location, exclusions)
 	case DirectoryScheme:
-		return generateDirectorySource(fs, location)
+		return generateDirectorySource(fs, location, exclusions)
 	case ImageScheme:
-		return generateImageSource(location, userInput, imageSource, registryOptions)
+		return generateImageSource(location, userInput, imageSource, registryOptions, pullOptions)
 	}
 
 	return &Source{}, func() {}, fmt.Errorf("unable to process input for scanning: '%s'", userInput)
 }
 
-func generateImageSource(location, userInput string, imageSource image.Source, registryOptions *image.RegistryOptions) (*Source, func(), error) {
+func generateImageSource(location, userInput string, imageSource image.Source, registryOptions *image.RegistryOptions, pullOptions *RegistryPullOptions) (*Source, func(), error) {
+	if imageSource == image.OciRegistrySource {
+		return generateRegistryImageSource(location, registryOptions, pullOptions)
+	}
+
 	img, err := stereoscope.GetImageFromSource(location, imageSource, registryOptions)
 	if err != nil {
 		log.Debugf("error parsing location: %s after detecting scheme; pulling image: %s", location, userInput)
@@ -70,10 +117,74 @@ func generateImageSource(location, userInput string, imageSource image.Source, r
 		return &Source{}, cleanup, fmt.Errorf("could not populate source with image: %w", err)
 	}
 
+	if err := checkImagePlatform(s.Metadata.ImageMetadata, pullOptions.platform()); err != nil {
+		if imageSource == image.DockerDaemonSource {
+			// the daemon provides whichever image was last pulled for the tag, regardless of the platform
+			return &Source{}, cleanup, fmt.Errorf("%w (use the \"registry:\" scheme to pull the requested platform directly from a registry)", err)
+		}
+		return &Source{}, cleanup, err
+	}
+
+	return &s, cleanup, nil
+}
+
+// generateRegistryImageSource pulls the given image directly from a registry (see pullRegistryImage).
+func generateRegistryImageSource(location string, registryOptions *image.RegistryOptions, pullOptions *RegistryPullOptions) (*Source, func(), error) {
+	img, cleanup, err := pullRegistryImage(location, registryOptions, pullOptions)
+	if err != nil {
+		return &Source{}, cleanup, fmt.Errorf("could not fetch image '%s': %w", location, err)
+	}
+
+	s, err := NewFromImage(img, location)
+	if err != nil {
+		return &Source{}, cleanup, fmt.Errorf("could not populate source with image: %w", err)
+	}
+
+	if err := checkImagePlatform(s.Metadata.ImageMetadata, pullOptions.platform()); err != nil {
+		return &Source{}, cleanup, err
+	}
+
+	return &s, cleanup, nil
+}
+
+// generateImageArchiveSource buffers the given image archive stream to a temp file within the given directory (or the
+// default temp dir when empty), since the image must be read with random access, and catalogs it as an image. The temp
+// file is removed by the returned cleanup function.
+func generateImageArchiveSource(reader io.Reader, tempDir string, registryOptions *image.RegistryOptions, pullOptions *RegistryPullOptions) (*Source, func(), error) {
+	archivePath, removeArchive, err := bufferToTmp(reader, tempDir)
+	if err != nil {
+		return &Source{}, func() {}, err
+	}
+
+	imageSource, err := image.DetectSourceFromPath(archivePath)
+	if err != nil || imageSource == image.UnknownSource {
+		removeArchive()
+		return &Source{}, func() {}, fmt.Errorf("unable to detect image archive type from %s: %w", stdinUserInput, err)
+	}
+
+	cleanup := func() {
+		stereoscope.Cleanup()
+		removeArchive()
+	}
+
+	img, err := stereoscope.GetImageFromSource(archivePath, imageSource, registryOptions)
+	if err != nil || img == nil {
+		return &Source{}, cleanup, fmt.Errorf("could not read image archive from %s: %w", stdinUserInput, err)
+	}
+
+	s, err := NewFromImage(img, stdinUserInput)
+	if err != nil {
+		return &Source{}, cleanup, fmt.Errorf("could not populate source with image: %w", err)
+	}
+
+	if err := checkImagePlatform(s.Metadata.ImageMetadata, pullOptions.platform()); err != nil {
+		return &Source{}, cleanup, err
+	}
+
 	return &s, cleanup, nil
 }
 
-func generateDirectorySource(fs afero.Fs, location string) (*Source, func(), error) {
+func generateDirectorySource(fs afero.Fs, location string, exclusions []string) (*Source, func(), error) {
 	fileMeta, err := fs.Stat(location)
 	if err != nil {
 		return &Source{}, func() {}, fmt.Errorf("unable to stat dir=%q: %w", location, err)
@@ -88,10 +199,15 @@ func generateDirectorySource(fs afero.Fs, location string) (*Source, func(), err
 		return &Source{}, func() {}, fmt.Errorf("could not populate source from path=%q: %w", location, err)
 	}
 
+	s.pathFilterFns, err = getDirectoryExclusionFunctions(location, exclusions)
+	if err != nil {
+		return &Source{}, func() {}, err
+	}
+
 	return &s, func() {}, nil
 }
 
-func generateFileSource(fs afero.Fs, location string) (*Source, func(), error) {
+func generateFileSource(fs afero.Fs, location string, exclusions []string) (*Source, func(), error) {
 	fileMeta, err := fs.Stat(location)
 	if err != nil {
 		return &Source{}, func() {}, fmt.Errorf("unable to stat dir=%q: %w", location, err)
@@ -103,6 +219,12 @@ func generateFileSource(fs afero.Fs, location string) (*Source, func(), error) {
 
 	s, cleanupFn := NewFromFile(location)
 
+	s.pathFilterFns, err = getDirectoryExclusionFunctions(s.path, exclusions)
+	if err != nil {
+		cleanupFn()
+		return &Source{}, func() {}, err
+	}
+
 	return &s, cleanupFn, nil
 }
 
@@ -180,7 +302,7 @@ func (s *Source) FileResolver(scope Scope) (FileResolver, error) {
 		s.mutex.Lock()
 		defer s.mutex.Unlock()
 		if s.directoryResolver == nil {
-			resolver, err := newDirectoryResolver(s.path)
+			resolver, err := newDirectoryResolver(s.path, append([]pathFilterFn{isUnixSystemRuntimePath}, s.pathFilterFns...)...)
 			if err != nil {
 				return nil, err
 			}
@@ -212,5 +334,38 @@ func unarchiveToTmp(path string, unarchiver archiver.Unarchiver) (string, func()
 		}
 	}
 
-	return tempDir, cleanupFn, unarchiver.Unarchive(path, tempDir)
+	absoluteSymlinks, err := checkArchiveLinks(path, unarchiver)
+	if err != nil {
+		return tempDir, cleanupFn, err
+	}
+
+	if err := unarchiver.Unarchive(path, tempDir); err != nil {
+		return tempDir, cleanupFn, err
+	}
+
+	return tempDir, cleanupFn, rewriteAbsoluteSymlinks(tempDir, absoluteSymlinks)
+}
+
+func bufferToTmp(reader io.Reader, dir string) (string, func(), error) {
+	tempFile, err := ioutil.TempFile(dir, "syft-stdin-archive-")
+	if err != nil {
+		return "", func() {}, fmt.Errorf("unable to create temp file for %s: %w", stdinUserInput, err)
+	}
+
+	cleanupFn := func() {
+		if err := os.Remove(tempFile.Name()); err != nil {
+			log.Warnf("unable to cleanup %s temp file: %+v", stdinUserInput, err)
+		}
+	}
+
+	_, err = io.Copy(tempFile, reader)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanupFn()
+		return "", func() {}, fmt.Errorf("unable to buffer %s to temp file: %w", stdinUserInput, err)
+	}
+
+	return tempFile.Name(), cleanupFn, nil
 }
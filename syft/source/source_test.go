@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 
@@ -171,6 +172,47 @@ func TestNewFromFile_WithArchive(t *testing.T) {
 	}
 }
 
+func TestNewFromImageArchiveReader(t *testing.T) {
+	// note: this is the checked-in OCI image layout tarball of the integration tests, so no docker daemon is needed
+	f, err := os.Open("../../test/integration/test-fixtures/oci-archive/image.tar")
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	tempDir := t.TempDir()
+	src, cleanup, err := generateImageArchiveSource(f, tempDir, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, ImageScheme, src.Metadata.Scheme)
+	assert.Equal(t, "stdin", src.Metadata.ImageMetadata.UserInput)
+	assert.Equal(t, "sha256:0300c58ecd8ceb86895bde1bfd8f3bbdc61dd941a1ca588578c5e865f2077600", src.Metadata.ImageMetadata.ManifestDigest)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+
+	locations, err := resolver.FilesByPath("/lib/apk/db/installed")
+	require.NoError(t, err)
+	assert.Len(t, locations, 1)
+
+	cleanup()
+
+	// the buffered archive should not be left behind
+	entries, err := ioutil.ReadDir(tempDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestNewFromImageArchiveReader_NotAnImage(t *testing.T) {
+	tempDir := t.TempDir()
+	_, cleanup, err := generateImageArchiveSource(strings.NewReader("not an image archive"), tempDir, nil, nil)
+	t.Cleanup(cleanup)
+	assert.Error(t, err)
+
+	// the buffered stream is removed right away, since there is no image to catalog
+	entries, err := ioutil.ReadDir(tempDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
 func TestNewFromDirectoryShared(t *testing.T) {
 	testCases := []struct {
 		desc       string
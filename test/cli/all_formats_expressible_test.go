@@ -21,7 +21,12 @@ func TestAllFormatsExpressible(t *testing.T) {
 
 	for _, o := range format.AllOptions {
 		t.Run(fmt.Sprintf("format:%s", o), func(t *testing.T) {
-			cmd, stdout, stderr := runSyft(t, nil, "dir:./test-fixtures/image-pkg-coverage", "-o", string(o))
+			// the commit and ref of a directory source are only known within a GitHub Actions workflow
+			env := map[string]string{
+				"GITHUB_SHA": "a7c5df1bd6e4a9c62b3e2e5e8db1b32a4c6b7e0e",
+				"GITHUB_REF": "refs/heads/main",
+			}
+			cmd, stdout, stderr := runSyft(t, env, "dir:./test-fixtures/image-pkg-coverage", "-o", string(o))
 			for _, traitFn := range commonAssertions {
 				traitFn(t, stdout, stderr, cmd.ProcessState.ExitCode())
 			}
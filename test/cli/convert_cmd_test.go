@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertCmd(t *testing.T) {
+	sbomPath := filepath.Join(t.TempDir(), "sbom.syft.json")
+	cmd, stdout, stderr := runSyft(t, nil, "packages", "dir:test-fixtures/image-pkg-coverage", "-o", "json", "--file", sbomPath)
+	if cmd.ProcessState.ExitCode() != 0 {
+		t.Log("STDOUT:\n", stdout)
+		t.Log("STDERR:\n", stderr)
+		t.Fatalf("unable to create syft-json SBOM")
+	}
+
+	spdxPath := filepath.Join(t.TempDir(), "sbom.spdx")
+	cmd, stdout, stderr = runSyft(t, nil, "packages", "dir:test-fixtures/image-pkg-coverage", "-o", "spdx", "--file", spdxPath)
+	if cmd.ProcessState.ExitCode() != 0 {
+		t.Log("STDOUT:\n", stdout)
+		t.Log("STDERR:\n", stderr)
+		t.Fatalf("unable to create SPDX SBOM")
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		assertions []traitAssertion
+	}{
+		{
+			name: "no-args-shows-help",
+			args: []string{"convert"},
+			assertions: []traitAssertion{
+				assertInOutput("an SBOM argument is required"),                      // specific error that should be shown
+				assertInOutput("Convert an existing SBOM into another SBOM format"), // excerpt from help description
+				assertFailingReturnCode,
+			},
+		},
+		{
+			name: "table-output",
+			args: []string{"convert", sbomPath},
+			assertions: []traitAssertion{
+				assertTableReport,
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "json-output",
+			args: []string{"convert", sbomPath, "-o", "json"},
+			assertions: []traitAssertion{
+				assertJsonReport,
+				assertInOutput(`"metadataType": "ApkMetadata"`), // proof of package metadata surviving the conversion
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "spdx-output",
+			args: []string{"convert", sbomPath, "-o", "spdx"},
+			assertions: []traitAssertion{
+				assertInOutput("SPDXVersion: SPDX-2.2"),
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "unsupported-input-format",
+			args: []string{"convert", spdxPath},
+			assertions: []traitAssertion{
+				assertFailingReturnCode,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, stdout, stderr := runSyft(t, nil, test.args...)
+			for _, traitFn := range test.assertions {
+				traitFn(t, stdout, stderr, cmd.ProcessState.ExitCode())
+			}
+			if t.Failed() {
+				t.Log("STDOUT:\n", stdout)
+				t.Log("STDERR:\n", stderr)
+				t.Log("COMMAND:", strings.Join(cmd.Args, " "))
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPackagesCmdFlags(t *testing.T) {
@@ -100,6 +104,85 @@ func TestPackagesCmdFlags(t *testing.T) {
 				assertSuccessfulReturnCode,
 			},
 		},
+		{
+			name: "file-digests-flag",
+			args: []string{"packages", "-o", "json", "--file-digests", "sha1", "--file-digests", "sha256", "dir:test-fixtures/file-digests"},
+			assertions: []traitAssertion{
+				assertJsonReport,
+				assertInOutput(`"value": "7d9d8aab2a94ebb951dbfe781a34a5476f8e2465"`),
+				assertInOutput(`"value": "464dcf2ca41b29ae47da6bbf7d33822135105c82011f646ba5f383fbb204b9d4"`),
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "file-digests-flag-unsupported-algorithm",
+			args: []string{"packages", "--file-digests", "sha512", "dir:test-fixtures/file-digests"},
+			assertions: []traitAssertion{
+				assertInOutput("unsupported hash algorithm: sha512"),
+				assertFailingReturnCode,
+			},
+		},
+		{
+			name: "dev-dependencies-reported-by-default",
+			args: []string{"packages", "-o", "json", "dir:test-fixtures/dev-dependencies"},
+			assertions: []traitAssertion{
+				assertPackageCount(3),
+				assertInOutput(`"name": "supports-color"`),
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "exclude-dev-flag",
+			args: []string{"packages", "-o", "json", "--exclude-dev", "dir:test-fixtures/dev-dependencies"},
+			assertions: []traitAssertion{
+				assertPackageCount(1),
+				assertInOutput(`"name": "lodash"`),
+				assertNotInOutput(`"name": "supports-color"`),
+				assertNotInOutput(`"name": "has-flag"`),
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "exclude-dev-by-env",
+			env: map[string]string{
+				"SYFT_PACKAGE_EXCLUDE_DEV": "true",
+			},
+			args: []string{"packages", "-o", "json", "dir:test-fixtures/dev-dependencies"},
+			assertions: []traitAssertion{
+				assertPackageCount(1),
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "corrupt-lockfile-skipped-by-default",
+			args: []string{"packages", "-o", "json", "dir:test-fixtures/corrupt-lockfile"},
+			assertions: []traitAssertion{
+				assertPackageCount(1),
+				assertInOutput(`"name": "requests"`),
+				assertSuccessfulReturnCode,
+			},
+		},
+		{
+			name: "fail-on-error-flag",
+			args: []string{"packages", "-o", "json", "--fail-on-error", "dir:test-fixtures/corrupt-lockfile"},
+			assertions: []traitAssertion{
+				assertInOutput("failed to parse 1 file:"),
+				assertInOutput(`package-lock.json (cataloger="javascript-lock-cataloger")`),
+				assertNotInOutput(`"name": "requests"`),
+				assertFailingReturnCode,
+			},
+		},
+		{
+			name: "fail-on-error-by-env",
+			env: map[string]string{
+				"SYFT_PACKAGE_FAIL_ON_ERROR": "true",
+			},
+			args: []string{"packages", "-o", "json", "dir:test-fixtures/corrupt-lockfile"},
+			assertions: []traitAssertion{
+				assertInOutput("failed to parse 1 file:"),
+				assertFailingReturnCode,
+			},
+		},
 		{
 			name: "attempt-upload-on-cli-switches",
 			args: []string{"packages", "-vv", "-H", "localhost:8080", "-u", "the-username", "-d", "test-fixtures/image-pkg-coverage/Dockerfile", "--overwrite-existing-image", coverageImage},
@@ -250,3 +333,56 @@ func TestRegistryAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestPackagesCmdDescriptor(t *testing.T) {
+	cmd, stdout, stderr := runSyft(t, nil, "version", "-o", "json")
+	require.Equal(t, 0, cmd.ProcessState.ExitCode(), stderr)
+
+	var buildVersion struct {
+		Version     string `json:"version"`
+		Application string `json:"application"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &buildVersion))
+
+	env := map[string]string{
+		"SYFT_REGISTRY_AUTH_AUTHORITY":   "registry.example.com",
+		"SYFT_REGISTRY_AUTH_USERNAME":    "the-registry-username",
+		"SYFT_REGISTRY_AUTH_PASSWORD":    "the-registry-password",
+		"SYFT_REGISTRY_AUTH_1_AUTHORITY": "ghcr.io",
+		"SYFT_REGISTRY_AUTH_1_TOKEN":     "the-registry-token",
+	}
+	cmd, stdout, stderr = runSyft(t, env, "packages", "dir:test-fixtures/image-pkg-coverage/pkgs/elixir", "-o", "json", "-q")
+	require.Equal(t, 0, cmd.ProcessState.ExitCode(), stderr)
+
+	var document struct {
+		Descriptor struct {
+			Name          string `json:"name"`
+			Version       string `json:"version"`
+			Configuration struct {
+				Package struct {
+					Cataloger struct {
+						Enabled bool `json:"enabled"`
+					} `json:"cataloger"`
+				} `json:"package"`
+				Registry struct {
+					Auth []map[string]interface{} `json:"auth"`
+				} `json:"registry"`
+			} `json:"configuration"`
+		} `json:"descriptor"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &document))
+
+	// the descriptor identifies the build of syft that produced the document...
+	assert.Equal(t, buildVersion.Application, document.Descriptor.Name)
+	assert.Equal(t, buildVersion.Version, document.Descriptor.Version)
+
+	// ...along with the configuration used for the scan (without any credentials)
+	assert.True(t, document.Descriptor.Configuration.Package.Cataloger.Enabled)
+	assert.ElementsMatch(t, []map[string]interface{}{
+		{"authority": "registry.example.com"},
+		{"authority": "ghcr.io"},
+	}, document.Descriptor.Configuration.Registry.Auth)
+	for _, secret := range []string{"the-registry-username", "the-registry-password", "the-registry-token"} {
+		assert.NotContains(t, stdout, secret)
+	}
+}
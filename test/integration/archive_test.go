@@ -0,0 +1,37 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchivePackages(t *testing.T) {
+	archivePath := "test-fixtures/archive/project.zip"
+	theSource, cleanupSource, err := source.New(source.ArchiveInputPrefix+archivePath, nil)
+	t.Cleanup(cleanupSource)
+	require.NoError(t, err)
+
+	assert.Equal(t, source.DirectoryScheme, theSource.Metadata.Scheme)
+	assert.Equal(t, archivePath, theSource.Metadata.Path)
+
+	catalog, _, _, err := syft.CatalogPackages(theSource, source.SquashedScope)
+	require.NoError(t, err)
+
+	observed := make(map[string]string)
+	for _, p := range catalog.Sorted() {
+		observed[p.Name] = p.Version
+		assert.Contains(t, []pkg.Type{pkg.GemPkg, pkg.PythonPkg}, p.Type, p.Name)
+	}
+
+	// the requirements.txt within the nested vendor.tar.gz archive is not cataloged (nested archives are not extracted)
+	assert.Equal(t, map[string]string{
+		"rack":     "2.2.3",
+		"rake":     "13.0.6",
+		"requests": "2.26.0",
+	}, observed)
+}
@@ -169,14 +169,57 @@ var dirOnlyTestCases = []testCase{
 		},
 	},
 	{
-		name:       "find apkdb packages",
-		pkgType:    pkg.ApkPkg,
-		duplicates: 2, // when the directory is cataloged we have duplicates between lib/ and pkgs/lib
+		name:        "find swift packages",
+		pkgType:     pkg.SwiftPkg,
+		pkgLanguage: pkg.Swift,
+		pkgInfo: map[string]string{
+			"swift-argument-parser": "1.0.3",
+			"swift-nio":             "124119f0bb12384cef35aa041d7c3a686108722d",
+		},
+	},
+	{
+		name:        "find haskell packages",
+		pkgType:     pkg.HackagePkg,
+		pkgLanguage: pkg.Haskell,
+		pkgInfo: map[string]string{
+			"HTTP":  "4000.3.16",
+			"aeson": "1.5.6.0",
+		},
+	},
+	{
+		name:        "find elixir packages",
+		pkgType:     pkg.HexPkg,
+		pkgLanguage: pkg.Elixir,
+		pkgInfo: map[string]string{
+			"castore": "0.1.10",
+			"plug":    "1.12.1",
+		},
+	},
+	{
+		name:        "find dart packages",
+		pkgType:     pkg.DartPubPkg,
+		pkgLanguage: pkg.Dart,
+		pkgInfo: map[string]string{
+			"http":        "0.13.4",
+			"http_parser": "4.0.0",
+		},
+	},
+	{
+		name:    "find apkdb packages",
+		pkgType: pkg.ApkPkg,
+		// note: the packages found in both lib/ and pkgs/lib are deduplicated when the directory is cataloged
 		pkgInfo: map[string]string{
 			"musl-utils": "1.1.24-r2",
 			"libc-utils": "0.7.2-r0",
 		},
 	},
+	{
+		name:    "find apkbuild packages",
+		pkgType: pkg.ApkbuildPkg,
+		pkgInfo: map[string]string{
+			"musl-fts": "1.2.7-r1",
+		},
+	},
 	{
 		name:        "find php composer package",
 		pkgType:     pkg.PhpComposerPkg,
@@ -184,11 +227,28 @@ var dirOnlyTestCases = []testCase{
 		pkgInfo: map[string]string{
 			"adoy/fastcgi-client":       "1.0.2",
 			"alcaeus/mongo-php-adapter": "1.1.11",
+			"behat/gherkin":             "v4.6.2",
+			"codeception/codeception":   "4.1.6",
 		},
 	},
 }
 
 var commonTestCases = []testCase{
+	{
+		name:        "find dotnet packages",
+		pkgType:     pkg.DotnetPkg,
+		pkgLanguage: pkg.Dotnet,
+		pkgInfo: map[string]string{
+			"Serilog": "2.10.0",
+		},
+	},
+	{
+		name:    "find conda packages",
+		pkgType: pkg.CondaPkg,
+		pkgInfo: map[string]string{
+			"openssl": "1.1.1l",
+		},
+	},
 	{
 		name:    "find rpmdb packages",
 		pkgType: pkg.RpmPkg,
@@ -218,9 +278,32 @@ var commonTestCases = []testCase{
 		name:        "find jenkins plugins",
 		pkgType:     pkg.JenkinsPluginPkg,
 		pkgLanguage: pkg.Java,
-		duplicates:  1, // there is a "example-jenkins-plugin" HPI, and nested within that a JAR of the same name
+		// note: there is a "example-jenkins-plugin" HPI, and nested within that a JAR of the same name (which is deduplicated)
 		pkgInfo: map[string]string{
 			"example-jenkins-plugin": "1.0-SNAPSHOT",
 		},
 	},
+	{
+		name:    "find runtime binaries",
+		pkgType: pkg.BinaryPkg,
+		pkgInfo: map[string]string{
+			"python": "3.9.7",
+		},
+	},
+	{
+		name:    "find windows programs",
+		pkgType: pkg.WindowsPkg,
+		pkgInfo: map[string]string{
+			"7-Zip 21.07 (x64)": "21.07",
+			"Office 16 Click-to-Run Extensibility Component": "16.0.14326.20404",
+			"Notepad++ (32-bit x86)":                         "8.1.9.3",
+		},
+	},
+	{
+		name:    "find linux kernels",
+		pkgType: pkg.LinuxKernelPkg,
+		pkgInfo: map[string]string{
+			"linux-kernel": "5.10.0-8-amd64",
+		},
+	},
 }
@@ -0,0 +1,35 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogerSelection(t *testing.T) {
+	// the fixture has packages for many ecosystems, however, only the selected cataloger should run
+	theSource, cleanupSource, err := source.New("dir:test-fixtures/image-pkg-coverage/pkgs", nil)
+	t.Cleanup(cleanupSource)
+	require.NoError(t, err)
+
+	cfg := cataloger.DefaultConfig()
+	cfg.Catalogers = []string{"rust-cataloger"}
+	catalog, _, _, err := syft.CatalogPackagesWithConfig(theSource, cfg)
+	require.NoError(t, err)
+
+	require.NotZero(t, catalog.PackageCount())
+	for p := range catalog.Enumerate() {
+		assert.Equal(t, "rust-cataloger", p.FoundBy, "unexpected package=%s", p)
+		assert.Equal(t, pkg.RustPkg, p.Type, "unexpected package=%s", p)
+	}
+
+	// unknown catalogers are rejected
+	cfg.Catalogers = []string{"-not-a-cataloger"}
+	_, _, _, err = syft.CatalogPackagesWithConfig(theSource, cfg)
+	assert.Error(t, err)
+}
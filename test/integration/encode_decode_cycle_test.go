@@ -2,14 +2,23 @@ package integration
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/go-test/deep"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
 
 	"github.com/anchore/syft/syft/format"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestEncodeDecodeEncodeCycleComparison is testing for differences in how SBOM documents get encoded on multiple cycles.
@@ -49,3 +58,94 @@ func TestEncodeDecodeEncodeCycleComparison(t *testing.T) {
 		})
 	}
 }
+
+// TestEncodeDecodeCycle_directoryCatalog ensures that a syft-json SBOM can be imported (e.g. to be converted to another
+// format) without losing any package details, including the package metadata and relationships.
+func TestEncodeDecodeCycle_directoryCatalog(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  func(t *testing.T) string
+		// a metadata type that must be present (in case cataloging the fixture silently stops finding it)
+		metadataType pkg.MetadataType
+	}{
+		{
+			name: "package coverage",
+			dir: func(t *testing.T) string {
+				return "test-fixtures/image-pkg-coverage"
+			},
+		},
+		{
+			name:         "go binary",
+			dir:          buildGoBinaryFixture,
+			metadataType: pkg.GolangBinMetadataType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			originalSBOM, _ := catalogDirectory(t, test.dir(t))
+			require.NotZero(t, originalSBOM.Artifacts.PackageCatalog.PackageCount())
+
+			by, err := syft.Encode(originalSBOM, format.JSONOption)
+			require.NoError(t, err)
+
+			newSBOM, newFormat, err := syft.Decode(bytes.NewReader(by))
+			require.NoError(t, err)
+			assert.Equal(t, format.JSONOption, newFormat)
+
+			expectedPackages := originalSBOM.Artifacts.PackageCatalog.Sorted()
+			actualPackages := newSBOM.Artifacts.PackageCatalog.Sorted()
+			require.Len(t, actualPackages, len(expectedPackages))
+
+			var metadataTypes []pkg.MetadataType
+			for idx, expected := range expectedPackages {
+				actual := actualPackages[idx]
+				metadataTypes = append(metadataTypes, actual.MetadataType)
+				assert.Equal(t, expected.ID(), actual.ID(), "package ID differs for %s", expected)
+				assert.Equal(t, expected.MetadataType, actual.MetadataType)
+				for _, d := range deep.Equal(expected.Metadata, actual.Metadata) {
+					if strings.HasPrefix(d, "Parent: pkg.Package != <nil pointer>") {
+						// the java archive parent package is not encoded (this is expected to be lossy)
+						continue
+					}
+					t.Errorf("package metadata difference (%s): %+v", expected, d)
+				}
+			}
+
+			if test.metadataType != "" {
+				assert.Contains(t, metadataTypes, test.metadataType)
+			}
+
+			relationshipKeys := func(relationships []artifact.Relationship) map[string]struct{} {
+				keys := make(map[string]struct{})
+				for _, r := range relationships {
+					keys[string(r.From.ID())+"/"+string(r.To.ID())+"/"+string(r.Type)] = struct{}{}
+				}
+				return keys
+			}
+			assert.Equal(t, relationshipKeys(originalSBOM.Relationships), relationshipKeys(newSBOM.Relationships))
+		})
+	}
+}
+
+// buildGoBinaryFixture builds a minimal go binary (which has no dependencies, only the main module) into a new
+// directory, returning the directory.
+func buildGoBinaryFixture(t *testing.T) string {
+	srcDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module github.com/anchore/hello\n\ngo 1.16\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	}
+	for name, contents := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(srcDir, name), []byte(contents), 0600))
+	}
+
+	binDir := t.TempDir()
+	cmd := exec.Command("go", "build", "-o", filepath.Join(binDir, "hello"), ".")
+	cmd.Dir = srcDir
+	// the fixture must not depend on the module settings of the test environment
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GO111MODULE=on", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("unable to build go binary fixture: %+v: %s", err, out)
+	}
+	return binDir
+}
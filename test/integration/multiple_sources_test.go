@@ -0,0 +1,72 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/syftjson"
+	syftjsonModel "github.com/anchore/syft/internal/formats/syftjson/model"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipleSources(t *testing.T) {
+	first, firstSource := catalogDirectory(t, "test-fixtures/multiple-sources/first")
+	second, secondSource := catalogDirectory(t, "test-fixtures/multiple-sources/second")
+
+	merged := sbom.Merge(first, second)
+
+	assert.Equal(t, firstSource.Metadata, merged.Source)
+	assert.Equal(t, []source.Metadata{firstSource.Metadata, secondSource.Metadata}, merged.Sources)
+
+	// the same package found in both sources is kept as two packages with distinct locations
+	var requestsLocations []string
+	for _, p := range merged.Artifacts.PackageCatalog.Sorted() {
+		if p.Name != "requests" {
+			continue
+		}
+		for _, l := range p.Locations {
+			requestsLocations = append(requestsLocations, l.RealPath)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"test-fixtures/multiple-sources/first/requirements.txt",
+		"test-fixtures/multiple-sources/second/requirements.txt",
+	}, requestsLocations)
+	assert.Equal(t, 3, merged.Artifacts.PackageCatalog.PackageCount())
+
+	// every package is attributed to the source it was found in
+	foundIn := make(map[string]artifact.ID)
+	for _, r := range merged.Relationships {
+		if r.Type != artifact.FoundInSourceRelationship {
+			continue
+		}
+		p, ok := r.From.(pkg.Package)
+		require.True(t, ok)
+		foundIn[p.Locations[0].RealPath+":"+p.Name] = r.To.ID()
+	}
+	assert.Equal(t, map[string]artifact.ID{
+		"test-fixtures/multiple-sources/first/requirements.txt:flask":     firstSource.Metadata.ID(),
+		"test-fixtures/multiple-sources/first/requirements.txt:requests":  firstSource.Metadata.ID(),
+		"test-fixtures/multiple-sources/second/requirements.txt:requests": secondSource.Metadata.ID(),
+	}, foundIn)
+
+	// the JSON output describes all sources
+	var buf bytes.Buffer
+	require.NoError(t, syftjson.Format().Encode(&buf, merged))
+
+	var doc syftjsonModel.Document
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	require.Len(t, doc.Sources, 2)
+	assert.Equal(t, string(firstSource.Metadata.ID()), doc.Sources[0].ID)
+	assert.Equal(t, "test-fixtures/multiple-sources/first", doc.Sources[0].Target)
+	assert.Equal(t, string(secondSource.Metadata.ID()), doc.Sources[1].ID)
+	assert.Equal(t, "test-fixtures/multiple-sources/second", doc.Sources[1].Target)
+	assert.Len(t, doc.Artifacts, 3)
+}
@@ -0,0 +1,51 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/distro"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCIArchiveSource(t *testing.T) {
+	// note: this fixture is a checked-in OCI image layout tarball (e.g. as created by "skopeo copy ... oci-archive:..."),
+	// so no docker daemon is needed to catalog it
+	userInput := "oci-archive:test-fixtures/oci-archive/image.tar"
+
+	theSource, cleanupSource, err := source.New(userInput, nil)
+	t.Cleanup(cleanupSource)
+	require.NoError(t, err)
+
+	assert.Equal(t, source.ImageScheme, theSource.Metadata.Scheme)
+	assert.Equal(t, "test-fixtures/oci-archive/image.tar", theSource.Metadata.ImageMetadata.UserInput)
+	assert.NotEmpty(t, theSource.Metadata.ImageMetadata.ID)
+	assert.Equal(t, "sha256:0300c58ecd8ceb86895bde1bfd8f3bbdc61dd941a1ca588578c5e865f2077600", theSource.Metadata.ImageMetadata.ManifestDigest)
+	assert.Len(t, theSource.Metadata.ImageMetadata.Layers, 1)
+
+	catalog, relationships, actualDistro, err := syft.CatalogPackages(theSource, source.SquashedScope)
+	require.NoError(t, err)
+
+	expectedDistro, err := distro.NewDistro(distro.Alpine, "3.14.2", "")
+	require.NoError(t, err)
+	assert.Equal(t, &expectedDistro, actualDistro)
+
+	var found []string
+	for p := range catalog.Enumerate(pkg.ApkPkg) {
+		found = append(found, p.Name+"@"+p.Version)
+	}
+	assert.Equal(t, []string{"musl@1.2.2-r3"}, found)
+
+	// the only layer introduced the package
+	var layers []string
+	for _, r := range relationships {
+		if r.Type == artifact.ContainedByLayerRelationship {
+			layers = append(layers, string(r.To.ID()))
+		}
+	}
+	assert.Equal(t, []string{theSource.Metadata.ImageMetadata.Layers[0].Digest}, layers)
+}
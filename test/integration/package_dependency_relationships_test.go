@@ -0,0 +1,28 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageDependencyRelationships(t *testing.T) {
+	sbom, _ := catalogDirectory(t, "test-fixtures/image-pkg-coverage/pkgs/rust")
+
+	var actual []string
+	for _, r := range sbom.Relationships {
+		if r.Type != artifact.DependencyOfRelationship {
+			continue
+		}
+		// the relationships must refer to the final packages within the catalog (with CPEs, PURLs, etc.)
+		require.NotNil(t, sbom.Artifacts.PackageCatalog.Package(r.From.ID()), "relationship from a missing package: %+v", r.From)
+		require.NotNil(t, sbom.Artifacts.PackageCatalog.Package(r.To.ID()), "relationship to a missing package: %+v", r.To)
+		actual = append(actual, fmt.Sprintf("%s -> %s", r.From.(pkg.Package).Name, r.To.(pkg.Package).Name))
+	}
+
+	assert.ElementsMatch(t, []string{"memchr -> nom", "version_check -> nom"}, actual)
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/anchore/syft/internal/formats/syftjson"
 	syftjsonModel "github.com/anchore/syft/internal/formats/syftjson/model"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
 )
 
 func TestPackageOwnershipRelationships(t *testing.T) {
@@ -49,3 +51,30 @@ func TestPackageOwnershipRelationships(t *testing.T) {
 	}
 
 }
+
+func TestPackageOwnershipRelationships_rpmOwnsJar(t *testing.T) {
+	sbom, _ := catalogFixtureImage(t, "image-owning-jar")
+
+	var found bool
+	for _, r := range sbom.Relationships {
+		if r.Type != artifact.OwnershipByFileOverlapRelationship {
+			continue
+		}
+		parent, ok := r.From.(pkg.Package)
+		if !ok || parent.Type != pkg.RpmPkg || parent.Name != "log4j" {
+			continue
+		}
+		child, ok := r.To.(pkg.Package)
+		if !ok || child.Type != pkg.JavaPkg {
+			continue
+		}
+		if _, ok := child.Metadata.(pkg.JavaMetadata); !ok {
+			t.Errorf("expected java metadata for owned package=%q", child.Name)
+		}
+		found = true
+	}
+
+	if !found {
+		t.Errorf("expected the log4j rpm to own the java packages found within the JARs it installs")
+	}
+}
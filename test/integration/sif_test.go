@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSifPackages(t *testing.T) {
+	if _, err := exec.LookPath("unsquashfs"); err != nil {
+		t.Skipf("unsquashfs is not available: %+v", err)
+	}
+
+	var client string
+	for _, candidate := range []string{"singularity", "apptainer"} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			client = candidate
+			break
+		}
+	}
+	if client == "" {
+		t.Skip("neither singularity nor apptainer is available")
+	}
+
+	// build a SIF image from a sandbox directory (which does not require root or network access)
+	sifPath := filepath.Join(t.TempDir(), "image.sif")
+	out, err := exec.Command(client, "build", "--force", sifPath, "test-fixtures/image-pkg-coverage/pkgs/elixir").CombinedOutput()
+	if err != nil {
+		t.Skipf("unable to build SIF image: %+v: %s", err, out)
+	}
+
+	theSource, cleanupSource, err := source.New(source.SifInputPrefix+sifPath, nil)
+	t.Cleanup(cleanupSource)
+	require.NoError(t, err)
+
+	assert.Equal(t, sifPath, theSource.Metadata.Path)
+	assert.Equal(t, sifPath, theSource.Metadata.SifMetadata.Path)
+	assert.NotEmpty(t, theSource.Metadata.SifMetadata.ID)
+
+	catalog, _, _, err := syft.CatalogPackages(theSource, source.SquashedScope)
+	require.NoError(t, err)
+
+	observed := make(map[string]string)
+	for p := range catalog.Enumerate(pkg.HexPkg) {
+		observed[p.Name] = p.Version
+	}
+	assert.Equal(t, map[string]string{"castore": "0.1.10", "plug": "1.12.1"}, observed)
+}
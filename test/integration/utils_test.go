@@ -7,6 +7,7 @@ import (
 
 	"github.com/anchore/stereoscope/pkg/imagetest"
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/pkg/cataloger"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -51,7 +52,9 @@ func catalogDirectory(t *testing.T, dir string) (sbom.SBOM, *source.Source) {
 		t.Fatalf("unable to get source: %+v", err)
 	}
 
-	pkgCatalog, relationships, actualDistro, err := syft.CatalogPackages(theSource, source.AllLayersScope)
+	cfg := cataloger.DefaultConfig()
+	cfg.Scope = source.AllLayersScope
+	pkgCatalog, relationships, actualDistro, err := syft.CatalogPackagesWithConfig(theSource, cfg)
 	if err != nil {
 		t.Fatalf("failed to catalog image: %+v", err)
 	}